@@ -0,0 +1,123 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	adminAPIKeysSuffix = "/organization/admin_api_keys"
+)
+
+// AdminAPIKey represents an organization admin API key.
+// Value is only populated in the response to CreateAdminAPIKey.
+type AdminAPIKey struct {
+	ID            string           `json:"id"`
+	Object        string           `json:"object"`
+	Name          string           `json:"name"`
+	RedactedValue string           `json:"redacted_value"`
+	Value         string           `json:"value,omitempty"`
+	CreatedAt     int64            `json:"created_at"`
+	LastUsedAt    *int64           `json:"last_used_at,omitempty"`
+	Owner         AdminAPIKeyOwner `json:"owner"`
+
+	httpHeader
+}
+
+// AdminAPIKeyOwner describes the user or service account that owns an admin API key.
+type AdminAPIKeyOwner struct {
+	Type      string `json:"type"`
+	Object    string `json:"object"`
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt int64  `json:"created_at"`
+	Role      string `json:"role"`
+}
+
+type AdminAPIKeyRequest struct {
+	Name string `json:"name"`
+}
+
+// AdminAPIKeysList is a list of admin API keys.
+type AdminAPIKeysList struct {
+	Object  string        `json:"object"`
+	Keys    []AdminAPIKey `json:"data"`
+	FirstID *string       `json:"first_id"`
+	LastID  *string       `json:"last_id"`
+	HasMore bool          `json:"has_more"`
+
+	httpHeader
+}
+
+type AdminAPIKeyDeleteResponse struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+
+	httpHeader
+}
+
+// CreateAdminAPIKey creates an organization admin API key.
+func (c *Client) CreateAdminAPIKey(
+	ctx context.Context,
+	request AdminAPIKeyRequest,
+	opts ...RequestOption,
+) (response AdminAPIKey, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(adminAPIKeysSuffix),
+		withBody(request), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// RetrieveAdminAPIKey retrieves an organization admin API key.
+func (c *Client) RetrieveAdminAPIKey(
+	ctx context.Context,
+	keyID string,
+	opts ...RequestOption,
+) (response AdminAPIKey, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", adminAPIKeysSuffix, keyID)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// DeleteAdminAPIKey deletes an organization admin API key.
+func (c *Client) DeleteAdminAPIKey(
+	ctx context.Context,
+	keyID string,
+	opts ...RequestOption,
+) (response AdminAPIKeyDeleteResponse, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", adminAPIKeysSuffix, keyID)
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ListAdminAPIKeys lists the organization admin API keys.
+func (c *Client) ListAdminAPIKeys(
+	ctx context.Context,
+	pagination Pagination,
+	opts ...RequestOption,
+) (response AdminAPIKeysList, err error) {
+	urlSuffix := adminAPIKeysSuffix + pagination.encode()
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
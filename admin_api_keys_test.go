@@ -0,0 +1,106 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// TestAdminAPIKeys Tests the admin API key endpoints of the API using the mocked server.
+func TestAdminAPIKeys(t *testing.T) {
+	keyID := "key_abc"
+	limit := 20
+
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler(
+		"/v1/organization/admin_api_keys/"+keyID,
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				resBytes, _ := json.Marshal(openai.AdminAPIKey{
+					ID:            keyID,
+					Object:        "organization.admin_api_key",
+					Name:          "Administration Key",
+					RedactedValue: "sk-admin...def",
+					Owner: openai.AdminAPIKeyOwner{
+						Type: "service_account",
+						ID:   "sa_456",
+						Role: "owner",
+					},
+				})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodDelete:
+				fmt.Fprintln(w, `{
+					"id": "key_abc",
+					"object": "organization.admin_api_key.deleted",
+					"deleted": true
+				}`)
+			}
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/organization/admin_api_keys",
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				var request openai.AdminAPIKeyRequest
+				err := json.NewDecoder(r.Body).Decode(&request)
+				checks.NoError(t, err, "Decode error")
+
+				resBytes, _ := json.Marshal(openai.AdminAPIKey{
+					ID:            keyID,
+					Object:        "organization.admin_api_key",
+					Name:          request.Name,
+					RedactedValue: "sk-admin...xyz",
+					Value:         "sk-admin-1234abcd",
+				})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodGet:
+				if r.URL.Query().Get("limit") != "20" {
+					t.Errorf("unexpected query: %s", r.URL.RawQuery)
+				}
+				resBytes, _ := json.Marshal(openai.AdminAPIKeysList{
+					Object: "list",
+					Keys: []openai.AdminAPIKey{
+						{ID: keyID, Object: "organization.admin_api_key"},
+					},
+				})
+				fmt.Fprintln(w, string(resBytes))
+			}
+		},
+	)
+
+	ctx := context.Background()
+
+	key, err := client.CreateAdminAPIKey(ctx, openai.AdminAPIKeyRequest{Name: "New Admin Key"})
+	checks.NoError(t, err, "CreateAdminAPIKey error")
+	if key.Value == "" {
+		t.Error("expected the key value to be returned on creation")
+	}
+
+	key, err = client.RetrieveAdminAPIKey(ctx, keyID)
+	checks.NoError(t, err, "RetrieveAdminAPIKey error")
+	if key.Owner.Role != "owner" {
+		t.Errorf("unexpected owner role: %s", key.Owner.Role)
+	}
+
+	list, err := client.ListAdminAPIKeys(ctx, openai.Pagination{Limit: &limit})
+	checks.NoError(t, err, "ListAdminAPIKeys error")
+	if len(list.Keys) != 1 {
+		t.Errorf("unexpected number of keys: %d", len(list.Keys))
+	}
+
+	deleted, err := client.DeleteAdminAPIKey(ctx, keyID)
+	checks.NoError(t, err, "DeleteAdminAPIKey error")
+	if !deleted.Deleted {
+		t.Error("expected key to be deleted")
+	}
+}
@@ -184,3 +184,56 @@ func TestCloudflareAzureFullURL(t *testing.T) {
 		})
 	}
 }
+
+func TestPathPrefixFullURL(t *testing.T) {
+	cases := []struct {
+		Name       string
+		APIType    APIType
+		BaseURL    string
+		PathPrefix string
+		Expect     string
+	}{
+		{
+			"OpenAINestedPrefix",
+			APITypeOpenAI,
+			"http://host:8000/",
+			"openai/v1/",
+			"http://host:8000/openai/v1/chat/completions",
+		},
+		{
+			"OpenAIPrefixInBaseURL",
+			APITypeOpenAI,
+			"http://host:8000/openai/v1",
+			"",
+			"http://host:8000/openai/v1/chat/completions",
+		},
+		{
+			"AzureCustomPrefix",
+			APITypeAzure,
+			"https://apim.example.com",
+			"/llm",
+			"https://apim.example.com/llm/deployments/chatgpt-demo/chat/completions?api-version=2023-05-15",
+		},
+		{
+			"AzureNoPrefix",
+			APITypeAzure,
+			"https://apim.example.com/",
+			"/",
+			"https://apim.example.com/deployments/chatgpt-demo/chat/completions?api-version=2023-05-15",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			config := DefaultAzureConfig("dummy", c.BaseURL)
+			config.APIType = c.APIType
+			config.PathPrefix = c.PathPrefix
+			cli := NewClientWithConfig(config)
+
+			actual := cli.fullURL("/chat/completions", "chatgpt-demo")
+			if actual != c.Expect {
+				t.Errorf("Expected %s, got %s", c.Expect, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package openai
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+)
+
+const userAgentBase = "go-openai"
+
+// AppInfo identifies the application using the client. It is appended to the
+// User-Agent header, e.g. "go-openai billing-service/1.4.0 (+https://wiki.corp/billing)",
+// so that gateways and API logs can attribute traffic per application.
+type AppInfo struct {
+	Name    string
+	Version string
+	URL     string
+}
+
+func (a *AppInfo) String() string {
+	if a == nil || a.Name == "" {
+		return ""
+	}
+	s := a.Name
+	if a.Version != "" {
+		s += "/" + a.Version
+	}
+	if a.URL != "" {
+		s += fmt.Sprintf(" (+%s)", a.URL)
+	}
+	return s
+}
+
+func (c *Client) userAgent() string {
+	if app := c.config.AppInfo.String(); app != "" {
+		return userAgentBase + " " + app
+	}
+	return userAgentBase
+}
+
+// setPlatformHeaders sets headers describing the runtime, in the format used
+// by the official OpenAI SDKs.
+func setPlatformHeaders(header http.Header) {
+	header.Set("X-Stainless-Lang", "go")
+	header.Set("X-Stainless-Runtime", "go")
+	header.Set("X-Stainless-Runtime-Version", strings.TrimPrefix(runtime.Version(), "go"))
+	header.Set("X-Stainless-OS", runtime.GOOS)
+	header.Set("X-Stainless-Arch", runtime.GOARCH)
+}
@@ -0,0 +1,48 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestAppInfo(t *testing.T) {
+	var header http.Header
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		fmt.Fprintln(w, `{"object":"list","data":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+
+	_, err := openai.NewClientWithConfig(config).ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+	if got := header.Get("User-Agent"); got != "go-openai" {
+		t.Errorf("unexpected default User-Agent %q", got)
+	}
+	if header.Get("X-Stainless-Lang") != "" {
+		t.Error("platform headers must not be sent by default")
+	}
+
+	config.AppInfo = &openai.AppInfo{Name: "billing", Version: "1.4.0", URL: "https://wiki.corp/billing"}
+	config.SendPlatformHeaders = true
+	_, err = openai.NewClientWithConfig(config).ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+	if got := header.Get("User-Agent"); got != "go-openai billing/1.4.0 (+https://wiki.corp/billing)" {
+		t.Errorf("unexpected User-Agent %q", got)
+	}
+	if header.Get("X-Stainless-Lang") != "go" || header.Get("X-Stainless-OS") != runtime.GOOS {
+		t.Errorf("unexpected platform headers %v", header)
+	}
+}
@@ -2,10 +2,8 @@ package openai
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 )
 
 const (
@@ -24,21 +22,59 @@ type Assistant struct {
 	Tools        []AssistantTool `json:"tools"`
 	FileIDs      []string        `json:"file_ids,omitempty"`
 	Metadata     map[string]any  `json:"metadata,omitempty"`
+	// ToolResources are the files available to the assistant's tools.
+	ToolResources *ToolResources `json:"tool_resources,omitempty"`
+	Temperature   *float32       `json:"temperature,omitempty"`
+	TopP          *float32       `json:"top_p,omitempty"`
+	// ResponseFormat is AssistantResponseFormatAuto or a response format object.
+	ResponseFormat any `json:"response_format,omitempty"`
 
 	httpHeader
 }
 
+// AssistantResponseFormatAuto is the default response format of assistants
+// and runs. Other formats are set with a *ChatCompletionResponseFormat, e.g.
+// of the json_schema type.
+const AssistantResponseFormatAuto = "auto"
+
 type AssistantToolType string
 
 const (
 	AssistantToolTypeCodeInterpreter AssistantToolType = "code_interpreter"
 	AssistantToolTypeRetrieval       AssistantToolType = "retrieval"
 	AssistantToolTypeFunction        AssistantToolType = "function"
+	AssistantToolTypeFileSearch      AssistantToolType = "file_search"
 )
 
 type AssistantTool struct {
 	Type     AssistantToolType   `json:"type"`
 	Function *FunctionDefinition `json:"function,omitempty"`
+	// FileSearch tunes a file_search tool. Nil uses the API defaults.
+	FileSearch *FileSearchToolOptions `json:"file_search,omitempty"`
+}
+
+// FileSearchToolOptions tunes the retrieval of a file_search tool.
+type FileSearchToolOptions struct {
+	// MaxNumResults is the maximum number of chunks returned to the model,
+	// between 1 and 50.
+	MaxNumResults int `json:"max_num_results,omitempty"`
+	// RankingOptions filters and orders the retrieved chunks.
+	RankingOptions *FileSearchRankingOptions `json:"ranking_options,omitempty"`
+}
+
+// FileSearchRanker selects the ranker of a file_search tool.
+type FileSearchRanker string
+
+const (
+	FileSearchRankerAuto            FileSearchRanker = "auto"
+	FileSearchRankerDefault20240821 FileSearchRanker = "default_2024_08_21"
+)
+
+// FileSearchRankingOptions configures the ranking of file_search results.
+type FileSearchRankingOptions struct {
+	Ranker FileSearchRanker `json:"ranker,omitempty"`
+	// ScoreThreshold drops the chunks that score lower, between 0 and 1.
+	ScoreThreshold float64 `json:"score_threshold"`
 }
 
 // AssistantRequest provides the assistant request parameters.
@@ -55,6 +91,16 @@ type AssistantRequest struct {
 	ToolResources map[string]interface{} `json:"tool_resources,omitempty"`
 	FileIDs       []string               `json:"file_ids,omitempty"`
 	Metadata      map[string]any         `json:"metadata,omitempty"`
+	// Temperature and TopP are the default sampling parameters of the runs
+	// of the assistant, between 0 and 2 and between 0 and 1.
+	Temperature *float32 `json:"temperature,omitempty"`
+	TopP        *float32 `json:"top_p,omitempty"`
+	// ResponseFormat is AssistantResponseFormatAuto or a
+	// *ChatCompletionResponseFormat, e.g. with a JSON schema.
+	ResponseFormat any `json:"response_format,omitempty"`
+	// ClearFields lists JSON field names, e.g. "instructions", that are sent as
+	// null so that ModifyAssistant clears them.
+	ClearFields []string `json:"-"`
 }
 
 // MarshalJSON provides a custom marshaller for the assistant request to handle the API use cases
@@ -62,6 +108,10 @@ type AssistantRequest struct {
 // If Tools is an empty slice, it's included in the JSON as an empty array ([]).
 // If Tools is populated, it's included in the JSON with the elements.
 func (a AssistantRequest) MarshalJSON() ([]byte, error) {
+	return marshalWithExtraBody(standardJSON{}, a)
+}
+
+func (a AssistantRequest) splitExtraBody() (any, map[string]any) {
 	type Alias AssistantRequest
 	assistantAlias := &struct {
 		Tools *[]AssistantTool `json:"tools,omitempty"`
@@ -74,7 +124,7 @@ func (a AssistantRequest) MarshalJSON() ([]byte, error) {
 		assistantAlias.Tools = &a.Tools
 	}
 
-	return json.Marshal(assistantAlias)
+	return assistantAlias, withClearedFields(nil, a.ClearFields)
 }
 
 // AssistantsList is a list of assistants.
@@ -114,9 +164,13 @@ type AssistantFilesList struct {
 }
 
 // CreateAssistant creates a new assistant.
-func (c *Client) CreateAssistant(ctx context.Context, request AssistantRequest) (response Assistant, err error) {
+func (c *Client) CreateAssistant(
+	ctx context.Context,
+	request AssistantRequest,
+	opts ...RequestOption,
+) (response Assistant, err error) {
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(assistantsSuffix), withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -129,10 +183,11 @@ func (c *Client) CreateAssistant(ctx context.Context, request AssistantRequest)
 func (c *Client) RetrieveAssistant(
 	ctx context.Context,
 	assistantID string,
+	opts ...RequestOption,
 ) (response Assistant, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s", assistantsSuffix, assistantID)
 	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -146,10 +201,11 @@ func (c *Client) ModifyAssistant(
 	ctx context.Context,
 	assistantID string,
 	request AssistantRequest,
+	opts ...RequestOption,
 ) (response Assistant, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s", assistantsSuffix, assistantID)
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -162,10 +218,11 @@ func (c *Client) ModifyAssistant(
 func (c *Client) DeleteAssistant(
 	ctx context.Context,
 	assistantID string,
+	opts ...RequestOption,
 ) (response AssistantDeleteResponse, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s", assistantsSuffix, assistantID)
 	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -175,35 +232,27 @@ func (c *Client) DeleteAssistant(
 }
 
 // ListAssistants Lists the currently available assistants.
+//
+// Deprecated: use ListAssistantsWithOptions.
 func (c *Client) ListAssistants(
 	ctx context.Context,
 	limit *int,
 	order *string,
 	after *string,
 	before *string,
+	opts ...RequestOption,
 ) (response AssistantsList, err error) {
-	urlValues := url.Values{}
-	if limit != nil {
-		urlValues.Add("limit", fmt.Sprintf("%d", *limit))
-	}
-	if order != nil {
-		urlValues.Add("order", *order)
-	}
-	if after != nil {
-		urlValues.Add("after", *after)
-	}
-	if before != nil {
-		urlValues.Add("before", *before)
-	}
-
-	encodedValues := ""
-	if len(urlValues) > 0 {
-		encodedValues = "?" + urlValues.Encode()
-	}
+	return c.ListAssistantsWithOptions(ctx, paginationOptions(limit, order, after, before, opts)...)
+}
 
-	urlSuffix := fmt.Sprintf("%s%s", assistantsSuffix, encodedValues)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+// ListAssistantsWithOptions lists the currently available assistants. Pages
+// are selected with ListOptions such as WithLimit and WithAfter.
+func (c *Client) ListAssistantsWithOptions(
+	ctx context.Context,
+	opts ...RequestOption,
+) (response AssistantsList, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(assistantsSuffix),
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -217,11 +266,12 @@ func (c *Client) CreateAssistantFile(
 	ctx context.Context,
 	assistantID string,
 	request AssistantFileRequest,
+	opts ...RequestOption,
 ) (response AssistantFile, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s%s", assistantsSuffix, assistantID, assistantsFilesSuffix)
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix),
 		withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -235,10 +285,11 @@ func (c *Client) RetrieveAssistantFile(
 	ctx context.Context,
 	assistantID string,
 	fileID string,
+	opts ...RequestOption,
 ) (response AssistantFile, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s%s/%s", assistantsSuffix, assistantID, assistantsFilesSuffix, fileID)
 	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -252,10 +303,11 @@ func (c *Client) DeleteAssistantFile(
 	ctx context.Context,
 	assistantID string,
 	fileID string,
+	opts ...RequestOption,
 ) (err error) {
 	urlSuffix := fmt.Sprintf("%s/%s%s/%s", assistantsSuffix, assistantID, assistantsFilesSuffix, fileID)
 	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -265,6 +317,8 @@ func (c *Client) DeleteAssistantFile(
 }
 
 // ListAssistantFiles Lists the currently available files for an assistant.
+//
+// Deprecated: use ListAssistantFilesWithOptions.
 func (c *Client) ListAssistantFiles(
 	ctx context.Context,
 	assistantID string,
@@ -272,29 +326,21 @@ func (c *Client) ListAssistantFiles(
 	order *string,
 	after *string,
 	before *string,
+	opts ...RequestOption,
 ) (response AssistantFilesList, err error) {
-	urlValues := url.Values{}
-	if limit != nil {
-		urlValues.Add("limit", fmt.Sprintf("%d", *limit))
-	}
-	if order != nil {
-		urlValues.Add("order", *order)
-	}
-	if after != nil {
-		urlValues.Add("after", *after)
-	}
-	if before != nil {
-		urlValues.Add("before", *before)
-	}
-
-	encodedValues := ""
-	if len(urlValues) > 0 {
-		encodedValues = "?" + urlValues.Encode()
-	}
+	return c.ListAssistantFilesWithOptions(ctx, assistantID, paginationOptions(limit, order, after, before, opts)...)
+}
 
-	urlSuffix := fmt.Sprintf("%s/%s%s%s", assistantsSuffix, assistantID, assistantsFilesSuffix, encodedValues)
+// ListAssistantFilesWithOptions lists the currently available files for an
+// assistant. Pages are selected with ListOptions such as WithLimit and WithAfter.
+func (c *Client) ListAssistantFilesWithOptions(
+	ctx context.Context,
+	assistantID string,
+	opts ...RequestOption,
+) (response AssistantFilesList, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s%s", assistantsSuffix, assistantID, assistantsFilesSuffix)
 	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
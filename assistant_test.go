@@ -256,6 +256,71 @@ When asked a question, write and run Python code to answer the question.`
 	})
 }
 
+func TestAssistantRequestClearFields(t *testing.T) {
+	data, err := json.Marshal(openai.AssistantRequest{
+		Model:       openai.GPT4o,
+		Name:        openai.String("kept"),
+		ClearFields: []string{"instructions", "description"},
+	})
+	checks.NoError(t, err)
+
+	const expected = `{"description":null,"instructions":null,"model":"gpt-4o","name":"kept"}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+}
+
+func TestAssistantRequestSampling(t *testing.T) {
+	data, err := json.Marshal(openai.AssistantRequest{
+		Model:       openai.GPT4o,
+		Temperature: openai.Float(0.2),
+		TopP:        openai.Float(0.5),
+		ResponseFormat: &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+				Name:   "answer",
+				Schema: json.RawMessage(`{"type":"object"}`),
+				Strict: true,
+			},
+		},
+	})
+	checks.NoError(t, err)
+
+	const expected = `{"model":"gpt-4o","temperature":0.2,"top_p":0.5,"response_format":{"type":"json_schema",` +
+		`"json_schema":{"name":"answer","schema":{"type":"object"},"strict":true}}}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	var assistant openai.Assistant
+	err = json.Unmarshal([]byte(`{"id":"asst_1","temperature":0.2,"top_p":1,"response_format":"auto"}`), &assistant)
+	checks.NoError(t, err)
+	if *assistant.Temperature != 0.2 || *assistant.TopP != 1 ||
+		assistant.ResponseFormat != openai.AssistantResponseFormatAuto {
+		t.Errorf("unexpected assistant %+v", assistant)
+	}
+}
+
+func TestAssistantFileSearchTool(t *testing.T) {
+	data, err := json.Marshal(openai.AssistantTool{
+		Type: openai.AssistantToolTypeFileSearch,
+		FileSearch: &openai.FileSearchToolOptions{
+			MaxNumResults: 5,
+			RankingOptions: &openai.FileSearchRankingOptions{
+				Ranker:         openai.FileSearchRankerAuto,
+				ScoreThreshold: 0.5,
+			},
+		},
+	})
+	checks.NoError(t, err)
+
+	const expected = `{"type":"file_search","file_search":{"max_num_results":5,` +
+		`"ranking_options":{"ranker":"auto","score_threshold":0.5}}}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+}
+
 func TestAzureAssistant(t *testing.T) {
 	assistantID := "asst_abc123"
 	assistantName := "Ambrogio"
@@ -13,7 +13,12 @@ import (
 
 // Whisper Defines the models provided by OpenAI to use when processing audio with OpenAI.
 const (
-	Whisper1 = "whisper-1"
+	Whisper1            = "whisper-1"
+	GPT4oTranscribe     = "gpt-4o-transcribe"
+	GPT4oMiniTranscribe = "gpt-4o-mini-transcribe"
+	// GPT4oTranscribeDiarize labels the speakers of the transcription, see
+	// AudioResponseFormatDiarizedJSON.
+	GPT4oTranscribeDiarize = "gpt-4o-transcribe-diarize"
 )
 
 // Response formats; Whisper uses AudioResponseFormatJSON by default.
@@ -25,8 +30,21 @@ const (
 	AudioResponseFormatSRT         AudioResponseFormat = "srt"
 	AudioResponseFormatVerboseJSON AudioResponseFormat = "verbose_json"
 	AudioResponseFormatVTT         AudioResponseFormat = "vtt"
+	// AudioResponseFormatDiarizedJSON returns the segments of each speaker in
+	// AudioResponse.DiarizedSegments. Only for GPT4oTranscribeDiarize.
+	AudioResponseFormatDiarizedJSON AudioResponseFormat = "diarized_json"
 )
 
+// KnownSpeaker names a speaker of a diarized transcription from a sample of
+// their voice.
+type KnownSpeaker struct {
+	// Name is the label given to the segments of the speaker.
+	Name string
+	// Reference is a data URL of a 2 to 10 seconds audio sample of the
+	// speaker, e.g. "data:audio/wav;base64,...".
+	Reference string
+}
+
 type TranscriptionTimestampGranularity string
 
 const (
@@ -49,6 +67,14 @@ type AudioRequest struct {
 	Language               string // Only for transcription.
 	Format                 AudioResponseFormat
 	TimestampGranularities []TranscriptionTimestampGranularity // Only for transcription.
+
+	// ChunkingStrategy splits the audio before transcribing it, "auto" to
+	// split on silences. Required by GPT4oTranscribeDiarize for audio longer
+	// than 30 seconds.
+	ChunkingStrategy string
+	// KnownSpeakers labels the segments of these speakers with their names
+	// instead of letters. Only for GPT4oTranscribeDiarize.
+	KnownSpeakers []KnownSpeaker
 }
 
 // AudioResponse represents a response structure for audio API.
@@ -75,10 +101,44 @@ type AudioResponse struct {
 		End   float64 `json:"end"`
 	} `json:"words"`
 	Text string `json:"text"`
+	// DiarizedSegments are the segments of each speaker, returned for
+	// AudioResponseFormatDiarizedJSON.
+	DiarizedSegments []DiarizedSegment `json:"-"`
+
+	httpHeader
+}
+
+// DiarizedSegment is a part of a diarized transcription spoken by a single
+// speaker.
+type DiarizedSegment struct {
+	ID    string  `json:"id"`
+	Type  string  `json:"type"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+	// Speaker is the name of a KnownSpeaker, or a letter for the others.
+	Speaker string `json:"speaker"`
+}
+
+type diarizedAudioResponse struct {
+	Task     string            `json:"task"`
+	Duration float64           `json:"duration"`
+	Text     string            `json:"text"`
+	Segments []DiarizedSegment `json:"segments"`
 
 	httpHeader
 }
 
+func (r *diarizedAudioResponse) ToAudioResponse() AudioResponse {
+	return AudioResponse{
+		Task:             r.Task,
+		Duration:         r.Duration,
+		Text:             r.Text,
+		DiarizedSegments: r.Segments,
+		httpHeader:       r.httpHeader,
+	}
+}
+
 type audioTextResponse struct {
 	Text string `json:"text"`
 
@@ -96,16 +156,18 @@ func (r *audioTextResponse) ToAudioResponse() AudioResponse {
 func (c *Client) CreateTranscription(
 	ctx context.Context,
 	request AudioRequest,
+	opts ...RequestOption,
 ) (response AudioResponse, err error) {
-	return c.callAudioAPI(ctx, request, "transcriptions")
+	return c.callAudioAPI(ctx, request, "transcriptions", opts...)
 }
 
 // CreateTranslation — API call to translate audio into English.
 func (c *Client) CreateTranslation(
 	ctx context.Context,
 	request AudioRequest,
+	opts ...RequestOption,
 ) (response AudioResponse, err error) {
-	return c.callAudioAPI(ctx, request, "translations")
+	return c.callAudioAPI(ctx, request, "translations", opts...)
 }
 
 // callAudioAPI — API call to an audio endpoint.
@@ -113,6 +175,7 @@ func (c *Client) callAudioAPI(
 	ctx context.Context,
 	request AudioRequest,
 	endpointSuffix string,
+	opts ...RequestOption,
 ) (response AudioResponse, err error) {
 	var formBody bytes.Buffer
 	builder := c.createFormBuilder(&formBody)
@@ -122,15 +185,20 @@ func (c *Client) callAudioAPI(
 	}
 
 	urlSuffix := fmt.Sprintf("/audio/%s", endpointSuffix)
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix, request.Model),
-		withBody(&formBody), withContentType(builder.FormDataContentType()))
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix, request.Model), withModel(request.Model),
+		withBody(&formBody), withContentType(builder.FormDataContentType()), withRequestOptions(opts))
 	if err != nil {
 		return AudioResponse{}, err
 	}
 
-	if request.HasJSONResponse() {
+	switch {
+	case request.Format == AudioResponseFormatDiarizedJSON:
+		var diarizedResponse diarizedAudioResponse
+		err = c.sendRequest(req, &diarizedResponse)
+		response = diarizedResponse.ToAudioResponse()
+	case request.HasJSONResponse():
 		err = c.sendRequest(req, &response)
-	} else {
+	default:
 		var textResponse audioTextResponse
 		err = c.sendRequest(req, &textResponse)
 		response = textResponse.ToAudioResponse()
@@ -143,7 +211,8 @@ func (c *Client) callAudioAPI(
 
 // HasJSONResponse returns true if the response format is JSON.
 func (r AudioRequest) HasJSONResponse() bool {
-	return r.Format == "" || r.Format == AudioResponseFormatJSON || r.Format == AudioResponseFormatVerboseJSON
+	return r.Format == "" || r.Format == AudioResponseFormatJSON || r.Format == AudioResponseFormatVerboseJSON ||
+		r.Format == AudioResponseFormatDiarizedJSON
 }
 
 // audioMultipartForm creates a form with audio file contents and the name of the model to use for
@@ -200,6 +269,24 @@ func audioMultipartForm(request AudioRequest, b utils.FormBuilder) error {
 		}
 	}
 
+	if request.ChunkingStrategy != "" {
+		err = b.WriteField("chunking_strategy", request.ChunkingStrategy)
+		if err != nil {
+			return fmt.Errorf("writing chunking_strategy: %w", err)
+		}
+	}
+
+	for _, speaker := range request.KnownSpeakers {
+		err = b.WriteField("known_speaker_names[]", speaker.Name)
+		if err != nil {
+			return fmt.Errorf("writing known_speaker_names[]: %w", err)
+		}
+		err = b.WriteField("known_speaker_references[]", speaker.Reference)
+		if err != nil {
+			return fmt.Errorf("writing known_speaker_references[]: %w", err)
+		}
+	}
+
 	// Close the multipart writer
 	return b.Close()
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"mime"
 	"mime/multipart"
@@ -26,7 +27,7 @@ func TestAudio(t *testing.T) {
 
 	testcases := []struct {
 		name     string
-		createFn func(context.Context, openai.AudioRequest) (openai.AudioResponse, error)
+		createFn func(context.Context, openai.AudioRequest, ...openai.RequestOption) (openai.AudioResponse, error)
 	}{
 		{
 			"transcribe",
@@ -76,7 +77,7 @@ func TestAudioWithOptionalArgs(t *testing.T) {
 
 	testcases := []struct {
 		name     string
-		createFn func(context.Context, openai.AudioRequest) (openai.AudioResponse, error)
+		createFn func(context.Context, openai.AudioRequest, ...openai.RequestOption) (openai.AudioResponse, error)
 	}{
 		{
 			"transcribe",
@@ -164,3 +165,43 @@ func handleAudioEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func TestAudioDiarization(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		checks.NoError(t, r.ParseMultipartForm(1<<20), "ParseMultipartForm error")
+		if got := r.MultipartForm.Value["known_speaker_names[]"]; strings.Join(got, ",") != "agent,customer" {
+			t.Errorf("unexpected known speaker names %v", got)
+		}
+		if got := r.MultipartForm.Value["known_speaker_references[]"]; len(got) != 2 ||
+			got[0] != "data:audio/wav;base64,AAA=" {
+			t.Errorf("unexpected known speaker references %v", got)
+		}
+		if r.FormValue("chunking_strategy") != "auto" || r.FormValue("response_format") != "diarized_json" {
+			t.Errorf("unexpected form %v", r.MultipartForm.Value)
+		}
+		fmt.Fprint(w, `{"task":"transcribe","duration":4.5,"text":"Hello. Hi.","segments":[`+
+			`{"type":"transcript.text.segment","id":"seg_0","start":0,"end":2,"text":"Hello.","speaker":"agent"},`+
+			`{"type":"transcript.text.segment","id":"seg_1","start":2,"end":4.5,"text":"Hi.","speaker":"A"}]}`)
+	})
+
+	response, err := client.CreateTranscription(context.Background(), openai.AudioRequest{
+		Model:            openai.GPT4oTranscribeDiarize,
+		FilePath:         "call.wav",
+		Reader:           bytes.NewBufferString("wav data"),
+		Format:           openai.AudioResponseFormatDiarizedJSON,
+		ChunkingStrategy: "auto",
+		KnownSpeakers: []openai.KnownSpeaker{
+			{Name: "agent", Reference: "data:audio/wav;base64,AAA="},
+			{Name: "customer", Reference: "data:audio/wav;base64,BBB="},
+		},
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if response.Text != "Hello. Hi." || response.Duration != 4.5 || len(response.DiarizedSegments) != 2 {
+		t.Fatalf("unexpected response %+v", response)
+	}
+	if segment := response.DiarizedSegments[1]; segment.ID != "seg_1" || segment.Speaker != "A" || segment.Start != 2 {
+		t.Errorf("unexpected segment %+v", segment)
+	}
+}
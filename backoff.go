@@ -0,0 +1,142 @@
+package openai
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Backoff decides whether and when a failed attempt of a call is retried.
+// Implementations must be safe for concurrent use.
+type Backoff interface {
+	// NextDelay returns the delay before retry number attempt, starting at 0,
+	// of a call whose last attempt was answered with resp or failed with err,
+	// and false when the call should not be retried.
+	NextDelay(attempt int, resp *http.Response, err error) (time.Duration, bool)
+}
+
+// ExponentialBackoff doubles the delay with every retry, from Min up to Max,
+// and randomizes it by Jitter to spread the retries of concurrent callers.
+type ExponentialBackoff struct {
+	// Min is the delay before the first retry. Defaults to 500ms.
+	Min time.Duration
+	// Max caps the delay. Defaults to 8s.
+	Max time.Duration
+	// Jitter is the fraction of the delay that is randomized, from 0 to 1:
+	// with 0.2, delays are between 80% and 100% of the exponential delay.
+	Jitter float64
+}
+
+// NextDelay implements Backoff.
+func (b ExponentialBackoff) NextDelay(attempt int, _ *http.Response, _ error) (time.Duration, bool) {
+	minDelay, maxDelay := b.Min, b.Max
+	if minDelay <= 0 {
+		minDelay = defaultRetryMinBackoff
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxBackoff
+	}
+
+	delay := minDelay
+	for i := 0; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	if b.Jitter > 0 {
+		jitter := b.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay -= time.Duration(jitter * rand.Float64() * float64(delay)) //nolint:gosec // not security sensitive
+	}
+	return delay, true
+}
+
+// ConstantBackoff waits the same delay before every retry.
+type ConstantBackoff time.Duration
+
+// NextDelay implements Backoff.
+func (b ConstantBackoff) NextDelay(int, *http.Response, error) (time.Duration, bool) {
+	return time.Duration(b), true
+}
+
+// RateLimitBackoff waits as long as the server asks: the Retry-After header
+// or, on 429 responses, until the exhausted limit is reset according to the
+// x-ratelimit-reset-requests and x-ratelimit-reset-tokens headers. Responses
+// without these headers and transport errors are retried after the delay of
+// Fallback.
+type RateLimitBackoff struct {
+	// Fallback is used when the response has no rate limit headers. Defaults
+	// to an ExponentialBackoff with the default delays.
+	Fallback Backoff
+	// Max caps the delay requested by the server. Calls that would wait longer
+	// are not retried. Zero means no limit.
+	Max time.Duration
+}
+
+// NextDelay implements Backoff.
+func (b RateLimitBackoff) NextDelay(attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	delay, ok := retryAfter(resp)
+	if !ok {
+		delay, ok = rateLimitResetDelay(resp)
+	}
+	if !ok {
+		fallback := b.Fallback
+		if fallback == nil {
+			fallback = ExponentialBackoff{}
+		}
+		return fallback.NextDelay(attempt, resp, err)
+	}
+	if b.Max > 0 && delay > b.Max {
+		return 0, false
+	}
+	return delay, true
+}
+
+// rateLimitResetDelay returns the time until the rate limits of a 429 response
+// are reset: the exhausted ones, or all of them when none is reported
+// exhausted.
+func rateLimitResetDelay(resp *http.Response) (time.Duration, bool) {
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	var resets, exhausted []time.Duration
+	for _, limit := range []string{"requests", "tokens"} {
+		reset, ok := parseResetDuration(resp.Header.Get("x-ratelimit-reset-" + limit))
+		if !ok {
+			continue
+		}
+		resets = append(resets, reset)
+		if resp.Header.Get("x-ratelimit-remaining-"+limit) == "0" {
+			exhausted = append(exhausted, reset)
+		}
+	}
+	if len(exhausted) > 0 {
+		resets = exhausted
+	}
+	if len(resets) == 0 {
+		return 0, false
+	}
+	delay := resets[0]
+	for _, reset := range resets[1:] {
+		if reset > delay {
+			delay = reset
+		}
+	}
+	return delay, true
+}
+
+// parseResetDuration parses a rate limit reset header, either a duration such
+// as "6m0s" or a number of seconds.
+func parseResetDuration(value string) (time.Duration, bool) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return d, true
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	return 0, false
+}
@@ -0,0 +1,143 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := openai.ExponentialBackoff{Min: 100 * time.Millisecond, Max: time.Second}
+	for attempt, expected := range []time.Duration{
+		100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second,
+	} {
+		delay, retry := backoff.NextDelay(attempt, nil, nil)
+		if !retry || delay != expected {
+			t.Errorf("attempt %d: expected %s, got %s (retry %t)", attempt, expected, delay, retry)
+		}
+	}
+
+	backoff.Jitter = 0.5
+	for attempt := 0; attempt < 20; attempt++ {
+		delay, _ := backoff.NextDelay(3, nil, nil)
+		if delay < 400*time.Millisecond || delay > 800*time.Millisecond {
+			t.Fatalf("jittered delay %s is outside of [400ms, 800ms]", delay)
+		}
+	}
+}
+
+func TestConstantBackoff(t *testing.T) {
+	delay, retry := openai.ConstantBackoff(time.Second).NextDelay(5, nil, errors.New("reset"))
+	if !retry || delay != time.Second {
+		t.Errorf("expected 1s, got %s (retry %t)", delay, retry)
+	}
+}
+
+func TestRateLimitBackoff(t *testing.T) {
+	response := func(status int, headers ...string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}}
+		for i := 0; i < len(headers); i += 2 {
+			resp.Header.Set(headers[i], headers[i+1])
+		}
+		return resp
+	}
+	backoff := openai.RateLimitBackoff{Fallback: openai.ConstantBackoff(time.Millisecond), Max: time.Minute}
+
+	testCases := []struct {
+		name  string
+		resp  *http.Response
+		delay time.Duration
+		retry bool
+	}{
+		{"retry after", response(http.StatusServiceUnavailable, "Retry-After", "3"), 3 * time.Second, true},
+		{
+			"exhausted tokens",
+			response(http.StatusTooManyRequests,
+				"x-ratelimit-remaining-requests", "10", "x-ratelimit-reset-requests", "30s",
+				"x-ratelimit-remaining-tokens", "0", "x-ratelimit-reset-tokens", "1.5s"),
+			1500 * time.Millisecond,
+			true,
+		},
+		{
+			"latest reset",
+			response(http.StatusTooManyRequests, "x-ratelimit-reset-requests", "2s", "x-ratelimit-reset-tokens", "5s"),
+			5 * time.Second,
+			true,
+		},
+		{"too long", response(http.StatusTooManyRequests, "Retry-After", "3600"), 0, false},
+		{"fallback", response(http.StatusBadGateway), time.Millisecond, true},
+		{"transport error", nil, time.Millisecond, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			delay, retry := backoff.NextDelay(0, tc.resp, nil)
+			if delay != tc.delay || retry != tc.retry {
+				t.Errorf("expected %s (retry %t), got %s (retry %t)", tc.delay, tc.retry, delay, retry)
+			}
+		})
+	}
+}
+
+type recordingBackoff struct {
+	mu       sync.Mutex
+	attempts []int
+	stopAt   int
+}
+
+func (b *recordingBackoff) NextDelay(attempt int, _ *http.Response, _ error) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.attempts = append(b.attempts, attempt)
+	return time.Millisecond, attempt < b.stopAt
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	attempts := 0
+	backoff := &recordingBackoff{stopAt: 1}
+	client := setupRetryAllTestClient(t, openai.RetryPolicy{MaxRetries: 5, Backoff: backoff},
+		func(w http.ResponseWriter, _ *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error":{"message":"overloaded","type":"server_error"}}`)
+		})
+
+	_, err := client.CreateChatCompletionStream(context.Background(), retryTestRequest)
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected a 503 APIError, got %v", err)
+	}
+	if attempts != 2 || fmt.Sprint(backoff.attempts) != "[0 1]" {
+		t.Errorf("expected the backoff to stop after 2 attempts, got %d attempts, backoff calls %v",
+			attempts, backoff.attempts)
+	}
+}
+
+func TestRetryPolicyRetriesTransportErrors(t *testing.T) {
+	attempts := 0
+	client := setupRetryAllTestClient(t, openai.RetryPolicy{MaxRetries: 2, Backoff: openai.ConstantBackoff(0)},
+		func(w http.ResponseWriter, _ *http.Request) {
+			attempts++
+			if attempts == 1 {
+				conn, _, err := w.(http.Hijacker).Hijack()
+				checks.NoError(t, err, "Hijack error")
+				conn.Close()
+				return
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: [DONE]\n\n")
+		})
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), retryTestRequest)
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	stream.Close()
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
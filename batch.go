@@ -0,0 +1,173 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	batchesSuffix = "/batches"
+)
+
+// BatchCompletionWindow24h is the only completion window supported by the API.
+const BatchCompletionWindow24h = "24h"
+
+// BatchStatus is the lifecycle state of a batch.
+type BatchStatus string
+
+const (
+	BatchStatusValidating BatchStatus = "validating"
+	BatchStatusFailed     BatchStatus = "failed"
+	BatchStatusInProgress BatchStatus = "in_progress"
+	BatchStatusFinalizing BatchStatus = "finalizing"
+	BatchStatusCompleted  BatchStatus = "completed"
+	BatchStatusExpired    BatchStatus = "expired"
+	BatchStatusCancelling BatchStatus = "cancelling"
+	BatchStatusCancelled  BatchStatus = "cancelled"
+)
+
+// IsTerminal reports whether a batch with status s has finished and will not
+// change anymore.
+func (s BatchStatus) IsTerminal() bool {
+	switch s {
+	case BatchStatusFailed, BatchStatusCompleted, BatchStatusExpired, BatchStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Batch represents a batch of requests processed asynchronously. The results
+// are in the output and error files, read with GetFileContent and parsed with
+// ParseBatchResponses and ParseBatchErrors.
+type Batch struct {
+	ID               string             `json:"id"`
+	Object           string             `json:"object"`
+	Endpoint         BatchEndpoint      `json:"endpoint"`
+	Errors           *BatchErrors       `json:"errors,omitempty"`
+	InputFileID      string             `json:"input_file_id"`
+	CompletionWindow string             `json:"completion_window"`
+	Status           BatchStatus        `json:"status"`
+	OutputFileID     *string            `json:"output_file_id,omitempty"`
+	ErrorFileID      *string            `json:"error_file_id,omitempty"`
+	CreatedAt        int64              `json:"created_at"`
+	InProgressAt     *int64             `json:"in_progress_at,omitempty"`
+	ExpiresAt        *int64             `json:"expires_at,omitempty"`
+	FinalizingAt     *int64             `json:"finalizing_at,omitempty"`
+	CompletedAt      *int64             `json:"completed_at,omitempty"`
+	FailedAt         *int64             `json:"failed_at,omitempty"`
+	ExpiredAt        *int64             `json:"expired_at,omitempty"`
+	CancellingAt     *int64             `json:"cancelling_at,omitempty"`
+	CancelledAt      *int64             `json:"cancelled_at,omitempty"`
+	RequestCounts    BatchRequestCounts `json:"request_counts"`
+	Metadata         map[string]string  `json:"metadata,omitempty"`
+
+	httpHeader
+}
+
+// BatchErrors lists the errors found in the input file of a batch that failed
+// validation.
+type BatchErrors struct {
+	Object string       `json:"object"`
+	Data   []BatchError `json:"data"`
+}
+
+// BatchError is an error in the input file of a batch. Line is the line of the
+// input file that caused it, if any.
+type BatchError struct {
+	Code    string  `json:"code"`
+	Message string  `json:"message"`
+	Param   *string `json:"param,omitempty"`
+	Line    *int    `json:"line,omitempty"`
+}
+
+// BatchRequestCounts counts the requests of a batch by outcome.
+type BatchRequestCounts struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+}
+
+// BatchRequest represents a request to create a batch from an input file
+// uploaded with PurposeBatch. CompletionWindow defaults to
+// BatchCompletionWindow24h.
+type BatchRequest struct {
+	InputFileID      string            `json:"input_file_id"`
+	Endpoint         BatchEndpoint     `json:"endpoint"`
+	CompletionWindow string            `json:"completion_window"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+// BatchesList is a list of batches.
+type BatchesList struct {
+	Object  string  `json:"object"`
+	Batches []Batch `json:"data"`
+	FirstID *string `json:"first_id"`
+	LastID  *string `json:"last_id"`
+	HasMore bool    `json:"has_more"`
+
+	httpHeader
+}
+
+// CreateBatch creates a batch that sends the requests of an input file, e.g.
+// written with WriteBatchLines and uploaded with CreateFileBytes. Poll
+// RetrieveBatch until its status is terminal.
+func (c *Client) CreateBatch(
+	ctx context.Context,
+	request BatchRequest,
+	opts ...RequestOption,
+) (response Batch, err error) {
+	if request.CompletionWindow == "" {
+		request.CompletionWindow = BatchCompletionWindow24h
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(batchesSuffix), withBody(request), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// RetrieveBatch retrieves a batch, including its status and request counts.
+func (c *Client) RetrieveBatch(ctx context.Context, batchID string, opts ...RequestOption) (response Batch, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", batchesSuffix, batchID)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// CancelBatch cancels an in-progress batch. The batch is cancelling for up to
+// 10 minutes before it is cancelled, with the results of the requests that
+// completed available in its output file.
+func (c *Client) CancelBatch(ctx context.Context, batchID string, opts ...RequestOption) (response Batch, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/cancel", batchesSuffix, batchID)
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ListBatches lists the batches of the organization.
+func (c *Client) ListBatches(
+	ctx context.Context,
+	pagination Pagination,
+	opts ...RequestOption,
+) (response BatchesList, err error) {
+	urlSuffix := batchesSuffix + pagination.encode()
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
@@ -0,0 +1,93 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// BatchRequestError is a request of a batch that failed, as reported in the
+// error file of the batch.
+type BatchRequestError struct {
+	// ID is the ID the batch gave to the request.
+	ID string
+	// CustomID is the ID given to the request in the input file.
+	CustomID string
+	// StatusCode is the HTTP status of the response to the request, or zero
+	// when the request was not sent, e.g. because the batch expired.
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *BatchRequestError) Error() string {
+	if e.StatusCode > 0 {
+		return fmt.Sprintf("batch request %s failed, status code: %d, message: %s", e.CustomID, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("batch request %s failed, code: %s, message: %s", e.CustomID, e.Code, e.Message)
+}
+
+type batchResultLine struct {
+	ID       string `json:"id"`
+	CustomID string `json:"custom_id"`
+	Response *struct {
+		StatusCode int           `json:"status_code"`
+		Body       ErrorResponse `json:"body"`
+	} `json:"response"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ParseBatchErrors reads the JSONL error file of a batch, e.g. from
+// GetFileContent, and returns the failed requests by custom_id. Lines of
+// requests that succeeded are skipped, so an output file may be given too.
+func ParseBatchErrors(r io.Reader) (map[string]*BatchRequestError, error) {
+	failures := make(map[string]*BatchRequestError)
+	reader := bufio.NewReader(r)
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return failures, err
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			var result batchResultLine
+			if unmarshalErr := json.Unmarshal(line, &result); unmarshalErr != nil {
+				return failures, fmt.Errorf("batch error file line %d: %w", lineNumber, unmarshalErr)
+			}
+			if failure := result.failure(); failure != nil {
+				failures[failure.CustomID] = failure
+			}
+		}
+		if err != nil {
+			return failures, nil
+		}
+	}
+}
+
+// failure returns the error of the request, or nil when it succeeded.
+func (l *batchResultLine) failure() *BatchRequestError {
+	failure := &BatchRequestError{ID: l.ID, CustomID: l.CustomID}
+	switch {
+	case l.Error != nil:
+		failure.Code = l.Error.Code
+		failure.Message = l.Error.Message
+	case l.Response != nil && l.Response.StatusCode >= http.StatusBadRequest:
+		failure.StatusCode = l.Response.StatusCode
+		if apiErr := l.Response.Body.Error; apiErr != nil {
+			failure.Code = fmt.Sprint(apiErr.Code)
+			if apiErr.Code == nil {
+				failure.Code = apiErr.Type
+			}
+			failure.Message = apiErr.Message
+		}
+	default:
+		return nil
+	}
+	return failure
+}
@@ -0,0 +1,41 @@
+package openai_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestParseBatchErrors(t *testing.T) {
+	const file = `{"id":"batch_req_1","custom_id":"req-1","response":{"status_code":200,"body":{}},"error":null}
+{"id":"batch_req_2","custom_id":"req-2","response":{"status_code":400,"body":` +
+		`{"error":{"message":"bad model","type":"invalid_request_error","code":"model_not_found"}}},"error":null}
+
+{"id":"batch_req_3","custom_id":"req-3","response":null,"error":{"code":"batch_expired","message":"expired"}}`
+
+	failures, err := openai.ParseBatchErrors(strings.NewReader(file))
+	checks.NoError(t, err, "ParseBatchErrors error")
+	if len(failures) != 2 || failures["req-1"] != nil {
+		t.Fatalf("expected the two failed requests, got %v", failures)
+	}
+	if got := failures["req-2"]; got.ID != "batch_req_2" || got.StatusCode != 400 ||
+		got.Code != "model_not_found" || got.Message != "bad model" {
+		t.Errorf("unexpected failure %+v", got)
+	}
+	if got := failures["req-3"]; got.StatusCode != 0 || got.Code != "batch_expired" || got.Message != "expired" {
+		t.Errorf("unexpected failure %+v", got)
+	}
+
+	var batchErr *openai.BatchRequestError
+	if !errors.As(error(failures["req-2"]), &batchErr) || batchErr.Error() == "" {
+		t.Error("expected failures to be errors")
+	}
+
+	_, err = openai.ParseBatchErrors(strings.NewReader("{}\nnot json\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected an error for line 2, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// BatchEndpoint is the endpoint the requests of a batch are sent to.
+type BatchEndpoint string
+
+const (
+	BatchEndpointChatCompletions BatchEndpoint = "/v1/chat/completions"
+	BatchEndpointCompletions     BatchEndpoint = "/v1/completions"
+	BatchEndpointEmbeddings      BatchEndpoint = "/v1/embeddings"
+)
+
+// BatchRequestLine is a line of the JSONL input file of a batch, with a
+// request body of type R, such as ChatCompletionRequest or EmbeddingRequest.
+// Lines are built with NewChatBatchLine, NewCompletionBatchLine and
+// NewEmbeddingBatchLine, so that the body matches the endpoint, and written
+// with WriteBatchLines.
+type BatchRequestLine[R any] struct {
+	// CustomID identifies the request in the output and error files. It must
+	// be unique in the batch.
+	CustomID string        `json:"custom_id"`
+	Method   string        `json:"method"`
+	URL      BatchEndpoint `json:"url"`
+	Body     R             `json:"body"`
+}
+
+// NewChatBatchLine returns a line sending request to the chat completions
+// endpoint.
+func NewChatBatchLine(customID string, request ChatCompletionRequest) BatchRequestLine[ChatCompletionRequest] {
+	return BatchRequestLine[ChatCompletionRequest]{
+		CustomID: customID,
+		Method:   http.MethodPost,
+		URL:      BatchEndpointChatCompletions,
+		Body:     request,
+	}
+}
+
+// NewCompletionBatchLine returns a line sending request to the legacy
+// completions endpoint.
+func NewCompletionBatchLine(customID string, request CompletionRequest) BatchRequestLine[CompletionRequest] {
+	return BatchRequestLine[CompletionRequest]{
+		CustomID: customID,
+		Method:   http.MethodPost,
+		URL:      BatchEndpointCompletions,
+		Body:     request,
+	}
+}
+
+// NewEmbeddingBatchLine returns a line sending request to the embeddings
+// endpoint.
+func NewEmbeddingBatchLine(customID string, request EmbeddingRequest) BatchRequestLine[EmbeddingRequest] {
+	return BatchRequestLine[EmbeddingRequest]{
+		CustomID: customID,
+		Method:   http.MethodPost,
+		URL:      BatchEndpointEmbeddings,
+		Body:     request,
+	}
+}
+
+// WriteBatchLines writes lines as the JSONL input file of a batch, e.g. to
+// upload with CreateFileBytes and PurposeBatch before calling CreateBatch.
+func WriteBatchLines[R any](w io.Writer, lines ...BatchRequestLine[R]) error {
+	encoder := json.NewEncoder(w)
+	for _, line := range lines {
+		if err := encoder.Encode(line); err != nil {
+			return fmt.Errorf("batch line %s: %w", line.CustomID, err)
+		}
+	}
+	return nil
+}
+
+// BatchResponse is the response to a request of a batch, with a body of type
+// T, such as ChatCompletionResponse or EmbeddingResponse. The body of failed
+// requests, with a StatusCode of 400 or more, holds an error instead, see
+// ParseBatchErrors.
+type BatchResponse[T any] struct {
+	StatusCode int    `json:"status_code"`
+	RequestID  string `json:"request_id"`
+	Body       T      `json:"body"`
+}
+
+// BatchResponseLine is a line of the JSONL output file of a batch. Response
+// is nil for requests that were not sent, e.g. because the batch expired, and
+// Error then tells why.
+type BatchResponseLine[T any] struct {
+	ID       string            `json:"id"`
+	CustomID string            `json:"custom_id"`
+	Response *BatchResponse[T] `json:"response"`
+	Error    *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ParseBatchResponses reads the JSONL output file of a batch, e.g. from
+// GetFileContent, with response bodies of type T:
+//
+//	lines, err := openai.ParseBatchResponses[openai.ChatCompletionResponse](content)
+func ParseBatchResponses[T any](r io.Reader) ([]BatchResponseLine[T], error) {
+	var lines []BatchResponseLine[T]
+	reader := bufio.NewReader(r)
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return lines, err
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			var result BatchResponseLine[T]
+			if unmarshalErr := json.Unmarshal(line, &result); unmarshalErr != nil {
+				return lines, fmt.Errorf("batch output file line %d: %w", lineNumber, unmarshalErr)
+			}
+			lines = append(lines, result)
+		}
+		if err != nil {
+			return lines, nil
+		}
+	}
+}
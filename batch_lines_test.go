@@ -0,0 +1,71 @@
+package openai_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestWriteBatchLines(t *testing.T) {
+	var file bytes.Buffer
+	err := openai.WriteBatchLines(&file,
+		openai.NewChatBatchLine("req-1", openai.ChatCompletionRequest{
+			Model:    openai.GPT4o,
+			Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "Hello!"}},
+		}),
+	)
+	checks.NoError(t, err, "WriteBatchLines error")
+	err = openai.WriteBatchLines(&file,
+		openai.NewEmbeddingBatchLine("req-2", openai.EmbeddingRequest{
+			Input: []string{"hello"},
+			Model: openai.SmallEmbedding3,
+		}),
+	)
+	checks.NoError(t, err, "WriteBatchLines error")
+	err = openai.WriteBatchLines(&file,
+		openai.NewCompletionBatchLine("req-3", openai.CompletionRequest{
+			Model:     openai.GPT3Dot5TurboInstruct,
+			Prompt:    "Say hi",
+			MaxTokens: 5,
+		}),
+	)
+	checks.NoError(t, err, "WriteBatchLines error")
+
+	const expected = `{"custom_id":"req-1","method":"POST","url":"/v1/chat/completions","body":` +
+		`{"model":"gpt-4o","messages":[{"role":"user","content":"Hello!"}]}}
+{"custom_id":"req-2","method":"POST","url":"/v1/embeddings","body":` +
+		`{"input":["hello"],"model":"text-embedding-3-small","user":""}}
+{"custom_id":"req-3","method":"POST","url":"/v1/completions","body":` +
+		`{"model":"gpt-3.5-turbo-instruct","prompt":"Say hi","max_tokens":5}}
+`
+	if file.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, file.String())
+	}
+}
+
+func TestParseBatchResponses(t *testing.T) {
+	const file = `{"id":"batch_req_1","custom_id":"req-1","response":{"status_code":200,"request_id":"req_abc",` +
+		`"body":{"id":"chatcmpl-1","choices":[{"index":0,"message":{"role":"assistant","content":"Hi!"}}]}},` +
+		`"error":null}
+
+{"id":"batch_req_2","custom_id":"req-2","response":null,"error":{"code":"batch_expired","message":"expired"}}`
+
+	lines, err := openai.ParseBatchResponses[openai.ChatCompletionResponse](strings.NewReader(file))
+	checks.NoError(t, err, "ParseBatchResponses error")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	if response := lines[0].Response; response == nil || response.StatusCode != 200 ||
+		response.RequestID != "req_abc" || response.Body.Choices[0].Message.Content != "Hi!" {
+		t.Errorf("unexpected response %+v", lines[0].Response)
+	}
+	if lines[1].Response != nil || lines[1].Error == nil || lines[1].Error.Code != "batch_expired" {
+		t.Errorf("unexpected expired line %+v", lines[1])
+	}
+
+	_, err = openai.ParseBatchResponses[openai.EmbeddingResponse](strings.NewReader("{"))
+	checks.HasError(t, err, "malformed lines should fail")
+}
@@ -0,0 +1,101 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// TestBatches Tests the batch endpoints of the API using the mocked server.
+func TestBatches(t *testing.T) {
+	batchID := "batch_abc123"
+
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler(
+		"/v1/batches/"+batchID+"/cancel",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			fmt.Fprintf(w, `{"id":%q,"object":"batch","status":"cancelling"}`, batchID)
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/batches/"+batchID,
+		func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprintf(w, `{"id":%q,"object":"batch","endpoint":"/v1/chat/completions","status":"completed",`+
+				`"output_file_id":"file-out","request_counts":{"total":2,"completed":1,"failed":1}}`, batchID)
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/batches",
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				var request openai.BatchRequest
+				err := json.NewDecoder(r.Body).Decode(&request)
+				checks.NoError(t, err, "Decode error")
+
+				resBytes, _ := json.Marshal(openai.Batch{
+					ID:               batchID,
+					Object:           "batch",
+					Endpoint:         request.Endpoint,
+					InputFileID:      request.InputFileID,
+					CompletionWindow: request.CompletionWindow,
+					Status:           openai.BatchStatusValidating,
+					Metadata:         request.Metadata,
+				})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodGet:
+				if r.URL.Query().Get("limit") != "1" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				fmt.Fprintf(w, `{"object":"list","data":[{"id":%q,"status":"in_progress"}],"has_more":true}`, batchID)
+			}
+		},
+	)
+
+	ctx := context.Background()
+
+	batch, err := client.CreateBatch(ctx, openai.BatchRequest{
+		InputFileID: "file-in",
+		Endpoint:    openai.BatchEndpointChatCompletions,
+		Metadata:    map[string]string{"job": "nightly"},
+	})
+	checks.NoError(t, err, "CreateBatch error")
+	if batch.ID != batchID || batch.CompletionWindow != openai.BatchCompletionWindow24h ||
+		batch.Endpoint != openai.BatchEndpointChatCompletions || batch.Metadata["job"] != "nightly" {
+		t.Errorf("unexpected batch %+v", batch)
+	}
+
+	batch, err = client.RetrieveBatch(ctx, batchID)
+	checks.NoError(t, err, "RetrieveBatch error")
+	if !batch.Status.IsTerminal() || batch.OutputFileID == nil || *batch.OutputFileID != "file-out" ||
+		batch.RequestCounts.Failed != 1 {
+		t.Errorf("unexpected batch %+v", batch)
+	}
+
+	batch, err = client.CancelBatch(ctx, batchID)
+	checks.NoError(t, err, "CancelBatch error")
+	if batch.Status != openai.BatchStatusCancelling || batch.Status.IsTerminal() {
+		t.Errorf("unexpected cancelled batch status %s", batch.Status)
+	}
+
+	limit := 1
+	batches, err := client.ListBatches(ctx, openai.Pagination{Limit: &limit})
+	checks.NoError(t, err, "ListBatches error")
+	if len(batches.Batches) != 1 || !batches.HasMore || batches.Batches[0].Status != openai.BatchStatusInProgress {
+		t.Errorf("unexpected batches %+v", batches)
+	}
+}
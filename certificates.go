@@ -0,0 +1,185 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	certificatesSuffix = "/organization/certificates"
+)
+
+// Certificate represents a client certificate uploaded to the organization
+// for mutual TLS authentication.
+type Certificate struct {
+	ID                 string             `json:"id"`
+	Object             string             `json:"object"`
+	Name               string             `json:"name"`
+	CreatedAt          int64              `json:"created_at"`
+	CertificateDetails CertificateDetails `json:"certificate_details"`
+	// Active is only present on objects returned from the list and activation endpoints.
+	Active *bool `json:"active,omitempty"`
+
+	httpHeader
+}
+
+// CertificateDetails holds the validity window and, when requested, the PEM content of a certificate.
+type CertificateDetails struct {
+	ValidAt   int64  `json:"valid_at"`
+	ExpiresAt int64  `json:"expires_at"`
+	Content   string `json:"content,omitempty"`
+}
+
+// CertificateRequest uploads a new certificate. Content must be a PEM-encoded X.509 certificate.
+type CertificateRequest struct {
+	Name    string `json:"name,omitempty"`
+	Content string `json:"content"`
+}
+
+// CertificateModifyRequest renames an existing certificate.
+type CertificateModifyRequest struct {
+	Name string `json:"name"`
+}
+
+// CertificateActivationRequest activates or deactivates a set of certificates at once.
+type CertificateActivationRequest struct {
+	CertificateIDs []string `json:"certificate_ids"`
+}
+
+// CertificatesList is a list of certificates.
+type CertificatesList struct {
+	Object       string        `json:"object"`
+	Certificates []Certificate `json:"data"`
+	FirstID      *string       `json:"first_id"`
+	LastID       *string       `json:"last_id"`
+	HasMore      bool          `json:"has_more"`
+
+	httpHeader
+}
+
+type CertificateDeleteResponse struct {
+	ID     string `json:"id"`
+	Object string `json:"object"`
+
+	httpHeader
+}
+
+// UploadCertificate uploads a certificate to the organization.
+// Uploaded certificates are inactive until activated.
+func (c *Client) UploadCertificate(
+	ctx context.Context,
+	request CertificateRequest,
+	opts ...RequestOption,
+) (response Certificate, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(certificatesSuffix),
+		withBody(request), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// RetrieveCertificate retrieves a certificate. When includeContent is true
+// the PEM content is returned in CertificateDetails.Content.
+func (c *Client) RetrieveCertificate(
+	ctx context.Context,
+	certificateID string,
+	includeContent bool,
+	opts ...RequestOption,
+) (response Certificate, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", certificatesSuffix, certificateID)
+	if includeContent {
+		urlSuffix += "?include[]=content"
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ModifyCertificate modifies a certificate. Only the name can be changed.
+func (c *Client) ModifyCertificate(
+	ctx context.Context,
+	certificateID string,
+	request CertificateModifyRequest,
+	opts ...RequestOption,
+) (response Certificate, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", certificatesSuffix, certificateID)
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// DeleteCertificate deletes a certificate. The certificate must be inactive.
+func (c *Client) DeleteCertificate(
+	ctx context.Context,
+	certificateID string,
+	opts ...RequestOption,
+) (response CertificateDeleteResponse, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", certificatesSuffix, certificateID)
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ListCertificates lists the certificates uploaded to the organization.
+func (c *Client) ListCertificates(
+	ctx context.Context,
+	pagination Pagination,
+	opts ...RequestOption,
+) (response CertificatesList, err error) {
+	urlSuffix := certificatesSuffix + pagination.encode()
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ActivateCertificates activates the given certificates for the organization.
+func (c *Client) ActivateCertificates(
+	ctx context.Context,
+	request CertificateActivationRequest,
+	opts ...RequestOption,
+) (response CertificatesList, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(certificatesSuffix+"/activate"),
+		withBody(request), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// DeactivateCertificates deactivates the given certificates for the organization.
+func (c *Client) DeactivateCertificates(
+	ctx context.Context,
+	request CertificateActivationRequest,
+	opts ...RequestOption,
+) (response CertificatesList, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(certificatesSuffix+"/deactivate"),
+		withBody(request), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
@@ -0,0 +1,164 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// TestCertificates Tests the organization certificates endpoints of the API using the mocked server.
+func TestCertificates(t *testing.T) {
+	certificateID := "cert_abc123"
+	active := true
+	limit := 10
+	order := "desc"
+	after := "cert_abc122"
+
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler(
+		"/v1/organization/certificates/activate",
+		func(w http.ResponseWriter, r *http.Request) {
+			var request openai.CertificateActivationRequest
+			err := json.NewDecoder(r.Body).Decode(&request)
+			checks.NoError(t, err, "Decode error")
+
+			resBytes, _ := json.Marshal(openai.CertificatesList{
+				Object: "organization.certificate.activation",
+				Certificates: []openai.Certificate{
+					{ID: request.CertificateIDs[0], Object: "organization.certificate", Active: &active},
+				},
+			})
+			fmt.Fprintln(w, string(resBytes))
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/organization/certificates/deactivate",
+		func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprintln(w, `{
+				"object": "organization.certificate.deactivation",
+				"data": [{"object": "organization.certificate", "id": "cert_abc123", "active": false}]
+			}`)
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/organization/certificates/"+certificateID,
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				cert := openai.Certificate{
+					ID:     certificateID,
+					Object: "certificate",
+					Name:   "My Certificate",
+				}
+				if r.URL.Query().Get("include[]") == "content" {
+					cert.CertificateDetails.Content = "-----BEGIN CERTIFICATE-----"
+				}
+				resBytes, _ := json.Marshal(cert)
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodPost:
+				var request openai.CertificateModifyRequest
+				err := json.NewDecoder(r.Body).Decode(&request)
+				checks.NoError(t, err, "Decode error")
+
+				resBytes, _ := json.Marshal(openai.Certificate{
+					ID:     certificateID,
+					Object: "certificate",
+					Name:   request.Name,
+				})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodDelete:
+				fmt.Fprintln(w, `{"object": "certificate.deleted", "id": "cert_abc123"}`)
+			}
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/organization/certificates",
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				var request openai.CertificateRequest
+				err := json.NewDecoder(r.Body).Decode(&request)
+				checks.NoError(t, err, "Decode error")
+
+				resBytes, _ := json.Marshal(openai.Certificate{
+					ID:     certificateID,
+					Object: "certificate",
+					Name:   request.Name,
+					CertificateDetails: openai.CertificateDetails{
+						ValidAt:   1718413200,
+						ExpiresAt: 1749949200,
+					},
+				})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodGet:
+				if r.URL.Query().Get("limit") != "10" || r.URL.Query().Get("after") != after {
+					t.Errorf("unexpected query: %s", r.URL.RawQuery)
+				}
+				resBytes, _ := json.Marshal(openai.CertificatesList{
+					Object: "list",
+					Certificates: []openai.Certificate{
+						{ID: certificateID, Object: "organization.certificate", Active: &active},
+					},
+					FirstID: &certificateID,
+					LastID:  &certificateID,
+				})
+				fmt.Fprintln(w, string(resBytes))
+			}
+		},
+	)
+
+	ctx := context.Background()
+
+	cert, err := client.UploadCertificate(ctx, openai.CertificateRequest{
+		Name:    "My Certificate",
+		Content: "-----BEGIN CERTIFICATE-----",
+	})
+	checks.NoError(t, err, "UploadCertificate error")
+	if cert.Name != "My Certificate" {
+		t.Errorf("unexpected certificate name: %s", cert.Name)
+	}
+
+	cert, err = client.RetrieveCertificate(ctx, certificateID, true)
+	checks.NoError(t, err, "RetrieveCertificate error")
+	if cert.CertificateDetails.Content == "" {
+		t.Error("expected certificate content to be included")
+	}
+
+	_, err = client.ModifyCertificate(ctx, certificateID, openai.CertificateModifyRequest{Name: "Renamed"})
+	checks.NoError(t, err, "ModifyCertificate error")
+
+	list, err := client.ListCertificates(ctx, openai.Pagination{Limit: &limit, Order: &order, After: &after})
+	checks.NoError(t, err, "ListCertificates error")
+	if len(list.Certificates) != 1 || list.Certificates[0].Active == nil || !*list.Certificates[0].Active {
+		t.Errorf("unexpected certificates list: %+v", list.Certificates)
+	}
+
+	list, err = client.ActivateCertificates(ctx, openai.CertificateActivationRequest{
+		CertificateIDs: []string{certificateID},
+	})
+	checks.NoError(t, err, "ActivateCertificates error")
+	if list.Certificates[0].ID != certificateID {
+		t.Errorf("unexpected activated certificate: %s", list.Certificates[0].ID)
+	}
+
+	list, err = client.DeactivateCertificates(ctx, openai.CertificateActivationRequest{
+		CertificateIDs: []string{certificateID},
+	})
+	checks.NoError(t, err, "DeactivateCertificates error")
+	if *list.Certificates[0].Active {
+		t.Error("expected certificate to be deactivated")
+	}
+
+	_, err = client.DeleteCertificate(ctx, certificateID)
+	checks.NoError(t, err, "DeleteCertificate error")
+}
@@ -9,7 +9,9 @@ import (
 
 // Chat message role defined by the OpenAI API.
 const (
-	ChatMessageRoleSystem    = "system"
+	ChatMessageRoleSystem = "system"
+	// ChatMessageRoleDeveloper replaces the system role for reasoning models.
+	ChatMessageRoleDeveloper = "developer"
 	ChatMessageRoleUser      = "user"
 	ChatMessageRoleAssistant = "assistant"
 	ChatMessageRoleFunction  = "function"
@@ -97,6 +99,10 @@ type ChatCompletionMessage struct {
 
 	// For Role=tool prompts this should be set to the ID given in the assistant's prior request to call a tool.
 	ToolCallID string `json:"tool_call_id,omitempty"`
+
+	// Refusal is set instead of Content on assistant messages when the model
+	// refused to answer.
+	Refusal string `json:"refusal,omitempty"`
 }
 
 func (m ChatCompletionMessage) MarshalJSON() ([]byte, error) {
@@ -112,6 +118,7 @@ func (m ChatCompletionMessage) MarshalJSON() ([]byte, error) {
 			FunctionCall *FunctionCall     `json:"function_call,omitempty"`
 			ToolCalls    []ToolCall        `json:"tool_calls,omitempty"`
 			ToolCallID   string            `json:"tool_call_id,omitempty"`
+			Refusal      string            `json:"refusal,omitempty"`
 		}(m)
 		return json.Marshal(msg)
 	}
@@ -123,6 +130,7 @@ func (m ChatCompletionMessage) MarshalJSON() ([]byte, error) {
 		FunctionCall *FunctionCall     `json:"function_call,omitempty"`
 		ToolCalls    []ToolCall        `json:"tool_calls,omitempty"`
 		ToolCallID   string            `json:"tool_call_id,omitempty"`
+		Refusal      string            `json:"refusal,omitempty"`
 	}(m)
 	return json.Marshal(msg)
 }
@@ -136,6 +144,7 @@ func (m *ChatCompletionMessage) UnmarshalJSON(bs []byte) error {
 		FunctionCall *FunctionCall `json:"function_call,omitempty"`
 		ToolCalls    []ToolCall    `json:"tool_calls,omitempty"`
 		ToolCallID   string        `json:"tool_call_id,omitempty"`
+		Refusal      string        `json:"refusal,omitempty"`
 	}{}
 	if err := json.Unmarshal(bs, &msg); err == nil {
 		*m = ChatCompletionMessage(msg)
@@ -149,6 +158,7 @@ func (m *ChatCompletionMessage) UnmarshalJSON(bs []byte) error {
 		FunctionCall *FunctionCall     `json:"function_call,omitempty"`
 		ToolCalls    []ToolCall        `json:"tool_calls,omitempty"`
 		ToolCallID   string            `json:"tool_call_id,omitempty"`
+		Refusal      string            `json:"refusal,omitempty"`
 	}{}
 	if err := json.Unmarshal(bs, &multiMsg); err != nil {
 		return err
@@ -163,6 +173,8 @@ type ToolCall struct {
 	ID       string       `json:"id"`
 	Type     ToolType     `json:"type"`
 	Function FunctionCall `json:"function"`
+	// FileSearch is only set on the file_search tool calls of run steps.
+	FileSearch *FileSearchToolCall `json:"file_search,omitempty"`
 }
 
 type FunctionCall struct {
@@ -175,11 +187,47 @@ type ChatCompletionResponseFormatType string
 
 const (
 	ChatCompletionResponseFormatTypeJSONObject ChatCompletionResponseFormatType = "json_object"
+	ChatCompletionResponseFormatTypeJSONSchema ChatCompletionResponseFormatType = "json_schema"
 	ChatCompletionResponseFormatTypeText       ChatCompletionResponseFormatType = "text"
 )
 
 type ChatCompletionResponseFormat struct {
-	Type ChatCompletionResponseFormatType `json:"type,omitempty"`
+	Type       ChatCompletionResponseFormatType        `json:"type,omitempty"`
+	JSONSchema *ChatCompletionResponseFormatJSONSchema `json:"json_schema,omitempty"`
+}
+
+// ChatCompletionResponseFormatJSONSchema is the schema of the answers of the
+// json_schema response format, also known as structured outputs.
+type ChatCompletionResponseFormatJSONSchema struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Schema is the JSON schema, e.g. a jsonschema.Definition or a
+	// json.RawMessage. Unmarshalled schemas are kept as a json.RawMessage.
+	Schema json.Marshaler `json:"schema"`
+	// Strict makes the model follow the schema exactly. Strict schemas must
+	// list every property as required and disallow additional properties.
+	Strict bool `json:"strict"`
+}
+
+func (r *ChatCompletionResponseFormatJSONSchema) UnmarshalJSON(data []byte) error {
+	schema := struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description,omitempty"`
+		Schema      json.RawMessage `json:"schema"`
+		Strict      bool            `json:"strict"`
+	}{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return err
+	}
+	*r = ChatCompletionResponseFormatJSONSchema{
+		Name:        schema.Name,
+		Description: schema.Description,
+		Strict:      schema.Strict,
+	}
+	if len(schema.Schema) > 0 && string(schema.Schema) != "null" {
+		r.Schema = schema.Schema
+	}
+	return nil
 }
 
 // ChatCompletionRequest represents a request structure for chat completion API.
@@ -191,15 +239,16 @@ type ChatCompletionRequest struct {
 	TopP             float32                       `json:"top_p,omitempty"`
 	N                int                           `json:"n,omitempty"`
 	Stream           bool                          `json:"stream,omitempty"`
-	Stop             []string                      `json:"stop,omitempty"`
+	Stop             StopSequences                 `json:"stop,omitempty"`
 	PresencePenalty  float32                       `json:"presence_penalty,omitempty"`
 	ResponseFormat   *ChatCompletionResponseFormat `json:"response_format,omitempty"`
 	Seed             *int                          `json:"seed,omitempty"`
 	FrequencyPenalty float32                       `json:"frequency_penalty,omitempty"`
 	// LogitBias is must be a token id string (specified by their token ID in the tokenizer), not a word string.
 	// incorrect: `"logit_bias":{"You": 6}`, correct: `"logit_bias":{"1639": 6}`
+	// Build it with NewLogitBias, or from words with BiasAgainstWordsWithRegisteredEncoder.
 	// refs: https://platform.openai.com/docs/api-reference/chat/create#chat/create-logit_bias
-	LogitBias map[string]int `json:"logit_bias,omitempty"`
+	LogitBias LogitBias `json:"logit_bias,omitempty"`
 	// LogProbs indicates whether to return log probabilities of the output tokens or not.
 	// If true, returns the log probabilities of each output token returned in the content of message.
 	// This option is currently not available on the gpt-4-vision-preview model.
@@ -218,6 +267,29 @@ type ChatCompletionRequest struct {
 	ToolChoice any `json:"tool_choice,omitempty"`
 	// Options for streaming response. Only set this when you set stream: true.
 	StreamOptions *StreamOptions `json:"stream_options,omitempty"`
+	// MaxCompletionTokens is an upper bound for the number of tokens that can be generated for a completion,
+	// including visible output tokens and reasoning tokens. It replaces MaxTokens for reasoning models.
+	MaxCompletionTokens int `json:"max_completion_tokens,omitempty"`
+	// Modalities are the output types to generate, text by default. Audio
+	// output also requires Audio.
+	Modalities []ChatModality `json:"modalities,omitempty"`
+	// Audio configures the audio output.
+	Audio *ChatCompletionAudio `json:"audio,omitempty"`
+	// ServiceTier selects the processing tier. Flex requests get a longer
+	// default timeout, see OperationTimeouts.Flex.
+	ServiceTier ServiceTier `json:"service_tier,omitempty"`
+	// ExtraBody holds fields merged into the request JSON, for parameters this
+	// client does not model yet or vendor extensions such as top_k.
+	ExtraBody map[string]any `json:"-"`
+}
+
+func (r ChatCompletionRequest) MarshalJSON() ([]byte, error) {
+	return marshalWithExtraBody(standardJSON{}, r)
+}
+
+func (r ChatCompletionRequest) splitExtraBody() (any, map[string]any) {
+	type Alias ChatCompletionRequest
+	return Alias(r), r.ExtraBody
 }
 
 type StreamOptions struct {
@@ -325,6 +397,9 @@ type ChatCompletionResponse struct {
 	Choices           []ChatCompletionChoice `json:"choices"`
 	Usage             Usage                  `json:"usage"`
 	SystemFingerprint string                 `json:"system_fingerprint"`
+	// ServiceTier is the tier that processed the request, which may differ
+	// from the requested one, e.g. when priority capacity was exhausted.
+	ServiceTier ServiceTier `json:"service_tier,omitempty"`
 
 	httpHeader
 }
@@ -333,6 +408,7 @@ type ChatCompletionResponse struct {
 func (c *Client) CreateChatCompletion(
 	ctx context.Context,
 	request ChatCompletionRequest,
+	opts ...RequestOption,
 ) (response ChatCompletionResponse, err error) {
 	if request.Stream {
 		err = ErrChatCompletionStreamNotSupported
@@ -345,7 +421,21 @@ func (c *Client) CreateChatCompletion(
 		return
 	}
 
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix, request.Model), withBody(request))
+	if err = request.Stop.Validate(); err != nil {
+		return
+	}
+	if err = request.LogitBias.Validate(); err != nil {
+		return
+	}
+	if err = validateChatAudio(request); err != nil {
+		return
+	}
+	if request, err = c.checkCompatibility(ctx, request); err != nil {
+		return
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix, request.Model), withModel(request.Model),
+		withServiceTier(request.ServiceTier), withBody(request), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
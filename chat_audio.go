@@ -0,0 +1,87 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidChatAudio is returned when the audio output of a chat completion
+// request is misconfigured.
+var ErrInvalidChatAudio = errors.New("invalid chat audio configuration")
+
+// ChatModality is an output type of a chat completion.
+type ChatModality string
+
+const (
+	ChatModalityText  ChatModality = "text"
+	ChatModalityAudio ChatModality = "audio"
+)
+
+// ChatAudioVoice is a voice of chat audio output.
+type ChatAudioVoice string
+
+const (
+	ChatAudioVoiceAlloy   ChatAudioVoice = "alloy"
+	ChatAudioVoiceAsh     ChatAudioVoice = "ash"
+	ChatAudioVoiceBallad  ChatAudioVoice = "ballad"
+	ChatAudioVoiceCoral   ChatAudioVoice = "coral"
+	ChatAudioVoiceEcho    ChatAudioVoice = "echo"
+	ChatAudioVoiceFable   ChatAudioVoice = "fable"
+	ChatAudioVoiceNova    ChatAudioVoice = "nova"
+	ChatAudioVoiceOnyx    ChatAudioVoice = "onyx"
+	ChatAudioVoiceSage    ChatAudioVoice = "sage"
+	ChatAudioVoiceShimmer ChatAudioVoice = "shimmer"
+	ChatAudioVoiceVerse   ChatAudioVoice = "verse"
+)
+
+// ChatAudioFormat is an encoding of chat audio output.
+type ChatAudioFormat string
+
+const (
+	ChatAudioFormatWAV   ChatAudioFormat = "wav"
+	ChatAudioFormatMP3   ChatAudioFormat = "mp3"
+	ChatAudioFormatFLAC  ChatAudioFormat = "flac"
+	ChatAudioFormatOpus  ChatAudioFormat = "opus"
+	ChatAudioFormatPCM16 ChatAudioFormat = "pcm16"
+)
+
+// ChatCompletionAudio configures the audio output of a chat completion. It
+// requires ChatModalityAudio in the request's Modalities.
+type ChatCompletionAudio struct {
+	Voice ChatAudioVoice `json:"voice"`
+	// Format must be ChatAudioFormatPCM16 when streaming.
+	Format ChatAudioFormat `json:"format"`
+}
+
+var (
+	chatAudioVoices = []ChatAudioVoice{
+		ChatAudioVoiceAlloy, ChatAudioVoiceAsh, ChatAudioVoiceBallad, ChatAudioVoiceCoral,
+		ChatAudioVoiceEcho, ChatAudioVoiceFable, ChatAudioVoiceNova, ChatAudioVoiceOnyx,
+		ChatAudioVoiceSage, ChatAudioVoiceShimmer, ChatAudioVoiceVerse,
+	}
+	chatAudioFormats = []ChatAudioFormat{
+		ChatAudioFormatWAV, ChatAudioFormatMP3, ChatAudioFormatFLAC, ChatAudioFormatOpus, ChatAudioFormatPCM16,
+	}
+)
+
+// validateChatAudio checks that the audio output of a request is consistent
+// with its modalities and supported by the API.
+func validateChatAudio(request ChatCompletionRequest) error {
+	wantsAudio := contains(request.Modalities, ChatModalityAudio)
+	switch {
+	case request.Audio == nil && !wantsAudio:
+		return nil
+	case request.Audio == nil:
+		return fmt.Errorf("%w: the audio modality requires the audio parameter", ErrInvalidChatAudio)
+	case !wantsAudio:
+		return fmt.Errorf("%w: the audio parameter requires the audio modality", ErrInvalidChatAudio)
+	case !contains(chatAudioVoices, request.Audio.Voice):
+		return fmt.Errorf("%w: unsupported voice %q", ErrInvalidChatAudio, request.Audio.Voice)
+	case !contains(chatAudioFormats, request.Audio.Format):
+		return fmt.Errorf("%w: unsupported format %q", ErrInvalidChatAudio, request.Audio.Format)
+	case request.Stream && request.Audio.Format != ChatAudioFormatPCM16:
+		return fmt.Errorf("%w: streamed audio must use the %s format, not %s",
+			ErrInvalidChatAudio, ChatAudioFormatPCM16, request.Audio.Format)
+	}
+	return nil
+}
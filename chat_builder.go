@@ -0,0 +1,168 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ErrInvalidChatRequest is returned by ChatRequestBuilder.Build for requests
+// that the API would reject.
+var ErrInvalidChatRequest = errors.New("invalid chat completion request")
+
+var jsonSchemaNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// ChatRequestBuilder builds a ChatCompletionRequest step by step:
+//
+//	request, err := openai.NewChatRequestBuilder(openai.GPT4o).
+//		AddSystem("Describe images in one sentence.").
+//		AddUser("What is in this image?").
+//		AddImage("https://example.com/cat.png", openai.ImageURLDetailLow).
+//		Build()
+//
+// Each step checks its arguments. The first mistake is returned by Build and
+// the steps after it are ignored.
+type ChatRequestBuilder struct {
+	request ChatCompletionRequest
+	err     error
+}
+
+// NewChatRequestBuilder returns a builder of a request for model.
+func NewChatRequestBuilder(model string) *ChatRequestBuilder {
+	return &ChatRequestBuilder{request: ChatCompletionRequest{Model: model}}
+}
+
+func (b *ChatRequestBuilder) fail(format string, args ...any) *ChatRequestBuilder {
+	if b.err == nil {
+		b.err = fmt.Errorf("%w: %s", ErrInvalidChatRequest, fmt.Sprintf(format, args...))
+	}
+	return b
+}
+
+func (b *ChatRequestBuilder) addMessage(role, content string) *ChatRequestBuilder {
+	if b.err != nil {
+		return b
+	}
+	if content == "" {
+		return b.fail("%s message %d has no content", role, len(b.request.Messages))
+	}
+	b.request.Messages = append(b.request.Messages, ChatCompletionMessage{Role: role, Content: content})
+	return b
+}
+
+// AddSystem adds a system message.
+func (b *ChatRequestBuilder) AddSystem(content string) *ChatRequestBuilder {
+	return b.addMessage(ChatMessageRoleSystem, content)
+}
+
+// AddUser adds a user message.
+func (b *ChatRequestBuilder) AddUser(content string) *ChatRequestBuilder {
+	return b.addMessage(ChatMessageRoleUser, content)
+}
+
+// AddAssistant adds an assistant message, e.g. a previous answer of the model
+// in a conversation.
+func (b *ChatRequestBuilder) AddAssistant(content string) *ChatRequestBuilder {
+	return b.addMessage(ChatMessageRoleAssistant, content)
+}
+
+// AddImage attaches the image at url, which may be a data URL, to the last
+// user message, or to a new user message when the last message is not from
+// the user.
+func (b *ChatRequestBuilder) AddImage(url string, detail ImageURLDetail) *ChatRequestBuilder {
+	if b.err != nil {
+		return b
+	}
+	if url == "" {
+		return b.fail("image has no URL")
+	}
+	switch detail {
+	case "", ImageURLDetailLow, ImageURLDetailHigh, ImageURLDetailAuto:
+	default:
+		return b.fail("unknown image detail %q", detail)
+	}
+
+	messages := b.request.Messages
+	if len(messages) == 0 || messages[len(messages)-1].Role != ChatMessageRoleUser {
+		messages = append(messages, ChatCompletionMessage{Role: ChatMessageRoleUser})
+	}
+	last := &messages[len(messages)-1]
+	if last.Content != "" {
+		last.MultiContent = append(last.MultiContent, ChatMessagePart{
+			Type: ChatMessagePartTypeText,
+			Text: last.Content,
+		})
+		last.Content = ""
+	}
+	last.MultiContent = append(last.MultiContent, ChatMessagePart{
+		Type:     ChatMessagePartTypeImageURL,
+		ImageURL: &ChatMessageImageURL{URL: url, Detail: detail},
+	})
+	b.request.Messages = messages
+	return b
+}
+
+// WithTools adds tools the model may call. Function tools need a name that is
+// unique in the request.
+func (b *ChatRequestBuilder) WithTools(tools ...Tool) *ChatRequestBuilder {
+	if b.err != nil {
+		return b
+	}
+	for _, tool := range tools {
+		if tool.Type == ToolTypeFunction {
+			if tool.Function == nil || tool.Function.Name == "" {
+				return b.fail("function tool has no name")
+			}
+			for _, existing := range b.request.Tools {
+				if existing.Function != nil && existing.Function.Name == tool.Function.Name {
+					return b.fail("function tool %q is defined twice", tool.Function.Name)
+				}
+			}
+		}
+		b.request.Tools = append(b.request.Tools, tool)
+	}
+	return b
+}
+
+// WithJSONSchema makes the model answer with JSON following schema, named
+// name, see ChatCompletionResponseFormatJSONSchema. The answer can be decoded
+// with ChatCompletionResponse.ParseInto.
+func (b *ChatRequestBuilder) WithJSONSchema(name string, schema json.Marshaler, strict bool) *ChatRequestBuilder {
+	if b.err != nil {
+		return b
+	}
+	if !jsonSchemaNamePattern.MatchString(name) {
+		return b.fail("JSON schema name %q must be 1 to 64 letters, digits, underscores or dashes", name)
+	}
+	if schema == nil {
+		return b.fail("JSON schema %q is nil", name)
+	}
+	b.request.ResponseFormat = &ChatCompletionResponseFormat{
+		Type: ChatCompletionResponseFormatTypeJSONSchema,
+		JSONSchema: &ChatCompletionResponseFormatJSONSchema{
+			Name:   name,
+			Schema: schema,
+			Strict: strict,
+		},
+	}
+	return b
+}
+
+// Build returns the request, or the first mistake found while building it.
+// The builder can be used further to build variations of the request.
+func (b *ChatRequestBuilder) Build() (ChatCompletionRequest, error) {
+	if b.err != nil {
+		return ChatCompletionRequest{}, b.err
+	}
+	if b.request.Model == "" {
+		return ChatCompletionRequest{}, fmt.Errorf("%w: no model", ErrInvalidChatRequest)
+	}
+	if len(b.request.Messages) == 0 {
+		return ChatCompletionRequest{}, fmt.Errorf("%w: no messages", ErrInvalidChatRequest)
+	}
+	request := b.request
+	request.Messages = append([]ChatCompletionMessage(nil), b.request.Messages...)
+	request.Tools = append([]Tool(nil), b.request.Tools...)
+	return request, nil
+}
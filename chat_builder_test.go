@@ -0,0 +1,72 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+func TestChatRequestBuilder(t *testing.T) {
+	schema := jsonschema.Definition{
+		Type:                 jsonschema.Object,
+		Properties:           map[string]jsonschema.Definition{"caption": {Type: jsonschema.String}},
+		Required:             []string{"caption"},
+		AdditionalProperties: false,
+	}
+	weather := openai.Tool{Type: openai.ToolTypeFunction, Function: &openai.FunctionDefinition{Name: "weather"}}
+
+	request, err := openai.NewChatRequestBuilder(openai.GPT4o).
+		AddSystem("Caption images.").
+		AddUser("What is in this image?").
+		AddImage("https://example.com/cat.png", openai.ImageURLDetailLow).
+		WithTools(weather).
+		WithJSONSchema("caption", schema, true).
+		Build()
+	checks.NoError(t, err, "Build error")
+
+	data, err := json.Marshal(request)
+	checks.NoError(t, err, "Marshal error")
+	expected := `{"model":"gpt-4o","messages":[` +
+		`{"role":"system","content":"Caption images."},` +
+		`{"role":"user","content":[{"type":"text","text":"What is in this image?"},` +
+		`{"type":"image_url","image_url":{"url":"https://example.com/cat.png","detail":"low"}}]}],` +
+		`"response_format":{"type":"json_schema","json_schema":{"name":"caption","schema":` +
+		`{"type":"object","properties":{"caption":{"type":"string","properties":{}}},"required":["caption"],` +
+		`"additionalProperties":false},"strict":true}},` +
+		`"tools":[{"type":"function","function":{"name":"weather","parameters":null}}]}`
+	if string(data) != expected {
+		t.Errorf("unexpected request\n got: %s\nwant: %s", data, expected)
+	}
+}
+
+func TestChatRequestBuilderErrors(t *testing.T) {
+	tool := func(name string) openai.Tool {
+		return openai.Tool{Type: openai.ToolTypeFunction, Function: &openai.FunctionDefinition{Name: name}}
+	}
+	testCases := []struct {
+		name    string
+		builder *openai.ChatRequestBuilder
+	}{
+		{"no model", openai.NewChatRequestBuilder("").AddUser("hi")},
+		{"no messages", openai.NewChatRequestBuilder(openai.GPT4o)},
+		{"empty message", openai.NewChatRequestBuilder(openai.GPT4o).AddSystem("").AddUser("hi")},
+		{"image without URL", openai.NewChatRequestBuilder(openai.GPT4o).AddImage("", "")},
+		{"unknown detail", openai.NewChatRequestBuilder(openai.GPT4o).AddImage("https://example.com/a.png", "max")},
+		{"unnamed tool", openai.NewChatRequestBuilder(openai.GPT4o).AddUser("hi").WithTools(tool(""))},
+		{"duplicate tool", openai.NewChatRequestBuilder(openai.GPT4o).AddUser("hi").WithTools(tool("a"), tool("a"))},
+		{
+			"invalid schema name",
+			openai.NewChatRequestBuilder(openai.GPT4o).AddUser("hi").WithJSONSchema("a b", jsonschema.Definition{}, false),
+		},
+		{"nil schema", openai.NewChatRequestBuilder(openai.GPT4o).AddUser("hi").WithJSONSchema("answer", nil, false)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.builder.Build()
+			checks.ErrorIs(t, err, openai.ErrInvalidChatRequest)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrModelRefusal is matched by errors.Is for every *RefusalError.
+	ErrModelRefusal = errors.New("model refused to answer")
+	// ErrNoChoices is returned by ParseInto for responses without choices.
+	ErrNoChoices = errors.New("chat completion has no choices")
+)
+
+// RefusalError is returned by ParseInto when the model refused to answer
+// instead of producing the requested JSON.
+type RefusalError struct {
+	Refusal string
+}
+
+func (e *RefusalError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrModelRefusal, e.Refusal)
+}
+
+func (e *RefusalError) Is(target error) bool {
+	return target == ErrModelRefusal
+}
+
+// ParseInto decodes the JSON content of the first choice into v, e.g. the
+// answer to a request with a JSON response format. A refusal of the model is
+// returned as a *RefusalError rather than as malformed JSON.
+func (r ChatCompletionResponse) ParseInto(v any) error {
+	if len(r.Choices) == 0 {
+		return ErrNoChoices
+	}
+	message := r.Choices[0].Message
+	if message.Refusal != "" {
+		return &RefusalError{Refusal: message.Refusal}
+	}
+	if err := json.Unmarshal([]byte(message.Content), v); err != nil {
+		return fmt.Errorf("parsing chat completion content: %w", err)
+	}
+	return nil
+}
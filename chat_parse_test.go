@@ -0,0 +1,63 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestChatCompletionResponseParseInto(t *testing.T) {
+	var response openai.ChatCompletionResponse
+	err := json.Unmarshal([]byte(`{"choices":[{"message":{"role":"assistant","content":"{\"city\":\"Paris\"}"}}]}`),
+		&response)
+	checks.NoError(t, err, "Unmarshal error")
+	var answer struct {
+		City string `json:"city"`
+	}
+	checks.NoError(t, response.ParseInto(&answer), "ParseInto error")
+	if answer.City != "Paris" {
+		t.Errorf("unexpected answer %+v", answer)
+	}
+
+	err = json.Unmarshal([]byte(`{"choices":[{"message":{"role":"assistant","content":null,`+
+		`"refusal":"I can't help with that."}}]}`), &response)
+	checks.NoError(t, err, "Unmarshal error")
+	err = response.ParseInto(&answer)
+	checks.ErrorIs(t, err, openai.ErrModelRefusal, "refusals should be ErrModelRefusal")
+	var refusal *openai.RefusalError
+	if !errors.As(err, &refusal) || refusal.Refusal != "I can't help with that." {
+		t.Errorf("unexpected refusal error %v", err)
+	}
+
+	response.Choices[0].Message = openai.ChatCompletionMessage{Content: "not json"}
+	err = response.ParseInto(&answer)
+	if err == nil || errors.Is(err, openai.ErrModelRefusal) {
+		t.Errorf("expected a decoding error, got %v", err)
+	}
+	checks.ErrorIs(t, openai.ChatCompletionResponse{}.ParseInto(&answer), openai.ErrNoChoices, "expected no choices")
+}
+
+func TestStreamChatRefusal(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"choices":[{"index":0,"delta":{"role":"assistant","refusal":"I can't "}}]}`+"\n\n")
+		fmt.Fprint(w, `data: {"choices":[{"index":0,"delta":{"refusal":"help."},"finish_reason":"stop"}]}`+"\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+
+	response, err := client.StreamChat(context.Background(), openai.ChatCompletionRequest{Model: openai.GPT4o},
+		openai.ChatStreamHandlers{})
+	checks.NoError(t, err, "StreamChat error")
+	if got := response.Choices[0].Message.Refusal; got != "I can't help." {
+		t.Errorf("unexpected refusal %q", got)
+	}
+	checks.ErrorIs(t, response.ParseInto(&struct{}{}), openai.ErrModelRefusal, "expected a refusal")
+}
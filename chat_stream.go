@@ -10,6 +10,7 @@ type ChatCompletionStreamChoiceDelta struct {
 	Role         string        `json:"role,omitempty"`
 	FunctionCall *FunctionCall `json:"function_call,omitempty"`
 	ToolCalls    []ToolCall    `json:"tool_calls,omitempty"`
+	Refusal      string        `json:"refusal,omitempty"`
 }
 
 type ChatCompletionStreamChoice struct {
@@ -33,6 +34,8 @@ type ChatCompletionStreamResponse struct {
 	SystemFingerprint   string                       `json:"system_fingerprint"`
 	PromptAnnotations   []PromptAnnotation           `json:"prompt_annotations,omitempty"`
 	PromptFilterResults []PromptFilterResult         `json:"prompt_filter_results,omitempty"`
+	// ServiceTier is the tier that processed the request.
+	ServiceTier ServiceTier `json:"service_tier,omitempty"`
 	// An optional field that will only be present when you set stream_options: {"include_usage": true} in your request.
 	// When present, it contains a null value except for the last chunk which contains the token usage statistics
 	// for the entire request.
@@ -52,6 +55,7 @@ type ChatCompletionStream struct {
 func (c *Client) CreateChatCompletionStream(
 	ctx context.Context,
 	request ChatCompletionRequest,
+	opts ...RequestOption,
 ) (stream *ChatCompletionStream, err error) {
 	urlSuffix := chatCompletionsSuffix
 	if !checkEndpointSupportsModel(urlSuffix, request.Model) {
@@ -60,7 +64,21 @@ func (c *Client) CreateChatCompletionStream(
 	}
 
 	request.Stream = true
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix, request.Model), withBody(request))
+	if err = request.Stop.Validate(); err != nil {
+		return
+	}
+	if err = request.LogitBias.Validate(); err != nil {
+		return
+	}
+	if err = validateChatAudio(request); err != nil {
+		return
+	}
+	if request, err = c.checkCompatibility(ctx, request); err != nil {
+		return
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix, request.Model), withModel(request.Model),
+		withServiceTier(request.ServiceTier), withBody(request), withStream(), withRequestOptions(opts))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,91 @@
+package openai
+
+import "sync"
+
+// ChatCompletionStreamDemux splits a chat completion stream with n > 1, whose
+// chunks interleave the choices, into one stream per choice.
+//
+// Choices are read on demand: receiving from one choice reads the underlying
+// stream until a chunk for that choice arrives and queues the chunks of the
+// other choices, so choices may be consumed one after the other or from
+// separate goroutines. Chunks of choices that are never received stay queued
+// until the demultiplexer is discarded.
+type ChatCompletionStreamDemux struct {
+	stream *ChatCompletionStream
+
+	mu      sync.Mutex
+	pending map[int][]ChatCompletionStreamChoice
+	usage   *Usage
+	// err is the error that ended the underlying stream, io.EOF when it ended
+	// normally.
+	err error
+}
+
+// NewChatCompletionStreamDemux returns a demultiplexer reading from stream.
+// The caller remains responsible for closing stream.
+func NewChatCompletionStreamDemux(stream *ChatCompletionStream) *ChatCompletionStreamDemux {
+	return &ChatCompletionStreamDemux{
+		stream:  stream,
+		pending: make(map[int][]ChatCompletionStreamChoice),
+	}
+}
+
+// Choice returns the stream of the choice with index.
+func (d *ChatCompletionStreamDemux) Choice(index int) *ChatCompletionChoiceStream {
+	return &ChatCompletionChoiceStream{demux: d, index: index}
+}
+
+// Usage returns the token usage of the whole request once the underlying
+// stream has ended, if the request set StreamOptions.IncludeUsage.
+func (d *ChatCompletionStreamDemux) Usage() *Usage {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.usage
+}
+
+func (d *ChatCompletionStreamDemux) recv(index int) (ChatCompletionStreamChoice, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for {
+		if queue := d.pending[index]; len(queue) > 0 {
+			choice := queue[0]
+			d.pending[index] = queue[1:]
+			return choice, nil
+		}
+		if d.err != nil {
+			return ChatCompletionStreamChoice{}, d.err
+		}
+
+		response, err := d.stream.Recv()
+		if err != nil {
+			d.err = err
+			continue
+		}
+		if response.Usage != nil {
+			d.usage = response.Usage
+		}
+		for _, choice := range response.Choices {
+			d.pending[choice.Index] = append(d.pending[choice.Index], choice)
+		}
+	}
+}
+
+// ChatCompletionChoiceStream is the stream of a single choice of a chat
+// completion, returned by ChatCompletionStreamDemux.Choice.
+type ChatCompletionChoiceStream struct {
+	demux *ChatCompletionStreamDemux
+	index int
+}
+
+// Index returns the index of the choice.
+func (s *ChatCompletionChoiceStream) Index() int {
+	return s.index
+}
+
+// Recv returns the next chunk of the choice. It returns io.EOF once the
+// underlying stream has ended and all chunks of the choice were received, or
+// the error that ended the underlying stream.
+func (s *ChatCompletionChoiceStream) Recv() (ChatCompletionStreamChoice, error) {
+	return s.demux.recv(s.index)
+}
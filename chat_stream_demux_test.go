@@ -0,0 +1,73 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestChatCompletionStreamDemux(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		chunks := []string{
+			`{"choices":[{"index":0,"delta":{"content":"a1"}},{"index":1,"delta":{"content":"b1"}}]}`,
+			`{"choices":[{"index":1,"delta":{"content":"b2"}}]}`,
+			`{"choices":[{"index":0,"delta":{"content":"a2"},"finish_reason":"stop"}]}`,
+			`{"choices":[{"index":1,"delta":{},"finish_reason":"stop"}]}`,
+			`{"choices":[],"usage":{"prompt_tokens":3,"completion_tokens":4,"total_tokens":7}}`,
+		}
+		for _, chunk := range chunks {
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model:         openai.GPT4o,
+		N:             2,
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
+	})
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	defer stream.Close()
+
+	demux := openai.NewChatCompletionStreamDemux(stream)
+	contents := make([]string, 2)
+	var wg sync.WaitGroup
+	for i := range contents {
+		wg.Add(1)
+		go func(choice *openai.ChatCompletionChoiceStream) {
+			defer wg.Done()
+			var sb strings.Builder
+			for {
+				chunk, recvErr := choice.Recv()
+				if errors.Is(recvErr, io.EOF) {
+					break
+				}
+				checks.NoError(t, recvErr, "Recv error")
+				if chunk.Index != choice.Index() {
+					t.Errorf("choice %d received a chunk of choice %d", choice.Index(), chunk.Index)
+				}
+				sb.WriteString(chunk.Delta.Content)
+			}
+			contents[choice.Index()] = sb.String()
+		}(demux.Choice(i))
+	}
+	wg.Wait()
+
+	if contents[0] != "a1a2" || contents[1] != "b1b2" {
+		t.Errorf("unexpected choice contents %q", contents)
+	}
+	if usage := demux.Usage(); usage == nil || usage.TotalTokens != 7 {
+		t.Errorf("unexpected usage %+v", usage)
+	}
+}
@@ -0,0 +1,151 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ChatStreamHandlers are the callbacks of StreamChat. Nil callbacks are
+// skipped. An error returned by a callback stops the stream and is returned
+// by StreamChat.
+type ChatStreamHandlers struct {
+	// OnContentDelta is called with each piece of the content of a choice.
+	OnContentDelta func(choice int, content string) error
+	// OnToolCallDelta is called with each fragment of a tool call of a choice.
+	// Fragments of the same call share delta.Index, and only the first one
+	// carries its ID and function name.
+	OnToolCallDelta func(choice int, delta ToolCall) error
+	// OnFinish is called once per choice with the reason it finished.
+	OnFinish func(choice int, reason FinishReason) error
+	// OnError is called with the error that ended the stream, if any, before
+	// StreamChat returns it.
+	OnError func(err error)
+}
+
+// StreamChat creates a streamed chat completion and runs the receive loop,
+// calling handlers as chunks arrive. It returns the completion assembled from
+// all chunks, with the content and tool calls of every choice and the token
+// usage. Usage is requested with StreamOptions unless request sets them.
+func (c *Client) StreamChat(
+	ctx context.Context,
+	request ChatCompletionRequest,
+	handlers ChatStreamHandlers,
+	opts ...RequestOption,
+) (response ChatCompletionResponse, err error) {
+	defer func() {
+		if err != nil && handlers.OnError != nil {
+			handlers.OnError(err)
+		}
+	}()
+
+	if request.StreamOptions == nil {
+		request.StreamOptions = &StreamOptions{IncludeUsage: true}
+	}
+	stream, err := c.CreateChatCompletionStream(ctx, request, opts...)
+	if err != nil {
+		return
+	}
+	defer stream.Close()
+
+	response.Object = "chat.completion"
+	response.httpHeader = stream.httpHeader
+	for {
+		chunk, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			if !stream.isFinished {
+				// The connection ended without the [DONE] message.
+				return response, io.ErrUnexpectedEOF
+			}
+			return response, nil
+		}
+		if recvErr != nil {
+			return response, recvErr
+		}
+		if err = response.addChunk(chunk, handlers); err != nil {
+			return
+		}
+	}
+}
+
+// addChunk merges a chunk of a streamed completion into r.
+func (r *ChatCompletionResponse) addChunk(chunk ChatCompletionStreamResponse, handlers ChatStreamHandlers) error {
+	r.ID = chunk.ID
+	r.Created = chunk.Created
+	r.Model = chunk.Model
+	r.SystemFingerprint = chunk.SystemFingerprint
+	if chunk.ServiceTier != "" {
+		r.ServiceTier = chunk.ServiceTier
+	}
+	if chunk.Usage != nil {
+		r.Usage = *chunk.Usage
+	}
+
+	for _, delta := range chunk.Choices {
+		for len(r.Choices) <= delta.Index {
+			r.Choices = append(r.Choices, ChatCompletionChoice{Index: len(r.Choices)})
+		}
+		if err := r.Choices[delta.Index].addDelta(delta, handlers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *ChatCompletionChoice) addDelta(delta ChatCompletionStreamChoice, handlers ChatStreamHandlers) error {
+	message := &c.Message
+	if delta.Delta.Role != "" {
+		message.Role = delta.Delta.Role
+	}
+
+	if content := delta.Delta.Content; content != "" {
+		message.Content += content
+		if handlers.OnContentDelta != nil {
+			if err := handlers.OnContentDelta(c.Index, content); err != nil {
+				return err
+			}
+		}
+	}
+
+	message.Refusal += delta.Delta.Refusal
+
+	if call := delta.Delta.FunctionCall; call != nil {
+		if message.FunctionCall == nil {
+			message.FunctionCall = &FunctionCall{}
+		}
+		message.FunctionCall.Name += call.Name
+		message.FunctionCall.Arguments += call.Arguments
+	}
+
+	for _, toolDelta := range delta.Delta.ToolCalls {
+		index := len(message.ToolCalls)
+		if toolDelta.Index != nil {
+			index = *toolDelta.Index
+		}
+		for len(message.ToolCalls) <= index {
+			message.ToolCalls = append(message.ToolCalls, ToolCall{})
+		}
+		call := &message.ToolCalls[index]
+		if toolDelta.ID != "" {
+			call.ID = toolDelta.ID
+		}
+		if toolDelta.Type != "" {
+			call.Type = toolDelta.Type
+		}
+		call.Function.Name += toolDelta.Function.Name
+		call.Function.Arguments += toolDelta.Function.Arguments
+		if handlers.OnToolCallDelta != nil {
+			if err := handlers.OnToolCallDelta(c.Index, toolDelta); err != nil {
+				return err
+			}
+		}
+	}
+
+	if delta.FinishReason != "" && delta.FinishReason != FinishReasonNull && c.FinishReason == "" {
+		c.FinishReason = delta.FinishReason
+		if handlers.OnFinish != nil {
+			return handlers.OnFinish(c.Index, delta.FinishReason)
+		}
+	}
+	return nil
+}
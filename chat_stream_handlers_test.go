@@ -0,0 +1,99 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestStreamChat(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		chunks := []string{
+			`{"id":"1","model":"gpt-4o","choices":[{"index":0,"delta":{"role":"assistant","content":"Hel"}}]}`,
+			`{"id":"1","model":"gpt-4o","choices":[{"index":0,"delta":{"content":"lo"}}]}`,
+			`{"id":"1","model":"gpt-4o","choices":[{"index":0,"delta":{"tool_calls":[` +
+				`{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"ci"}}]}}]}`,
+			`{"id":"1","model":"gpt-4o","choices":[{"index":0,"delta":{"tool_calls":[` +
+				`{"index":0,"function":{"arguments":"ty\":\"Paris\"}"}}]}}]}`,
+			`{"id":"1","model":"gpt-4o","choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`,
+			`{"id":"1","model":"gpt-4o","choices":[],"usage":{"prompt_tokens":5,"completion_tokens":6,"total_tokens":11}}`,
+		}
+		for _, chunk := range chunks {
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+
+	var (
+		content    string
+		toolDeltas int
+		finished   openai.FinishReason
+	)
+	response, err := client.StreamChat(context.Background(), openai.ChatCompletionRequest{
+		Model: openai.GPT4o,
+	}, openai.ChatStreamHandlers{
+		OnContentDelta: func(_ int, delta string) error {
+			content += delta
+			return nil
+		},
+		OnToolCallDelta: func(_ int, _ openai.ToolCall) error {
+			toolDeltas++
+			return nil
+		},
+		OnFinish: func(_ int, reason openai.FinishReason) error {
+			finished = reason
+			return nil
+		},
+		OnError: func(err error) {
+			t.Errorf("unexpected OnError call: %v", err)
+		},
+	})
+	checks.NoError(t, err, "StreamChat error")
+
+	if content != "Hello" || toolDeltas != 2 || finished != openai.FinishReasonToolCalls {
+		t.Errorf("unexpected callbacks: content %q, %d tool deltas, finish reason %q", content, toolDeltas, finished)
+	}
+	if len(response.Choices) != 1 {
+		t.Fatalf("expected 1 choice, got %d", len(response.Choices))
+	}
+	message := response.Choices[0].Message
+	if message.Role != openai.ChatMessageRoleAssistant || message.Content != "Hello" {
+		t.Errorf("unexpected message %+v", message)
+	}
+	if len(message.ToolCalls) != 1 || message.ToolCalls[0].ID != "call_1" ||
+		message.ToolCalls[0].Function.Arguments != `{"city":"Paris"}` {
+		t.Errorf("unexpected tool calls %+v", message.ToolCalls)
+	}
+	if response.Usage.TotalTokens != 11 {
+		t.Errorf("unexpected usage %+v", response.Usage)
+	}
+}
+
+func TestStreamChatErrors(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"choices":[{"index":0,"delta":{"content":"Hi"}}]}`+"\n\n")
+	})
+
+	var reported error
+	_, err := client.StreamChat(context.Background(), openai.ChatCompletionRequest{Model: openai.GPT4o},
+		openai.ChatStreamHandlers{OnError: func(err error) { reported = err }})
+	checks.ErrorIs(t, err, io.ErrUnexpectedEOF, "a stream without [DONE] should be truncated")
+	checks.ErrorIs(t, reported, io.ErrUnexpectedEOF, "OnError should receive the error")
+
+	errStop := errors.New("stop")
+	_, err = client.StreamChat(context.Background(), openai.ChatCompletionRequest{Model: openai.GPT4o},
+		openai.ChatStreamHandlers{OnContentDelta: func(int, string) error { return errStop }})
+	checks.ErrorIs(t, err, errStop, "callback errors should stop the stream")
+}
@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptrace"
 	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/sashabaranov/go-openai"
 	"github.com/sashabaranov/go-openai/internal/test/checks"
@@ -539,3 +542,81 @@ func compareChatStreamResponseChoices(c1, c2 openai.ChatCompletionStreamChoice)
 	}
 	return true
 }
+
+func TestChatCompletionStreamCloseWhileRecv(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	release := make(chan struct{})
+	defer close(release)
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"choices":[{"index":0,"delta":{"content":"Hi"}}]}`+"\n\n")
+		w.(http.Flusher).Flush()
+		<-release
+	})
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model: openai.GPT4o,
+	})
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	_, err = stream.Recv()
+	checks.NoError(t, err, "Recv error")
+
+	recvErr := make(chan error, 1)
+	go func() {
+		_, err := stream.Recv()
+		recvErr <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checks.NoError(t, stream.Close(), "Close error")
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case err = <-recvErr:
+		checks.ErrorIs(t, err, openai.ErrStreamClosed, "Recv should be interrupted by Close")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Recv did not return after Close")
+	}
+	_, err = stream.Recv()
+	checks.ErrorIs(t, err, openai.ErrStreamClosed, "Recv after Close")
+}
+
+func TestChatCompletionStreamCloseReusesConnection(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"choices":[{"index":0,"delta":{"content":"Hi"}}]}`+"\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+		// Data after [DONE] is left unread by Recv and must be drained by Close.
+		w.(http.Flusher).Flush()
+		time.Sleep(10 * time.Millisecond)
+		fmt.Fprint(w, ": end of stream\n\n")
+	})
+
+	var reused []bool
+	ctx := httptrace.WithClientTrace(context.Background(), &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) { reused = append(reused, info.Reused) },
+	})
+	for i := 0; i < 2; i++ {
+		stream, err := client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{Model: openai.GPT4o})
+		checks.NoError(t, err, "CreateChatCompletionStream error")
+		for err == nil {
+			_, err = stream.Recv()
+		}
+		checks.ErrorIs(t, err, io.EOF, "stream should end")
+		checks.NoError(t, stream.Close(), "Close error")
+	}
+
+	if len(reused) != 2 || !reused[1] {
+		t.Errorf("expected the second stream to reuse the connection, got %v", reused)
+	}
+}
@@ -66,6 +66,23 @@ func TestChatRequestOmitEmpty(t *testing.T) {
 	}
 }
 
+func TestChatRequestExtraBody(t *testing.T) {
+	data, err := json.Marshal(openai.ChatCompletionRequest{
+		Model:     "gpt-4",
+		MaxTokens: 10,
+		ExtraBody: map[string]any{
+			"top_k":      40,
+			"max_tokens": 20,
+		},
+	})
+	checks.NoError(t, err)
+
+	const expected = `{"max_tokens":20,"messages":null,"model":"gpt-4","top_k":40}`
+	if string(data) != expected {
+		t.Errorf("expected JSON %v but was %v", expected, string(data))
+	}
+}
+
 func TestChatCompletionsWithStream(t *testing.T) {
 	config := openai.DefaultConfig("whatever")
 	config.BaseURL = "http://localhost/v1"
@@ -79,6 +96,86 @@ func TestChatCompletionsWithStream(t *testing.T) {
 	checks.ErrorIs(t, err, openai.ErrChatCompletionStreamNotSupported, "unexpected error")
 }
 
+func TestChatCompletionsInvalidAudio(t *testing.T) {
+	config := openai.DefaultConfig("whatever")
+	config.BaseURL = "http://localhost/v1"
+	client := openai.NewClientWithConfig(config)
+	ctx := context.Background()
+
+	audio := []openai.ChatModality{openai.ChatModalityText, openai.ChatModalityAudio}
+	testCases := []struct {
+		name       string
+		modalities []openai.ChatModality
+		audio      *openai.ChatCompletionAudio
+	}{
+		{"audio without modality", nil, &openai.ChatCompletionAudio{
+			Voice: openai.ChatAudioVoiceAlloy, Format: openai.ChatAudioFormatWAV}},
+		{"modality without audio", audio, nil},
+		{"unknown voice", audio, &openai.ChatCompletionAudio{Voice: "bob", Format: openai.ChatAudioFormatWAV}},
+		{"unknown format", audio, &openai.ChatCompletionAudio{Voice: openai.ChatAudioVoiceAlloy, Format: "aac"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+				Model:      openai.GPT4o,
+				Modalities: tc.modalities,
+				Audio:      tc.audio,
+			})
+			checks.ErrorIs(t, err, openai.ErrInvalidChatAudio, "unexpected error")
+		})
+	}
+
+	_, err := client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
+		Model:      openai.GPT4o,
+		Modalities: audio,
+		Audio:      &openai.ChatCompletionAudio{Voice: openai.ChatAudioVoiceAlloy, Format: openai.ChatAudioFormatWAV},
+	})
+	checks.ErrorIs(t, err, openai.ErrInvalidChatAudio, "streamed audio must be pcm16")
+}
+
+func TestChatCompletionsInvalidLogitBias(t *testing.T) {
+	config := openai.DefaultConfig("whatever")
+	config.BaseURL = "http://localhost/v1"
+	client := openai.NewClientWithConfig(config)
+	ctx := context.Background()
+
+	request := openai.ChatCompletionRequest{
+		Model:     openai.GPT4o,
+		Messages:  []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "Hello!"}},
+		LogitBias: openai.LogitBias{"You": 6},
+	}
+	_, err := client.CreateChatCompletion(ctx, request)
+	checks.ErrorIs(t, err, openai.ErrInvalidLogitBias, "logit_bias keyed by word")
+
+	request.LogitBias = openai.NewLogitBias(map[int]int{50256: -101})
+	_, err = client.CreateChatCompletionStream(ctx, request)
+	checks.ErrorIs(t, err, openai.ErrInvalidLogitBias, "logit_bias out of range")
+}
+
+func TestChatCompletionsAudio(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&body), "decode request")
+		if fmt.Sprint(body["modalities"]) != "[text audio]" {
+			t.Errorf("unexpected modalities: %v", body["modalities"])
+		}
+		if fmt.Sprint(body["audio"]) != "map[format:mp3 voice:coral]" {
+			t.Errorf("unexpected audio: %v", body["audio"])
+		}
+		fmt.Fprint(w, `{"id":"chatcmpl-1","choices":[]}`)
+	})
+
+	_, err := client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+		Model:      openai.GPT4o,
+		Modalities: []openai.ChatModality{openai.ChatModalityText, openai.ChatModalityAudio},
+		Audio:      &openai.ChatCompletionAudio{Voice: openai.ChatAudioVoiceCoral, Format: openai.ChatAudioFormatMP3},
+		Messages:   []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "Hello!"}},
+	})
+	checks.NoError(t, err, "CreateChatCompletion error")
+}
+
 // TestCompletions Tests the completions endpoint of the API using the mocked server.
 func TestChatCompletions(t *testing.T) {
 	client, server, teardown := setupOpenAITestServer()
@@ -527,3 +624,42 @@ func TestFinishReason(t *testing.T) {
 		}
 	}
 }
+
+func TestChatCompletionResponseFormatJSONSchemaRoundTrip(t *testing.T) {
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hi"}},
+		ResponseFormat: &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+				Name: "answer",
+				Schema: jsonschema.Definition{
+					Type:                 jsonschema.Object,
+					Properties:           map[string]jsonschema.Definition{"text": {Type: jsonschema.String}},
+					Required:             []string{"text"},
+					AdditionalProperties: false,
+				},
+				Strict: true,
+			},
+		},
+	}
+	data, err := json.Marshal(request)
+	checks.NoError(t, err, "Marshal error")
+
+	var decoded openai.ChatCompletionRequest
+	checks.NoError(t, json.Unmarshal(data, &decoded), "Unmarshal error")
+	if _, ok := decoded.ResponseFormat.JSONSchema.Schema.(json.RawMessage); !ok {
+		t.Fatalf("expected the schema to be kept as raw JSON, got %T", decoded.ResponseFormat.JSONSchema.Schema)
+	}
+	again, err := json.Marshal(decoded)
+	checks.NoError(t, err, "Marshal error")
+	if string(again) != string(data) {
+		t.Errorf("round trip changed the request:\n%s\n%s", data, again)
+	}
+
+	var empty openai.ChatCompletionResponseFormatJSONSchema
+	checks.NoError(t, json.Unmarshal([]byte(`{"name":"answer","schema":null}`), &empty), "Unmarshal error")
+	if empty.Schema != nil {
+		t.Errorf("expected a null schema to stay nil, got %s", empty.Schema)
+	}
+}
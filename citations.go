@@ -0,0 +1,113 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Citation is an entry of the bibliography returned by CitationResolver.
+type Citation struct {
+	// Number is the reference number used in the text, starting at 1.
+	Number   int
+	FileID   string
+	FileName string
+	// Quote is the cited text, when the API returned it.
+	Quote string
+}
+
+// CitationResolver replaces the file citation markers of message texts, such
+// as "【4:0†source】", with numbered references like "[1]", and lists the cited
+// files. File names are cached, so reuse a resolver across the messages of a
+// thread.
+type CitationResolver struct {
+	files FilesService
+
+	mu    sync.Mutex
+	names map[string]string
+}
+
+// NewCitationResolver returns a CitationResolver that fetches file names with files,
+// usually a *Client.
+func NewCitationResolver(files FilesService) *CitationResolver {
+	return &CitationResolver{
+		files: files,
+		names: make(map[string]string),
+	}
+}
+
+// Resolve returns the text with its file citation markers replaced by
+// references and the cited files in the order of their numbers. Citations of
+// the same file share a number. Other annotations are left in the text.
+func (r *CitationResolver) Resolve(ctx context.Context, text MessageText) (string, []Citation, error) {
+	annotations := make([]*Annotation, 0, len(text.Annotations))
+	for _, annotation := range text.Annotations {
+		if annotation != nil && annotation.FileCitation != nil {
+			annotations = append(annotations, annotation)
+		}
+	}
+	sort.SliceStable(annotations, func(i, j int) bool {
+		return annotations[i].StartIndex < annotations[j].StartIndex
+	})
+
+	var (
+		citations []Citation
+		numbers   = make(map[string]int)
+		out       strings.Builder
+		// The indexes count characters, not bytes.
+		runes = []rune(text.Value)
+		next  int
+	)
+	for _, annotation := range annotations {
+		fileID := annotation.FileCitation.FileID
+		number, ok := numbers[fileID]
+		if !ok {
+			name, err := r.fileName(ctx, fileID)
+			if err != nil {
+				return "", nil, err
+			}
+			number = len(citations) + 1
+			numbers[fileID] = number
+			citations = append(citations, Citation{
+				Number:   number,
+				FileID:   fileID,
+				FileName: name,
+				Quote:    annotation.FileCitation.Quote,
+			})
+		}
+
+		start, end := annotation.StartIndex, annotation.EndIndex
+		if start < next || end < start || end > len(runes) ||
+			(annotation.Text != "" && string(runes[start:end]) != annotation.Text) {
+			// The indexes don't match the text, skip the marker rather than
+			// cutting the text in the wrong place.
+			continue
+		}
+		out.WriteString(string(runes[next:start]))
+		fmt.Fprintf(&out, "[%d]", number)
+		next = end
+	}
+	out.WriteString(string(runes[next:]))
+	return out.String(), citations, nil
+}
+
+func (r *CitationResolver) fileName(ctx context.Context, fileID string) (string, error) {
+	r.mu.Lock()
+	name, ok := r.names[fileID]
+	r.mu.Unlock()
+	if ok {
+		return name, nil
+	}
+
+	file, err := r.files.GetFile(ctx, fileID)
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	r.names[fileID] = file.FileName
+	r.mu.Unlock()
+	return file.FileName, nil
+}
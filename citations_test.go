@@ -0,0 +1,64 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func citationAnnotation(text, marker, fileID string) *openai.Annotation {
+	start := len([]rune(text[:strings.Index(text, marker)]))
+	return &openai.Annotation{
+		Type:         openai.AnnotationTypeFileCitation,
+		Text:         marker,
+		StartIndex:   start,
+		EndIndex:     start + len([]rune(marker)),
+		FileCitation: &openai.FileCitation{FileID: fileID},
+	}
+}
+
+func TestCitationResolver(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	requests := make(map[string]int)
+	server.RegisterHandler("/v1/files/*", func(w http.ResponseWriter, r *http.Request) {
+		fileID := strings.TrimPrefix(r.URL.Path, "/v1/files/")
+		requests[fileID]++
+		fmt.Fprintf(w, `{"id":%q,"filename":"%s.pdf"}`, fileID, strings.TrimPrefix(fileID, "file-"))
+	})
+
+	const value = "Paris is the capital【4:0†source】. It is large【4:1†source】 and old【4:2†source】."
+	text := openai.MessageText{
+		Value: value,
+		Annotations: []*openai.Annotation{
+			citationAnnotation(value, "【4:2†source】", "file-history"),
+			citationAnnotation(value, "【4:0†source】", "file-geography"),
+			citationAnnotation(value, "【4:1†source】", "file-geography"),
+		},
+	}
+
+	resolver := openai.NewCitationResolver(client)
+	for i := 0; i < 2; i++ {
+		resolved, citations, err := resolver.Resolve(context.Background(), text)
+		checks.NoError(t, err, "Resolve error")
+
+		const expected = "Paris is the capital[1]. It is large[1] and old[2]."
+		if resolved != expected {
+			t.Fatalf("expected %q, got %q", expected, resolved)
+		}
+		if len(citations) != 2 || citations[0].FileName != "geography.pdf" || citations[1].Number != 2 ||
+			citations[1].FileID != "file-history" {
+			t.Fatalf("unexpected citations: %+v", citations)
+		}
+	}
+
+	if requests["file-geography"] != 1 || requests["file-history"] != 1 {
+		t.Fatalf("expected file names to be fetched once, got %v", requests)
+	}
+}
@@ -2,12 +2,16 @@ package openai
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptrace"
+	"net/url"
 	"strings"
+	"time"
 
 	utils "github.com/sashabaranov/go-openai/internal"
 )
@@ -24,14 +28,34 @@ type Response interface {
 	SetHeader(http.Header)
 }
 
-type httpHeader http.Header
+// httpHeader is embedded in every response type. It holds the response
+// headers and, with ClientConfig.KeepRawResponse, the raw response body.
+type httpHeader struct {
+	header  http.Header
+	rawBody []byte
+}
 
 func (h *httpHeader) SetHeader(header http.Header) {
-	*h = httpHeader(header)
+	h.header = header
 }
 
 func (h *httpHeader) Header() http.Header {
-	return http.Header(*h)
+	return h.header
+}
+
+// RawJSON returns the undecoded response body, so fields this client does not
+// model yet can be read without sending the request again. It is nil unless
+// ClientConfig.KeepRawResponse is set, and for streams and binary responses.
+func (h *httpHeader) RawJSON() json.RawMessage {
+	return h.rawBody
+}
+
+func (h *httpHeader) setRawBody(body []byte) {
+	h.rawBody = body
+}
+
+type rawBodySetter interface {
+	setRawBody([]byte)
 }
 
 func (h *httpHeader) GetRateLimitHeaders() RateLimitHeaders {
@@ -44,17 +68,24 @@ type RawResponse struct {
 	httpHeader
 }
 
-// NewClient creates new OpenAI API client.
-func NewClient(authToken string) *Client {
-	config := DefaultConfig(authToken)
-	return NewClientWithConfig(config)
+// NewClient creates new OpenAI API client, from DefaultConfig with the
+// settings of opts applied in order:
+//
+//	client := openai.NewClient(key, openai.ClientWithOrganization(org))
+func NewClient(authToken string, opts ...ClientOption) *Client {
+	return NewClientWithConfig(DefaultConfig(authToken).with(opts))
 }
 
 // NewClientWithConfig creates new OpenAI API client for specified config.
 func NewClientWithConfig(config ClientConfig) *Client {
+	config.HTTPClient = httpClientWithTransport(config)
+	requestBuilder := utils.NewRequestBuilder()
+	if config.JSONCodec != nil {
+		requestBuilder = utils.NewRequestBuilderWithMarshaller(codecMarshaller{config.JSONCodec})
+	}
 	return &Client{
 		config:         config,
-		requestBuilder: utils.NewRequestBuilder(),
+		requestBuilder: requestBuilder,
 		createFormBuilder: func(body io.Writer) utils.FormBuilder {
 			return utils.NewFormBuilder(body)
 		},
@@ -73,56 +104,179 @@ func NewOrgClient(authToken, org string) *Client {
 type requestOptions struct {
 	body   any
 	header http.Header
+	// model is checked for deprecation before the request is built.
+	model string
+	// serviceTier selects the default timeout of flex processing requests.
+	serviceTier ServiceTier
+
+	// Set by the exported RequestOptions.
+	extraHeader http.Header
+	query       url.Values
+	timeout     time.Duration
+	baseURL     string
+	trace       *httptrace.ClientTrace
+	// stream requests a server-sent event stream rather than a JSON response.
+	stream bool
+	// streamIdleTimeout overrides ClientConfig.StreamIdleTimeout when set.
+	streamIdleTimeout *time.Duration
+	// vectorStatus filters the vector stores listed by ListVectorsWithOptions.
+	vectorStatus VectorStatus
+	// err is set by options given an invalid value.
+	err error
 }
 
-type requestOption func(*requestOptions)
+// newRequestOptions returns the default options with setters applied.
+func newRequestOptions(setters []RequestOption) *requestOptions {
+	args := &requestOptions{
+		body:        nil,
+		header:      make(http.Header),
+		extraHeader: make(http.Header),
+	}
+	for _, setter := range setters {
+		setter(args)
+	}
+	return args
+}
 
-func withBody(body any) requestOption {
+func withBody(body any) RequestOption {
 	return func(args *requestOptions) {
 		args.body = body
 	}
 }
 
-func withContentType(contentType string) requestOption {
+func withStream() RequestOption {
+	return func(args *requestOptions) {
+		args.stream = true
+	}
+}
+
+func withContentType(contentType string) RequestOption {
 	return func(args *requestOptions) {
 		args.header.Set("Content-Type", contentType)
 	}
 }
 
-func withBetaAssistantVersion(version string) requestOption {
+func withModel(model string) RequestOption {
+	return func(args *requestOptions) {
+		args.model = model
+	}
+}
+
+func withBetaAssistantVersion(version string) RequestOption {
 	return func(args *requestOptions) {
 		args.header.Set("OpenAI-Beta", fmt.Sprintf("assistants=%s", version))
 	}
 }
 
-func (c *Client) newRequest(ctx context.Context, method, url string, setters ...requestOption) (*http.Request, error) {
-	// Default Options
-	args := &requestOptions{
-		body:   nil,
-		header: make(http.Header),
+// withRequestOptions applies the options passed to a client method.
+func withRequestOptions(opts []RequestOption) RequestOption {
+	return func(args *requestOptions) {
+		for _, opt := range opts {
+			opt(args)
+		}
 	}
-	for _, setter := range setters {
-		setter(args)
+}
+
+func (c *Client) newRequest(ctx context.Context, method, url string, setters ...RequestOption) (*http.Request, error) {
+	args := newRequestOptions(setters)
+	if args.err != nil {
+		return nil, args.err
+	}
+	setContentHeaders(args)
+	// Routed requests go to another backend than Provider's.
+	route, routed := c.matchModelRoute(args.model)
+	if !routed {
+		if err := c.checkProviderSupport(method, url); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case args.baseURL != "":
+		url = c.rebaseURL(url, args.baseURL)
+	case routed && route.BaseURL != "":
+		url = c.rebaseURL(url, route.BaseURL)
+	}
+	if err := c.checkModelDeprecation(args.model); err != nil {
+		return nil, err
+	}
+
+	if _, hasDeadline := ctx.Deadline(); args.timeout == 0 && !hasDeadline {
+		args.timeout = c.config.OperationTimeouts.timeout(method, url)
+		if args.serviceTier == ServiceTierFlex {
+			args.timeout = c.config.OperationTimeouts.flexTimeout()
+		}
+	}
+
+	if args.trace != nil {
+		ctx = httptrace.WithClientTrace(ctx, args.trace)
+	}
+	if args.streamIdleTimeout != nil {
+		ctx = context.WithValue(ctx, streamIdleTimeoutKey{}, *args.streamIdleTimeout)
+	}
+	var cancel context.CancelFunc
+	if args.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, args.timeout)
+		ctx = context.WithValue(ctx, requestCancelKey{}, cancel)
 	}
 	req, err := c.requestBuilder.Build(ctx, method, url, args.body, args.header)
 	if err != nil {
+		if cancel != nil {
+			cancel()
+		}
 		return nil, err
 	}
 	c.setCommonHeaders(req)
+	if routed {
+		route.setAuthorization(req.Header)
+	}
+	if c.config.HeadersFromContext != nil {
+		for key, values := range c.config.HeadersFromContext(ctx) {
+			req.Header[http.CanonicalHeaderKey(key)] = values
+		}
+	}
+
+	for key, values := range args.extraHeader {
+		req.Header[key] = values
+	}
+	c.setIdempotencyKey(req)
+	if len(args.query) > 0 {
+		query := req.URL.Query()
+		for key, values := range args.query {
+			query[key] = values
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+	if err = c.config.Compression.compressRequest(req); err == nil {
+		err = c.signRequest(req)
+	}
+	if err != nil {
+		if cancel != nil {
+			cancel()
+		}
+		return nil, err
+	}
 	return req, nil
 }
 
-func (c *Client) sendRequest(req *http.Request, v Response) error {
-	req.Header.Set("Accept", "application/json")
-
-	// Check whether Content-Type is already set, Upload Files API requires
-	// Content-Type == multipart/form-data
-	contentType := req.Header.Get("Content-Type")
-	if contentType == "" {
-		req.Header.Set("Content-Type", "application/json")
+// setContentHeaders sets the headers describing the body and the expected
+// response, before the request is signed.
+func setContentHeaders(args *requestOptions) {
+	if args.stream {
+		args.header.Set("Content-Type", "application/json")
+		args.header.Set("Accept", "text/event-stream")
+		args.header.Set("Cache-Control", "no-cache")
+		args.header.Set("Connection", "keep-alive")
+		return
+	}
+	args.header.Set("Accept", "application/json")
+	// Uploads set a multipart/form-data Content-Type.
+	if args.header.Get("Content-Type") == "" {
+		args.header.Set("Content-Type", "application/json")
 	}
+}
 
-	res, err := c.config.HTTPClient.Do(req)
+func (c *Client) sendRequest(req *http.Request, v Response) (err error) {
+	res, err := c.doWithRetry(req)
 	if err != nil {
 		return err
 	}
@@ -137,11 +291,36 @@ func (c *Client) sendRequest(req *http.Request, v Response) error {
 		return c.handleErrorResp(res)
 	}
 
-	return decodeResponse(res.Body, v)
+	setter, keepRaw := v.(rawBodySetter)
+	keepRaw = keepRaw && c.config.KeepRawResponse
+	checkFields := v != nil && (c.config.StrictDecoding || c.config.OnUnknownFields != nil)
+	defer func() {
+		if err == nil {
+			c.reportUsage(req.Context(), v)
+		}
+	}()
+	if !keepRaw && !checkFields {
+		return c.decode(res.Body, v)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if keepRaw {
+		setter.setRawBody(body)
+	}
+	if err = c.decode(bytes.NewReader(body), v); err != nil {
+		return err
+	}
+	if checkFields {
+		return c.checkUnknownFields(body, v)
+	}
+	return nil
 }
 
 func (c *Client) sendRequestRaw(req *http.Request) (response RawResponse, err error) {
-	resp, err := c.config.HTTPClient.Do(req) //nolint:bodyclose // body should be closed by outer function
+	resp, err := c.doWithRetry(req) //nolint:bodyclose // body should be closed by outer function
 	if err != nil {
 		return
 	}
@@ -157,12 +336,7 @@ func (c *Client) sendRequestRaw(req *http.Request) (response RawResponse, err er
 }
 
 func sendRequestStreamV2(client *Client, req *http.Request) (stream *StreamerV2, err error) {
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "text/event-stream")
-	req.Header.Set("Cache-Control", "no-cache")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.config.HTTPClient.Do(req)
+	resp, err := client.doWithRetry(req)
 	if err != nil {
 		return
 	}
@@ -174,30 +348,40 @@ func sendRequestStreamV2(client *Client, req *http.Request) (stream *StreamerV2,
 		resp.Body.Close()
 		return nil, fmt.Errorf("unexpected status code: %d , body:%s", resp.StatusCode, string(body))
 	}
+	client.withIdleTimeout(req, resp)
 
-	return NewStreamerV2(resp.Body), nil
+	return NewStreamerV2Size(resp.Body, client.config.StreamMaxLineSize), nil
 }
 
 func sendRequestStream[T streamable](client *Client, req *http.Request) (*streamReader[T], error) {
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "text/event-stream")
-	req.Header.Set("Cache-Control", "no-cache")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.config.HTTPClient.Do(req) //nolint:bodyclose // body is closed in stream.Close()
+	clock := streamClock{started: time.Now()}
+	resp, err := client.doWithRetry(req) //nolint:bodyclose // body is closed in stream.Close()
 	if err != nil {
 		return new(streamReader[T]), err
 	}
 	if isFailureStatusCode(resp) {
 		return new(streamReader[T]), client.handleErrorResp(resp)
 	}
+	client.withIdleTimeout(req, resp)
+	var onRecv func(*T, StreamStats)
+	if client.config.OnUsage != nil {
+		onRecv = func(response *T, stats StreamStats) {
+			client.reportUsage(context.WithValue(req.Context(), streamStatsKey{}, stats), response)
+		}
+	}
+	var unmarshaler utils.Unmarshaler = &utils.JSONUnmarshaler{}
+	if client.config.JSONCodec != nil {
+		unmarshaler = client.config.JSONCodec
+	}
 	return &streamReader[T]{
+		clock:              clock,
+		onRecv:             onRecv,
 		emptyMessagesLimit: client.config.EmptyMessagesLimit,
 		reader:             bufio.NewReader(resp.Body),
 		response:           resp,
 		errAccumulator:     utils.NewErrorAccumulator(),
-		unmarshaler:        &utils.JSONUnmarshaler{},
-		httpHeader:         httpHeader(resp.Header),
+		unmarshaler:        unmarshaler,
+		httpHeader:         httpHeader{header: resp.Header},
 	}, nil
 }
 
@@ -213,6 +397,10 @@ func (c *Client) setCommonHeaders(req *http.Request) {
 	if c.config.OrgID != "" {
 		req.Header.Set("OpenAI-Organization", c.config.OrgID)
 	}
+	req.Header.Set("User-Agent", c.userAgent())
+	if c.config.SendPlatformHeaders {
+		setPlatformHeaders(req.Header)
+	}
 }
 
 func isFailureStatusCode(resp *http.Response) bool {
@@ -248,8 +436,7 @@ func decodeString(body io.Reader, output *string) error {
 func (c *Client) fullURL(suffix string, args ...any) string {
 	// /openai/deployments/{model}/chat/completions?api-version={api_version}
 	if c.config.APIType == APITypeAzure || c.config.APIType == APITypeAzureAD {
-		baseURL := c.config.BaseURL
-		baseURL = strings.TrimRight(baseURL, "/")
+		baseURL := c.apiBaseURL()
 		// if suffix is /models change to {endpoint}/openai/models?api-version=2022-12-01
 		// https://learn.microsoft.com/en-us/rest/api/cognitiveservices/azureopenaistable/models/list?tabs=HTTP
 		queryToken := "?"
@@ -258,7 +445,7 @@ func (c *Client) fullURL(suffix string, args ...any) string {
 		}
 
 		if containsSubstr([]string{"/vector_stores", "/models", "/assistants", "/threads", "/files"}, suffix) {
-			return fmt.Sprintf("%s/%s%s%sapi-version=%s", baseURL, azureAPIPrefix, suffix, queryToken, c.config.APIVersion)
+			return fmt.Sprintf("%s%s%sapi-version=%s", baseURL, suffix, queryToken, c.config.APIVersion)
 		}
 		azureDeploymentName := "UNKNOWN"
 		if len(args) > 0 {
@@ -267,29 +454,61 @@ func (c *Client) fullURL(suffix string, args ...any) string {
 				azureDeploymentName = c.config.GetAzureDeploymentByModel(model)
 			}
 		}
-		return fmt.Sprintf("%s/%s/%s/%s%s%sapi-version=%s",
-			baseURL, azureAPIPrefix, azureDeploymentsPrefix,
+		return fmt.Sprintf("%s/%s/%s%s%sapi-version=%s",
+			baseURL, azureDeploymentsPrefix,
 			azureDeploymentName, suffix, queryToken, c.config.APIVersion,
 		)
 	}
 
 	// https://developers.cloudflare.com/ai-gateway/providers/azureopenai/
 	if c.config.APIType == APITypeCloudflareAzure {
-		baseURL := c.config.BaseURL
-		baseURL = strings.TrimRight(baseURL, "/")
-		return fmt.Sprintf("%s%s?api-version=%s", baseURL, suffix, c.config.APIVersion)
+		return fmt.Sprintf("%s%s?api-version=%s", c.apiBaseURL(), suffix, c.config.APIVersion)
+	}
+
+	return fmt.Sprintf("%s%s", c.apiBaseURL(), suffix)
+}
+
+// apiBaseURL returns the URL that endpoint paths are appended to: BaseURL
+// followed by the path prefix.
+func (c *Client) apiBaseURL() string {
+	prefix := c.config.PathPrefix
+	if prefix == "" && (c.config.APIType == APITypeAzure || c.config.APIType == APITypeAzureAD) {
+		prefix = azureAPIPrefix
 	}
+	prefix = strings.Trim(prefix, "/")
+	if prefix != "" {
+		prefix = "/" + prefix
+	}
+	return strings.TrimRight(c.config.BaseURL, "/") + prefix
+}
 
-	return fmt.Sprintf("%s%s", c.config.BaseURL, suffix)
+// rebaseURL replaces the configured base URL at the start of a URL built by
+// fullURL with baseURL.
+func (c *Client) rebaseURL(fullURL, baseURL string) string {
+	configured := strings.TrimRight(c.config.BaseURL, "/")
+	if !strings.HasPrefix(fullURL, configured) {
+		return fullURL
+	}
+	return strings.TrimRight(baseURL, "/") + fullURL[len(configured):]
 }
 
 func (c *Client) handleErrorResp(resp *http.Response) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize+1))
+	truncated := len(body) > maxErrorBodySize
+	if truncated {
+		body = body[:maxErrorBodySize]
+	}
 	var errRes ErrorResponse
-	err := json.NewDecoder(resp.Body).Decode(&errRes)
+	if err == nil {
+		err = json.NewDecoder(bytes.NewReader(body)).Decode(&errRes)
+	}
 	if err != nil || errRes.Error == nil {
 		reqErr := &RequestError{
 			HTTPStatusCode: resp.StatusCode,
 			Err:            err,
+			Header:         resp.Header,
+			Body:           body,
+			BodyTruncated:  truncated,
 		}
 		if errRes.Error != nil {
 			reqErr.Err = errRes.Error
@@ -0,0 +1,67 @@
+package openai
+
+import "net/http"
+
+// ClientOption configures the client created by NewClient or derived by
+// Client.With. Options are applied to a copy of the configuration, which is
+// never shared between clients nor exposed, so that a client cannot be
+// reconfigured once created.
+type ClientOption struct {
+	apply func(*ClientConfig)
+}
+
+// With returns a new client with the configuration of c changed by opts, e.g.
+// another organization or retry policy for some calls. c itself is never
+// modified, so clients shared between goroutines are derived from instead of
+// reconfigured. Derived clients share the HTTP client and connection pool of c
+// unless an option replaces the HTTP client.
+func (c *Client) With(opts ...ClientOption) *Client {
+	config := c.config
+	config.ModelRoutes = append([]ModelRoute(nil), c.config.ModelRoutes...)
+	return NewClientWithConfig(config.with(opts))
+}
+
+// with returns config with the settings of opts applied in order.
+func (config ClientConfig) with(opts []ClientOption) ClientConfig {
+	for _, opt := range opts {
+		opt.apply(&config)
+	}
+	return config
+}
+
+// ClientWithAPIKey sets the key the client authenticates with.
+func ClientWithAPIKey(apiKey string) ClientOption {
+	return ClientOption{func(config *ClientConfig) {
+		config.authToken = apiKey
+	}}
+}
+
+// ClientWithBaseURL sets the base URL of the API, e.g. the URL of a proxy or
+// of an OpenAI-compatible server. To send a single call elsewhere, use the
+// WithBaseURL request option.
+func ClientWithBaseURL(baseURL string) ClientOption {
+	return ClientOption{func(config *ClientConfig) {
+		config.BaseURL = baseURL
+	}}
+}
+
+// ClientWithOrganization sets the organization the calls are billed to.
+func ClientWithOrganization(orgID string) ClientOption {
+	return ClientOption{func(config *ClientConfig) {
+		config.OrgID = orgID
+	}}
+}
+
+// ClientWithHTTPClient sets the HTTP client used to send the requests.
+func ClientWithHTTPClient(client *http.Client) ClientOption {
+	return ClientOption{func(config *ClientConfig) {
+		config.HTTPClient = client
+	}}
+}
+
+// ClientWithRetryPolicy sets the retry policy of the client.
+func ClientWithRetryPolicy(policy RetryPolicy) ClientOption {
+	return ClientOption{func(config *ClientConfig) {
+		config.RetryPolicy = policy
+	}}
+}
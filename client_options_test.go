@@ -0,0 +1,79 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestNewClientWithOptions(t *testing.T) {
+	server := test.NewTestServer()
+	var (
+		mu   sync.Mutex
+		orgs = map[string]int{}
+	)
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		orgs[r.Header.Get("OpenAI-Organization")]++
+		mu.Unlock()
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	client := openai.NewClient(
+		test.GetTestToken(),
+		openai.ClientWithBaseURL(ts.URL+"/v1"),
+		openai.ClientWithOrganization("org-base"),
+	)
+	_, err := client.ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			derived := client.With(openai.ClientWithOrganization(fmt.Sprintf("org-%d", i)))
+			_, derivedErr := derived.ListModels(context.Background())
+			checks.NoError(t, derivedErr, "ListModels error")
+		}(i)
+	}
+	wg.Wait()
+
+	_, err = client.ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+
+	if orgs["org-base"] != 2 {
+		t.Errorf("expected the base client to keep its organization, got %v", orgs)
+	}
+	for i := 0; i < 4; i++ {
+		if org := fmt.Sprintf("org-%d", i); orgs[org] != 1 {
+			t.Errorf("expected one call for %s, got %v", org, orgs)
+		}
+	}
+}
+
+func TestClientWithAPIKey(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	client := openai.NewClient("", openai.ClientWithBaseURL(ts.URL+"/v1"))
+	_, err := client.ListModels(context.Background())
+	checks.HasError(t, err, "ListModels should fail without an API key")
+
+	_, err = client.With(openai.ClientWithAPIKey(test.GetTestToken())).ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+}
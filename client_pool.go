@@ -0,0 +1,284 @@
+package openai
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	poolMinCooldown = time.Second
+	poolMaxCooldown = 30 * time.Second
+)
+
+// PoolMember is an API key of a ClientPool and the endpoint it is used with.
+type PoolMember struct {
+	// BaseURL replaces ClientConfig.BaseURL for the requests sent with this
+	// member. Empty uses ClientConfig.BaseURL.
+	BaseURL string
+	APIKey  string
+}
+
+// PoolMemberStatus is the health of a pool member.
+type PoolMemberStatus struct {
+	BaseURL string
+	// Failures is the number of consecutive failed requests.
+	Failures int
+	// UnavailableUntil is when the member is used again after failures or
+	// after exhausting its rate limit. Zero when the member is available.
+	UnavailableUntil time.Time
+}
+
+// ClientPool is a Client that spreads requests over several API keys and
+// endpoints. Requests go round-robin to the available members. A member that
+// answers with 429 or 5xx, or cannot be reached, is set aside for a cooldown
+// and the request is sent to the next member, provided its body can be sent
+// again and ClientConfig.RetryPolicy allows retrying it: by default, only
+// GET and HEAD requests and requests with an Idempotency-Key fail over, so
+// that creates are not run twice. With ClientConfig.SignRequest, requests are
+// signed again once the endpoint and key of the member are set. A member that
+// reports no remaining requests in its rate limit headers is set aside until
+// the limit resets. When every member is set aside the request is still sent,
+// to the member that becomes available first.
+//
+// Only requests to ClientConfig.BaseURL are spread over the members. Requests
+// sent elsewhere, with WithBaseURL or a ModelRoute, keep their URL and
+// credentials and are sent as they are.
+//
+// With a ClientConfig.RetryPolicy, every retry of a call goes through the
+// failover again, so a call is sent up to (MaxRetries+1) times the number of
+// members.
+//
+// ClientPool has the methods of Client. Members authenticate as the client
+// does: with an api-key header for Azure and with bearer tokens otherwise.
+type ClientPool struct {
+	*Client
+	transport *poolTransport
+}
+
+// NewClientPool returns a pool of members using config for everything but
+// the endpoint and the API key.
+func NewClientPool(config ClientConfig, members ...PoolMember) *ClientPool {
+	httpClient := *httpClientWithTransport(config)
+	transport := &poolTransport{
+		base:    httpClient.Transport,
+		baseURL: strings.TrimRight(config.BaseURL, "/"),
+		apiType: config.APIType,
+	}
+	if transport.base == nil {
+		transport.base = http.DefaultTransport
+	}
+	for _, member := range members {
+		if member.BaseURL == "" {
+			member.BaseURL = config.BaseURL
+		}
+		member.BaseURL = strings.TrimRight(member.BaseURL, "/")
+		transport.members = append(transport.members, &poolMemberState{PoolMember: member})
+	}
+	httpClient.Transport = transport
+	config.HTTPClient = &httpClient
+
+	client := NewClientWithConfig(config)
+	transport.policy = config.RetryPolicy
+	if config.SignRequest != nil {
+		transport.sign = client.signRequest
+	}
+	return &ClientPool{
+		Client:    client,
+		transport: transport,
+	}
+}
+
+// Members returns the status of the members, in the order they were given.
+func (p *ClientPool) Members() []PoolMemberStatus {
+	p.transport.mu.Lock()
+	defer p.transport.mu.Unlock()
+	statuses := make([]PoolMemberStatus, len(p.transport.members))
+	for i, member := range p.transport.members {
+		statuses[i] = PoolMemberStatus{
+			BaseURL:          member.BaseURL,
+			Failures:         member.failures,
+			UnavailableUntil: member.unavailableUntil,
+		}
+	}
+	return statuses
+}
+
+type poolMemberState struct {
+	PoolMember
+	failures         int
+	unavailableUntil time.Time
+}
+
+// poolTransport sends each request with a member of the pool, failing over to
+// the next member on transient errors.
+type poolTransport struct {
+	base    http.RoundTripper
+	baseURL string
+	apiType APIType
+	policy  RetryPolicy
+	// sign signs the requests again for the member they are sent to.
+	sign func(req *http.Request) error
+
+	mu      sync.Mutex
+	members []*poolMemberState
+	next    int
+}
+
+func (t *poolTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path, ok := t.poolPath(req.URL)
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+	candidates := t.candidates()
+	if len(candidates) == 0 {
+		return t.base.RoundTrip(req)
+	}
+	// A body that cannot be rewound can only be sent to one member, and
+	// requests that are not safe to repeat are not failed over.
+	failover := (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil) && t.policy.allowsRetry(req)
+
+	var (
+		resp *http.Response
+		err  error
+	)
+	for i, member := range candidates {
+		if i > 0 {
+			if !failover {
+				break
+			}
+			if resp != nil {
+				_, _ = io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+		}
+		attempt := req.Clone(req.Context())
+		if i > 0 && req.GetBody != nil {
+			if attempt.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		if attempt.URL, err = url.Parse(member.BaseURL + path); err != nil {
+			return nil, err
+		}
+		attempt.Host = attempt.URL.Host
+		t.setAuthorization(attempt.Header, member)
+		if t.sign != nil {
+			if err = t.sign(attempt); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err = t.base.RoundTrip(attempt) //nolint:bodyclose // closed above or by the caller
+		if err == nil && !isRetryableStatusCode(resp.StatusCode) {
+			t.succeeded(member, resp)
+			return resp, nil
+		}
+		if req.Context().Err() != nil {
+			break
+		}
+		t.failed(member, resp)
+	}
+	return resp, err
+}
+
+// candidates returns the members in the order to try them: the available
+// ones round-robin, then the others by when they become available.
+func (t *poolTransport) candidates() []*poolMemberState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.members) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	var available, unavailable []*poolMemberState
+	for i := range t.members {
+		member := t.members[(t.next+i)%len(t.members)]
+		if member.unavailableUntil.After(now) {
+			unavailable = append(unavailable, member)
+		} else {
+			available = append(available, member)
+		}
+	}
+	t.next = (t.next + 1) % len(t.members)
+
+	for i := 1; i < len(unavailable); i++ {
+		for j := i; j > 0 && unavailable[j].unavailableUntil.Before(unavailable[j-1].unavailableUntil); j-- {
+			unavailable[j], unavailable[j-1] = unavailable[j-1], unavailable[j]
+		}
+	}
+	return append(available, unavailable...)
+}
+
+// poolPath returns the part of u after the configured base URL, and false
+// when u is not under the base URL.
+func (t *poolTransport) poolPath(u *url.URL) (string, bool) {
+	full := u.String()
+	if !strings.HasPrefix(full, t.baseURL) {
+		return "", false
+	}
+	path := full[len(t.baseURL):]
+	if path != "" && path[0] != '/' && path[0] != '?' {
+		return "", false
+	}
+	return path, true
+}
+
+// setAuthorization replaces the credentials of the client with the key of
+// member, in the scheme of the client.
+func (t *poolTransport) setAuthorization(header http.Header, member *poolMemberState) {
+	if t.apiType == APITypeAzure || t.apiType == APITypeCloudflareAzure {
+		header.Del("Authorization")
+		header.Set(AzureAPIKeyHeader, member.APIKey)
+		return
+	}
+	header.Del(AzureAPIKeyHeader)
+	header.Set("Authorization", "Bearer "+member.APIKey)
+}
+
+func (t *poolTransport) succeeded(member *poolMemberState, resp *http.Response) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	member.failures = 0
+	member.unavailableUntil = time.Time{}
+	if resp.Header.Get("x-ratelimit-remaining-requests") == "0" {
+		member.unavailableUntil = rateLimitReset(resp)
+	}
+}
+
+func (t *poolTransport) failed(member *poolMemberState, resp *http.Response) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	member.failures++
+	cooldown := poolMinCooldown
+	for i := 1; i < member.failures && cooldown < poolMaxCooldown; i++ {
+		cooldown *= 2
+	}
+	if cooldown > poolMaxCooldown {
+		cooldown = poolMaxCooldown
+	}
+	until := time.Now().Add(cooldown)
+	if after, ok := retryAfter(resp); ok && time.Now().Add(after).After(until) {
+		until = time.Now().Add(after)
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		if reset := rateLimitReset(resp); reset.After(until) {
+			until = reset
+		}
+	}
+	member.unavailableUntil = until
+}
+
+// rateLimitReset returns when the request rate limit reported by resp resets.
+func rateLimitReset(resp *http.Response) time.Time {
+	value := resp.Header.Get("x-ratelimit-reset-requests")
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Now().Add(time.Duration(seconds * float64(time.Second)))
+	}
+	return ResetTime(value).Time()
+}
@@ -0,0 +1,145 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestClientPoolFailover(t *testing.T) {
+	var limitedCalls, healthyCalls int
+	limited := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limitedCalls++
+		if r.Header.Get("Authorization") != "Bearer key-1" {
+			t.Errorf("unexpected Authorization %q", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer limited.Close()
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		healthyCalls++
+		if r.Header.Get("Authorization") != "Bearer key-2" {
+			t.Errorf("unexpected Authorization %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"choices":[]}`)
+	}))
+	defer healthy.Close()
+
+	config := openai.DefaultConfig("")
+	config.BaseURL = limited.URL + "/v1"
+	// Idempotency keys make the chat completions safe to fail over.
+	config.AutoIdempotencyKeys = true
+	pool := openai.NewClientPool(config,
+		openai.PoolMember{APIKey: "key-1"},
+		openai.PoolMember{BaseURL: healthy.URL + "/v1", APIKey: "key-2"},
+	)
+	var api openai.ChatService = pool
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "Hello!"}},
+	}
+	for i := 0; i < 3; i++ {
+		_, err := api.CreateChatCompletion(context.Background(), request)
+		checks.NoError(t, err, "CreateChatCompletion error")
+	}
+
+	if limitedCalls != 1 || healthyCalls != 3 {
+		t.Errorf("expected 1 call to the limited member and 3 to the healthy one, got %d and %d",
+			limitedCalls, healthyCalls)
+	}
+	members := pool.Members()
+	if members[0].Failures != 1 || members[0].UnavailableUntil.IsZero() || !members[1].UnavailableUntil.IsZero() {
+		t.Errorf("unexpected member statuses %+v", members)
+	}
+}
+
+func TestClientPoolFailoverIdempotentOnly(t *testing.T) {
+	var limitedCalls, healthyCalls int
+	limited := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		limitedCalls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer limited.Close()
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		healthyCalls++
+		if r.Header.Get("X-Signature") != "Bearer key-2 "+r.Host {
+			t.Errorf("expected the request to be signed for the member, got %q", r.Header.Get("X-Signature"))
+		}
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}))
+	defer healthy.Close()
+
+	config := openai.DefaultConfig("")
+	config.BaseURL = limited.URL + "/v1"
+	config.SignRequest = func(req *http.Request, _ []byte) error {
+		req.Header.Set("X-Signature", req.Header.Get("Authorization")+" "+req.URL.Host)
+		return nil
+	}
+	newPool := func() *openai.ClientPool {
+		return openai.NewClientPool(config,
+			openai.PoolMember{APIKey: "key-1"},
+			openai.PoolMember{BaseURL: healthy.URL + "/v1", APIKey: "key-2"},
+		)
+	}
+
+	_, err := newPool().CreateVector(context.Background(), openai.VectorRequest{})
+	checks.HasError(t, err, "CreateVector should not fail over")
+	if limitedCalls != 1 || healthyCalls != 0 {
+		t.Errorf("expected the create to be sent once, got %d and %d calls", limitedCalls, healthyCalls)
+	}
+
+	_, err = newPool().ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+	if limitedCalls != 2 || healthyCalls != 1 {
+		t.Errorf("expected the list to fail over, got %d and %d calls", limitedCalls, healthyCalls)
+	}
+}
+
+func TestClientPoolCredentials(t *testing.T) {
+	var memberAuth, memberAPIKey, otherAuth string
+	member := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		memberAuth, memberAPIKey = r.Header.Get("Authorization"), r.Header.Get(openai.AzureAPIKeyHeader)
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}))
+	defer member.Close()
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		otherAuth = r.Header.Get("Authorization")
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}))
+	defer other.Close()
+
+	config := openai.DefaultConfig("client-key")
+	config.BaseURL = member.URL + "/v1"
+	pool := openai.NewClientPool(config, openai.PoolMember{APIKey: "member-key"})
+
+	_, err := pool.ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+	if memberAuth != "Bearer member-key" {
+		t.Errorf("expected the member key, got %q", memberAuth)
+	}
+
+	// Requests sent elsewhere keep the credentials of the client.
+	_, err = pool.ListModels(context.Background(), openai.WithBaseURL(other.URL+"/v1"))
+	checks.NoError(t, err, "ListModels error")
+	if otherAuth != "Bearer client-key" {
+		t.Errorf("expected the client key to be sent to the other endpoint, got %q", otherAuth)
+	}
+
+	azure := openai.NewClientPool(openai.DefaultAzureConfig("client-key", member.URL),
+		openai.PoolMember{APIKey: "azure-member-key"})
+	_, err = azure.ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+	if memberAPIKey != "azure-member-key" || memberAuth != "" {
+		t.Errorf("expected the member key in the api-key header, got %q and Authorization %q",
+			memberAPIKey, memberAuth)
+	}
+}
@@ -237,6 +237,9 @@ func TestClientReturnsRequestBuilderErrors(t *testing.T) {
 		{"CreateChatCompletionStream", func() (any, error) {
 			return client.CreateChatCompletionStream(ctx, ChatCompletionRequest{Model: GPT3Dot5Turbo})
 		}},
+		{"StreamChat", func() (any, error) {
+			return client.StreamChat(ctx, ChatCompletionRequest{Model: GPT3Dot5Turbo}, ChatStreamHandlers{})
+		}},
 		{"CreateFineTune", func() (any, error) {
 			return client.CreateFineTune(ctx, FineTuneRequest{})
 		}},
@@ -270,6 +273,9 @@ func TestClientReturnsRequestBuilderErrors(t *testing.T) {
 		{"Moderations", func() (any, error) {
 			return client.Moderations(ctx, ModerationRequest{})
 		}},
+		{"ModerateLongText", func() (any, error) {
+			return client.ModerateLongText(ctx, ModerationRequest{Input: "One. Two."}, ModerationSplitOptions{MaxChunkLength: 5})
+		}},
 		{"Edits", func() (any, error) {
 			return client.Edits(ctx, EditsRequest{})
 		}},
@@ -309,6 +315,12 @@ func TestClientReturnsRequestBuilderErrors(t *testing.T) {
 		{"DeleteFineTuneModel", func() (any, error) {
 			return client.DeleteFineTuneModel(ctx, "")
 		}},
+		{"ListFineTunedModels", func() (any, error) {
+			return client.ListFineTunedModels(ctx)
+		}},
+		{"DeleteFineTunedModel", func() (any, error) {
+			return client.DeleteFineTunedModel(ctx, "")
+		}},
 		{"CreateAssistant", func() (any, error) {
 			return client.CreateAssistant(ctx, AssistantRequest{})
 		}},
@@ -324,12 +336,18 @@ func TestClientReturnsRequestBuilderErrors(t *testing.T) {
 		{"ListAssistants", func() (any, error) {
 			return client.ListAssistants(ctx, nil, nil, nil, nil)
 		}},
+		{"ListAssistantsWithOptions", func() (any, error) {
+			return client.ListAssistantsWithOptions(ctx, WithLimit(1))
+		}},
 		{"CreateAssistantFile", func() (any, error) {
 			return client.CreateAssistantFile(ctx, "", AssistantFileRequest{})
 		}},
 		{"ListAssistantFiles", func() (any, error) {
 			return client.ListAssistantFiles(ctx, "", nil, nil, nil, nil)
 		}},
+		{"ListAssistantFilesWithOptions", func() (any, error) {
+			return client.ListAssistantFilesWithOptions(ctx, "")
+		}},
 		{"RetrieveAssistantFile", func() (any, error) {
 			return client.RetrieveAssistantFile(ctx, "", "")
 		}},
@@ -342,12 +360,18 @@ func TestClientReturnsRequestBuilderErrors(t *testing.T) {
 		{"ListMessage", func() (any, error) {
 			return client.ListMessage(ctx, "", nil, nil, nil, nil)
 		}},
+		{"ListMessagesWithOptions", func() (any, error) {
+			return client.ListMessagesWithOptions(ctx, "")
+		}},
 		{"RetrieveMessage", func() (any, error) {
 			return client.RetrieveMessage(ctx, "", "")
 		}},
 		{"ModifyMessage", func() (any, error) {
 			return client.ModifyMessage(ctx, "", "", nil)
 		}},
+		{"DeleteMessage", func() (any, error) {
+			return client.DeleteMessage(ctx, "", "")
+		}},
 		{"RetrieveMessageFile", func() (any, error) {
 			return client.RetrieveMessageFile(ctx, "", "", "")
 		}},
@@ -384,6 +408,21 @@ func TestClientReturnsRequestBuilderErrors(t *testing.T) {
 		{"CancelRun", func() (any, error) {
 			return client.CancelRun(ctx, "", "")
 		}},
+		{"ListRunsIterator", func() (any, error) {
+			it := client.ListRunsIterator(ctx, "")
+			return it.Next(), it.Err()
+		}},
+		{"ListRunStepsIterator", func() (any, error) {
+			it := client.ListRunStepsIterator(ctx, "", "")
+			return it.Next(), it.Err()
+		}},
+		{"ListMessagesIterator", func() (any, error) {
+			it := client.ListMessagesIterator(ctx, "")
+			return it.Next(), it.Err()
+		}},
+		{"CancelRunAndWait", func() (any, error) {
+			return client.CancelRunAndWait(ctx, "", "", 0)
+		}},
 		{"CreateThreadAndRun", func() (any, error) {
 			return client.CreateThreadAndRun(ctx, CreateThreadAndRunRequest{})
 		}},
@@ -396,6 +435,174 @@ func TestClientReturnsRequestBuilderErrors(t *testing.T) {
 		{"CreateSpeech", func() (any, error) {
 			return client.CreateSpeech(ctx, CreateSpeechRequest{Model: TTSModel1, Voice: VoiceAlloy})
 		}},
+		{"UploadCertificate", func() (any, error) {
+			return client.UploadCertificate(ctx, CertificateRequest{})
+		}},
+		{"RetrieveCertificate", func() (any, error) {
+			return client.RetrieveCertificate(ctx, "", false)
+		}},
+		{"ModifyCertificate", func() (any, error) {
+			return client.ModifyCertificate(ctx, "", CertificateModifyRequest{})
+		}},
+		{"DeleteCertificate", func() (any, error) {
+			return client.DeleteCertificate(ctx, "")
+		}},
+		{"ListCertificates", func() (any, error) {
+			return client.ListCertificates(ctx, Pagination{})
+		}},
+		{"ActivateCertificates", func() (any, error) {
+			return client.ActivateCertificates(ctx, CertificateActivationRequest{})
+		}},
+		{"DeactivateCertificates", func() (any, error) {
+			return client.DeactivateCertificates(ctx, CertificateActivationRequest{})
+		}},
+		{"CreateAdminAPIKey", func() (any, error) {
+			return client.CreateAdminAPIKey(ctx, AdminAPIKeyRequest{})
+		}},
+		{"RetrieveAdminAPIKey", func() (any, error) {
+			return client.RetrieveAdminAPIKey(ctx, "")
+		}},
+		{"DeleteAdminAPIKey", func() (any, error) {
+			return client.DeleteAdminAPIKey(ctx, "")
+		}},
+		{"ListAdminAPIKeys", func() (any, error) {
+			return client.ListAdminAPIKeys(ctx, Pagination{})
+		}},
+		{"CreateEval", func() (any, error) {
+			return client.CreateEval(ctx, EvalRequest{})
+		}},
+		{"RetrieveEval", func() (any, error) {
+			return client.RetrieveEval(ctx, "")
+		}},
+		{"ModifyEval", func() (any, error) {
+			return client.ModifyEval(ctx, "", EvalModifyRequest{})
+		}},
+		{"DeleteEval", func() (any, error) {
+			return client.DeleteEval(ctx, "")
+		}},
+		{"ListEvals", func() (any, error) {
+			return client.ListEvals(ctx, Pagination{})
+		}},
+		{"CreateEvalRun", func() (any, error) {
+			return client.CreateEvalRun(ctx, "", EvalRunRequest{})
+		}},
+		{"RetrieveEvalRun", func() (any, error) {
+			return client.RetrieveEvalRun(ctx, "", "")
+		}},
+		{"CancelEvalRun", func() (any, error) {
+			return client.CancelEvalRun(ctx, "", "")
+		}},
+		{"DeleteEvalRun", func() (any, error) {
+			return client.DeleteEvalRun(ctx, "", "")
+		}},
+		{"ListEvalRuns", func() (any, error) {
+			return client.ListEvalRuns(ctx, "", Pagination{})
+		}},
+		{"RetrieveEvalRunOutputItem", func() (any, error) {
+			return client.RetrieveEvalRunOutputItem(ctx, "", "", "")
+		}},
+		{"ListEvalRunOutputItems", func() (any, error) {
+			return client.ListEvalRunOutputItems(ctx, "", "", "", Pagination{})
+		}},
+		{"CreateContainer", func() (any, error) {
+			return client.CreateContainer(ctx, ContainerRequest{})
+		}},
+		{"RetrieveContainer", func() (any, error) {
+			return client.RetrieveContainer(ctx, "")
+		}},
+		{"DeleteContainer", func() (any, error) {
+			return client.DeleteContainer(ctx, "")
+		}},
+		{"ListContainers", func() (any, error) {
+			return client.ListContainers(ctx, Pagination{})
+		}},
+		{"CreateContainerFile", func() (any, error) {
+			return client.CreateContainerFile(ctx, "", ContainerFileRequest{FileID: "file"})
+		}},
+		{"CreateContainerFileUpload", func() (any, error) {
+			return client.CreateContainerFile(ctx, "", ContainerFileRequest{Name: "file"})
+		}},
+		{"RetrieveContainerFile", func() (any, error) {
+			return client.RetrieveContainerFile(ctx, "", "")
+		}},
+		{"DeleteContainerFile", func() (any, error) {
+			return client.DeleteContainerFile(ctx, "", "")
+		}},
+		{"ListContainerFiles", func() (any, error) {
+			return client.ListContainerFiles(ctx, "", Pagination{})
+		}},
+		{"GetContainerFileContent", func() (any, error) {
+			return client.GetContainerFileContent(ctx, "", "")
+		}},
+		{"CreateResponse", func() (any, error) {
+			return client.CreateResponse(ctx, ResponseRequest{})
+		}},
+		{"CreateResponseStream", func() (any, error) {
+			return client.CreateResponseStream(ctx, ResponseRequest{})
+		}},
+		{"RetrieveResponse", func() (any, error) {
+			return client.RetrieveResponse(ctx, "")
+		}},
+		{"DeleteResponse", func() (any, error) {
+			return client.DeleteResponse(ctx, "")
+		}},
+		{"ConnectRealtime", func() (any, error) {
+			return client.ConnectRealtime(ctx, "")
+		}},
+		{"CreateRealtimeSession", func() (any, error) {
+			return client.CreateRealtimeSession(ctx, RealtimeSession{})
+		}},
+		{"ConnectRealtimeTranscription", func() (any, error) {
+			return client.ConnectRealtimeTranscription(ctx)
+		}},
+		{"CreateRealtimeTranscriptionSession", func() (any, error) {
+			return client.CreateRealtimeTranscriptionSession(ctx, RealtimeTranscriptionSession{})
+		}},
+		{"CreateVideo", func() (any, error) {
+			return client.CreateVideo(ctx, VideoRequest{})
+		}},
+		{"RetrieveVideo", func() (any, error) {
+			return client.RetrieveVideo(ctx, "")
+		}},
+		{"DeleteVideo", func() (any, error) {
+			return client.DeleteVideo(ctx, "")
+		}},
+		{"ListVideos", func() (any, error) {
+			return client.ListVideos(ctx, Pagination{})
+		}},
+		{"RemixVideo", func() (any, error) {
+			return client.RemixVideo(ctx, "", VideoRemixRequest{})
+		}},
+		{"GetVideoContent", func() (any, error) {
+			return client.GetVideoContent(ctx, "", VideoContentVariantVideo)
+		}},
+		{"CreateBatch", func() (any, error) {
+			return client.CreateBatch(ctx, BatchRequest{})
+		}},
+		{"RetrieveBatch", func() (any, error) {
+			return client.RetrieveBatch(ctx, "")
+		}},
+		{"CancelBatch", func() (any, error) {
+			return client.CancelBatch(ctx, "")
+		}},
+		{"ListBatches", func() (any, error) {
+			return client.ListBatches(ctx, Pagination{})
+		}},
+		{"ListVectorsWithOptions", func() (any, error) {
+			return client.ListVectorsWithOptions(ctx)
+		}},
+		{"ListVectorFilesWithOptions", func() (any, error) {
+			return client.ListVectorFilesWithOptions(ctx, "")
+		}},
+		{"CreateVectorFileBatch", func() (any, error) {
+			return client.CreateVectorFileBatch(ctx, "", VectorFileBatchRequest{})
+		}},
+		{"RetrieveVectorFileBatch", func() (any, error) {
+			return client.RetrieveVectorFileBatch(ctx, "", "")
+		}},
+		{"PurgeVectorStoreFiles", func() (any, error) {
+			return client.PurgeVectorStoreFiles(ctx, "", VectorPurgeOptions{})
+		}},
 	}
 
 	for _, testCase := range testCases {
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// runBatchSubmit uploads a JSONL input file and creates a batch sending its
+// requests to the endpoint.
+func runBatchSubmit(ctx context.Context, client *openai.Client, args []string, out io.Writer) error {
+	flags := newFlagSet("batch submit")
+	endpoint := flags.String("endpoint", string(openai.BatchEndpointChatCompletions), "endpoint of the requests")
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if flags.NArg() != 1 {
+		return errUsage
+	}
+
+	file, err := uploadFile(ctx, client, flags.Arg(0), string(openai.PurposeBatch))
+	if err != nil {
+		return err
+	}
+	batch, err := client.CreateBatch(ctx, openai.BatchRequest{
+		InputFileID: file.ID,
+		Endpoint:    openai.BatchEndpoint(*endpoint),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s\t%s\n", batch.ID, batch.Status)
+	return nil
+}
+
+// runBatchStatus prints the status and request counts of batches, with the
+// IDs of their output and error files once they are available.
+func runBatchStatus(ctx context.Context, client *openai.Client, args []string, out io.Writer) error {
+	flags := newFlagSet("batch status")
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if flags.NArg() == 0 {
+		return errUsage
+	}
+
+	for _, batchID := range flags.Args() {
+		batch, err := client.RetrieveBatch(ctx, batchID)
+		if err != nil {
+			return err
+		}
+		counts := batch.RequestCounts
+		fmt.Fprintf(out, "%s\t%s\t%d/%d completed\t%d failed\t%s\t%s\n", batch.ID, batch.Status,
+			counts.Completed, counts.Total, counts.Failed, fileID(batch.OutputFileID), fileID(batch.ErrorFileID))
+	}
+	return nil
+}
+
+// fileID returns id, or "-" when it is not set.
+func fileID(id *string) string {
+	if id == nil || *id == "" {
+		return "-"
+	}
+	return *id
+}
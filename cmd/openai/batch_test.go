@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestBatchCommands(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, r *http.Request) {
+		if purpose := r.FormValue("purpose"); purpose != string(openai.PurposeBatch) {
+			http.Error(w, "unexpected purpose "+purpose, http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"id":"file-in","filename":"requests.jsonl","purpose":"batch"}`)
+	})
+	server.RegisterHandler("/v1/batches", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.BatchRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+		if request.InputFileID != "file-in" || request.Endpoint != openai.BatchEndpointEmbeddings {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"id":"batch_1","status":"validating"}`)
+	})
+	server.RegisterHandler("/v1/batches/batch_1", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"id":"batch_1","status":"completed","output_file_id":"file-out",`+
+			`"request_counts":{"total":3,"completed":3,"failed":0}}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := openai.NewClientWithConfig(config)
+
+	input := filepath.Join(t.TempDir(), "requests.jsonl")
+	checks.NoError(t, os.WriteFile(input, []byte("{}\n"), 0o600), "WriteFile error")
+
+	var out bytes.Buffer
+	err := runBatchSubmit(context.Background(), client, []string{"-endpoint", "/v1/embeddings", input}, &out)
+	checks.NoError(t, err, "batch submit error")
+	if out.String() != "batch_1\tvalidating\n" {
+		t.Errorf("unexpected submit output %q", out.String())
+	}
+
+	out.Reset()
+	err = runBatchStatus(context.Background(), client, []string{"batch_1"}, &out)
+	checks.NoError(t, err, "batch status error")
+	if out.String() != "batch_1\tcompleted\t3/3 completed\t0 failed\tfile-out\t-\n" {
+		t.Errorf("unexpected status output %q", out.String())
+	}
+
+	err = runBatchStatus(context.Background(), client, nil, &out)
+	checks.ErrorIs(t, err, errUsage, "batch status without IDs")
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func runChat(ctx context.Context, client *openai.Client, args []string, out io.Writer) error {
+	flags := newFlagSet("chat")
+	model := flags.String("model", openai.GPT4o, "chat model")
+	system := flags.String("system", "", "system message")
+	stream := flags.Bool("stream", false, "print the reply as it is generated")
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if flags.NArg() == 0 {
+		return errUsage
+	}
+
+	request := openai.ChatCompletionRequest{Model: *model}
+	if *system != "" {
+		request.Messages = append(request.Messages, openai.ChatCompletionMessage{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: *system,
+		})
+	}
+	request.Messages = append(request.Messages, openai.ChatCompletionMessage{
+		Role:    openai.ChatMessageRoleUser,
+		Content: strings.Join(flags.Args(), " "),
+	})
+
+	if !*stream {
+		resp, err := client.CreateChatCompletion(ctx, request)
+		if err != nil {
+			return err
+		}
+		if len(resp.Choices) == 0 {
+			return errors.New("the response has no choices")
+		}
+		_, err = fmt.Fprintln(out, resp.Choices[0].Message.Content)
+		return err
+	}
+
+	chatStream, err := client.CreateChatCompletionStream(ctx, request)
+	if err != nil {
+		return err
+	}
+	defer chatStream.Close()
+	for {
+		resp, recvErr := chatStream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			_, err = fmt.Fprintln(out)
+			return err
+		}
+		if recvErr != nil {
+			return recvErr
+		}
+		if len(resp.Choices) > 0 {
+			fmt.Fprint(out, resp.Choices[0].Delta.Content)
+		}
+	}
+}
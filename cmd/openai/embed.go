@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func runEmbed(ctx context.Context, client *openai.Client, args []string, out io.Writer) error {
+	flags := newFlagSet("embed")
+	model := flags.String("model", string(openai.SmallEmbedding3), "embedding model")
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if flags.NArg() == 0 {
+		return errUsage
+	}
+
+	resp, err := client.CreateEmbeddings(ctx, openai.EmbeddingRequestStrings{
+		Input: flags.Args(),
+		Model: openai.EmbeddingModel(*model),
+	})
+	if err != nil {
+		return err
+	}
+
+	// One JSON array per line, in the order of the arguments.
+	encoder := json.NewEncoder(out)
+	for _, embedding := range resp.Data {
+		if err = encoder.Encode(embedding.Embedding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func runFilesUpload(ctx context.Context, client *openai.Client, args []string, out io.Writer) error {
+	flags := newFlagSet("files upload")
+	purpose := flags.String("purpose", string(openai.PurposeAssistants), "purpose of the files")
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if flags.NArg() == 0 {
+		return errUsage
+	}
+
+	for _, path := range flags.Args() {
+		file, err := uploadFile(ctx, client, path, *purpose)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s\t%s\t%d\n", file.ID, file.FileName, file.Bytes)
+	}
+	return nil
+}
+
+func uploadFile(ctx context.Context, client *openai.Client, path, purpose string) (openai.File, error) {
+	file, err := client.CreateFile(ctx, openai.FileRequest{
+		FileName: filepath.Base(path),
+		FilePath: path,
+		Purpose:  purpose,
+	})
+	if err != nil {
+		return file, fmt.Errorf("upload %s: %w", path, err)
+	}
+	return file, nil
+}
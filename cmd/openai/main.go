@@ -0,0 +1,106 @@
+// Command openai is a small command line client built on go-openai. It is a
+// smoke test for the package against the live API and an example of its use.
+//
+// The API key is read from OPENAI_API_KEY. OPENAI_BASE_URL and OPENAI_ORG_ID
+// are used when set.
+//
+//	openai chat [-model m] [-system s] [-stream] prompt...
+//	openai embed [-model m] text...
+//	openai files upload [-purpose p] path...
+//	openai vector-store sync [-delete] vector-store-id dir
+//	openai batch submit [-endpoint e] input.jsonl
+//	openai batch status batch-id...
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+var errUsage = errors.New("usage")
+
+const usage = `usage: openai <command> [arguments]
+
+commands:
+  chat               send a chat completion request
+  embed              print the embedding of each argument
+  files upload       upload files
+  vector-store sync  make a vector store hold the files of a directory
+  batch submit       upload a JSONL input file and create a batch
+  batch status       print the status of batches
+`
+
+type command func(ctx context.Context, client *openai.Client, args []string, out io.Writer) error
+
+var commands = map[string]command{
+	"chat":              runChat,
+	"embed":             runEmbed,
+	"files upload":      runFilesUpload,
+	"vector-store sync": runVectorStoreSync,
+	"batch submit":      runBatchSubmit,
+	"batch status":      runBatchStatus,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := run(ctx, os.Args[1:], os.Stdout)
+	stop()
+	if errors.Is(err, errUsage) {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "openai:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	name, cmd := args[0], commands[args[0]]
+	if cmd == nil && len(args) > 1 {
+		name = args[0] + " " + args[1]
+		cmd = commands[name]
+	}
+	if cmd == nil {
+		return errUsage
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	return cmd(ctx, client, args[len(strings.Fields(name)):], out)
+}
+
+func newClient() (*openai.Client, error) {
+	key := os.Getenv("OPENAI_API_KEY")
+	if key == "" {
+		return nil, errors.New("OPENAI_API_KEY is not set")
+	}
+	config := openai.DefaultConfig(key)
+	if baseURL := os.Getenv("OPENAI_BASE_URL"); baseURL != "" {
+		config.BaseURL = baseURL
+	}
+	config.OrgID = os.Getenv("OPENAI_ORG_ID")
+	config.AppInfo = &openai.AppInfo{Name: "openai-cli"}
+	return openai.NewClientWithConfig(config), nil
+}
+
+// newFlagSet returns a flag set for a command that reports errors instead of
+// exiting, so that main prints the usage.
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+	return flags
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+const vectorFilesPageSize = 100
+
+// runVectorStoreSync uploads the regular files of a directory that a vector
+// store does not hold yet, matching them by file name. With -delete, the files
+// of the store that are not in the directory are removed from it.
+func runVectorStoreSync(ctx context.Context, client *openai.Client, args []string, out io.Writer) error {
+	flags := newFlagSet("vector-store sync")
+	remove := flags.Bool("delete", false, "remove files that are not in the directory from the vector store")
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if flags.NArg() != 2 {
+		return errUsage
+	}
+	storeID, dir := flags.Arg(0), flags.Arg(1)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	stored, err := vectorStoreFileNames(ctx, client, storeID)
+	if err != nil {
+		return err
+	}
+
+	local := make(map[string]bool)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		local[entry.Name()] = true
+		if _, ok := stored[entry.Name()]; ok {
+			continue
+		}
+		file, uploadErr := uploadFile(ctx, client, filepath.Join(dir, entry.Name()), string(openai.PurposeAssistants))
+		if uploadErr != nil {
+			return uploadErr
+		}
+		_, err = client.CreateVectorFile(ctx, storeID, openai.VectorFileRequest{FileID: file.ID})
+		if err != nil {
+			return fmt.Errorf("add %s to the vector store: %w", entry.Name(), err)
+		}
+		fmt.Fprintf(out, "+ %s\t%s\n", file.ID, entry.Name())
+	}
+
+	if !*remove {
+		return nil
+	}
+	for name, fileID := range stored {
+		if local[name] {
+			continue
+		}
+		if err = client.DeleteVectorFile(ctx, storeID, fileID); err != nil {
+			return fmt.Errorf("remove %s from the vector store: %w", name, err)
+		}
+		fmt.Fprintf(out, "- %s\t%s\n", fileID, name)
+	}
+	return nil
+}
+
+// vectorStoreFileNames maps the names of the files in a vector store to their
+// IDs. The names are taken from the file list of the organization, rather than
+// retrieved file by file, and only files missing from it are retrieved.
+func vectorStoreFileNames(ctx context.Context, client *openai.Client, storeID string) (map[string]string, error) {
+	var ids []string
+	var after []openai.ListOption
+	for {
+		opts := append([]openai.ListOption{openai.WithLimit(vectorFilesPageSize)}, after...)
+		page, err := client.ListVectorFilesWithOptions(ctx, storeID, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, vectorFile := range page.VectorFiles {
+			ids = append(ids, vectorFile.ID)
+		}
+		if !page.HasMore || len(page.VectorFiles) == 0 {
+			break
+		}
+		after = []openai.ListOption{openai.WithAfter(page.VectorFiles[len(page.VectorFiles)-1].ID)}
+	}
+
+	names := make(map[string]string)
+	if len(ids) == 0 {
+		return names, nil
+	}
+	fileNames, err := fileNamesByID(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		name, ok := fileNames[id]
+		if !ok {
+			file, fileErr := client.GetFile(ctx, id)
+			if fileErr != nil {
+				return nil, fileErr
+			}
+			name = file.FileName
+		}
+		names[filepath.Base(name)] = id
+	}
+	return names, nil
+}
+
+// fileNamesByID maps the IDs of the files of the organization to their names.
+func fileNamesByID(ctx context.Context, client *openai.Client) (map[string]string, error) {
+	names := make(map[string]string)
+	var after []openai.ListOption
+	for {
+		page, err := client.ListFiles(ctx, after...)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range page.Files {
+			names[file.ID] = file.FileName
+		}
+		if !page.HasMore || len(page.Files) == 0 {
+			return names, nil
+		}
+		after = []openai.ListOption{openai.WithAfter(page.Files[len(page.Files)-1].ID)}
+	}
+}
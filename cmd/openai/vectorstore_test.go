@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestVectorStoreFileNames(t *testing.T) {
+	server := test.NewTestServer()
+	var retrieved []string
+	server.RegisterHandler("/v1/vector_stores/vs_1/files", func(w http.ResponseWriter, r *http.Request) {
+		// Pages shorter than the requested limit must not end the listing.
+		if r.URL.Query().Get("after") == "" {
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"file-1"},{"id":"file-2"}],"has_more":true}`)
+			return
+		}
+		fmt.Fprint(w, `{"object":"list","data":[{"id":"file-3"}],"has_more":false}`)
+	})
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("after") == "" {
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"file-1","filename":"a.md"}],"has_more":true}`)
+			return
+		}
+		fmt.Fprint(w, `{"object":"list","data":[{"id":"file-2","filename":"docs/b.md"}],"has_more":false}`)
+	})
+	server.RegisterHandler("/v1/files/*", func(w http.ResponseWriter, r *http.Request) {
+		retrieved = append(retrieved, r.URL.Path)
+		fmt.Fprint(w, `{"id":"file-3","filename":"c.md"}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	names, err := vectorStoreFileNames(context.Background(), openai.NewClientWithConfig(config), "vs_1")
+	checks.NoError(t, err, "vectorStoreFileNames error")
+
+	expected := map[string]string{"a.md": "file-1", "b.md": "file-2", "c.md": "file-3"}
+	if fmt.Sprint(names) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+	if fmt.Sprint(retrieved) != "[/v1/files/file-3]" {
+		t.Errorf("expected only the unlisted file to be retrieved, got %v", retrieved)
+	}
+}
@@ -0,0 +1,235 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrIncompatibleParameter is matched by errors.Is for every
+// *IncompatibleParameterError.
+var ErrIncompatibleParameter = errors.New("parameter is not supported by the model")
+
+// IncompatibleParameterError reports a parameter of a request that the model,
+// or the other parameters of the request, rule out.
+type IncompatibleParameterError struct {
+	Model string
+	// Field is the JSON name of the offending parameter, e.g. "temperature".
+	Field  string
+	Reason string
+}
+
+func (e *IncompatibleParameterError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrIncompatibleParameter, e.Field, e.Reason)
+}
+
+func (e *IncompatibleParameterError) Is(target error) bool {
+	return target == ErrIncompatibleParameter
+}
+
+// CompatibilityMode selects what chat completion calls do with requests that
+// CheckCompatibility finds issues with.
+type CompatibilityMode int
+
+const (
+	// CompatibilityOff sends requests without checking them.
+	CompatibilityOff CompatibilityMode = iota
+	// CompatibilityError fails calls with the first issue found, without
+	// sending the request.
+	CompatibilityError
+	// CompatibilityWarn passes the issues to
+	// ClientConfig.OnIncompatibleParameter and sends the request anyway.
+	CompatibilityWarn
+	// CompatibilityAdapt adapts requests for reasoning models with
+	// AdaptRequest, passing each change to ClientConfig.OnRequestAdapted, so
+	// that requests written for other models work unchanged. Issues that
+	// cannot be adapted fail the call as with CompatibilityError.
+	CompatibilityAdapt
+)
+
+// RequestAdaptation is a change made to a request by AdaptRequest.
+type RequestAdaptation struct {
+	Model string
+	// Field is the JSON name of the changed parameter, e.g. "max_tokens".
+	Field  string
+	Change string
+}
+
+func (a RequestAdaptation) String() string {
+	return fmt.Sprintf("%s: %s", a.Field, a.Change)
+}
+
+// AdaptRequest returns request adapted for its model, and the changes made.
+// For reasoning models, system messages become developer messages,
+// max_tokens is moved to max_completion_tokens, and the sampling parameters
+// they reject, such as temperature and logprobs, are removed. Requests for
+// other models are returned unchanged. The messages of request are not
+// modified.
+func AdaptRequest(request ChatCompletionRequest) (ChatCompletionRequest, []RequestAdaptation) {
+	capabilities, ok := ModelInfo(request.Model)
+	if !ok || !capabilities.Reasoning {
+		return request, nil
+	}
+
+	var adaptations []RequestAdaptation
+	adapt := func(field, change string) {
+		adaptations = append(adaptations, RequestAdaptation{Model: request.Model, Field: field, Change: change})
+	}
+	remove := func(field string, set bool) bool {
+		if set {
+			adapt(field, "removed, reasoning models do not support it")
+		}
+		return set
+	}
+
+	copied := false
+	for i, message := range request.Messages {
+		if message.Role != ChatMessageRoleSystem {
+			continue
+		}
+		if !copied {
+			request.Messages = append([]ChatCompletionMessage(nil), request.Messages...)
+			copied = true
+		}
+		request.Messages[i].Role = ChatMessageRoleDeveloper
+		adapt(fmt.Sprintf("messages[%d].role", i), "system replaced by developer")
+	}
+	if request.MaxTokens > 0 {
+		if request.MaxCompletionTokens == 0 {
+			request.MaxCompletionTokens = request.MaxTokens
+			adapt("max_tokens", "moved to max_completion_tokens")
+		} else {
+			adapt("max_tokens", "removed in favor of max_completion_tokens")
+		}
+		request.MaxTokens = 0
+	}
+	if remove("temperature", request.Temperature != 0 && request.Temperature != 1) {
+		request.Temperature = 0
+	}
+	if remove("top_p", request.TopP != 0 && request.TopP != 1) {
+		request.TopP = 0
+	}
+	if remove("presence_penalty", request.PresencePenalty != 0) {
+		request.PresencePenalty = 0
+	}
+	if remove("frequency_penalty", request.FrequencyPenalty != 0) {
+		request.FrequencyPenalty = 0
+	}
+	if remove("logprobs", request.LogProbs) {
+		request.LogProbs = false
+	}
+	if remove("top_logprobs", request.TopLogProbs != 0) {
+		request.TopLogProbs = 0
+	}
+	if remove("logit_bias", len(request.LogitBias) > 0) {
+		request.LogitBias = nil
+	}
+	return request, adaptations
+}
+
+// CheckCompatibility returns the parameters of request that are known to be
+// rejected by its model, such as temperature with reasoning models or tools
+// with models that cannot call them, or by the other parameters of the
+// request. Models unknown to ModelInfo are only checked for combinations
+// that no model supports.
+func CheckCompatibility(request ChatCompletionRequest) []*IncompatibleParameterError {
+	var issues []*IncompatibleParameterError
+	report := func(field, format string, args ...any) {
+		issues = append(issues, &IncompatibleParameterError{
+			Model:  request.Model,
+			Field:  field,
+			Reason: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if request.N > 1 && contains(request.Modalities, ChatModalityAudio) {
+		report("n", "audio output generates a single choice, not %d", request.N)
+	}
+	if request.TopLogProbs > 0 && !request.LogProbs {
+		report("top_logprobs", "requires logprobs")
+	}
+
+	capabilities, ok := ModelInfo(request.Model)
+	if !ok {
+		return issues
+	}
+	if capabilities.Reasoning {
+		reasoning := "reasoning model " + request.Model + " does not support it"
+		if request.Temperature != 0 && request.Temperature != 1 {
+			report("temperature", "%s, only the default of 1", reasoning)
+		}
+		if request.TopP != 0 && request.TopP != 1 {
+			report("top_p", "%s, only the default of 1", reasoning)
+		}
+		if request.PresencePenalty != 0 {
+			report("presence_penalty", "%s", reasoning)
+		}
+		if request.FrequencyPenalty != 0 {
+			report("frequency_penalty", "%s", reasoning)
+		}
+		if request.LogProbs {
+			report("logprobs", "%s", reasoning)
+		}
+		if len(request.LogitBias) > 0 {
+			report("logit_bias", "%s", reasoning)
+		}
+		if request.MaxTokens > 0 {
+			report("max_tokens", "%s, use max_completion_tokens", reasoning)
+		}
+	}
+	if request.LogProbs && request.Model == GPT4VisionPreview {
+		report("logprobs", "%s does not support it", request.Model)
+	}
+	if len(request.Tools) > 0 && !capabilities.Tools {
+		report("tools", "%s cannot call tools", request.Model)
+	}
+	if request.ResponseFormat != nil && request.ResponseFormat.Type == ChatCompletionResponseFormatTypeJSONSchema &&
+		!capabilities.JSONSchema {
+		report("response_format", "%s does not support structured outputs", request.Model)
+	}
+	if !capabilities.Vision {
+		for i, message := range request.Messages {
+			for _, part := range message.MultiContent {
+				if part.Type == ChatMessagePartTypeImageURL {
+					report(fmt.Sprintf("messages[%d].content", i), "%s does not accept images", request.Model)
+					break
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// checkCompatibility applies ClientConfig.CompatibilityCheck to request,
+// returning the request to send.
+func (c *Client) checkCompatibility(
+	ctx context.Context,
+	request ChatCompletionRequest,
+) (ChatCompletionRequest, error) {
+	mode := c.config.CompatibilityCheck
+	if mode == CompatibilityOff {
+		return request, nil
+	}
+	if mode == CompatibilityAdapt {
+		var adaptations []RequestAdaptation
+		request, adaptations = AdaptRequest(request)
+		if c.config.OnRequestAdapted != nil {
+			for _, adaptation := range adaptations {
+				c.config.OnRequestAdapted(ctx, adaptation)
+			}
+		}
+	}
+	issues := CheckCompatibility(request)
+	if len(issues) == 0 {
+		return request, nil
+	}
+	if mode != CompatibilityWarn {
+		return request, issues[0]
+	}
+	if c.config.OnIncompatibleParameter != nil {
+		for _, issue := range issues {
+			c.config.OnIncompatibleParameter(ctx, issue)
+		}
+	}
+	return request, nil
+}
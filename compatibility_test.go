@@ -0,0 +1,192 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	user := []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hi"}}
+	image := []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, MultiContent: []openai.ChatMessagePart{
+		{Type: openai.ChatMessagePartTypeImageURL, ImageURL: &openai.ChatMessageImageURL{URL: "https://example.com/a.png"}},
+	}}}
+	tools := []openai.Tool{{Type: openai.ToolTypeFunction, Function: &openai.FunctionDefinition{Name: "weather"}}}
+
+	testCases := []struct {
+		name    string
+		request openai.ChatCompletionRequest
+		fields  string
+	}{
+		{"compatible", openai.ChatCompletionRequest{Model: openai.GPT4o, Messages: user, Temperature: 0.2}, "[]"},
+		{
+			"reasoning sampling",
+			openai.ChatCompletionRequest{Model: "o3-mini", Messages: user, Temperature: 0.2, TopP: 0.9, MaxTokens: 10},
+			"[temperature top_p max_tokens]",
+		},
+		{"reasoning default temperature", openai.ChatCompletionRequest{Model: "o1", Messages: user, Temperature: 1}, "[]"},
+		{"reasoning logprobs", openai.ChatCompletionRequest{Model: "o4-mini", Messages: user, LogProbs: true}, "[logprobs]"},
+		{"top logprobs", openai.ChatCompletionRequest{Model: openai.GPT4o, Messages: user, TopLogProbs: 2}, "[top_logprobs]"},
+		{
+			"audio choices",
+			openai.ChatCompletionRequest{Model: "gpt-4o-audio-preview", Messages: user, N: 2,
+				Modalities: []openai.ChatModality{openai.ChatModalityText, openai.ChatModalityAudio}},
+			"[n]",
+		},
+		{"tools", openai.ChatCompletionRequest{Model: "o1-mini", Messages: user, Tools: tools}, "[tools]"},
+		{"images", openai.ChatCompletionRequest{Model: openai.GPT3Dot5Turbo, Messages: image}, "[messages[0].content]"},
+		{
+			"structured outputs",
+			openai.ChatCompletionRequest{Model: openai.GPT4Turbo, Messages: user,
+				ResponseFormat: &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONSchema}},
+			"[response_format]",
+		},
+		{"unknown model", openai.ChatCompletionRequest{Model: "my-model", Messages: user, Tools: tools}, "[]"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var fields []string
+			for _, issue := range openai.CheckCompatibility(tc.request) {
+				checks.ErrorIs(t, issue, openai.ErrIncompatibleParameter)
+				fields = append(fields, issue.Field)
+			}
+			if fmt.Sprint(fields) != tc.fields {
+				t.Errorf("expected issues with %s, got %v", tc.fields, fields)
+			}
+		})
+	}
+}
+
+func TestCompatibilityCheckModes(t *testing.T) {
+	server := test.NewTestServer()
+	calls := 0
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"object":"chat.completion","choices":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	request := openai.ChatCompletionRequest{
+		Model:       "o3",
+		Messages:    []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hi"}},
+		Temperature: 0.5,
+	}
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.CompatibilityCheck = openai.CompatibilityError
+	client := openai.NewClientWithConfig(config)
+
+	_, err := client.CreateChatCompletion(context.Background(), request)
+	var paramErr *openai.IncompatibleParameterError
+	if !errors.As(err, &paramErr) || paramErr.Field != "temperature" || paramErr.Model != "o3" {
+		t.Fatalf("expected an IncompatibleParameterError for temperature, got %v", err)
+	}
+	_, err = client.CreateChatCompletionStream(context.Background(), request)
+	checks.ErrorIs(t, err, openai.ErrIncompatibleParameter)
+	if calls != 0 {
+		t.Fatalf("expected no request to be sent, got %d", calls)
+	}
+
+	var warnings []string
+	config.CompatibilityCheck = openai.CompatibilityWarn
+	config.OnIncompatibleParameter = func(_ context.Context, err *openai.IncompatibleParameterError) {
+		warnings = append(warnings, err.Field)
+	}
+	client = openai.NewClientWithConfig(config)
+	_, err = client.CreateChatCompletion(context.Background(), request)
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if calls != 1 || fmt.Sprint(warnings) != "[temperature]" {
+		t.Errorf("expected the request to be sent with a warning, got %d calls and warnings %v", calls, warnings)
+	}
+}
+
+func TestAdaptRequest(t *testing.T) {
+	messages := []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleSystem, Content: "Be brief."},
+		{Role: openai.ChatMessageRoleUser, Content: "hi"},
+	}
+	request := openai.ChatCompletionRequest{
+		Model:       "o3",
+		Messages:    messages,
+		MaxTokens:   100,
+		Temperature: 0.3,
+		LogProbs:    true,
+		TopLogProbs: 2,
+		Seed:        new(int),
+	}
+
+	adapted, adaptations := openai.AdaptRequest(request)
+	var changes []string
+	for _, adaptation := range adaptations {
+		changes = append(changes, adaptation.Field)
+	}
+	if fmt.Sprint(changes) != "[messages[0].role max_tokens temperature logprobs top_logprobs]" {
+		t.Errorf("unexpected adaptations %v", adaptations)
+	}
+	if adapted.Messages[0].Role != openai.ChatMessageRoleDeveloper || messages[0].Role != openai.ChatMessageRoleSystem {
+		t.Errorf("expected a developer message without modifying the original messages")
+	}
+	if adapted.MaxTokens != 0 || adapted.MaxCompletionTokens != 100 || adapted.Temperature != 0 ||
+		adapted.LogProbs || adapted.TopLogProbs != 0 || adapted.Seed == nil {
+		t.Errorf("unexpected adapted request %+v", adapted)
+	}
+	if issues := openai.CheckCompatibility(adapted); len(issues) != 0 {
+		t.Errorf("adapted request still has issues %v", issues)
+	}
+
+	for _, model := range []string{openai.GPT4o, "gpt-5-chat-latest"} {
+		request.Model = model
+		if _, adaptations = openai.AdaptRequest(request); len(adaptations) != 0 {
+			t.Errorf("expected no adaptations for %s, got %v", request.Model, adaptations)
+		}
+	}
+}
+
+func TestCompatibilityAdaptMode(t *testing.T) {
+	server := test.NewTestServer()
+	var sent openai.ChatCompletionRequest
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&sent), "decode request")
+		fmt.Fprint(w, `{"object":"chat.completion","choices":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var logged []string
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.CompatibilityCheck = openai.CompatibilityAdapt
+	config.OnRequestAdapted = func(_ context.Context, adaptation openai.RequestAdaptation) {
+		logged = append(logged, adaptation.String())
+	}
+	client := openai.NewClientWithConfig(config)
+	_, err := client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+		Model:     "o4-mini",
+		Messages:  []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleSystem, Content: "Be brief."}},
+		MaxTokens: 50,
+	})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if sent.Messages[0].Role != openai.ChatMessageRoleDeveloper || sent.MaxCompletionTokens != 50 || sent.MaxTokens != 0 {
+		t.Errorf("unexpected request sent %+v", sent)
+	}
+	if len(logged) != 2 {
+		t.Errorf("expected 2 logged adaptations, got %v", logged)
+	}
+
+	_, err = client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+		Model:    "o1-mini",
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hi"}},
+		Tools:    []openai.Tool{{Type: openai.ToolTypeFunction, Function: &openai.FunctionDefinition{Name: "f"}}},
+	})
+	checks.ErrorIs(t, err, openai.ErrIncompatibleParameter)
+}
@@ -3,13 +3,26 @@ package openai
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+
+	"github.com/sashabaranov/go-openai/tokenizer"
 )
 
 var (
 	ErrCompletionUnsupportedModel              = errors.New("this model is not supported with this method, please use CreateChatCompletion client method instead") //nolint:lll
 	ErrCompletionStreamNotSupported            = errors.New("streaming is not supported with this method, please use CreateCompletionStream")                      //nolint:lll
 	ErrCompletionRequestPromptTypeNotSupported = errors.New("the type of CompletionRequest.Prompt only supports string and []string")                              //nolint:lll
+	ErrCompletionInvalidParameter              = errors.New("invalid completion request parameter")
+	// ErrInvalidLogitBias is returned for a logit_bias of a chat or completion
+	// request that the API would reject, and by the helpers that build one.
+	ErrInvalidLogitBias = errors.New("invalid logit_bias")
+)
+
+const (
+	maxCompletionLogProbs = 5
+	maxLogitBias          = 100
 )
 
 // GPT3 Defines the models provided by OpenAI to use when generating
@@ -129,25 +142,131 @@ func checkPromptType(prompt any) bool {
 
 // CompletionRequest represents a request structure for completion API.
 type CompletionRequest struct {
-	Model            string   `json:"model"`
-	Prompt           any      `json:"prompt,omitempty"`
-	Suffix           string   `json:"suffix,omitempty"`
-	MaxTokens        int      `json:"max_tokens,omitempty"`
-	Temperature      float32  `json:"temperature,omitempty"`
-	TopP             float32  `json:"top_p,omitempty"`
-	N                int      `json:"n,omitempty"`
-	Stream           bool     `json:"stream,omitempty"`
-	LogProbs         int      `json:"logprobs,omitempty"`
-	Echo             bool     `json:"echo,omitempty"`
-	Stop             []string `json:"stop,omitempty"`
-	PresencePenalty  float32  `json:"presence_penalty,omitempty"`
-	FrequencyPenalty float32  `json:"frequency_penalty,omitempty"`
-	BestOf           int      `json:"best_of,omitempty"`
+	Model  string `json:"model"`
+	Prompt any    `json:"prompt,omitempty"`
+	// Suffix is the text that comes after the completion, for inserting text.
+	Suffix      string  `json:"suffix,omitempty"`
+	MaxTokens   int     `json:"max_tokens,omitempty"`
+	Temperature float32 `json:"temperature,omitempty"`
+	TopP        float32 `json:"top_p,omitempty"`
+	N           int     `json:"n,omitempty"`
+	Stream      bool    `json:"stream,omitempty"`
+	// LogProbs returns the log probabilities of this many of the most likely
+	// tokens at each position, at most 5.
+	LogProbs int `json:"logprobs,omitempty"`
+	// Echo returns the prompt in addition to the completion.
+	Echo             bool          `json:"echo,omitempty"`
+	Stop             StopSequences `json:"stop,omitempty"`
+	PresencePenalty  float32       `json:"presence_penalty,omitempty"`
+	FrequencyPenalty float32       `json:"frequency_penalty,omitempty"`
+	// BestOf generates this many completions on the server and returns the N
+	// with the highest log probability per token. It must be at least N and
+	// can't be used with streaming.
+	BestOf int `json:"best_of,omitempty"`
 	// LogitBias is must be a token id string (specified by their token ID in the tokenizer), not a word string.
 	// incorrect: `"logit_bias":{"You": 6}`, correct: `"logit_bias":{"1639": 6}`
 	// refs: https://platform.openai.com/docs/api-reference/completions/create#completions/create-logit_bias
-	LogitBias map[string]int `json:"logit_bias,omitempty"`
-	User      string         `json:"user,omitempty"`
+	LogitBias LogitBias `json:"logit_bias,omitempty"`
+	User      string    `json:"user,omitempty"`
+	// StreamOptions configures streams. With IncludeUsage, the last message
+	// of the stream has no choices and the usage of the whole request.
+	StreamOptions *StreamOptions `json:"stream_options,omitempty"`
+}
+
+// LogitBias maps token IDs, as decimal strings, to a bias from -100 to 100
+// added to the logits of the token before sampling.
+type LogitBias map[string]int
+
+// NewLogitBias returns the LogitBias of biases keyed by token ID.
+func NewLogitBias(biases map[int]int) LogitBias {
+	logitBias := make(LogitBias, len(biases))
+	for tokenID, bias := range biases {
+		logitBias.Set(tokenID, bias)
+	}
+	return logitBias
+}
+
+// Set sets the bias of a token.
+func (b LogitBias) Set(tokenID, bias int) {
+	b[strconv.Itoa(tokenID)] = bias
+}
+
+// BiasWordsWithRegisteredEncoder returns the LogitBias that adds bias to the
+// tokens of words, as encoded for model by the Encoder registered with the
+// tokenizer package. The tokens of each word are biased both at the start of
+// the text and after a space, and all the tokens of words split into several
+// tokens are biased.
+//
+// The tokenizer package does not embed any vocabulary, so it returns
+// tokenizer.ErrNoEncoder until an Encoder, e.g. a tiktoken implementation, is
+// registered for the encoding of the model:
+//
+//	tokenizer.Register(tokenizer.O200kBase, encoder)
+func BiasWordsWithRegisteredEncoder(model string, words []string, bias int) (LogitBias, error) {
+	if bias < -maxLogitBias || bias > maxLogitBias {
+		return nil, fmt.Errorf("%w: bias is %d, must be between -%d and %d",
+			ErrInvalidLogitBias, bias, maxLogitBias, maxLogitBias)
+	}
+	encoder, err := tokenizer.EncoderForModel(model)
+	if err != nil {
+		return nil, err
+	}
+	logitBias := LogitBias{}
+	for _, word := range words {
+		for _, text := range []string{word, " " + word} {
+			for _, tokenID := range encoder.Encode(text) {
+				logitBias.Set(tokenID, bias)
+			}
+		}
+	}
+	return logitBias, nil
+}
+
+// BiasAgainstWordsWithRegisteredEncoder returns the LogitBias that makes
+// model less likely to use words, with weight from 0 to 100, where 100
+// practically bans them. Like BiasWordsWithRegisteredEncoder, it requires an
+// Encoder registered for the encoding of the model.
+func BiasAgainstWordsWithRegisteredEncoder(model string, words []string, weight int) (LogitBias, error) {
+	return BiasWordsWithRegisteredEncoder(model, words, -weight)
+}
+
+// Validate checks that the keys are token IDs and the biases are in range.
+func (b LogitBias) Validate() error {
+	for token, bias := range b {
+		if _, err := strconv.Atoi(token); err != nil {
+			return fmt.Errorf("%w: key %q is not a token ID", ErrInvalidLogitBias, token)
+		}
+		if bias < -maxLogitBias || bias > maxLogitBias {
+			return fmt.Errorf("%w: bias of token %s is %d, must be between -%d and %d",
+				ErrInvalidLogitBias, token, bias, maxLogitBias, maxLogitBias)
+		}
+	}
+	return nil
+}
+
+// validateCompletionRequest checks the parameters that the API would reject.
+func validateCompletionRequest(request CompletionRequest) error {
+	if request.LogProbs < 0 || request.LogProbs > maxCompletionLogProbs {
+		return fmt.Errorf("%w: logprobs is %d, must be between 0 and %d",
+			ErrCompletionInvalidParameter, request.LogProbs, maxCompletionLogProbs)
+	}
+	if request.BestOf != 0 {
+		if request.Stream && request.BestOf > 1 {
+			return fmt.Errorf("%w: best_of can't be used with streaming", ErrCompletionInvalidParameter)
+		}
+		if request.BestOf < request.N {
+			return fmt.Errorf("%w: best_of is %d, must be at least n (%d)",
+				ErrCompletionInvalidParameter, request.BestOf, request.N)
+		}
+	}
+	if request.StreamOptions != nil && !request.Stream {
+		return fmt.Errorf("%w: stream_options requires streaming, use CreateCompletionStream",
+			ErrCompletionInvalidParameter)
+	}
+	if err := request.Stop.Validate(); err != nil {
+		return err
+	}
+	return request.LogitBias.Validate()
 }
 
 // CompletionChoice represents one of possible completions.
@@ -186,6 +305,7 @@ type CompletionResponse struct {
 func (c *Client) CreateCompletion(
 	ctx context.Context,
 	request CompletionRequest,
+	opts ...RequestOption,
 ) (response CompletionResponse, err error) {
 	if request.Stream {
 		err = ErrCompletionStreamNotSupported
@@ -203,7 +323,12 @@ func (c *Client) CreateCompletion(
 		return
 	}
 
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix, request.Model), withBody(request))
+	if err = validateCompletionRequest(request); err != nil {
+		return
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix, request.Model), withModel(request.Model),
+		withBody(request), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
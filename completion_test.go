@@ -14,6 +14,7 @@ import (
 
 	"github.com/sashabaranov/go-openai"
 	"github.com/sashabaranov/go-openai/internal/test/checks"
+	"github.com/sashabaranov/go-openai/tokenizer"
 )
 
 func TestCompletionsWrongModel(t *testing.T) {
@@ -59,6 +60,109 @@ func TestCompletions(t *testing.T) {
 	checks.NoError(t, err, "CreateCompletion error")
 }
 
+func TestCompletionsLegacyParameters(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/completions", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		bias, _ := body["logit_bias"].(map[string]any)
+		if body["suffix"] != "!" || body["echo"] != true || body["best_of"] != 3.0 ||
+			body["logprobs"] != 2.0 || bias["50256"] != -100.0 {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"id":"cmpl-1","choices":[{"text":"Hello world","logprobs":{"tokens":["Hello"]}}]}`)
+	})
+
+	resp, err := client.CreateCompletion(context.Background(), openai.CompletionRequest{
+		Model:     openai.GPT3Dot5TurboInstruct,
+		Prompt:    "Hello",
+		Suffix:    "!",
+		Echo:      true,
+		BestOf:    3,
+		LogProbs:  2,
+		LogitBias: openai.NewLogitBias(map[int]int{50256: -100}),
+	})
+	checks.NoError(t, err, "CreateCompletion error")
+	if len(resp.Choices) != 1 || resp.Choices[0].LogProbs.Tokens[0] != "Hello" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestCompletionsInvalidParameters(t *testing.T) {
+	client := openai.NewClient("")
+	for name, req := range map[string]openai.CompletionRequest{
+		"logprobs":        {LogProbs: 6},
+		"best_of below n": {BestOf: 1, N: 2},
+	} {
+		req.Model = openai.GPT3Dot5TurboInstruct
+		req.Prompt = "Hello"
+		_, err := client.CreateCompletion(context.Background(), req)
+		checks.ErrorIs(t, err, openai.ErrCompletionInvalidParameter, name)
+	}
+	for name, bias := range map[string]openai.LogitBias{
+		"logit_bias word":  {"You": 6},
+		"logit_bias range": openai.NewLogitBias(map[int]int{1639: 101}),
+	} {
+		_, err := client.CreateCompletion(context.Background(), openai.CompletionRequest{
+			Model:     openai.GPT3Dot5TurboInstruct,
+			Prompt:    "Hello",
+			LogitBias: bias,
+		})
+		checks.ErrorIs(t, err, openai.ErrInvalidLogitBias, name)
+	}
+
+	_, err := client.CreateCompletionStream(context.Background(), openai.CompletionRequest{
+		Model:  openai.GPT3Dot5TurboInstruct,
+		Prompt: "Hello",
+		BestOf: 2,
+	})
+	checks.ErrorIs(t, err, openai.ErrCompletionInvalidParameter, "best_of with streaming")
+}
+
+// vocabEncoder encodes texts in its vocabulary as one token and other texts as
+// one token per byte.
+type vocabEncoder map[string]int
+
+func (e vocabEncoder) Count(text string) int { return len(e.Encode(text)) }
+
+func (e vocabEncoder) Encode(text string) []int {
+	if id, ok := e[text]; ok {
+		return []int{id}
+	}
+	var ids []int
+	for i := 0; i < len(text); i++ {
+		ids = append(ids, int(text[i]))
+	}
+	return ids
+}
+
+func TestBiasAgainstWordsWithRegisteredEncoder(t *testing.T) {
+	tokenizer.Register("test_vocab", vocabEncoder{"ab": 1000, " ab": 1001})
+	tokenizer.RegisterModel("test-bias-model", "test_vocab")
+	t.Cleanup(func() {
+		tokenizer.Unregister("test_vocab")
+		tokenizer.UnregisterModel("test-bias-model")
+	})
+
+	bias, err := openai.BiasAgainstWordsWithRegisteredEncoder("test-bias-model", []string{"ab", "xy"}, 100)
+	checks.NoError(t, err, "BiasAgainstWordsWithRegisteredEncoder error")
+	expected := openai.LogitBias{"1000": -100, "1001": -100, "120": -100, "121": -100, "32": -100}
+	if fmt.Sprint(bias) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, bias)
+	}
+	checks.NoError(t, bias.Validate(), "Validate error")
+
+	_, err = openai.BiasWordsWithRegisteredEncoder("test-bias-model", []string{"ab"}, 101)
+	checks.ErrorIs(t, err, openai.ErrInvalidLogitBias)
+	_, err = openai.BiasAgainstWordsWithRegisteredEncoder(openai.GPT3Dot5TurboInstruct, []string{"ab"}, 10)
+	checks.ErrorIs(t, err, tokenizer.ErrNoEncoder)
+}
+
 // handleCompletionEndpoint Handles the completion endpoint by the test server.
 func handleCompletionEndpoint(w http.ResponseWriter, r *http.Request) {
 	var err error
@@ -0,0 +1,101 @@
+package openai
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// CompressionConfig configures gzip compression of request and response bodies.
+type CompressionConfig struct {
+	// MinRequestSize compresses request bodies of at least this many bytes,
+	// such as large embedding batches or long prompts. Zero disables request
+	// compression. The API or gateway must accept gzip encoded requests.
+	MinRequestSize int
+	// AcceptGzip asks for gzip compressed responses even when the HTTP
+	// transport has compression disabled. Responses with a gzip
+	// Content-Encoding are always decompressed.
+	AcceptGzip bool
+}
+
+// compressRequest gzips the body of req when it is large enough and can be
+// read again for retries.
+func (c CompressionConfig) compressRequest(req *http.Request) error {
+	if c.AcceptGzip {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	if c.MinRequestSize <= 0 || req.GetBody == nil || req.ContentLength < int64(c.MinRequestSize) ||
+		req.Header.Get("Content-Encoding") != "" {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err = io.Copy(writer, body); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	data := compressed.Bytes()
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	req.ContentLength = int64(len(data))
+	req.Header.Set("Content-Encoding", "gzip")
+	return nil
+}
+
+// decompressResponse replaces the body of a gzip encoded response that the
+// HTTP transport did not decompress.
+func decompressResponse(resp *http.Response) error {
+	if resp.Uncompressed || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		return err
+	}
+	resp.Body = &gzipBody{Reader: reader, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	err := b.Reader.Close()
+	if closeErr := b.body.Close(); closeErr != nil {
+		return closeErr
+	}
+	return err
+}
+
+// prepareResponse applies the response wrappers common to all requests.
+func prepareResponse(req *http.Request, resp *http.Response, err error) (*http.Response, error) {
+	resp, err = releaseOnClose(req, resp, err)
+	if err != nil {
+		return resp, err
+	}
+	if err = decompressResponse(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,65 @@
+package openai_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCompression(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			reader, err := gzip.NewReader(r.Body)
+			checks.NoError(t, err, "gzip.NewReader error")
+			body = reader
+		}
+		var req openai.EmbeddingRequest
+		checks.NoError(t, json.NewDecoder(body).Decode(&req), "Decode error")
+
+		w.Header().Set("X-Request-Encoding", r.Header.Get("Content-Encoding"))
+		resBytes, _ := json.Marshal(openai.EmbeddingResponse{Model: req.Model})
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			_, _ = w.Write(resBytes)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(w)
+		_, _ = writer.Write(resBytes)
+		_ = writer.Close()
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.Compression = openai.CompressionConfig{MinRequestSize: 1024, AcceptGzip: true}
+	client := openai.NewClientWithConfig(config)
+
+	large := openai.EmbeddingRequest{Input: []string{strings.Repeat("hello ", 1000)}, Model: openai.SmallEmbedding3}
+	res, err := client.CreateEmbeddings(context.Background(), large)
+	checks.NoError(t, err, "CreateEmbeddings error")
+	if res.Model != openai.SmallEmbedding3 {
+		t.Errorf("expected the gzip response to be decoded, got model %q", res.Model)
+	}
+	if res.Header().Get("X-Request-Encoding") != "gzip" {
+		t.Error("expected a large request to be compressed")
+	}
+
+	small := openai.EmbeddingRequest{Input: []string{"hello"}, Model: openai.SmallEmbedding3}
+	res, err = client.CreateEmbeddings(context.Background(), small)
+	checks.NoError(t, err, "CreateEmbeddings error")
+	if res.Header().Get("X-Request-Encoding") != "" {
+		t.Error("expected a small request not to be compressed")
+	}
+}
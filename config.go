@@ -1,8 +1,10 @@
 package openai
 
 import (
+	"context"
 	"net/http"
 	"regexp"
+	"time"
 )
 
 const (
@@ -37,8 +39,99 @@ type ClientConfig struct {
 	AssistantVersion     string
 	AzureModelMapperFunc func(model string) string // replace model to azure deployment name func
 	HTTPClient           *http.Client
+	// Transport tunes the connection pool when HTTPClient has no Transport of
+	// its own. Clients with the same settings and no dialer, proxy or TLS
+	// settings share a transport. The zero value uses http.DefaultTransport.
+	Transport TransportConfig
+	// Provider is the OpenAI-compatible backend at BaseURL. Calls to endpoints
+	// that it does not serve fail with an *UnsupportedByProviderError.
+	Provider Provider
+	// PathPrefix is inserted between BaseURL and the endpoint paths, e.g.
+	// "/openai/v1" for servers at http://host:8000 that nest the API. For
+	// Azure it replaces the default "/openai" prefix, and "/" removes it.
+	PathPrefix string
+	// ModelRoutes send the requests for some models to other endpoints, e.g.
+	// open models to a self-hosted server. The first matching route applies
+	// to calls that take a model, such as chat completions and embeddings.
+	ModelRoutes []ModelRoute
 
 	EmptyMessagesLimit uint
+	// StreamMaxLineSize is the longest line accepted in an assistant event stream.
+	// Zero uses DefaultSSEMaxLineSize and a negative value removes the limit.
+	// Chat and completion streams read lines incrementally and have no limit.
+	StreamMaxLineSize int
+	// StreamIdleTimeout makes streams fail with a *StreamIdleTimeoutError when
+	// no data arrives for this long. WithStreamIdleTimeout overrides it for a
+	// single stream. Zero disables the timeout.
+	StreamIdleTimeout time.Duration
+	// RetryPolicy controls retries of transient failures. The zero value disables them.
+	RetryPolicy RetryPolicy
+	// KeepRawResponse keeps the body of JSON responses, available with RawJSON.
+	KeepRawResponse bool
+	// StrictDecoding makes JSON responses with fields that the response type
+	// does not model fail with an *UnknownFieldsError, to detect API schema
+	// drift. The response is still decoded. Types with custom JSON decoding,
+	// such as ChatCompletionMessage, are not inspected.
+	StrictDecoding bool
+	// OnUnknownFields is called with the paths of the fields that a response
+	// has and its type does not model, e.g. "choices[].message.audio", whether
+	// or not StrictDecoding is set.
+	OnUnknownFields func(responseType string, fields []string)
+	// OperationTimeouts sets default timeouts by kind of operation.
+	OperationTimeouts OperationTimeouts
+	// AppInfo is appended to the User-Agent header when set.
+	AppInfo *AppInfo
+	// SendPlatformHeaders adds X-Stainless-* headers describing the Go runtime,
+	// OS and architecture to every request.
+	SendPlatformHeaders bool
+	// Compression configures gzip compression of requests and responses.
+	Compression CompressionConfig
+	// OnModelDeprecated is called before each request for a model that the
+	// model registry lists as deprecated, so that migrations are planned
+	// before the model is shut down.
+	OnModelDeprecated func(model string, deprecation ModelDeprecation)
+	// RejectShutDownModels makes requests for models past their shutdown date
+	// fail with a *ModelShutdownError instead of being sent.
+	RejectShutDownModels bool
+	// AutoIdempotencyKeys gives every POST and PATCH request a random
+	// Idempotency-Key header unless WithIdempotencyKey sets one, so that
+	// retries of create calls don't create duplicates.
+	AutoIdempotencyKeys bool
+	// SignRequest is called with every request right before it is sent, with
+	// its final method, URL, headers and body. Streamed file uploads are
+	// signed with a nil body, see RequestSigner.
+	SignRequest RequestSigner
+	// OnUsage is called with the token usage of every response that reports
+	// it, including the final chunk of streams that request usage with
+	// StreamOptions, e.g. for token accounting per tenant with values of ctx.
+	// model is the model that served the request.
+	OnUsage func(ctx context.Context, model string, usage Usage)
+	// SplitEmbeddingRequests makes CreateEmbeddings send requests with more
+	// than MaxEmbeddingInputs inputs or MaxEmbeddingRequestTokens tokens as
+	// several requests, and merge their embeddings and usage in input order.
+	SplitEmbeddingRequests bool
+	// ValidateEmbeddingRequests makes CreateEmbeddings check its requests with
+	// ValidateEmbeddingRequest, failing with an *EmbeddingInputError that
+	// names the input that is too long instead of sending the request. Off by
+	// default, because text inputs are only counted approximately unless a
+	// tokenizer encoder is registered.
+	ValidateEmbeddingRequests bool
+	// JSONCodec replaces encoding/json for request bodies, responses and
+	// stream chunks. Error responses are still decoded with encoding/json.
+	JSONCodec JSONCodec
+	// HeadersFromContext derives headers from the context of every call,
+	// e.g. a tenant or trace ID put there by middleware, and sets them on
+	// the request. Headers set with WithHeader take precedence.
+	HeadersFromContext func(ctx context.Context) http.Header
+	// CompatibilityCheck makes chat completion calls check their requests with
+	// CheckCompatibility before sending them. Off by default.
+	CompatibilityCheck CompatibilityMode
+	// OnIncompatibleParameter is called with each issue found in the
+	// CompatibilityWarn mode, e.g. to log it.
+	OnIncompatibleParameter func(ctx context.Context, err *IncompatibleParameterError)
+	// OnRequestAdapted is called with each change made to a request in the
+	// CompatibilityAdapt mode, e.g. to log it.
+	OnRequestAdapted func(ctx context.Context, adaptation RequestAdaptation)
 }
 
 func DefaultConfig(authToken string) ClientConfig {
@@ -50,6 +143,7 @@ func DefaultConfig(authToken string) ClientConfig {
 		OrgID:            "",
 
 		HTTPClient: &http.Client{},
+		Transport:  DefaultTransportConfig(),
 
 		EmptyMessagesLimit: defaultEmptyMessagesLimit,
 	}
@@ -67,6 +161,7 @@ func DefaultAzureConfig(apiKey, baseURL string) ClientConfig {
 		},
 
 		HTTPClient: &http.Client{},
+		Transport:  DefaultTransportConfig(),
 
 		EmptyMessagesLimit: defaultEmptyMessagesLimit,
 	}
@@ -1,9 +1,15 @@
 package openai_test
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
 )
 
 func TestGetAzureDeploymentByModel(t *testing.T) {
@@ -60,3 +66,42 @@ func TestGetAzureDeploymentByModel(t *testing.T) {
 		})
 	}
 }
+
+func TestHeadersFromContext(t *testing.T) {
+	server := test.NewTestServer()
+	var tenants, traces []string
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		tenants = append(tenants, r.Header.Get("X-Tenant-Id"))
+		traces = append(traces, r.Header.Get("X-Trace-Id"))
+		fmt.Fprintln(w, `{"object":"list","data":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.HeadersFromContext = func(ctx context.Context) http.Header {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		if tenant == "" {
+			return nil
+		}
+		return http.Header{"x-tenant-id": {tenant}, "X-Trace-Id": {"from-context"}}
+	}
+	client := openai.NewClientWithConfig(config)
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	_, err := client.ListModels(ctx)
+	checks.NoError(t, err, "ListModels error")
+	_, err = client.ListModels(ctx, openai.WithHeader("X-Trace-Id", "from-option"))
+	checks.NoError(t, err, "ListModels error")
+	_, err = client.ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+
+	if strings.Join(tenants, ",") != "acme,acme," {
+		t.Errorf("unexpected tenant headers %q", tenants)
+	}
+	if strings.Join(traces, ",") != "from-context,from-option," {
+		t.Errorf("unexpected trace headers %q", traces)
+	}
+}
@@ -0,0 +1,277 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	containersSuffix = "/containers"
+)
+
+// ContainerExpiresAfter controls when an idle container is expired.
+type ContainerExpiresAfter struct {
+	// Anchor is the time the expiry is measured from. Only "last_active_at" is supported.
+	Anchor  string `json:"anchor"`
+	Minutes int    `json:"minutes"`
+}
+
+// Container represents a code interpreter container.
+type Container struct {
+	ID           string                 `json:"id"`
+	Object       string                 `json:"object"`
+	Name         string                 `json:"name"`
+	Status       string                 `json:"status"`
+	CreatedAt    int64                  `json:"created_at"`
+	LastActiveAt int64                  `json:"last_active_at,omitempty"`
+	ExpiresAfter *ContainerExpiresAfter `json:"expires_after,omitempty"`
+
+	httpHeader
+}
+
+type ContainerRequest struct {
+	Name         string                 `json:"name"`
+	FileIDs      []string               `json:"file_ids,omitempty"`
+	ExpiresAfter *ContainerExpiresAfter `json:"expires_after,omitempty"`
+}
+
+// ContainersList is a list of containers.
+type ContainersList struct {
+	Object     string      `json:"object"`
+	Containers []Container `json:"data"`
+	FirstID    *string     `json:"first_id"`
+	LastID     *string     `json:"last_id"`
+	HasMore    bool        `json:"has_more"`
+
+	httpHeader
+}
+
+type ContainerDeleteResponse struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+
+	httpHeader
+}
+
+// ContainerFile represents a file stored in a container. Source is "user" for
+// uploaded files and "assistant" for files written by the code interpreter.
+type ContainerFile struct {
+	ID          string `json:"id"`
+	Object      string `json:"object"`
+	ContainerID string `json:"container_id"`
+	CreatedAt   int64  `json:"created_at"`
+	Bytes       int    `json:"bytes"`
+	Path        string `json:"path"`
+	Source      string `json:"source"`
+
+	httpHeader
+}
+
+// ContainerFileRequest adds a file to a container, either by referencing an
+// existing file with FileID or by uploading Bytes under Name.
+type ContainerFileRequest struct {
+	FileID string
+	Name   string
+	Bytes  []byte
+}
+
+// ContainerFilesList is a list of files in a container.
+type ContainerFilesList struct {
+	Object  string          `json:"object"`
+	Files   []ContainerFile `json:"data"`
+	FirstID *string         `json:"first_id"`
+	LastID  *string         `json:"last_id"`
+	HasMore bool            `json:"has_more"`
+
+	httpHeader
+}
+
+type ContainerFileDeleteResponse struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+
+	httpHeader
+}
+
+// CreateContainer creates a code interpreter container.
+func (c *Client) CreateContainer(
+	ctx context.Context,
+	request ContainerRequest,
+	opts ...RequestOption,
+) (response Container, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(containersSuffix),
+		withBody(request), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// RetrieveContainer retrieves a container.
+func (c *Client) RetrieveContainer(
+	ctx context.Context,
+	containerID string,
+	opts ...RequestOption,
+) (response Container, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", containersSuffix, containerID)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// DeleteContainer deletes a container.
+func (c *Client) DeleteContainer(
+	ctx context.Context,
+	containerID string,
+	opts ...RequestOption,
+) (response ContainerDeleteResponse, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", containersSuffix, containerID)
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ListContainers lists the containers in the project.
+func (c *Client) ListContainers(
+	ctx context.Context,
+	pagination Pagination,
+	opts ...RequestOption,
+) (response ContainersList, err error) {
+	urlSuffix := containersSuffix + pagination.encode()
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// CreateContainerFile adds a file to a container. When request.FileID is set the
+// existing file is copied into the container, otherwise request.Bytes is uploaded.
+func (c *Client) CreateContainerFile(
+	ctx context.Context,
+	containerID string,
+	request ContainerFileRequest,
+	opts ...RequestOption,
+) (response ContainerFile, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/files", containersSuffix, containerID)
+
+	if request.FileID != "" {
+		body := struct {
+			FileID string `json:"file_id"`
+		}{request.FileID}
+		req, reqErr := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(body), withRequestOptions(opts))
+		if reqErr != nil {
+			err = reqErr
+			return
+		}
+
+		err = c.sendRequest(req, &response)
+		return
+	}
+
+	var b bytes.Buffer
+	builder := c.createFormBuilder(&b)
+
+	err = builder.CreateFormFileReader("file", bytes.NewReader(request.Bytes), request.Name)
+	if err != nil {
+		return
+	}
+
+	err = builder.Close()
+	if err != nil {
+		return
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix),
+		withBody(&b), withContentType(builder.FormDataContentType()), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// RetrieveContainerFile retrieves the metadata of a file in a container.
+func (c *Client) RetrieveContainerFile(
+	ctx context.Context,
+	containerID string,
+	fileID string,
+	opts ...RequestOption,
+) (response ContainerFile, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/files/%s", containersSuffix, containerID, fileID)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// DeleteContainerFile deletes a file from a container.
+func (c *Client) DeleteContainerFile(
+	ctx context.Context,
+	containerID string,
+	fileID string,
+	opts ...RequestOption,
+) (response ContainerFileDeleteResponse, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/files/%s", containersSuffix, containerID, fileID)
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ListContainerFiles lists the files in a container.
+func (c *Client) ListContainerFiles(
+	ctx context.Context,
+	containerID string,
+	pagination Pagination,
+	opts ...RequestOption,
+) (response ContainerFilesList, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/files%s", containersSuffix, containerID, pagination.encode())
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// GetContainerFileContent downloads the content of a file in a container.
+// The caller is responsible for closing the returned response.
+func (c *Client) GetContainerFileContent(
+	ctx context.Context,
+	containerID string,
+	fileID string,
+	opts ...RequestOption,
+) (content RawResponse, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/files/%s/content", containersSuffix, containerID, fileID)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	return c.sendRequestRaw(req)
+}
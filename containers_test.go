@@ -0,0 +1,179 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// TestContainers Tests the containers endpoints of the API using the mocked server.
+func TestContainers(t *testing.T) {
+	containerID := "cntr_abc123"
+	fileID := "cfile_abc456"
+	limit := 10
+
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler(
+		"/v1/containers/"+containerID+"/files/"+fileID+"/content",
+		func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprint(w, "col1,col2\n1,2\n")
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/containers/"+containerID+"/files/"+fileID,
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				resBytes, _ := json.Marshal(openai.ContainerFile{
+					ID:          fileID,
+					Object:      "container.file",
+					ContainerID: containerID,
+					Path:        "/mnt/data/output.csv",
+					Source:      "assistant",
+				})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodDelete:
+				fmt.Fprintln(w, `{"id": "cfile_abc456", "object": "container.file.deleted", "deleted": true}`)
+			}
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/containers/"+containerID+"/files",
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				file := openai.ContainerFile{ID: fileID, Object: "container.file", ContainerID: containerID}
+				if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+					_, header, err := r.FormFile("file")
+					checks.NoError(t, err, "FormFile error")
+					file.Path = "/mnt/data/" + header.Filename
+					file.Source = "user"
+				} else {
+					var request struct {
+						FileID string `json:"file_id"`
+					}
+					err := json.NewDecoder(r.Body).Decode(&request)
+					checks.NoError(t, err, "Decode error")
+					if request.FileID != "file-abc" {
+						t.Errorf("unexpected file_id: %s", request.FileID)
+					}
+				}
+				resBytes, _ := json.Marshal(file)
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodGet:
+				resBytes, _ := json.Marshal(openai.ContainerFilesList{
+					Object: "list",
+					Files:  []openai.ContainerFile{{ID: fileID, ContainerID: containerID}},
+				})
+				fmt.Fprintln(w, string(resBytes))
+			}
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/containers/"+containerID,
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				resBytes, _ := json.Marshal(openai.Container{ID: containerID, Object: "container", Status: "running"})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodDelete:
+				fmt.Fprintln(w, `{"id": "cntr_abc123", "object": "container.deleted", "deleted": true}`)
+			}
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/containers",
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				var request openai.ContainerRequest
+				err := json.NewDecoder(r.Body).Decode(&request)
+				checks.NoError(t, err, "Decode error")
+
+				resBytes, _ := json.Marshal(openai.Container{
+					ID:           containerID,
+					Object:       "container",
+					Name:         request.Name,
+					Status:       "running",
+					ExpiresAfter: request.ExpiresAfter,
+				})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodGet:
+				if r.URL.Query().Get("limit") != "10" {
+					t.Errorf("unexpected query: %s", r.URL.RawQuery)
+				}
+				resBytes, _ := json.Marshal(openai.ContainersList{
+					Object:     "list",
+					Containers: []openai.Container{{ID: containerID}},
+				})
+				fmt.Fprintln(w, string(resBytes))
+			}
+		},
+	)
+
+	ctx := context.Background()
+
+	container, err := client.CreateContainer(ctx, openai.ContainerRequest{
+		Name:         "analysis",
+		ExpiresAfter: &openai.ContainerExpiresAfter{Anchor: "last_active_at", Minutes: 20},
+	})
+	checks.NoError(t, err, "CreateContainer error")
+	if container.ExpiresAfter == nil || container.ExpiresAfter.Minutes != 20 {
+		t.Errorf("unexpected expires_after: %+v", container.ExpiresAfter)
+	}
+
+	_, err = client.RetrieveContainer(ctx, containerID)
+	checks.NoError(t, err, "RetrieveContainer error")
+
+	containers, err := client.ListContainers(ctx, openai.Pagination{Limit: &limit})
+	checks.NoError(t, err, "ListContainers error")
+	if len(containers.Containers) != 1 {
+		t.Errorf("unexpected number of containers: %d", len(containers.Containers))
+	}
+
+	_, err = client.CreateContainerFile(ctx, containerID, openai.ContainerFileRequest{FileID: "file-abc"})
+	checks.NoError(t, err, "CreateContainerFile error")
+
+	file, err := client.CreateContainerFile(ctx, containerID, openai.ContainerFileRequest{
+		Name:  "input.csv",
+		Bytes: []byte("a,b\n"),
+	})
+	checks.NoError(t, err, "CreateContainerFile error")
+	if file.Path != "/mnt/data/input.csv" {
+		t.Errorf("unexpected file path: %s", file.Path)
+	}
+
+	_, err = client.RetrieveContainerFile(ctx, containerID, fileID)
+	checks.NoError(t, err, "RetrieveContainerFile error")
+
+	_, err = client.ListContainerFiles(ctx, containerID, openai.Pagination{})
+	checks.NoError(t, err, "ListContainerFiles error")
+
+	content, err := client.GetContainerFileContent(ctx, containerID, fileID)
+	checks.NoError(t, err, "GetContainerFileContent error")
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	checks.NoError(t, err, "ReadAll error")
+	if string(data) != "col1,col2\n1,2\n" {
+		t.Errorf("unexpected content: %q", data)
+	}
+
+	_, err = client.DeleteContainerFile(ctx, containerID, fileID)
+	checks.NoError(t, err, "DeleteContainerFile error")
+
+	_, err = client.DeleteContainer(ctx, containerID)
+	checks.NoError(t, err, "DeleteContainer error")
+}
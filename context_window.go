@@ -0,0 +1,108 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai/tokenizer"
+)
+
+var (
+	// ErrContextWindowExceeded is matched by errors.Is for every *ContextWindowError.
+	ErrContextWindowExceeded = errors.New("request exceeds the model context window")
+	// ErrMaxOutputTokensExceeded is returned by ValidateRequest when the
+	// completion budget is larger than the model can generate.
+	ErrMaxOutputTokensExceeded = errors.New("request exceeds the model maximum output tokens")
+)
+
+// ContextWindowError is returned by ValidateRequest when the prompt and the
+// requested completion do not fit in the model's context window.
+type ContextWindowError struct {
+	Model string
+	// ContextWindow is the model's context window in tokens.
+	ContextWindow int
+	// PromptTokens is the counted size of the messages and tools.
+	PromptTokens int
+	// CompletionTokens is the completion budget requested with MaxCompletionTokens or MaxTokens.
+	CompletionTokens int
+}
+
+func (e *ContextWindowError) Error() string {
+	return fmt.Sprintf("%s: model %s has a context window of %d tokens, request needs %d prompt + %d completion tokens",
+		ErrContextWindowExceeded, e.Model, e.ContextWindow, e.PromptTokens, e.CompletionTokens)
+}
+
+// Overflow returns the number of tokens by which the request exceeds the context window.
+func (e *ContextWindowError) Overflow() int {
+	return e.PromptTokens + e.CompletionTokens - e.ContextWindow
+}
+
+func (e *ContextWindowError) Is(target error) bool {
+	return target == ErrContextWindowExceeded
+}
+
+// RegisterContextWindow sets the context window, in tokens, of models whose name
+// starts with prefix. Use it for new models or for Azure deployment names. The
+// other capabilities are kept from the model's current entry, if any.
+func RegisterContextWindow(prefix string, tokens int) {
+	modelRegistryMu.Lock()
+	defer modelRegistryMu.Unlock()
+
+	capabilities, _ := lookupModelLocked(prefix)
+	capabilities.ContextWindow = tokens
+	modelRegistry.Set(prefix, capabilities)
+}
+
+// ContextWindow returns the context window of model in tokens. Fine-tuned model
+// IDs resolve to their base model.
+func ContextWindow(model string) (int, bool) {
+	capabilities, ok := ModelInfo(model)
+	if !ok || capabilities.ContextWindow == 0 {
+		return 0, false
+	}
+	return capabilities.ContextWindow, true
+}
+
+// ValidateRequest checks before sending that the request's prompt plus its
+// completion budget fit in the model's context window, and returns a
+// *ContextWindowError if they do not. Requests for models with an unknown
+// context window are not checked. A completion budget larger than the
+// model's MaxOutputTokens fails with ErrMaxOutputTokensExceeded.
+//
+// The prompt is counted with CountMessagesTokens, falling back to the
+// approximate counter for models unknown to the tokenizer package.
+func ValidateRequest(request ChatCompletionRequest) error {
+	capabilities, ok := ModelInfo(request.Model)
+	if !ok || capabilities.ContextWindow == 0 {
+		return nil
+	}
+	window := capabilities.ContextWindow
+
+	counter, err := tokenizer.ForModel(request.Model)
+	if err != nil {
+		counter = tokenizer.Approximate
+	}
+	prompt, err := countMessagesTokens(counter, request.Model, request.Messages, request.Tools)
+	if err != nil {
+		return err
+	}
+
+	completion := request.MaxCompletionTokens
+	if completion == 0 {
+		completion = request.MaxTokens
+	}
+	if capabilities.MaxOutputTokens > 0 && completion > capabilities.MaxOutputTokens {
+		return fmt.Errorf("%w: model %s generates at most %d tokens, request asks for %d",
+			ErrMaxOutputTokensExceeded, request.Model, capabilities.MaxOutputTokens, completion)
+	}
+
+	if prompt+completion > window {
+		return &ContextWindowError{
+			Model:            request.Model,
+			ContextWindow:    window,
+			PromptTokens:     prompt,
+			CompletionTokens: completion,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package openai_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestContextWindow(t *testing.T) {
+	cases := map[string]int{
+		openai.GPT4o:                    128000,
+		"gpt-4o-mini-2024-07-18":        128000,
+		openai.GPT40613:                 8192,
+		openai.GPT4Turbo20240409:        128000,
+		openai.GPT3Dot5Turbo1106:        16385,
+		openai.GPT3Dot5Turbo0613:        4096,
+		"o1-mini-2024-09-12":            128000,
+		"ft:gpt-4o-mini:acme::abc12345": 128000,
+	}
+	for model, expected := range cases {
+		window, ok := openai.ContextWindow(model)
+		if !ok || window != expected {
+			t.Errorf("%s: expected %d, got %d (%v)", model, expected, window, ok)
+		}
+	}
+
+	if _, ok := openai.ContextWindow("my-deployment"); ok {
+		t.Error("expected unknown model to have no context window")
+	}
+	openai.RegisterContextWindow("my-deployment", 1000)
+	if window, _ := openai.ContextWindow("my-deployment"); window != 1000 {
+		t.Errorf("expected registered window of 1000, got %d", window)
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	registerWordCounter(t)
+	openai.RegisterContextWindow("test-words-model", 100)
+
+	request := openai.ChatCompletionRequest{
+		Model: "test-words-model",
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleUser, Content: strings.Repeat("word ", 50)},
+		},
+		MaxTokens: 40,
+	}
+	// 3 overhead + 1 role + 50 content + 3 reply priming = 57 prompt tokens.
+	checks.NoError(t, openai.ValidateRequest(request), "request within the window should be valid")
+
+	request.MaxCompletionTokens = 50
+	err := openai.ValidateRequest(request)
+	checks.ErrorIs(t, err, openai.ErrContextWindowExceeded, "expected ErrContextWindowExceeded")
+
+	var windowErr *openai.ContextWindowError
+	if !errors.As(err, &windowErr) {
+		t.Fatalf("expected *ContextWindowError, got %T", err)
+	}
+	if windowErr.PromptTokens != 57 || windowErr.CompletionTokens != 50 || windowErr.Overflow() != 7 {
+		t.Errorf("unexpected error details: %+v", windowErr)
+	}
+
+	request.Model = "unknown-model"
+	checks.NoError(t, openai.ValidateRequest(request), "unknown models should not be validated")
+}
@@ -37,8 +37,13 @@ type EditsResponse struct {
 will need to migrate to GPT-3.5 Turbo by January 4, 2024.
 You can use CreateChatCompletion or CreateChatCompletionStream instead.
 */
-func (c *Client) Edits(ctx context.Context, request EditsRequest) (response EditsResponse, err error) {
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/edits", fmt.Sprint(request.Model)), withBody(request))
+func (c *Client) Edits(
+	ctx context.Context,
+	request EditsRequest,
+	opts ...RequestOption,
+) (response EditsResponse, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/edits", fmt.Sprint(request.Model)),
+		withModel(fmt.Sprint(request.Model)), withBody(request), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
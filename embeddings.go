@@ -160,6 +160,18 @@ type EmbeddingRequest struct {
 	// Dimensions The number of dimensions the resulting output embeddings should have.
 	// Only supported in text-embedding-3 and later models.
 	Dimensions int `json:"dimensions,omitempty"`
+	// ExtraBody holds fields merged into the request JSON, for parameters this
+	// client does not model yet or vendor extensions.
+	ExtraBody map[string]any `json:"-"`
+}
+
+func (r EmbeddingRequest) MarshalJSON() ([]byte, error) {
+	return marshalWithExtraBody(standardJSON{}, r)
+}
+
+func (r EmbeddingRequest) splitExtraBody() (any, map[string]any) {
+	type Alias EmbeddingRequest
+	return Alias(r), r.ExtraBody
 }
 
 func (r EmbeddingRequest) Convert() EmbeddingRequest {
@@ -187,6 +199,9 @@ type EmbeddingRequestStrings struct {
 	// Dimensions The number of dimensions the resulting output embeddings should have.
 	// Only supported in text-embedding-3 and later models.
 	Dimensions int `json:"dimensions,omitempty"`
+	// ExtraBody holds fields merged into the request JSON, for parameters this
+	// client does not model yet or vendor extensions.
+	ExtraBody map[string]any `json:"-"`
 }
 
 func (r EmbeddingRequestStrings) Convert() EmbeddingRequest {
@@ -196,6 +211,7 @@ func (r EmbeddingRequestStrings) Convert() EmbeddingRequest {
 		User:           r.User,
 		EncodingFormat: r.EncodingFormat,
 		Dimensions:     r.Dimensions,
+		ExtraBody:      r.ExtraBody,
 	}
 }
 
@@ -219,6 +235,9 @@ type EmbeddingRequestTokens struct {
 	// Dimensions The number of dimensions the resulting output embeddings should have.
 	// Only supported in text-embedding-3 and later models.
 	Dimensions int `json:"dimensions,omitempty"`
+	// ExtraBody holds fields merged into the request JSON, for parameters this
+	// client does not model yet or vendor extensions.
+	ExtraBody map[string]any `json:"-"`
 }
 
 func (r EmbeddingRequestTokens) Convert() EmbeddingRequest {
@@ -228,6 +247,7 @@ func (r EmbeddingRequestTokens) Convert() EmbeddingRequest {
 		User:           r.User,
 		EncodingFormat: r.EncodingFormat,
 		Dimensions:     r.Dimensions,
+		ExtraBody:      r.ExtraBody,
 	}
 }
 
@@ -239,9 +259,29 @@ func (r EmbeddingRequestTokens) Convert() EmbeddingRequest {
 func (c *Client) CreateEmbeddings(
 	ctx context.Context,
 	conv EmbeddingRequestConverter,
+	opts ...RequestOption,
 ) (res EmbeddingResponse, err error) {
 	baseReq := conv.Convert()
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/embeddings", string(baseReq.Model)), withBody(baseReq))
+	if c.config.ValidateEmbeddingRequests {
+		if err = validateEmbeddingRequest(baseReq, !c.config.SplitEmbeddingRequests); err != nil {
+			return
+		}
+	}
+	if c.config.SplitEmbeddingRequests {
+		if batches := splitEmbeddingRequest(baseReq); len(batches) > 1 {
+			return c.createEmbeddingBatches(ctx, batches, opts...)
+		}
+	}
+	return c.createEmbeddings(ctx, baseReq, opts...)
+}
+
+func (c *Client) createEmbeddings(
+	ctx context.Context,
+	baseReq EmbeddingRequest,
+	opts ...RequestOption,
+) (res EmbeddingResponse, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/embeddings", string(baseReq.Model)),
+		withModel(string(baseReq.Model)), withBody(baseReq), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
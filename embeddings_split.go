@@ -0,0 +1,80 @@
+package openai
+
+import "context"
+
+// splitEmbeddingRequest splits the inputs of request into batches within
+// MaxEmbeddingInputs and MaxEmbeddingRequestTokens. Requests whose inputs are
+// not a list are returned as is.
+func splitEmbeddingRequest(request EmbeddingRequest) []EmbeddingRequest {
+	tokens, ok := embeddingInputTokens(request)
+	if !ok {
+		return []EmbeddingRequest{request}
+	}
+
+	var (
+		batches      []EmbeddingRequest
+		start, total int
+	)
+	for i, count := range tokens {
+		if i > start && (i-start == MaxEmbeddingInputs || total+count > MaxEmbeddingRequestTokens) {
+			batches = append(batches, request.withInputs(start, i))
+			start, total = i, 0
+		}
+		total += count
+	}
+	if start == 0 {
+		return []EmbeddingRequest{request}
+	}
+	return append(batches, request.withInputs(start, len(tokens)))
+}
+
+// withInputs returns a copy of r with the inputs from start to end.
+func (r EmbeddingRequest) withInputs(start, end int) EmbeddingRequest {
+	switch input := r.Input.(type) {
+	case []string:
+		r.Input = input[start:end]
+	case [][]int:
+		r.Input = input[start:end]
+	}
+	return r
+}
+
+// createEmbeddingBatches sends the batches one after the other and merges the
+// responses, with the indexes of the embeddings relative to all the inputs.
+func (c *Client) createEmbeddingBatches(
+	ctx context.Context,
+	batches []EmbeddingRequest,
+	opts ...RequestOption,
+) (res EmbeddingResponse, err error) {
+	offset := 0
+	for i, batch := range batches {
+		var batchRes EmbeddingResponse
+		batchRes, err = c.createEmbeddings(ctx, batch, opts...)
+		if err != nil {
+			return EmbeddingResponse{}, err
+		}
+		if i == 0 {
+			res.Object = batchRes.Object
+			res.Model = batchRes.Model
+			res.httpHeader = batchRes.httpHeader
+		}
+		for _, embedding := range batchRes.Data {
+			embedding.Index += offset
+			res.Data = append(res.Data, embedding)
+		}
+		res.Usage.PromptTokens += batchRes.Usage.PromptTokens
+		res.Usage.TotalTokens += batchRes.Usage.TotalTokens
+		offset += embeddingInputCount(batch)
+	}
+	return res, nil
+}
+
+func embeddingInputCount(request EmbeddingRequest) int {
+	switch input := request.Input.(type) {
+	case []string:
+		return len(input)
+	case [][]int:
+		return len(input)
+	}
+	return 1
+}
@@ -0,0 +1,78 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCreateEmbeddingsSplit(t *testing.T) {
+	server := test.NewTestServer()
+	var batchSizes []int
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Input []json.RawMessage `json:"input"`
+		}
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+		batchSizes = append(batchSizes, len(request.Input))
+
+		response := openai.EmbeddingResponse{Object: "list", Model: openai.SmallEmbedding3}
+		for i := range request.Input {
+			response.Data = append(response.Data, openai.Embedding{Index: i, Embedding: []float32{float32(i)}})
+		}
+		response.Usage = openai.Usage{PromptTokens: len(request.Input), TotalTokens: len(request.Input)}
+		checks.NoError(t, json.NewEncoder(w).Encode(response), "Encode error")
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.SplitEmbeddingRequests = true
+	client := openai.NewClientWithConfig(config)
+
+	inputs := make([]string, openai.MaxEmbeddingInputs+2)
+	for i := range inputs {
+		inputs[i] = "text"
+	}
+	response, err := client.CreateEmbeddings(context.Background(), openai.EmbeddingRequestStrings{
+		Model: openai.SmallEmbedding3,
+		Input: inputs,
+	})
+	checks.NoError(t, err, "CreateEmbeddings error")
+	if len(batchSizes) != 2 || batchSizes[0] != openai.MaxEmbeddingInputs || batchSizes[1] != 2 {
+		t.Errorf("unexpected batches %v", batchSizes)
+	}
+	if len(response.Data) != len(inputs) || response.Usage.TotalTokens != len(inputs) {
+		t.Fatalf("expected %d embeddings and tokens, got %d and %+v", len(inputs), len(response.Data), response.Usage)
+	}
+	for i, embedding := range response.Data {
+		if embedding.Index != i {
+			t.Fatalf("expected embedding %d to have index %d, got %d", i, i, embedding.Index)
+		}
+	}
+	if last := response.Data[len(inputs)-1]; last.Embedding[0] != 1 {
+		t.Errorf("expected the last embedding to come from the second batch, got %v", last.Embedding)
+	}
+
+	batchSizes = nil
+	tokens := make([][]int, 40)
+	for i := range tokens {
+		tokens[i] = make([]int, openai.MaxEmbeddingInputTokens)
+	}
+	_, err = client.CreateEmbeddings(context.Background(), openai.EmbeddingRequestTokens{
+		Model: openai.SmallEmbedding3,
+		Input: tokens,
+	})
+	checks.NoError(t, err, "CreateEmbeddings error")
+	perBatch := openai.MaxEmbeddingRequestTokens / openai.MaxEmbeddingInputTokens
+	if len(batchSizes) != 2 || batchSizes[0] != perBatch || batchSizes[1] != len(tokens)-perBatch {
+		t.Errorf("unexpected batches %v", batchSizes)
+	}
+}
@@ -158,6 +158,28 @@ func TestEmbeddingEndpoint(t *testing.T) {
 	checks.HasError(t, err, "CreateEmbeddings error")
 }
 
+func TestEmbeddingRequestExtraBody(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req["input_type"] != "query" || req["model"] != string(openai.SmallEmbedding3) {
+			t.Errorf("unexpected request body: %v", req)
+		}
+		resBytes, _ := json.Marshal(openai.EmbeddingResponse{})
+		fmt.Fprintln(w, string(resBytes))
+	})
+
+	_, err := client.CreateEmbeddings(context.Background(), openai.EmbeddingRequestStrings{
+		Input:     []string{"hello"},
+		Model:     openai.SmallEmbedding3,
+		ExtraBody: map[string]any{"input_type": "query"},
+	})
+	checks.NoError(t, err, "CreateEmbeddings error")
+}
+
 func TestAzureEmbeddingEndpoint(t *testing.T) {
 	client, server, teardown := setupAzureTestServer()
 	defer teardown()
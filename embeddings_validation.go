@@ -0,0 +1,129 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai/tokenizer"
+)
+
+// Limits of the embeddings endpoint.
+const (
+	// MaxEmbeddingInputTokens is the maximum number of tokens of each input
+	// for models missing from the model registry, where the context window
+	// of the embedding models sets the limit.
+	MaxEmbeddingInputTokens = 8191
+	// MaxEmbeddingInputs is the maximum number of inputs per request.
+	MaxEmbeddingInputs = 2048
+	// MaxEmbeddingRequestTokens is the maximum number of tokens of all the
+	// inputs of a request.
+	MaxEmbeddingRequestTokens = 300000
+)
+
+var (
+	// ErrEmbeddingInputTooLong is matched by errors.Is for every
+	// *EmbeddingInputError.
+	ErrEmbeddingInputTooLong = errors.New("embedding input exceeds the token limit")
+	// ErrEmbeddingRequestTooLarge is returned by ValidateEmbeddingRequest when
+	// the request has more than MaxEmbeddingInputs inputs or
+	// MaxEmbeddingRequestTokens tokens.
+	ErrEmbeddingRequestTooLarge = errors.New("embedding request exceeds the input limits")
+)
+
+// EmbeddingInputError is returned by ValidateEmbeddingRequest for an input
+// longer than the context window of the model.
+type EmbeddingInputError struct {
+	// Index is the position of the input in the request.
+	Index  int
+	Tokens int
+	// Limit is the maximum number of tokens of an input.
+	Limit int
+}
+
+func (e *EmbeddingInputError) Error() string {
+	return fmt.Sprintf("%s: input %d has %d tokens, the limit is %d",
+		ErrEmbeddingInputTooLong, e.Index, e.Tokens, e.Limit)
+}
+
+func (e *EmbeddingInputError) Is(target error) bool {
+	return target == ErrEmbeddingInputTooLong
+}
+
+// ValidateEmbeddingRequest checks before sending that each input of the
+// request fits in the context window of the model, as listed by ModelInfo or
+// MaxEmbeddingInputTokens for unlisted models, returning an
+// *EmbeddingInputError for the first that does not, and that the request is
+// within MaxEmbeddingInputs and MaxEmbeddingRequestTokens.
+//
+// CreateEmbeddings calls it only with ClientConfig.ValidateEmbeddingRequests.
+// Text inputs are counted with the tokenizer package, which approximates the
+// counts unless an encoder is registered, so inputs close to the limit may be
+// rejected wrongly. Inputs of other types than strings and token arrays are
+// not checked.
+func ValidateEmbeddingRequest(conv EmbeddingRequestConverter) error {
+	return validateEmbeddingRequest(conv.Convert(), true)
+}
+
+// validateEmbeddingRequest implements ValidateEmbeddingRequest. The limits of
+// the whole request are only checked with checkRequest, so that requests that
+// will be split are not rejected.
+func validateEmbeddingRequest(request EmbeddingRequest, checkRequest bool) error {
+	tokens, ok := embeddingInputTokens(request)
+	if !ok {
+		return nil
+	}
+	if checkRequest && len(tokens) > MaxEmbeddingInputs {
+		return fmt.Errorf("%w: %d inputs, the limit is %d", ErrEmbeddingRequestTooLarge, len(tokens), MaxEmbeddingInputs)
+	}
+	limit := embeddingInputLimit(string(request.Model))
+	total := 0
+	for i, count := range tokens {
+		if count > limit {
+			return &EmbeddingInputError{Index: i, Tokens: count, Limit: limit}
+		}
+		total += count
+	}
+	if checkRequest && total > MaxEmbeddingRequestTokens {
+		return fmt.Errorf("%w: %d tokens, the limit is %d", ErrEmbeddingRequestTooLarge, total, MaxEmbeddingRequestTokens)
+	}
+	return nil
+}
+
+// embeddingInputLimit returns the maximum number of tokens of an input for
+// model.
+func embeddingInputLimit(model string) int {
+	if capabilities, ok := ModelInfo(model); ok && capabilities.ContextWindow > 0 {
+		return capabilities.ContextWindow
+	}
+	return MaxEmbeddingInputTokens
+}
+
+// embeddingInputTokens returns the number of tokens of each input of request.
+// It reports false for inputs of unknown types.
+func embeddingInputTokens(request EmbeddingRequest) ([]int, bool) {
+	counter, err := tokenizer.ForModel(string(request.Model))
+	if err != nil {
+		counter = tokenizer.Approximate
+	}
+
+	switch input := request.Input.(type) {
+	case string:
+		return []int{counter.Count(input)}, true
+	case []string:
+		tokens := make([]int, len(input))
+		for i, text := range input {
+			tokens[i] = counter.Count(text)
+		}
+		return tokens, true
+	case []int:
+		return []int{len(input)}, true
+	case [][]int:
+		tokens := make([]int, len(input))
+		for i, ids := range input {
+			tokens[i] = len(ids)
+		}
+		return tokens, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,83 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestValidateEmbeddingRequest(t *testing.T) {
+	err := openai.ValidateEmbeddingRequest(openai.EmbeddingRequestStrings{
+		Model: openai.SmallEmbedding3,
+		Input: []string{"short", "also short"},
+	})
+	checks.NoError(t, err, "valid request")
+
+	long := make([]int, openai.MaxEmbeddingInputTokens+1)
+	err = openai.ValidateEmbeddingRequest(openai.EmbeddingRequestTokens{
+		Model: openai.SmallEmbedding3,
+		Input: [][]int{{1, 2}, {3}, long},
+	})
+	checks.ErrorIs(t, err, openai.ErrEmbeddingInputTooLong, "long input")
+	var inputErr *openai.EmbeddingInputError
+	if !errors.As(err, &inputErr) || inputErr.Index != 2 || inputErr.Tokens != len(long) || inputErr.Limit != 8191 {
+		t.Errorf("expected input 2 to be reported, got %v", err)
+	}
+
+	err = openai.ValidateEmbeddingRequest(openai.EmbeddingRequest{
+		Model: openai.SmallEmbedding3,
+		Input: strings.Repeat("word ", openai.MaxEmbeddingInputTokens+100),
+	})
+	checks.ErrorIs(t, err, openai.ErrEmbeddingInputTooLong, "long text input")
+
+	err = openai.ValidateEmbeddingRequest(openai.EmbeddingRequestStrings{
+		Model: openai.SmallEmbedding3,
+		Input: make([]string, openai.MaxEmbeddingInputs+1),
+	})
+	checks.ErrorIs(t, err, openai.ErrEmbeddingRequestTooLarge, "too many inputs")
+
+	inputs := make([][]int, 40)
+	for i := range inputs {
+		inputs[i] = make([]int, openai.MaxEmbeddingInputTokens)
+	}
+	err = openai.ValidateEmbeddingRequest(openai.EmbeddingRequestTokens{Model: openai.SmallEmbedding3, Input: inputs})
+	checks.ErrorIs(t, err, openai.ErrEmbeddingRequestTooLarge, "too many tokens")
+
+	err = openai.ValidateEmbeddingRequest(openai.EmbeddingRequest{Input: []any{"not checked"}})
+	checks.NoError(t, err, "unknown input types are not checked")
+}
+
+func TestCreateEmbeddingsValidation(t *testing.T) {
+	server := test.NewTestServer()
+	calls := 0
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.ValidateEmbeddingRequests = true
+	client := openai.NewClientWithConfig(config)
+
+	_, err := client.CreateEmbeddings(context.Background(), openai.EmbeddingRequestTokens{
+		Model: openai.SmallEmbedding3,
+		Input: [][]int{{1}, make([]int, openai.MaxEmbeddingInputTokens+1)},
+	})
+	var inputErr *openai.EmbeddingInputError
+	if !errors.As(err, &inputErr) || inputErr.Index != 1 {
+		t.Fatalf("expected input 1 to be reported, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no request to be sent, got %d", calls)
+	}
+}
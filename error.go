@@ -3,7 +3,17 @@ package openai
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxErrorBodySize caps the body kept on a RequestError.
+	maxErrorBodySize = 64 << 10
+	// errorBodyExcerptSize caps the part of the body included in the message
+	// of a RequestError.
+	errorBodyExcerptSize = 256
 )
 
 // APIError provides error information returned by the OpenAI API.
@@ -23,10 +33,18 @@ type InnerError struct {
 	ContentFilterResults ContentFilterResults `json:"content_filter_result,omitempty"`
 }
 
-// RequestError provides information about generic request errors.
+// RequestError provides information about generic request errors, e.g. error
+// responses that are not in the JSON format of the API, such as the HTML pages
+// of proxies and load balancers.
 type RequestError struct {
 	HTTPStatusCode int
 	Err            error
+	// Header is the header of the error response.
+	Header http.Header
+	// Body is the raw body of the error response, up to 64 KiB.
+	Body []byte
+	// BodyTruncated reports whether Body was cut at the size limit.
+	BodyTruncated bool
 }
 
 type ErrorResponse struct {
@@ -41,6 +59,23 @@ func (e *APIError) Error() string {
 	return e.Message
 }
 
+// Is makes errors.Is match the sentinel errors of the API error codes, such as
+// ErrResourceUnavailable, by the Code or Type of e.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrResourceUnavailable:
+		return e.hasCode(resourceUnavailableCode)
+	default:
+		return false
+	}
+}
+
+// hasCode reports whether the code or the type of e is code.
+func (e *APIError) hasCode(code string) bool {
+	errCode, _ := e.Code.(string)
+	return errCode == code || e.Type == code
+}
+
 func (e *APIError) UnmarshalJSON(data []byte) (err error) {
 	var rawMap map[string]json.RawMessage
 	err = json.Unmarshal(data, &rawMap)
@@ -101,7 +136,24 @@ func (e *APIError) UnmarshalJSON(data []byte) (err error) {
 }
 
 func (e *RequestError) Error() string {
-	return fmt.Sprintf("error, status code: %d, message: %s", e.HTTPStatusCode, e.Err)
+	msg := fmt.Sprintf("error, status code: %d, message: %s", e.HTTPStatusCode, e.Err)
+	if _, isAPIError := e.Err.(*APIError); isAPIError || len(e.Body) == 0 {
+		return msg
+	}
+	return fmt.Sprintf("%s, body: %s", msg, bodyExcerpt(e.Body))
+}
+
+// bodyExcerpt returns the beginning of body on a single line.
+func bodyExcerpt(body []byte) string {
+	excerpt := strings.Join(strings.Fields(string(body)), " ")
+	if len(excerpt) <= errorBodyExcerptSize {
+		return excerpt
+	}
+	end := errorBodyExcerptSize
+	for end > 0 && !utf8.RuneStart(excerpt[end]) {
+		end--
+	}
+	return excerpt[:end] + "..."
 }
 
 func (e *RequestError) Unwrap() error {
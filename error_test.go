@@ -1,9 +1,12 @@
 package openai_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/sashabaranov/go-openai"
@@ -277,3 +280,45 @@ func TestRequestError(t *testing.T) {
 		t.Fatalf("Empty request error occurred")
 	}
 }
+
+func TestRequestErrorNonJSONBody(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	const page = "<html>\n<head><title>502 Bad Gateway</title></head>\n</html>\n"
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("X-Proxy", "lb-1")
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, page)
+	})
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, strings.Repeat("x", 100<<10))
+	})
+
+	_, err := client.ListModels(context.Background())
+	var reqErr *openai.RequestError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("expected a RequestError, got %v", err)
+	}
+	if reqErr.HTTPStatusCode != http.StatusBadGateway || string(reqErr.Body) != page || reqErr.BodyTruncated {
+		t.Errorf("unexpected request error %+v", reqErr)
+	}
+	if reqErr.Header.Get("X-Proxy") != "lb-1" {
+		t.Errorf("unexpected header %v", reqErr.Header)
+	}
+	if !strings.Contains(err.Error(), "body: <html> <head><title>502 Bad Gateway</title></head> </html>") {
+		t.Errorf("unexpected error message %q", err)
+	}
+
+	_, err = client.ListFiles(context.Background())
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("expected a RequestError, got %v", err)
+	}
+	if len(reqErr.Body) != 64<<10 || !reqErr.BodyTruncated {
+		t.Errorf("expected a truncated body, got %d bytes", len(reqErr.Body))
+	}
+	if !strings.HasSuffix(err.Error(), "...") || len(err.Error()) > 400 {
+		t.Errorf("unexpected error message %q", err)
+	}
+}
@@ -0,0 +1,541 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	evalsSuffix = "/evals"
+)
+
+// EvalDataSourceConfigType is the type of data source used to define an eval's item schema.
+type EvalDataSourceConfigType string
+
+const (
+	EvalDataSourceConfigTypeCustom            EvalDataSourceConfigType = "custom"
+	EvalDataSourceConfigTypeLogs              EvalDataSourceConfigType = "logs"
+	EvalDataSourceConfigTypeStoredCompletions EvalDataSourceConfigType = "stored_completions"
+)
+
+// EvalDataSourceConfig describes the shape of the items an eval runs over.
+type EvalDataSourceConfig struct {
+	Type EvalDataSourceConfigType `json:"type"`
+	// ItemSchema is the JSON schema for each row of the data source. Only used with the custom type.
+	// You can pass json.RawMessage or a jsonschema.Definition.
+	ItemSchema any `json:"item_schema,omitempty"`
+	// IncludeSampleSchema makes the sample namespace available to testing criteria.
+	// Set this when the eval runs generate model outputs.
+	IncludeSampleSchema bool `json:"include_sample_schema,omitempty"`
+	// Metadata filters used with the logs and stored_completions types.
+	Metadata map[string]any `json:"metadata,omitempty"`
+	// Schema is the resolved JSON schema, only populated on responses.
+	Schema map[string]any `json:"schema,omitempty"`
+}
+
+// EvalTestingCriterionType is the type of grader used by a testing criterion.
+type EvalTestingCriterionType string
+
+const (
+	EvalTestingCriterionTypeLabelModel     EvalTestingCriterionType = "label_model"
+	EvalTestingCriterionTypeStringCheck    EvalTestingCriterionType = "string_check"
+	EvalTestingCriterionTypeTextSimilarity EvalTestingCriterionType = "text_similarity"
+	EvalTestingCriterionTypePython         EvalTestingCriterionType = "python"
+	EvalTestingCriterionTypeScoreModel     EvalTestingCriterionType = "score_model"
+)
+
+// EvalStringCheckOperation is the comparison applied by a string_check grader.
+type EvalStringCheckOperation string
+
+const (
+	EvalStringCheckOperationEq    EvalStringCheckOperation = "eq"
+	EvalStringCheckOperationNe    EvalStringCheckOperation = "ne"
+	EvalStringCheckOperationLike  EvalStringCheckOperation = "like"
+	EvalStringCheckOperationIlike EvalStringCheckOperation = "ilike"
+)
+
+// EvalMessage is a templated message used as input to model graders.
+// Content may reference item fields with {{item.field}} and sample fields with {{sample.output_text}}.
+type EvalMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// EvalTestingCriterion is a grader applied to every item of an eval run.
+// Only the fields relevant to Type need to be set.
+type EvalTestingCriterion struct {
+	Type EvalTestingCriterionType `json:"type"`
+	Name string                   `json:"name"`
+
+	// label_model and score_model graders.
+	Model string `json:"model,omitempty"`
+	// Input is a []EvalMessage for model graders, or a template string for string_check
+	// and text_similarity graders.
+	Input         any       `json:"input,omitempty"`
+	Labels        []string  `json:"labels,omitempty"`
+	PassingLabels []string  `json:"passing_labels,omitempty"`
+	Range         []float64 `json:"range,omitempty"`
+
+	// string_check and text_similarity graders.
+	Reference        string                   `json:"reference,omitempty"`
+	Operation        EvalStringCheckOperation `json:"operation,omitempty"`
+	EvaluationMetric string                   `json:"evaluation_metric,omitempty"`
+
+	// python graders.
+	Source   string `json:"source,omitempty"`
+	ImageTag string `json:"image_tag,omitempty"`
+
+	// PassThreshold is used by text_similarity, python and score_model graders.
+	PassThreshold *float64 `json:"pass_threshold,omitempty"`
+}
+
+// Eval represents an evaluation: a data source configuration plus the testing criteria.
+type Eval struct {
+	ID               string                 `json:"id"`
+	Object           string                 `json:"object"`
+	Name             string                 `json:"name"`
+	CreatedAt        int64                  `json:"created_at"`
+	DataSourceConfig EvalDataSourceConfig   `json:"data_source_config"`
+	TestingCriteria  []EvalTestingCriterion `json:"testing_criteria"`
+	Metadata         map[string]string      `json:"metadata,omitempty"`
+
+	httpHeader
+}
+
+type EvalRequest struct {
+	Name             string                 `json:"name,omitempty"`
+	DataSourceConfig EvalDataSourceConfig   `json:"data_source_config"`
+	TestingCriteria  []EvalTestingCriterion `json:"testing_criteria"`
+	Metadata         map[string]string      `json:"metadata,omitempty"`
+}
+
+type EvalModifyRequest struct {
+	Name     string            `json:"name,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// EvalsList is a list of evals.
+type EvalsList struct {
+	Object  string `json:"object"`
+	Evals   []Eval `json:"data"`
+	FirstID string `json:"first_id"`
+	LastID  string `json:"last_id"`
+	HasMore bool   `json:"has_more"`
+
+	httpHeader
+}
+
+type EvalDeleteResponse struct {
+	EvalID  string `json:"eval_id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+
+	httpHeader
+}
+
+// EvalRunDataSourceType is the type of data source an eval run reads its items from.
+type EvalRunDataSourceType string
+
+const (
+	EvalRunDataSourceTypeJSONL       EvalRunDataSourceType = "jsonl"
+	EvalRunDataSourceTypeCompletions EvalRunDataSourceType = "completions"
+	EvalRunDataSourceTypeResponses   EvalRunDataSourceType = "responses"
+)
+
+// EvalRunSourceType selects where the rows of a run data source come from.
+type EvalRunSourceType string
+
+const (
+	EvalRunSourceTypeFileID            EvalRunSourceType = "file_id"
+	EvalRunSourceTypeFileContent       EvalRunSourceType = "file_content"
+	EvalRunSourceTypeStoredCompletions EvalRunSourceType = "stored_completions"
+	EvalRunSourceTypeResponses         EvalRunSourceType = "responses"
+)
+
+// EvalRunSourceContent is an inline row of a file_content source.
+type EvalRunSourceContent struct {
+	Item   map[string]any `json:"item"`
+	Sample map[string]any `json:"sample,omitempty"`
+}
+
+// EvalRunSource is the source of the rows of an eval run.
+type EvalRunSource struct {
+	Type EvalRunSourceType `json:"type"`
+	// ID of an uploaded JSONL file, for the file_id type.
+	ID string `json:"id,omitempty"`
+	// Inline rows, for the file_content type.
+	Content []EvalRunSourceContent `json:"content,omitempty"`
+	// Filters for the stored_completions and responses types.
+	Model         string            `json:"model,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	CreatedAfter  *int64            `json:"created_after,omitempty"`
+	CreatedBefore *int64            `json:"created_before,omitempty"`
+	Limit         *int              `json:"limit,omitempty"`
+}
+
+// EvalRunInputMessages is the prompt template used to sample model outputs in completions runs.
+type EvalRunInputMessages struct {
+	// Type is either "template" or "item_reference".
+	Type          string        `json:"type"`
+	Template      []EvalMessage `json:"template,omitempty"`
+	ItemReference string        `json:"item_reference,omitempty"`
+}
+
+type EvalRunSamplingParams struct {
+	Temperature         *float32 `json:"temperature,omitempty"`
+	TopP                *float32 `json:"top_p,omitempty"`
+	MaxCompletionTokens *int     `json:"max_completion_tokens,omitempty"`
+	Seed                *int     `json:"seed,omitempty"`
+}
+
+// EvalRunDataSource configures which items a run evaluates and, for the completions and
+// responses types, how samples are generated.
+type EvalRunDataSource struct {
+	Type           EvalRunDataSourceType  `json:"type"`
+	Source         EvalRunSource          `json:"source"`
+	Model          string                 `json:"model,omitempty"`
+	InputMessages  *EvalRunInputMessages  `json:"input_messages,omitempty"`
+	SamplingParams *EvalRunSamplingParams `json:"sampling_params,omitempty"`
+}
+
+type EvalRunStatus string
+
+const (
+	EvalRunStatusQueued     EvalRunStatus = "queued"
+	EvalRunStatusInProgress EvalRunStatus = "in_progress"
+	EvalRunStatusCompleted  EvalRunStatus = "completed"
+	EvalRunStatusCanceled   EvalRunStatus = "canceled"
+	EvalRunStatusFailed     EvalRunStatus = "failed"
+)
+
+type EvalRunResultCounts struct {
+	Total   int `json:"total"`
+	Errored int `json:"errored"`
+	Failed  int `json:"failed"`
+	Passed  int `json:"passed"`
+}
+
+type EvalRunModelUsage struct {
+	ModelName        string `json:"model_name"`
+	InvocationCount  int    `json:"invocation_count"`
+	PromptTokens     int    `json:"prompt_tokens"`
+	CompletionTokens int    `json:"completion_tokens"`
+	TotalTokens      int    `json:"total_tokens"`
+	CachedTokens     int    `json:"cached_tokens"`
+}
+
+type EvalRunCriteriaResult struct {
+	TestingCriteria string `json:"testing_criteria"`
+	Passed          int    `json:"passed"`
+	Failed          int    `json:"failed"`
+}
+
+type EvalRunError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// EvalRun is a single execution of an eval against a data source.
+type EvalRun struct {
+	ID                        string                  `json:"id"`
+	Object                    string                  `json:"object"`
+	EvalID                    string                  `json:"eval_id"`
+	Name                      string                  `json:"name"`
+	Model                     string                  `json:"model"`
+	Status                    EvalRunStatus           `json:"status"`
+	CreatedAt                 int64                   `json:"created_at"`
+	ReportURL                 string                  `json:"report_url"`
+	DataSource                EvalRunDataSource       `json:"data_source"`
+	ResultCounts              EvalRunResultCounts     `json:"result_counts"`
+	PerModelUsage             []EvalRunModelUsage     `json:"per_model_usage"`
+	PerTestingCriteriaResults []EvalRunCriteriaResult `json:"per_testing_criteria_results"`
+	Error                     *EvalRunError           `json:"error,omitempty"`
+	Metadata                  map[string]string       `json:"metadata,omitempty"`
+
+	httpHeader
+}
+
+type EvalRunRequest struct {
+	Name       string            `json:"name,omitempty"`
+	DataSource EvalRunDataSource `json:"data_source"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+}
+
+// EvalRunsList is a list of eval runs.
+type EvalRunsList struct {
+	Object  string    `json:"object"`
+	Runs    []EvalRun `json:"data"`
+	FirstID string    `json:"first_id"`
+	LastID  string    `json:"last_id"`
+	HasMore bool      `json:"has_more"`
+
+	httpHeader
+}
+
+type EvalRunDeleteResponse struct {
+	RunID   string `json:"run_id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+
+	httpHeader
+}
+
+type EvalOutputItemStatus string
+
+const (
+	EvalOutputItemStatusPass EvalOutputItemStatus = "pass"
+	EvalOutputItemStatusFail EvalOutputItemStatus = "fail"
+)
+
+// EvalOutputItemResult is the outcome of one testing criterion for one item.
+type EvalOutputItemResult struct {
+	Name   string         `json:"name"`
+	Type   string         `json:"type,omitempty"`
+	Score  float64        `json:"score"`
+	Passed bool           `json:"passed"`
+	Sample map[string]any `json:"sample,omitempty"`
+}
+
+// EvalOutputItemSample is the model output generated for an item.
+type EvalOutputItemSample struct {
+	Model        string        `json:"model"`
+	Input        []EvalMessage `json:"input"`
+	Output       []EvalMessage `json:"output"`
+	FinishReason string        `json:"finish_reason"`
+	Usage        Usage         `json:"usage"`
+	Error        *EvalRunError `json:"error,omitempty"`
+	Seed         int           `json:"seed"`
+	Temperature  float32       `json:"temperature"`
+	TopP         float32       `json:"top_p"`
+}
+
+// EvalOutputItem holds the per-item results of an eval run.
+type EvalOutputItem struct {
+	ID               string                 `json:"id"`
+	Object           string                 `json:"object"`
+	EvalID           string                 `json:"eval_id"`
+	RunID            string                 `json:"run_id"`
+	CreatedAt        int64                  `json:"created_at"`
+	Status           EvalOutputItemStatus   `json:"status"`
+	DatasourceItemID int64                  `json:"datasource_item_id"`
+	DatasourceItem   map[string]any         `json:"datasource_item"`
+	Results          []EvalOutputItemResult `json:"results"`
+	Sample           *EvalOutputItemSample  `json:"sample,omitempty"`
+
+	httpHeader
+}
+
+// EvalOutputItemsList is a list of eval run output items.
+type EvalOutputItemsList struct {
+	Object  string           `json:"object"`
+	Items   []EvalOutputItem `json:"data"`
+	FirstID string           `json:"first_id"`
+	LastID  string           `json:"last_id"`
+	HasMore bool             `json:"has_more"`
+
+	httpHeader
+}
+
+// CreateEval creates an eval.
+func (c *Client) CreateEval(
+	ctx context.Context,
+	request EvalRequest,
+	opts ...RequestOption,
+) (response Eval, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(evalsSuffix), withBody(request), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// RetrieveEval retrieves an eval.
+func (c *Client) RetrieveEval(ctx context.Context, evalID string, opts ...RequestOption) (response Eval, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", evalsSuffix, evalID)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ModifyEval modifies the name or metadata of an eval.
+func (c *Client) ModifyEval(
+	ctx context.Context,
+	evalID string,
+	request EvalModifyRequest,
+	opts ...RequestOption,
+) (response Eval, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", evalsSuffix, evalID)
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// DeleteEval deletes an eval.
+func (c *Client) DeleteEval(
+	ctx context.Context,
+	evalID string,
+	opts ...RequestOption,
+) (response EvalDeleteResponse, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", evalsSuffix, evalID)
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ListEvals lists the evals of the project.
+func (c *Client) ListEvals(
+	ctx context.Context,
+	pagination Pagination,
+	opts ...RequestOption,
+) (response EvalsList, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(evalsSuffix+pagination.encode()), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// CreateEvalRun starts a run of an eval.
+func (c *Client) CreateEvalRun(
+	ctx context.Context,
+	evalID string,
+	request EvalRunRequest,
+	opts ...RequestOption,
+) (response EvalRun, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/runs", evalsSuffix, evalID)
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// RetrieveEvalRun retrieves an eval run.
+func (c *Client) RetrieveEvalRun(
+	ctx context.Context,
+	evalID, runID string,
+	opts ...RequestOption,
+) (response EvalRun, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/runs/%s", evalsSuffix, evalID, runID)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// CancelEvalRun cancels an ongoing eval run.
+func (c *Client) CancelEvalRun(
+	ctx context.Context,
+	evalID, runID string,
+	opts ...RequestOption,
+) (response EvalRun, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/runs/%s", evalsSuffix, evalID, runID)
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// DeleteEvalRun deletes an eval run.
+func (c *Client) DeleteEvalRun(
+	ctx context.Context,
+	evalID, runID string,
+	opts ...RequestOption,
+) (response EvalRunDeleteResponse, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/runs/%s", evalsSuffix, evalID, runID)
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ListEvalRuns lists the runs of an eval.
+func (c *Client) ListEvalRuns(
+	ctx context.Context,
+	evalID string,
+	pagination Pagination,
+	opts ...RequestOption,
+) (response EvalRunsList, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/runs%s", evalsSuffix, evalID, pagination.encode())
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// RetrieveEvalRunOutputItem retrieves a single output item of an eval run.
+func (c *Client) RetrieveEvalRunOutputItem(
+	ctx context.Context,
+	evalID, runID, outputItemID string,
+	opts ...RequestOption,
+) (response EvalOutputItem, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/runs/%s/output_items/%s", evalsSuffix, evalID, runID, outputItemID)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ListEvalRunOutputItems lists the output items of an eval run.
+// An empty status returns items regardless of whether they passed.
+func (c *Client) ListEvalRunOutputItems(
+	ctx context.Context,
+	evalID, runID string,
+	status EvalOutputItemStatus,
+	pagination Pagination,
+	opts ...RequestOption,
+) (response EvalOutputItemsList, err error) {
+	encodedValues := pagination.encode()
+	if status != "" {
+		statusValue := url.Values{"status": {string(status)}}.Encode()
+		if encodedValues == "" {
+			encodedValues = "?" + statusValue
+		} else {
+			encodedValues += "&" + statusValue
+		}
+	}
+
+	urlSuffix := fmt.Sprintf("%s/%s/runs/%s/output_items%s", evalsSuffix, evalID, runID, encodedValues)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
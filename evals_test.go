@@ -0,0 +1,261 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// TestEvals Tests the evals endpoints of the API using the mocked server.
+func TestEvals(t *testing.T) {
+	evalID := "eval_abc123"
+	runID := "evalrun_abc456"
+	outputItemID := "outputitem_abc789"
+	limit := 5
+
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler(
+		"/v1/evals/"+evalID+"/runs/"+runID+"/output_items/"+outputItemID,
+		func(w http.ResponseWriter, _ *http.Request) {
+			resBytes, _ := json.Marshal(openai.EvalOutputItem{
+				ID:     outputItemID,
+				Object: "eval.run.output_item",
+				EvalID: evalID,
+				RunID:  runID,
+				Status: openai.EvalOutputItemStatusFail,
+				Results: []openai.EvalOutputItemResult{
+					{Name: "String check", Score: 0, Passed: false},
+				},
+			})
+			fmt.Fprintln(w, string(resBytes))
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/evals/"+evalID+"/runs/"+runID+"/output_items",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("status") != "fail" || r.URL.Query().Get("limit") != "5" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			resBytes, _ := json.Marshal(openai.EvalOutputItemsList{
+				Object: "list",
+				Items:  []openai.EvalOutputItem{{ID: outputItemID, Status: openai.EvalOutputItemStatusFail}},
+			})
+			fmt.Fprintln(w, string(resBytes))
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/evals/"+evalID+"/runs/"+runID,
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				resBytes, _ := json.Marshal(openai.EvalRun{
+					ID:     runID,
+					Object: "eval.run",
+					EvalID: evalID,
+					Status: openai.EvalRunStatusCompleted,
+					ResultCounts: openai.EvalRunResultCounts{
+						Total:  2,
+						Passed: 1,
+						Failed: 1,
+					},
+				})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodPost:
+				resBytes, _ := json.Marshal(openai.EvalRun{
+					ID:     runID,
+					Object: "eval.run",
+					EvalID: evalID,
+					Status: openai.EvalRunStatusCanceled,
+				})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodDelete:
+				fmt.Fprintln(w, `{"object": "eval.run.deleted", "deleted": true, "run_id": "evalrun_abc456"}`)
+			}
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/evals/"+evalID+"/runs",
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				var request openai.EvalRunRequest
+				err := json.NewDecoder(r.Body).Decode(&request)
+				checks.NoError(t, err, "Decode error")
+				if request.DataSource.Source.Type != openai.EvalRunSourceTypeFileContent {
+					t.Errorf("unexpected source type: %s", request.DataSource.Source.Type)
+				}
+
+				resBytes, _ := json.Marshal(openai.EvalRun{
+					ID:         runID,
+					Object:     "eval.run",
+					EvalID:     evalID,
+					Name:       request.Name,
+					Status:     openai.EvalRunStatusQueued,
+					DataSource: request.DataSource,
+				})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodGet:
+				resBytes, _ := json.Marshal(openai.EvalRunsList{
+					Object: "list",
+					Runs:   []openai.EvalRun{{ID: runID, EvalID: evalID}},
+				})
+				fmt.Fprintln(w, string(resBytes))
+			}
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/evals/"+evalID,
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				resBytes, _ := json.Marshal(openai.Eval{ID: evalID, Object: "eval", Name: "Sentiment"})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodPost:
+				var request openai.EvalModifyRequest
+				err := json.NewDecoder(r.Body).Decode(&request)
+				checks.NoError(t, err, "Decode error")
+
+				resBytes, _ := json.Marshal(openai.Eval{ID: evalID, Object: "eval", Name: request.Name})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodDelete:
+				fmt.Fprintln(w, `{"object": "eval.deleted", "deleted": true, "eval_id": "eval_abc123"}`)
+			}
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/evals",
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				var request openai.EvalRequest
+				err := json.NewDecoder(r.Body).Decode(&request)
+				checks.NoError(t, err, "Decode error")
+
+				resBytes, _ := json.Marshal(openai.Eval{
+					ID:               evalID,
+					Object:           "eval",
+					Name:             request.Name,
+					DataSourceConfig: request.DataSourceConfig,
+					TestingCriteria:  request.TestingCriteria,
+				})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodGet:
+				resBytes, _ := json.Marshal(openai.EvalsList{
+					Object: "list",
+					Evals:  []openai.Eval{{ID: evalID, Object: "eval"}},
+				})
+				fmt.Fprintln(w, string(resBytes))
+			}
+		},
+	)
+
+	ctx := context.Background()
+
+	eval, err := client.CreateEval(ctx, openai.EvalRequest{
+		Name: "Sentiment",
+		DataSourceConfig: openai.EvalDataSourceConfig{
+			Type: openai.EvalDataSourceConfigTypeCustom,
+			ItemSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {"input": {"type": "string"}, "ground_truth": {"type": "string"}},
+				"required": ["input", "ground_truth"]
+			}`),
+			IncludeSampleSchema: true,
+		},
+		TestingCriteria: []openai.EvalTestingCriterion{
+			{
+				Type:      openai.EvalTestingCriterionTypeStringCheck,
+				Name:      "Match output to ground truth",
+				Input:     "{{sample.output_text}}",
+				Reference: "{{item.ground_truth}}",
+				Operation: openai.EvalStringCheckOperationEq,
+			},
+		},
+	})
+	checks.NoError(t, err, "CreateEval error")
+	if len(eval.TestingCriteria) != 1 || eval.TestingCriteria[0].Operation != openai.EvalStringCheckOperationEq {
+		t.Errorf("unexpected testing criteria: %+v", eval.TestingCriteria)
+	}
+
+	_, err = client.RetrieveEval(ctx, evalID)
+	checks.NoError(t, err, "RetrieveEval error")
+
+	eval, err = client.ModifyEval(ctx, evalID, openai.EvalModifyRequest{Name: "Renamed"})
+	checks.NoError(t, err, "ModifyEval error")
+	if eval.Name != "Renamed" {
+		t.Errorf("unexpected eval name: %s", eval.Name)
+	}
+
+	_, err = client.ListEvals(ctx, openai.Pagination{Limit: &limit})
+	checks.NoError(t, err, "ListEvals error")
+
+	run, err := client.CreateEvalRun(ctx, evalID, openai.EvalRunRequest{
+		Name: "nightly",
+		DataSource: openai.EvalRunDataSource{
+			Type:  openai.EvalRunDataSourceTypeCompletions,
+			Model: openai.GPT4o,
+			Source: openai.EvalRunSource{
+				Type: openai.EvalRunSourceTypeFileContent,
+				Content: []openai.EvalRunSourceContent{
+					{Item: map[string]any{"input": "I love it", "ground_truth": "positive"}},
+				},
+			},
+			InputMessages: &openai.EvalRunInputMessages{
+				Type: "template",
+				Template: []openai.EvalMessage{
+					{Role: openai.ChatMessageRoleUser, Content: "{{item.input}}"},
+				},
+			},
+		},
+	})
+	checks.NoError(t, err, "CreateEvalRun error")
+	if run.Status != openai.EvalRunStatusQueued {
+		t.Errorf("unexpected run status: %s", run.Status)
+	}
+
+	run, err = client.RetrieveEvalRun(ctx, evalID, runID)
+	checks.NoError(t, err, "RetrieveEvalRun error")
+	if run.ResultCounts.Failed != 1 {
+		t.Errorf("unexpected result counts: %+v", run.ResultCounts)
+	}
+
+	_, err = client.ListEvalRuns(ctx, evalID, openai.Pagination{})
+	checks.NoError(t, err, "ListEvalRuns error")
+
+	run, err = client.CancelEvalRun(ctx, evalID, runID)
+	checks.NoError(t, err, "CancelEvalRun error")
+	if run.Status != openai.EvalRunStatusCanceled {
+		t.Errorf("unexpected run status: %s", run.Status)
+	}
+
+	items, err := client.ListEvalRunOutputItems(ctx, evalID, runID, openai.EvalOutputItemStatusFail,
+		openai.Pagination{Limit: &limit})
+	checks.NoError(t, err, "ListEvalRunOutputItems error")
+	if len(items.Items) != 1 {
+		t.Errorf("unexpected number of output items: %d", len(items.Items))
+	}
+
+	item, err := client.RetrieveEvalRunOutputItem(ctx, evalID, runID, outputItemID)
+	checks.NoError(t, err, "RetrieveEvalRunOutputItem error")
+	if len(item.Results) != 1 || item.Results[0].Passed {
+		t.Errorf("unexpected output item results: %+v", item.Results)
+	}
+
+	_, err = client.DeleteEvalRun(ctx, evalID, runID)
+	checks.NoError(t, err, "DeleteEvalRun error")
+
+	_, err = client.DeleteEval(ctx, evalID)
+	checks.NoError(t, err, "DeleteEval error")
+}
@@ -12,6 +12,7 @@ import (
 	"os"
 
 	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/tokenizer"
 )
 
 func Example() {
@@ -345,3 +346,39 @@ func ExampleAPIError() {
 		}
 	}
 }
+
+// exampleEncoder is a toy vocabulary standing in for a tiktoken Encoder.
+type exampleEncoder map[string]int
+
+func (e exampleEncoder) Count(text string) int { return len(e.Encode(text)) }
+
+func (e exampleEncoder) Encode(text string) []int {
+	if id, ok := e[text]; ok {
+		return []int{id}
+	}
+	return nil
+}
+
+func ExampleBiasAgainstWordsWithRegisteredEncoder() {
+	// The tokenizer package has no vocabulary of its own: register an Encoder,
+	// e.g. a tiktoken implementation, for the encoding of the model first.
+	tokenizer.Register("example_vocab", exampleEncoder{"sorry": 41021, " sorry": 14936})
+	tokenizer.RegisterModel("example-model", "example_vocab")
+	defer tokenizer.Unregister("example_vocab")
+	defer tokenizer.UnregisterModel("example-model")
+
+	bias, err := openai.BiasAgainstWordsWithRegisteredEncoder("example-model", []string{"sorry"}, 100)
+	if err != nil {
+		fmt.Printf("BiasAgainstWordsWithRegisteredEncoder error: %v\n", err)
+		return
+	}
+	fmt.Println(bias)
+
+	// Without an Encoder registered for o200k_base, the words of gpt-4o
+	// cannot be turned into tokens.
+	_, err = openai.BiasAgainstWordsWithRegisteredEncoder(openai.GPT4o, []string{"sorry"}, 100)
+	fmt.Println(errors.Is(err, tokenizer.ErrNoEncoder))
+	// Output:
+	// map[14936:-100 41021:-100]
+	// true
+}
@@ -0,0 +1,49 @@
+package openai
+
+import "encoding/json"
+
+// withClearedFields returns extra with the JSON fields in clear set to null.
+func withClearedFields(extra map[string]any, clear []string) map[string]any {
+	if len(clear) == 0 {
+		return extra
+	}
+	merged := make(map[string]any, len(extra)+len(clear))
+	for key, value := range extra {
+		merged[key] = value
+	}
+	for _, field := range clear {
+		merged[field] = nil
+	}
+	return merged
+}
+
+// extraBodyRequest is implemented by the requests whose JSON is merged with
+// extra fields. splitExtraBody returns the request as a type without the
+// MarshalJSON method, and the extra fields.
+type extraBodyRequest interface {
+	splitExtraBody() (body any, extra map[string]any)
+}
+
+// marshalWithExtraBody marshals r with codec and merges the extra fields into
+// the resulting JSON object. Extra fields take precedence over the modeled
+// ones, so they can also be used to override a field's serialization.
+func marshalWithExtraBody(codec JSONCodec, r extraBodyRequest) ([]byte, error) {
+	v, extra := r.splitExtraBody()
+	data, err := codec.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err = codec.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		raw, marshalErr := codec.Marshal(value)
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+		fields[key] = raw
+	}
+	return codec.Marshal(fields)
+}
@@ -22,6 +22,8 @@ const (
 	PurposeFineTuneResults  PurposeType = "fine-tune-results"
 	PurposeAssistants       PurposeType = "assistants"
 	PurposeAssistantsOutput PurposeType = "assistants_output"
+	PurposeBatch            PurposeType = "batch"
+	PurposeUserData         PurposeType = "user_data"
 )
 
 // FileBytesRequest represents a file upload request.
@@ -50,13 +52,25 @@ type File struct {
 
 // FilesList is a list of files that belong to the user or organization.
 type FilesList struct {
-	Files []File `json:"data"`
+	Files   []File  `json:"data"`
+	FirstID *string `json:"first_id"`
+	LastID  *string `json:"last_id"`
+	HasMore bool    `json:"has_more"`
 
 	httpHeader
 }
 
+// WithFilePurpose makes ListFiles return only the files with purpose.
+func WithFilePurpose(purpose PurposeType) ListOption {
+	return withQuerySet("purpose", string(purpose))
+}
+
 // CreateFileBytes uploads bytes directly to OpenAI without requiring a local file.
-func (c *Client) CreateFileBytes(ctx context.Context, request FileBytesRequest) (file File, err error) {
+func (c *Client) CreateFileBytes(
+	ctx context.Context,
+	request FileBytesRequest,
+	opts ...RequestOption,
+) (file File, err error) {
 	var b bytes.Buffer
 	reader := bytes.NewReader(request.Bytes)
 	builder := c.createFormBuilder(&b)
@@ -77,7 +91,7 @@ func (c *Client) CreateFileBytes(ctx context.Context, request FileBytesRequest)
 	}
 
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/files"),
-		withBody(&b), withContentType(builder.FormDataContentType()))
+		withBody(&b), withContentType(builder.FormDataContentType()), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -87,44 +101,37 @@ func (c *Client) CreateFileBytes(ctx context.Context, request FileBytesRequest)
 }
 
 // CreateFile uploads a jsonl file to GPT3
-// FilePath must be a local file path.
-func (c *Client) CreateFile(ctx context.Context, request FileRequest) (file File, err error) {
-	var b bytes.Buffer
-	builder := c.createFormBuilder(&b)
-
-	err = builder.WriteField("purpose", request.Purpose)
-	if err != nil {
-		return
-	}
-
+// FilePath must be a local file path. The file is streamed to the API rather
+// than read into memory, so it may be larger than the available memory.
+func (c *Client) CreateFile(ctx context.Context, request FileRequest, opts ...RequestOption) (file File, err error) {
 	fileData, err := os.Open(request.FilePath)
 	if err != nil {
 		return
 	}
+	defer fileData.Close()
 
-	err = builder.CreateFormFile("file", fileData)
-	if err != nil {
-		return
+	size := int64(-1)
+	if info, statErr := fileData.Stat(); statErr == nil && info.Mode().IsRegular() {
+		size = info.Size()
 	}
-
-	err = builder.Close()
-	if err != nil {
-		return
+	form := &streamedForm{
+		fields:    []formField{{name: "purpose", value: request.Purpose}},
+		fileField: "file",
+		file:      fileData,
+		fileSize:  size,
 	}
-
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/files"),
-		withBody(&b), withContentType(builder.FormDataContentType()))
+	req, err := c.newFormRequest(ctx, c.fullURL("/files"), form, opts)
 	if err != nil {
 		return
 	}
 
-	err = c.sendRequest(req, &file)
+	err = form.wait(c.sendRequest(req, &file))
 	return
 }
 
 // DeleteFile deletes an existing file.
-func (c *Client) DeleteFile(ctx context.Context, fileID string) (err error) {
-	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL("/files/"+fileID))
+func (c *Client) DeleteFile(ctx context.Context, fileID string, opts ...RequestOption) (err error) {
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL("/files/"+fileID), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -135,8 +142,10 @@ func (c *Client) DeleteFile(ctx context.Context, fileID string) (err error) {
 
 // ListFiles Lists the currently available files,
 // and provides basic information about each file such as the file name and purpose.
-func (c *Client) ListFiles(ctx context.Context) (files FilesList, err error) {
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL("/files"))
+// Large file lists are paged with WithLimit and WithAfter, passing LastID while
+// HasMore is set, and filtered with WithFilePurpose.
+func (c *Client) ListFiles(ctx context.Context, opts ...RequestOption) (files FilesList, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL("/files"), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -147,9 +156,9 @@ func (c *Client) ListFiles(ctx context.Context) (files FilesList, err error) {
 
 // GetFile Retrieves a file instance, providing basic information about the file
 // such as the file name and purpose.
-func (c *Client) GetFile(ctx context.Context, fileID string) (file File, err error) {
+func (c *Client) GetFile(ctx context.Context, fileID string, opts ...RequestOption) (file File, err error) {
 	urlSuffix := fmt.Sprintf("/files/%s", fileID)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -158,9 +167,13 @@ func (c *Client) GetFile(ctx context.Context, fileID string) (file File, err err
 	return
 }
 
-func (c *Client) GetFileContent(ctx context.Context, fileID string) (content RawResponse, err error) {
+func (c *Client) GetFileContent(
+	ctx context.Context,
+	fileID string,
+	opts ...RequestOption,
+) (content RawResponse, err error) {
 	urlSuffix := fmt.Sprintf("/files/%s/content", fileID)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -8,7 +8,9 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -42,6 +44,44 @@ func TestFileUpload(t *testing.T) {
 	checks.NoError(t, err, "CreateFile error")
 }
 
+func TestFileUploadStreamsWithContentLength(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	content := strings.Repeat("{\"prompt\": \"a\", \"completion\": \"b\"}\n", 1000)
+	path := filepath.Join(t.TempDir(), "train.jsonl")
+	checks.NoError(t, os.WriteFile(path, []byte(content), 0o600), "WriteFile error")
+
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength <= int64(len(content)) || len(r.TransferEncoding) > 0 {
+			http.Error(w, "missing content length", http.StatusBadRequest)
+			return
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		data, err := io.ReadAll(file)
+		if err != nil || string(data) != content || r.FormValue("purpose") != "fine-tune" {
+			http.Error(w, "unexpected form", http.StatusBadRequest)
+			return
+		}
+		resBytes, _ := json.Marshal(openai.File{ID: "file-abc", Bytes: len(data)})
+		fmt.Fprint(w, string(resBytes))
+	})
+
+	file, err := client.CreateFile(context.Background(), openai.FileRequest{
+		FilePath: path,
+		Purpose:  "fine-tune",
+	})
+	checks.NoError(t, err, "CreateFile error")
+	if file.Bytes != len(content) {
+		t.Fatalf("expected %d bytes, got %d", len(content), file.Bytes)
+	}
+}
+
 // handleCreateFile Handles the images endpoint by the test server.
 func handleCreateFile(w http.ResponseWriter, r *http.Request) {
 	var err error
@@ -102,6 +142,46 @@ func TestListFile(t *testing.T) {
 	checks.NoError(t, err, "ListFiles error")
 }
 
+func TestListFilePages(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("purpose") != "batch" || query.Get("limit") != "1" || query.Get("order") != "asc" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		if query.Get("after") == "" {
+			fmt.Fprint(w, `{"data":[{"id":"file-1"}],"first_id":"file-1","last_id":"file-1","has_more":true}`)
+			return
+		}
+		if query.Get("after") != "file-1" {
+			t.Errorf("unexpected cursor %s", query.Get("after"))
+		}
+		fmt.Fprint(w, `{"data":[{"id":"file-2"}],"first_id":"file-2","last_id":"file-2","has_more":false}`)
+	})
+
+	var ids []string
+	opts := []openai.RequestOption{
+		openai.WithFilePurpose("batch"),
+		openai.WithLimit(1),
+		openai.WithOrder(openai.SortOrderAsc),
+	}
+	for {
+		page, err := client.ListFiles(context.Background(), opts...)
+		checks.NoError(t, err, "ListFiles error")
+		for _, file := range page.Files {
+			ids = append(ids, file.ID)
+		}
+		if !page.HasMore {
+			break
+		}
+		opts = append(opts, openai.WithAfter(*page.LastID))
+	}
+	if strings.Join(ids, ",") != "file-1,file-2" {
+		t.Errorf("unexpected files %v", ids)
+	}
+}
+
 func TestGetFile(t *testing.T) {
 	client, server, teardown := setupOpenAITestServer()
 	defer teardown()
@@ -99,9 +99,13 @@ type FineTuneDeleteResponse struct {
 // Deprecated: On August 22nd, 2023, OpenAI announced the deprecation of the /v1/fine-tunes API.
 // This API will be officially deprecated on January 4th, 2024.
 // OpenAI recommends to migrate to the new fine tuning API implemented in fine_tuning_job.go.
-func (c *Client) CreateFineTune(ctx context.Context, request FineTuneRequest) (response FineTune, err error) {
+func (c *Client) CreateFineTune(
+	ctx context.Context,
+	request FineTuneRequest,
+	opts ...RequestOption,
+) (response FineTune, err error) {
 	urlSuffix := "/fine-tunes"
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request))
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -114,8 +118,13 @@ func (c *Client) CreateFineTune(ctx context.Context, request FineTuneRequest) (r
 // Deprecated: On August 22nd, 2023, OpenAI announced the deprecation of the /v1/fine-tunes API.
 // This API will be officially deprecated on January 4th, 2024.
 // OpenAI recommends to migrate to the new fine tuning API implemented in fine_tuning_job.go.
-func (c *Client) CancelFineTune(ctx context.Context, fineTuneID string) (response FineTune, err error) {
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/fine-tunes/"+fineTuneID+"/cancel"))
+func (c *Client) CancelFineTune(
+	ctx context.Context,
+	fineTuneID string,
+	opts ...RequestOption,
+) (response FineTune, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/fine-tunes/"+fineTuneID+"/cancel"),
+		withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -127,8 +136,8 @@ func (c *Client) CancelFineTune(ctx context.Context, fineTuneID string) (respons
 // Deprecated: On August 22nd, 2023, OpenAI announced the deprecation of the /v1/fine-tunes API.
 // This API will be officially deprecated on January 4th, 2024.
 // OpenAI recommends to migrate to the new fine tuning API implemented in fine_tuning_job.go.
-func (c *Client) ListFineTunes(ctx context.Context) (response FineTuneList, err error) {
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL("/fine-tunes"))
+func (c *Client) ListFineTunes(ctx context.Context, opts ...RequestOption) (response FineTuneList, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL("/fine-tunes"), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -140,9 +149,13 @@ func (c *Client) ListFineTunes(ctx context.Context) (response FineTuneList, err
 // Deprecated: On August 22nd, 2023, OpenAI announced the deprecation of the /v1/fine-tunes API.
 // This API will be officially deprecated on January 4th, 2024.
 // OpenAI recommends to migrate to the new fine tuning API implemented in fine_tuning_job.go.
-func (c *Client) GetFineTune(ctx context.Context, fineTuneID string) (response FineTune, err error) {
+func (c *Client) GetFineTune(
+	ctx context.Context,
+	fineTuneID string,
+	opts ...RequestOption,
+) (response FineTune, err error) {
 	urlSuffix := fmt.Sprintf("/fine-tunes/%s", fineTuneID)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -154,8 +167,12 @@ func (c *Client) GetFineTune(ctx context.Context, fineTuneID string) (response F
 // Deprecated: On August 22nd, 2023, OpenAI announced the deprecation of the /v1/fine-tunes API.
 // This API will be officially deprecated on January 4th, 2024.
 // OpenAI recommends to migrate to the new fine tuning API implemented in fine_tuning_job.go.
-func (c *Client) DeleteFineTune(ctx context.Context, fineTuneID string) (response FineTuneDeleteResponse, err error) {
-	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL("/fine-tunes/"+fineTuneID))
+func (c *Client) DeleteFineTune(
+	ctx context.Context,
+	fineTuneID string,
+	opts ...RequestOption,
+) (response FineTuneDeleteResponse, err error) {
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL("/fine-tunes/"+fineTuneID), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -167,8 +184,12 @@ func (c *Client) DeleteFineTune(ctx context.Context, fineTuneID string) (respons
 // Deprecated: On August 22nd, 2023, OpenAI announced the deprecation of the /v1/fine-tunes API.
 // This API will be officially deprecated on January 4th, 2024.
 // OpenAI recommends to migrate to the new fine tuning API implemented in fine_tuning_job.go.
-func (c *Client) ListFineTuneEvents(ctx context.Context, fineTuneID string) (response FineTuneEventList, err error) {
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL("/fine-tunes/"+fineTuneID+"/events"))
+func (c *Client) ListFineTuneEvents(
+	ctx context.Context,
+	fineTuneID string,
+	opts ...RequestOption,
+) (response FineTuneEventList, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL("/fine-tunes/"+fineTuneID+"/events"), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
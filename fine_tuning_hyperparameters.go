@@ -0,0 +1,53 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+const hyperparameterAuto = "auto"
+
+// AutoOr is a fine-tuning hyperparameter that is either "auto", which lets
+// the API choose the value, or a fixed value.
+type AutoOr[T int | float64] struct {
+	Auto  bool
+	Value T
+}
+
+// AutoValue returns a hyperparameter chosen by the API.
+func AutoValue[T int | float64]() *AutoOr[T] {
+	return &AutoOr[T]{Auto: true}
+}
+
+// FixedValue returns a hyperparameter set to value.
+func FixedValue[T int | float64](value T) *AutoOr[T] {
+	return &AutoOr[T]{Value: value}
+}
+
+func (v AutoOr[T]) MarshalJSON() ([]byte, error) {
+	if v.Auto {
+		return json.Marshal(hyperparameterAuto)
+	}
+	return json.Marshal(v.Value)
+}
+
+func (v *AutoOr[T]) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if s != hyperparameterAuto {
+			return fmt.Errorf("invalid hyperparameter value %q", s)
+		}
+		*v = AutoOr[T]{Auto: true}
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*v = AutoOr[T]{Value: value}
+	return nil
+}
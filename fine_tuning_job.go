@@ -21,12 +21,19 @@ type FineTuningJob struct {
 	ValidationFile  string          `json:"validation_file,omitempty"`
 	ResultFiles     []string        `json:"result_files"`
 	TrainedTokens   int             `json:"trained_tokens"`
+	// Integrations are the integrations enabled for the job.
+	Integrations []FineTuningIntegration `json:"integrations,omitempty"`
 
 	httpHeader
 }
 
 type Hyperparameters struct {
-	Epochs any `json:"n_epochs,omitempty"`
+	// Epochs is the number of passes over the training dataset.
+	Epochs *AutoOr[int] `json:"n_epochs,omitempty"`
+	// BatchSize is the number of examples in each training batch.
+	BatchSize *AutoOr[int] `json:"batch_size,omitempty"`
+	// LearningRateMultiplier scales the learning rate of the base model.
+	LearningRateMultiplier *AutoOr[float64] `json:"learning_rate_multiplier,omitempty"`
 }
 
 type FineTuningJobRequest struct {
@@ -35,6 +42,45 @@ type FineTuningJobRequest struct {
 	Model           string           `json:"model,omitempty"`
 	Hyperparameters *Hyperparameters `json:"hyperparameters,omitempty"`
 	Suffix          string           `json:"suffix,omitempty"`
+	// Integrations report the training run to other services.
+	Integrations []FineTuningIntegration `json:"integrations,omitempty"`
+}
+
+// FineTuningIntegrationType is the service of a fine-tuning integration.
+type FineTuningIntegrationType string
+
+const (
+	FineTuningIntegrationTypeWandb FineTuningIntegrationType = "wandb"
+)
+
+// FineTuningIntegration reports a fine-tuning job to another service.
+type FineTuningIntegration struct {
+	Type  FineTuningIntegrationType `json:"type"`
+	Wandb *WandbIntegration         `json:"wandb,omitempty"`
+}
+
+// WandbIntegration logs the metrics of a fine-tuning job to a Weights and
+// Biases run.
+type WandbIntegration struct {
+	// Project is the Weights and Biases project of the run.
+	Project string `json:"project"`
+	// Name is the display name of the run, the job ID by default.
+	Name string `json:"name,omitempty"`
+	// Entity is the team or user of the run, the default entity of the API
+	// key registered with OpenAI by default.
+	Entity string `json:"entity,omitempty"`
+	// Tags are attached to the run, in addition to the default
+	// "openai/finetune", "openai/{base-model}" and "openai/{ftjob-id}".
+	Tags []string `json:"tags,omitempty"`
+}
+
+// NewWandbIntegration returns an integration that logs a fine-tuning job to
+// a run in project.
+func NewWandbIntegration(project string) FineTuningIntegration {
+	return FineTuningIntegration{
+		Type:  FineTuningIntegrationTypeWandb,
+		Wandb: &WandbIntegration{Project: project},
+	}
 }
 
 type FineTuningJobEventList struct {
@@ -59,9 +105,10 @@ type FineTuningJobEvent struct {
 func (c *Client) CreateFineTuningJob(
 	ctx context.Context,
 	request FineTuningJobRequest,
+	opts ...RequestOption,
 ) (response FineTuningJob, err error) {
 	urlSuffix := "/fine_tuning/jobs"
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request))
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -71,8 +118,13 @@ func (c *Client) CreateFineTuningJob(
 }
 
 // CancelFineTuningJob cancel a fine tuning job.
-func (c *Client) CancelFineTuningJob(ctx context.Context, fineTuningJobID string) (response FineTuningJob, err error) {
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/fine_tuning/jobs/"+fineTuningJobID+"/cancel"))
+func (c *Client) CancelFineTuningJob(
+	ctx context.Context,
+	fineTuningJobID string,
+	opts ...RequestOption,
+) (response FineTuningJob, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/fine_tuning/jobs/"+fineTuningJobID+"/cancel"),
+		withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -85,9 +137,10 @@ func (c *Client) CancelFineTuningJob(ctx context.Context, fineTuningJobID string
 func (c *Client) RetrieveFineTuningJob(
 	ctx context.Context,
 	fineTuningJobID string,
+	opts ...RequestOption,
 ) (response FineTuningJob, err error) {
 	urlSuffix := fmt.Sprintf("/fine_tuning/jobs/%s", fineTuningJobID)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -99,6 +152,8 @@ func (c *Client) RetrieveFineTuningJob(
 type listFineTuningJobEventsParameters struct {
 	after *string
 	limit *int
+
+	requestOptions []RequestOption
 }
 
 type ListFineTuningJobEventsParameter func(*listFineTuningJobEventsParameters)
@@ -115,6 +170,14 @@ func ListFineTuningJobEventsWithLimit(limit int) ListFineTuningJobEventsParamete
 	}
 }
 
+// ListFineTuningJobEventsWithRequestOptions passes RequestOptions to the call,
+// since ListFineTuningJobEvents already takes variadic parameters.
+func ListFineTuningJobEventsWithRequestOptions(opts ...RequestOption) ListFineTuningJobEventsParameter {
+	return func(args *listFineTuningJobEventsParameters) {
+		args.requestOptions = append(args.requestOptions, opts...)
+	}
+}
+
 // ListFineTuningJobs list fine tuning jobs events.
 func (c *Client) ListFineTuningJobEvents(
 	ctx context.Context,
@@ -147,6 +210,7 @@ func (c *Client) ListFineTuningJobEvents(
 		ctx,
 		http.MethodGet,
 		c.fullURL("/fine_tuning/jobs/"+fineTuningJobID+"/events"+encodedValues),
+		withRequestOptions(parameters.requestOptions),
 	)
 	if err != nil {
 		return
@@ -33,7 +33,7 @@ func TestFineTuningJob(t *testing.T) {
 				ValidationFile: "",
 				TrainingFile:   "file-abc123",
 				Hyperparameters: openai.Hyperparameters{
-					Epochs: "auto",
+					Epochs: openai.AutoValue[int](),
 				},
 				TrainedTokens: 5768,
 			})
@@ -102,3 +102,48 @@ func TestFineTuningJob(t *testing.T) {
 	)
 	checks.NoError(t, err, "ListFineTuningJobEvents error")
 }
+
+func TestHyperparametersJSON(t *testing.T) {
+	data, err := json.Marshal(openai.Hyperparameters{
+		Epochs:                 openai.FixedValue(3),
+		BatchSize:              openai.AutoValue[int](),
+		LearningRateMultiplier: openai.FixedValue(0.5),
+	})
+	checks.NoError(t, err, "Marshal error")
+	const expected = `{"n_epochs":3,"batch_size":"auto","learning_rate_multiplier":0.5}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	data, err = json.Marshal(openai.Hyperparameters{})
+	checks.NoError(t, err, "Marshal error")
+	if string(data) != "{}" {
+		t.Errorf("expected unset hyperparameters to be omitted, got %s", data)
+	}
+
+	var hyperparameters openai.Hyperparameters
+	err = json.Unmarshal([]byte(`{"n_epochs":"auto","batch_size":8,"learning_rate_multiplier":1.8}`), &hyperparameters)
+	checks.NoError(t, err, "Unmarshal error")
+	if !hyperparameters.Epochs.Auto || hyperparameters.BatchSize.Value != 8 ||
+		hyperparameters.LearningRateMultiplier.Value != 1.8 {
+		t.Errorf("unexpected hyperparameters %+v", hyperparameters)
+	}
+
+	err = json.Unmarshal([]byte(`{"n_epochs":"many"}`), &hyperparameters)
+	checks.HasError(t, err, "invalid values should fail")
+}
+
+func TestFineTuningJobIntegrations(t *testing.T) {
+	wandb := openai.NewWandbIntegration("my-project")
+	wandb.Wandb.Tags = []string{"nightly"}
+	data, err := json.Marshal(openai.FineTuningJobRequest{
+		TrainingFile: "file-abc123",
+		Integrations: []openai.FineTuningIntegration{wandb},
+	})
+	checks.NoError(t, err, "Marshal error")
+	const expected = `{"training_file":"file-abc123",` +
+		`"integrations":[{"type":"wandb","wandb":{"project":"my-project","tags":["nightly"]}}]}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+}
@@ -0,0 +1,139 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+
+	utils "github.com/sashabaranov/go-openai/internal"
+)
+
+// formField is a plain field of a streamed multipart form.
+type formField struct {
+	name  string
+	value string
+}
+
+// streamedForm is a multipart form with a single file part that is written
+// into the request body while the request is sent, so that large files are
+// uploaded without being held in memory.
+type streamedForm struct {
+	fields    []formField
+	fileField string
+	// file is the content of the file part. An *os.File is added with
+	// CreateFormFile and any other reader with CreateFormFileReader.
+	file     io.Reader
+	fileName string
+	// fileSize is the number of bytes in file, or -1 when it is not known.
+	fileSize int64
+
+	reader *io.PipeReader
+	done   chan struct{}
+	err    error
+}
+
+// newFormRequest returns a POST request whose body streams form. The caller
+// must call form.wait once the request has been sent.
+func (c *Client) newFormRequest(
+	ctx context.Context,
+	url string,
+	form *streamedForm,
+	opts []RequestOption,
+) (*http.Request, error) {
+	pr, pw := io.Pipe()
+	builder := c.createFormBuilder(pw)
+	req, err := c.newRequest(ctx, http.MethodPost, url, withBody(pr),
+		withContentType(builder.FormDataContentType()), withRequestOptions(opts))
+	if err != nil {
+		pr.Close()
+		return nil, err
+	}
+	if size, ok := form.size(builder); ok {
+		req.ContentLength = size
+	}
+
+	form.reader = pr
+	form.done = make(chan struct{})
+	go func() {
+		defer close(form.done)
+		writeErr := form.write(builder)
+		if writeErr == nil {
+			writeErr = builder.Close()
+		}
+		form.err = writeErr
+		pw.CloseWithError(writeErr)
+	}()
+	return req, nil
+}
+
+func (f *streamedForm) write(builder utils.FormBuilder) error {
+	for _, field := range f.fields {
+		if err := builder.WriteField(field.name, field.value); err != nil {
+			return err
+		}
+	}
+	if file, ok := f.file.(*os.File); ok {
+		return builder.CreateFormFile(f.fileField, file)
+	}
+	return builder.CreateFormFileReader(f.fileField, f.file, f.fileName)
+}
+
+// size returns the length of the encoded form. It is only known when the file
+// size is and the form is written by the default builder, whose encoding is
+// reproduced here without the file content.
+func (f *streamedForm) size(builder utils.FormBuilder) (int64, bool) {
+	if _, ok := builder.(*utils.DefaultFormBuilder); !ok || f.fileSize < 0 {
+		return 0, false
+	}
+	_, params, err := mime.ParseMediaType(builder.FormDataContentType())
+	if err != nil {
+		return 0, false
+	}
+
+	var counter byteCounter
+	writer := multipart.NewWriter(&counter)
+	if err = writer.SetBoundary(params["boundary"]); err != nil {
+		return 0, false
+	}
+	for _, field := range f.fields {
+		if err = writer.WriteField(field.name, field.value); err != nil {
+			return 0, false
+		}
+	}
+	fileName := path.Base(f.fileName)
+	if file, ok := f.file.(*os.File); ok {
+		fileName = file.Name()
+	}
+	if _, err = writer.CreateFormFile(f.fileField, fileName); err != nil {
+		return 0, false
+	}
+	if err = writer.Close(); err != nil {
+		return 0, false
+	}
+	return int64(counter) + f.fileSize, true
+}
+
+// wait stops writing the form and waits for the writer to return. It returns
+// the error of the form builder if there was one, and err otherwise.
+func (f *streamedForm) wait(err error) error {
+	f.reader.Close()
+	<-f.done
+	// The pipe is closed when the transport gives up on the body or the
+	// response arrives before the whole body was read; err tells why.
+	if f.err != nil && !errors.Is(f.err, io.ErrClosedPipe) {
+		return f.err
+	}
+	return err
+}
+
+type byteCounter int64
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
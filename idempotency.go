@@ -0,0 +1,44 @@
+package openai
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+)
+
+// IdempotencyKeyHeader is the header with which servers and gateways that
+// support it recognize retries of the same mutating call.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// WithIdempotencyKey sets the idempotency key of a mutating call, such as
+// creating a file, batch or vector store. Retries of the call, by the client
+// or by the caller with the same key, then don't create duplicates when the
+// API or a gateway in front of it supports idempotency keys.
+func WithIdempotencyKey(key string) RequestOption {
+	return WithHeader(IdempotencyKeyHeader, key)
+}
+
+// NewIdempotencyKey returns a random idempotency key in UUID format.
+func NewIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("openai: reading random bytes: %v", err))
+	}
+	b[6] = b[6]&0x0f | 0x40 // Version 4.
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant.
+	h := hex.EncodeToString(b[:])
+	return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+// setIdempotencyKey gives mutating requests without an idempotency key a new
+// one when ClientConfig.AutoIdempotencyKeys is set. The key is set once per
+// call, so the retries of the call share it.
+func (c *Client) setIdempotencyKey(req *http.Request) {
+	if !c.config.AutoIdempotencyKeys || req.Header.Get(IdempotencyKeyHeader) != "" {
+		return
+	}
+	if req.Method == http.MethodPost || req.Method == http.MethodPatch {
+		req.Header.Set(IdempotencyKeyHeader, NewIdempotencyKey())
+	}
+}
@@ -0,0 +1,63 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestIdempotencyKeys(t *testing.T) {
+	var keys []string
+	attempts := 0
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		keys = append(keys, r.Header.Get(openai.IdempotencyKeyHeader))
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(openai.IdempotencyKeyHeader))
+		fmt.Fprint(w, `{"data":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.RetryPolicy = openai.RetryPolicy{MaxRetries: 1, MinBackoff: time.Millisecond}
+	config.AutoIdempotencyKeys = true
+	client := openai.NewClientWithConfig(config)
+	ctx := context.Background()
+
+	stream, err := client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{Model: openai.GPT4o})
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	stream.Close()
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if len(keys) != 2 || !uuid.MatchString(keys[0]) || keys[0] != keys[1] {
+		t.Fatalf("expected both attempts to share a generated key, got %q", keys)
+	}
+
+	keys = nil
+	stream, err = client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{Model: openai.GPT4o},
+		openai.WithIdempotencyKey("my-key"))
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	stream.Close()
+	_, err = client.ListModels(ctx)
+	checks.NoError(t, err, "ListModels error")
+	if len(keys) != 2 || keys[0] != "my-key" || keys[1] != "" {
+		t.Errorf("expected the given key and no key on GET, got %q", keys)
+	}
+}
@@ -16,6 +16,10 @@ const (
 	// dall-e-3 supported only.
 	CreateImageSize1792x1024 = "1792x1024"
 	CreateImageSize1024x1792 = "1024x1792"
+	// gpt-image-1 supported only.
+	CreateImageSize1536x1024 = "1536x1024"
+	CreateImageSize1024x1536 = "1024x1536"
+	CreateImageSizeAuto      = "auto"
 )
 
 const (
@@ -24,13 +28,19 @@ const (
 )
 
 const (
-	CreateImageModelDallE2 = "dall-e-2"
-	CreateImageModelDallE3 = "dall-e-3"
+	CreateImageModelDallE2    = "dall-e-2"
+	CreateImageModelDallE3    = "dall-e-3"
+	CreateImageModelGPTImage1 = "gpt-image-1"
 )
 
 const (
 	CreateImageQualityHD       = "hd"
 	CreateImageQualityStandard = "standard"
+	// gpt-image-1 supported only.
+	CreateImageQualityLow    = "low"
+	CreateImageQualityMedium = "medium"
+	CreateImageQualityHigh   = "high"
+	CreateImageQualityAuto   = "auto"
 )
 
 const (
@@ -38,6 +48,17 @@ const (
 	CreateImageStyleNatural = "natural"
 )
 
+// ImageModeration is the content moderation level of gpt-image-1 image
+// generation.
+type ImageModeration string
+
+const (
+	// ImageModerationAuto is the default filtering.
+	ImageModerationAuto ImageModeration = "auto"
+	// ImageModerationLow filters less restrictively.
+	ImageModerationLow ImageModeration = "low"
+)
+
 // ImageRequest represents the request structure for the image API.
 type ImageRequest struct {
 	Prompt         string `json:"prompt,omitempty"`
@@ -48,6 +69,9 @@ type ImageRequest struct {
 	Style          string `json:"style,omitempty"`
 	ResponseFormat string `json:"response_format,omitempty"`
 	User           string `json:"user,omitempty"`
+	// Moderation sets the moderation level of gpt-image-1. Other models
+	// don't accept it.
+	Moderation ImageModeration `json:"moderation,omitempty"`
 }
 
 // ImageResponse represents a response structure for image API.
@@ -66,9 +90,17 @@ type ImageResponseDataInner struct {
 }
 
 // CreateImage - API call to create an image. This is the main endpoint of the DALL-E API.
-func (c *Client) CreateImage(ctx context.Context, request ImageRequest) (response ImageResponse, err error) {
+func (c *Client) CreateImage(
+	ctx context.Context,
+	request ImageRequest,
+	opts ...RequestOption,
+) (response ImageResponse, err error) {
+	if err = validateImageRequest(request); err != nil {
+		return
+	}
 	urlSuffix := "/images/generations"
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix, request.Model), withBody(request))
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix, request.Model), withModel(request.Model),
+		withBody(request), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -77,6 +109,17 @@ func (c *Client) CreateImage(ctx context.Context, request ImageRequest) (respons
 	return
 }
 
+// ImageInputFidelity is how closely gpt-image-1 edits preserve the input
+// image.
+type ImageInputFidelity string
+
+const (
+	ImageInputFidelityLow ImageInputFidelity = "low"
+	// ImageInputFidelityHigh preserves details such as faces and logos more
+	// accurately, at the cost of more input tokens.
+	ImageInputFidelityHigh ImageInputFidelity = "high"
+)
+
 // ImageEditRequest represents the request structure for the image API.
 type ImageEditRequest struct {
 	Image          *os.File `json:"image,omitempty"`
@@ -86,10 +129,19 @@ type ImageEditRequest struct {
 	N              int      `json:"n,omitempty"`
 	Size           string   `json:"size,omitempty"`
 	ResponseFormat string   `json:"response_format,omitempty"`
+	// InputFidelity is only accepted by gpt-image-1.
+	InputFidelity ImageInputFidelity `json:"input_fidelity,omitempty"`
 }
 
 // CreateEditImage - API call to create an image. This is the main endpoint of the DALL-E API.
-func (c *Client) CreateEditImage(ctx context.Context, request ImageEditRequest) (response ImageResponse, err error) {
+func (c *Client) CreateEditImage(
+	ctx context.Context,
+	request ImageEditRequest,
+	opts ...RequestOption,
+) (response ImageResponse, err error) {
+	if err = validateImageEditRequest(request); err != nil {
+		return
+	}
 	body := &bytes.Buffer{}
 	builder := c.createFormBuilder(body)
 
@@ -127,13 +179,27 @@ func (c *Client) CreateEditImage(ctx context.Context, request ImageEditRequest)
 		return
 	}
 
+	if request.Model != "" {
+		err = builder.WriteField("model", request.Model)
+		if err != nil {
+			return
+		}
+	}
+
+	if request.InputFidelity != "" {
+		err = builder.WriteField("input_fidelity", string(request.InputFidelity))
+		if err != nil {
+			return
+		}
+	}
+
 	err = builder.Close()
 	if err != nil {
 		return
 	}
 
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/images/edits", request.Model),
-		withBody(body), withContentType(builder.FormDataContentType()))
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/images/edits", request.Model), withModel(request.Model),
+		withBody(body), withContentType(builder.FormDataContentType()), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -153,7 +219,14 @@ type ImageVariRequest struct {
 
 // CreateVariImage - API call to create an image variation. This is the main endpoint of the DALL-E API.
 // Use abbreviations(vari for variation) because ci-lint has a single-line length limit ...
-func (c *Client) CreateVariImage(ctx context.Context, request ImageVariRequest) (response ImageResponse, err error) {
+func (c *Client) CreateVariImage(
+	ctx context.Context,
+	request ImageVariRequest,
+	opts ...RequestOption,
+) (response ImageResponse, err error) {
+	if err = validateImageVariRequest(request); err != nil {
+		return
+	}
 	body := &bytes.Buffer{}
 	builder := c.createFormBuilder(body)
 
@@ -184,7 +257,7 @@ func (c *Client) CreateVariImage(ctx context.Context, request ImageVariRequest)
 	}
 
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/images/variations", request.Model),
-		withBody(body), withContentType(builder.FormDataContentType()))
+		withModel(request.Model), withBody(body), withContentType(builder.FormDataContentType()), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -31,6 +31,85 @@ func TestImages(t *testing.T) {
 	checks.NoError(t, err, "CreateImage error")
 }
 
+func TestImagesModeration(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/images/generations", func(w http.ResponseWriter, r *http.Request) {
+		imageReq, err := getImageBody(r)
+		checks.NoError(t, err, "getImageBody error")
+		if imageReq.Moderation != openai.ImageModerationLow {
+			t.Errorf("expected low moderation, got %q", imageReq.Moderation)
+		}
+		fmt.Fprintln(w, `{"created":1,"data":[{"b64_json":"e30K"}]}`)
+	})
+	_, err := client.CreateImage(context.Background(), openai.ImageRequest{
+		Prompt:     "Lorem ipsum",
+		Model:      openai.CreateImageModelGPTImage1,
+		Moderation: openai.ImageModerationLow,
+	})
+	checks.NoError(t, err, "CreateImage error")
+}
+
+func TestImagesValidation(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/images/generations", handleImageEndpoint)
+
+	testCases := []struct {
+		name    string
+		request openai.ImageRequest
+		valid   bool
+	}{
+		{"dall-e-2 default", openai.ImageRequest{N: 10, Size: openai.CreateImageSize256x256}, true},
+		{"dall-e-2 size", openai.ImageRequest{Size: openai.CreateImageSize1792x1024}, false},
+		{"dall-e-2 style", openai.ImageRequest{Style: openai.CreateImageStyleVivid}, false},
+		{"dall-e-2 n", openai.ImageRequest{N: 11}, false},
+		{"dall-e-3 n", openai.ImageRequest{Model: openai.CreateImageModelDallE3, N: 2}, false},
+		{"dall-e-3 hd", openai.ImageRequest{
+			Model:   openai.CreateImageModelDallE3,
+			Quality: openai.CreateImageQualityHD,
+			Size:    openai.CreateImageSize1024x1792,
+			Style:   openai.CreateImageStyleNatural,
+		}, true},
+		{"dall-e-3 quality", openai.ImageRequest{
+			Model: openai.CreateImageModelDallE3, Quality: openai.CreateImageQualityHigh,
+		}, false},
+		{"gpt-image-1", openai.ImageRequest{
+			Model:      openai.CreateImageModelGPTImage1,
+			Quality:    openai.CreateImageQualityMedium,
+			Size:       openai.CreateImageSize1536x1024,
+			Moderation: openai.ImageModerationLow,
+		}, true},
+		{"gpt-image-1 response format", openai.ImageRequest{
+			Model: openai.CreateImageModelGPTImage1, ResponseFormat: openai.CreateImageResponseFormatURL,
+		}, false},
+		{"dall-e-3 moderation", openai.ImageRequest{
+			Model: openai.CreateImageModelDallE3, Moderation: openai.ImageModerationLow,
+		}, false},
+		{"unknown model", openai.ImageRequest{Model: "my-deployment", Size: "4096x4096"}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.request.Prompt = "Lorem ipsum"
+			_, err := client.CreateImage(context.Background(), tc.request)
+			if tc.valid {
+				checks.NoError(t, err, "CreateImage error")
+			} else {
+				checks.ErrorIs(t, err, openai.ErrInvalidImageRequest, "CreateImage should fail validation")
+			}
+		})
+	}
+
+	_, err := client.CreateVariImage(context.Background(), openai.ImageVariRequest{
+		Model: openai.CreateImageModelGPTImage1,
+	})
+	checks.ErrorIs(t, err, openai.ErrInvalidImageRequest, "gpt-image-1 does not create variations")
+	_, err = client.CreateEditImage(context.Background(), openai.ImageEditRequest{
+		InputFidelity: openai.ImageInputFidelityHigh,
+	})
+	checks.ErrorIs(t, err, openai.ErrInvalidImageRequest, "dall-e-2 does not support input_fidelity")
+}
+
 // handleImageEndpoint Handles the images endpoint by the test server.
 func handleImageEndpoint(w http.ResponseWriter, r *http.Request) {
 	var err error
@@ -142,6 +221,34 @@ func TestImageEditWithoutMask(t *testing.T) {
 	checks.NoError(t, err, "CreateImage error")
 }
 
+func TestImageEditInputFidelity(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/images/edits", func(w http.ResponseWriter, r *http.Request) {
+		checks.NoError(t, r.ParseMultipartForm(1<<20), "ParseMultipartForm error")
+		if r.FormValue("model") != openai.CreateImageModelGPTImage1 || r.FormValue("input_fidelity") != "high" {
+			t.Errorf("unexpected form %v", r.MultipartForm.Value)
+		}
+		handleEditImageEndpoint(w, r)
+	})
+
+	origin, err := os.Create("image.png")
+	checks.NoError(t, err, "open origin file error")
+	defer func() {
+		origin.Close()
+		os.Remove("image.png")
+	}()
+
+	_, err = client.CreateEditImage(context.Background(), openai.ImageEditRequest{
+		Image:         origin,
+		Prompt:        "Put the logo on a mug",
+		Model:         openai.CreateImageModelGPTImage1,
+		N:             1,
+		InputFidelity: openai.ImageInputFidelityHigh,
+	})
+	checks.NoError(t, err, "CreateEditImage error")
+}
+
 // handleEditImageEndpoint Handles the images endpoint by the test server.
 func handleEditImageEndpoint(w http.ResponseWriter, r *http.Request) {
 	var resBytes []byte
@@ -97,6 +97,16 @@ func TestImageFormBuilderFailures(t *testing.T) {
 	_, err = client.CreateEditImage(ctx, req)
 	checks.ErrorIs(t, err, mockFailedErr, "CreateImage should return error if form builder fails")
 
+	req.Model = CreateImageModelGPTImage1
+	req.InputFidelity = ImageInputFidelityHigh
+	failForField = "model"
+	_, err = client.CreateEditImage(ctx, req)
+	checks.ErrorIs(t, err, mockFailedErr, "CreateImage should return error if form builder fails")
+
+	failForField = "input_fidelity"
+	_, err = client.CreateEditImage(ctx, req)
+	checks.ErrorIs(t, err, mockFailedErr, "CreateImage should return error if form builder fails")
+
 	failForField = ""
 	mockBuilder.mockClose = func() error {
 		return mockFailedErr
@@ -0,0 +1,134 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidImageRequest is returned, without sending the request, when the
+// parameters of an image request are not supported by its model.
+var ErrInvalidImageRequest = errors.New("invalid image request")
+
+// imageModelSpec lists the parameters an image model accepts. Empty lists
+// mean that the parameter is not accepted at all.
+type imageModelSpec struct {
+	sizes     []string
+	qualities []string
+	styles    []string
+	maxN      int
+	// responseFormat is false for models that always return base64 images.
+	responseFormat bool
+	moderation     bool
+	edits          bool
+	variations     bool
+}
+
+var imageModelSpecs = map[string]imageModelSpec{
+	CreateImageModelDallE2: {
+		sizes:          []string{CreateImageSize256x256, CreateImageSize512x512, CreateImageSize1024x1024},
+		qualities:      []string{CreateImageQualityStandard},
+		maxN:           10,
+		responseFormat: true,
+		edits:          true,
+		variations:     true,
+	},
+	CreateImageModelDallE3: {
+		sizes:          []string{CreateImageSize1024x1024, CreateImageSize1792x1024, CreateImageSize1024x1792},
+		qualities:      []string{CreateImageQualityStandard, CreateImageQualityHD},
+		styles:         []string{CreateImageStyleVivid, CreateImageStyleNatural},
+		maxN:           1,
+		responseFormat: true,
+	},
+	CreateImageModelGPTImage1: {
+		sizes: []string{
+			CreateImageSize1024x1024, CreateImageSize1536x1024, CreateImageSize1024x1536, CreateImageSizeAuto,
+		},
+		qualities: []string{
+			CreateImageQualityLow, CreateImageQualityMedium, CreateImageQualityHigh, CreateImageQualityAuto,
+		},
+		maxN:       10,
+		moderation: true,
+		edits:      true,
+	},
+}
+
+// imageSpec returns model, which defaults to dall-e-2 as in the API, and its
+// spec. Unknown models, e.g. Azure deployments, are not validated.
+func imageSpec(model string) (string, imageModelSpec, bool) {
+	if model == "" {
+		model = CreateImageModelDallE2
+	}
+	spec, ok := imageModelSpecs[model]
+	return model, spec, ok
+}
+
+func checkImageParameter(model, parameter, value string, supported []string) error {
+	if value == "" || contains(supported, value) {
+		return nil
+	}
+	if len(supported) == 0 {
+		return fmt.Errorf("%w: %s does not support the %s parameter", ErrInvalidImageRequest, model, parameter)
+	}
+	return fmt.Errorf("%w: %s does not support %s %q, use one of %s",
+		ErrInvalidImageRequest, model, parameter, value, strings.Join(supported, ", "))
+}
+
+func (s imageModelSpec) checkCommon(model, size string, n int, responseFormat string) error {
+	if err := checkImageParameter(model, "size", size, s.sizes); err != nil {
+		return err
+	}
+	if n < 0 || n > s.maxN {
+		return fmt.Errorf("%w: %s generates 1 to %d images per request, not %d", ErrInvalidImageRequest, model, s.maxN, n)
+	}
+	if responseFormat != "" && !s.responseFormat {
+		return fmt.Errorf("%w: %s does not support the response_format parameter, it always returns b64_json",
+			ErrInvalidImageRequest, model)
+	}
+	return nil
+}
+
+func validateImageRequest(request ImageRequest) error {
+	model, spec, ok := imageSpec(request.Model)
+	if !ok {
+		return nil
+	}
+	if err := spec.checkCommon(model, request.Size, request.N, request.ResponseFormat); err != nil {
+		return err
+	}
+	if err := checkImageParameter(model, "quality", request.Quality, spec.qualities); err != nil {
+		return err
+	}
+	if err := checkImageParameter(model, "style", request.Style, spec.styles); err != nil {
+		return err
+	}
+	if request.Moderation != "" && !spec.moderation {
+		return fmt.Errorf("%w: %s does not support the moderation parameter", ErrInvalidImageRequest, model)
+	}
+	return nil
+}
+
+func validateImageEditRequest(request ImageEditRequest) error {
+	model, spec, ok := imageSpec(request.Model)
+	if !ok {
+		return nil
+	}
+	if !spec.edits {
+		return fmt.Errorf("%w: %s does not support image edits", ErrInvalidImageRequest, model)
+	}
+	if request.InputFidelity != "" && model != CreateImageModelGPTImage1 {
+		return fmt.Errorf("%w: %s does not support the input_fidelity parameter", ErrInvalidImageRequest, model)
+	}
+	return spec.checkCommon(model, request.Size, request.N, request.ResponseFormat)
+}
+
+func validateImageVariRequest(request ImageVariRequest) error {
+	model, spec, ok := imageSpec(request.Model)
+	if !ok {
+		return nil
+	}
+	if !spec.variations {
+		return fmt.Errorf("%w: %s does not support image variations", ErrInvalidImageRequest, model)
+	}
+	return spec.checkCommon(model, request.Size, request.N, request.ResponseFormat)
+}
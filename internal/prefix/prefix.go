@@ -0,0 +1,71 @@
+// Package prefix maps names to values by their longest registered prefix, as
+// used for the model tables of the openai and tokenizer packages.
+package prefix
+
+import (
+	"sort"
+	"strings"
+)
+
+// Table maps prefixes to values. The prefixes are kept sorted from the longest
+// to the shortest when they are set, so that lookups don't sort them again.
+// A Table is not safe for concurrent use: callers guard it with their own lock.
+type Table[V any] struct {
+	values   map[string]V
+	prefixes []string
+}
+
+// NewTable returns the table of values keyed by prefix.
+func NewTable[V any](values map[string]V) *Table[V] {
+	t := &Table[V]{values: make(map[string]V, len(values))}
+	for prefix, value := range values {
+		t.values[prefix] = value
+		t.prefixes = append(t.prefixes, prefix)
+	}
+	sort.Slice(t.prefixes, func(i, j int) bool { return longer(t.prefixes[i], t.prefixes[j]) })
+	return t
+}
+
+// Set sets the value of prefix.
+func (t *Table[V]) Set(prefix string, value V) {
+	if _, ok := t.values[prefix]; !ok {
+		i := sort.Search(len(t.prefixes), func(i int) bool { return !longer(t.prefixes[i], prefix) })
+		t.prefixes = append(t.prefixes, "")
+		copy(t.prefixes[i+1:], t.prefixes[i:])
+		t.prefixes[i] = prefix
+	}
+	t.values[prefix] = value
+}
+
+// Delete removes prefix from the table.
+func (t *Table[V]) Delete(prefix string) {
+	if _, ok := t.values[prefix]; !ok {
+		return
+	}
+	delete(t.values, prefix)
+	i := sort.Search(len(t.prefixes), func(i int) bool { return !longer(t.prefixes[i], prefix) })
+	t.prefixes = append(t.prefixes[:i], t.prefixes[i+1:]...)
+}
+
+// Lookup returns the value of the longest prefix of name.
+func (t *Table[V]) Lookup(name string) (V, bool) {
+	if value, ok := t.values[name]; ok {
+		return value, true
+	}
+	for _, prefix := range t.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return t.values[prefix], true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// longer orders prefixes from the longest to the shortest, then
+// alphabetically so that the order does not depend on map iteration.
+func longer(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) > len(b)
+	}
+	return a < b
+}
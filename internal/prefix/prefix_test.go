@@ -0,0 +1,34 @@
+package prefix_test
+
+import (
+	"testing"
+
+	"github.com/sashabaranov/go-openai/internal/prefix"
+)
+
+func TestTableLookup(t *testing.T) {
+	table := prefix.NewTable(map[string]int{"gpt-4": 1, "gpt-4o": 2, "o3": 3})
+	table.Set("gpt-4o-mini", 4)
+	table.Set("gpt-4", 5)
+
+	for name, expected := range map[string]int{
+		"gpt-4":                  5,
+		"gpt-4-turbo":            5,
+		"gpt-4o-2024-08-06":      2,
+		"gpt-4o-mini-2024-07-18": 4,
+		"o3-mini":                3,
+	} {
+		if value, ok := table.Lookup(name); !ok || value != expected {
+			t.Errorf("Lookup(%q) = %d, %v, expected %d", name, value, ok, expected)
+		}
+	}
+	if _, ok := table.Lookup("davinci"); ok {
+		t.Error("expected no value for a name without a registered prefix")
+	}
+
+	table.Delete("gpt-4o-mini")
+	table.Delete("unknown")
+	if value, _ := table.Lookup("gpt-4o-mini-2024-07-18"); value != 2 {
+		t.Errorf("expected the deleted prefix to fall back to gpt-4o, got %d", value)
+	}
+}
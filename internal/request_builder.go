@@ -16,8 +16,13 @@ type HTTPRequestBuilder struct {
 }
 
 func NewRequestBuilder() *HTTPRequestBuilder {
+	return NewRequestBuilderWithMarshaller(&JSONMarshaller{})
+}
+
+// NewRequestBuilderWithMarshaller returns a builder encoding bodies with marshaller.
+func NewRequestBuilderWithMarshaller(marshaller Marshaller) *HTTPRequestBuilder {
 	return &HTTPRequestBuilder{
-		marshaller: &JSONMarshaller{},
+		marshaller: marshaller,
 	}
 }
 
@@ -0,0 +1,342 @@
+// Package websocket implements the subset of the WebSocket protocol (RFC 6455)
+// used by the realtime API: a client handshake over HTTP/1.1, and text
+// messages in both directions. Extensions and subprotocols are not supported.
+package websocket
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // SHA-1 is mandated by the WebSocket handshake.
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+
+	acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+	// MaxMessageSize caps the size of a received message.
+	MaxMessageSize = 32 << 20
+)
+
+var (
+	// ErrBadHandshake is returned when the server does not switch to the
+	// WebSocket protocol, e.g. because it answered the handshake with an
+	// error response.
+	ErrBadHandshake = errors.New("websocket: bad handshake")
+	// ErrMessageTooLarge is returned when a message exceeds MaxMessageSize.
+	ErrMessageTooLarge = errors.New("websocket: message too large")
+	// ErrProtocol is returned when the peer breaks the protocol.
+	ErrProtocol = errors.New("websocket: protocol error")
+)
+
+// Conn is a WebSocket connection. Reads must not be concurrent, but writes
+// may happen concurrently with reads and with each other.
+type Conn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	// client connections mask the frames they send.
+	client bool
+
+	writeMu   sync.Mutex
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// Dial sends req, a GET request to an http or https URL, as the opening
+// handshake of a connection. The context of req bounds the handshake only.
+// When the server does not switch protocols, its response is returned with
+// ErrBadHandshake and must be closed by the caller.
+func Dial(req *http.Request) (*Conn, *http.Response, error) {
+	ctx := req.Context()
+	conn, err := dial(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := newKey()
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	reader := bufio.NewReader(conn)
+	resp, err := handshake(ctx, conn, reader, req)
+	if err == nil {
+		// The deadline of the handshake must not outlive it.
+		err = conn.SetDeadline(time.Time{})
+	}
+	if err != nil {
+		conn.Close()
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols ||
+		!strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") ||
+		resp.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		// The body is read before the connection is closed by the caller.
+		resp.Body = &connBody{ReadCloser: resp.Body, conn: conn}
+		return nil, resp, ErrBadHandshake
+	}
+	return &Conn{conn: conn, reader: reader, client: true}, resp, nil
+}
+
+func dial(ctx context.Context, req *http.Request) (net.Conn, error) {
+	host := req.URL.Hostname()
+	port := req.URL.Port()
+	switch req.URL.Scheme {
+	case "http", "ws":
+		if port == "" {
+			port = "80"
+		}
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	case "https", "wss":
+		if port == "" {
+			port = "443"
+		}
+		dialer := tls.Dialer{Config: &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}}
+		return dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	default:
+		return nil, fmt.Errorf("websocket: unsupported scheme %q", req.URL.Scheme)
+	}
+}
+
+// handshake sends req and reads the response of the server, interrupted when
+// ctx is done.
+func handshake(ctx context.Context, conn net.Conn, reader *bufio.Reader, req *http.Request) (*http.Response, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			conn.SetDeadline(time.Now()) //nolint:errcheck // the handshake fails with a timeout either way.
+		case <-stop:
+		}
+	}()
+	defer func() {
+		close(stop)
+		<-stopped
+	}()
+
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+	return http.ReadResponse(reader, req)
+}
+
+// connBody closes the connection of a failed handshake with its response.
+type connBody struct {
+	io.ReadCloser
+	conn net.Conn
+}
+
+func (b *connBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.conn.Close()
+	return err
+}
+
+// Accept completes the opening handshake of r as a server. It is meant for
+// test servers.
+func Accept(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") || key == "" {
+		http.Error(w, "expected a websocket handshake", http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, errors.New("websocket: response writer does not support hijacking")
+	}
+	conn, buffered, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	_, err = fmt.Fprintf(buffered, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\n"+
+		"Connection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", acceptKey(key))
+	if err == nil {
+		err = buffered.Flush()
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &Conn{conn: conn, reader: buffered.Reader}, nil
+}
+
+func newKey() (string, error) {
+	var key [16]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key[:]), nil
+}
+
+func acceptKey(key string) string {
+	hash := sha1.Sum([]byte(key + acceptGUID)) //nolint:gosec // SHA-1 is mandated by the WebSocket handshake.
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// ReadMessage returns the next text or binary message. Pings are answered
+// while reading. io.EOF is returned once the peer has closed the connection.
+func (c *Conn) ReadMessage() ([]byte, error) {
+	var message []byte
+	started := false
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch opcode {
+		case opPing:
+			if err = c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+		case opPong:
+		case opClose:
+			c.writeFrame(opClose, payload) //nolint:errcheck // the connection is closing anyway.
+			c.conn.Close()
+			return nil, io.EOF
+		case opText, opBinary, opContinuation:
+			if (opcode == opContinuation) != started {
+				return nil, ErrProtocol
+			}
+			started = true
+			if len(message)+len(payload) > MaxMessageSize {
+				return nil, ErrMessageTooLarge
+			}
+			message = append(message, payload...)
+			if fin {
+				return message, nil
+			}
+		default:
+			return nil, ErrProtocol
+		}
+	}
+}
+
+func (c *Conn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	var header [2]byte
+	if _, err = io.ReadFull(c.reader, header[:]); err != nil {
+		return
+	}
+	fin = header[0]&0x80 != 0
+	opcode = header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+	size := uint64(header[1] & 0x7f)
+	switch size {
+	case 126:
+		var extended [2]byte
+		if _, err = io.ReadFull(c.reader, extended[:]); err != nil {
+			return
+		}
+		size = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		if _, err = io.ReadFull(c.reader, extended[:]); err != nil {
+			return
+		}
+		size = binary.BigEndian.Uint64(extended[:])
+	}
+	if size > MaxMessageSize {
+		err = ErrMessageTooLarge
+		return
+	}
+	var mask [4]byte
+	if masked {
+		if _, err = io.ReadFull(c.reader, mask[:]); err != nil {
+			return
+		}
+	}
+	payload = make([]byte, size)
+	if _, err = io.ReadFull(c.reader, payload); err != nil {
+		return
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return
+}
+
+// WriteMessage sends data as a text message.
+func (c *Conn) WriteMessage(data []byte) error {
+	return c.writeFrame(opText, data)
+}
+
+func (c *Conn) writeFrame(opcode byte, payload []byte) error {
+	frame := []byte{0x80 | opcode, 0}
+	size := len(payload)
+	switch {
+	case size < 126:
+		frame[1] = byte(size)
+	case size <= 0xffff:
+		frame[1] = 126
+		var extended [2]byte
+		binary.BigEndian.PutUint16(extended[:], uint16(size))
+		frame = append(frame, extended[:]...)
+	default:
+		frame[1] = 127
+		var extended [8]byte
+		binary.BigEndian.PutUint64(extended[:], uint64(size))
+		frame = append(frame, extended[:]...)
+	}
+	if c.client {
+		var mask [4]byte
+		if _, err := rand.Read(mask[:]); err != nil {
+			return err
+		}
+		frame[1] |= 0x80
+		frame = append(frame, mask[:]...)
+		start := len(frame)
+		frame = append(frame, payload...)
+		for i := range frame[start:] {
+			frame[start+i] ^= mask[i%4]
+		}
+	} else {
+		frame = append(frame, payload...)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// Close sends a normal closure to the peer and closes the connection. It is
+// safe to call more than once.
+func (c *Conn) Close() error {
+	c.closeOnce.Do(func() {
+		c.writeFrame(opClose, []byte{0x03, 0xe8}) //nolint:errcheck // the peer may be gone already.
+		c.closeErr = c.conn.Close()
+	})
+	return c.closeErr
+}
@@ -0,0 +1,102 @@
+package websocket_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai/internal/websocket"
+)
+
+func dialTestServer(t *testing.T, handler http.HandlerFunc) *websocket.Conn {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, _, err := websocket.Dial(req)
+	if err != nil {
+		t.Fatalf("Dial error: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestEcho(t *testing.T) {
+	conn := dialTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		server, err := websocket.Accept(w, r)
+		if err != nil {
+			return
+		}
+		defer server.Close()
+		for {
+			message, readErr := server.ReadMessage()
+			if readErr != nil {
+				return
+			}
+			if writeErr := server.WriteMessage(message); writeErr != nil {
+				return
+			}
+		}
+	})
+
+	// The sizes cover the three encodings of the payload length.
+	for _, size := range []int{5, 300, 70000} {
+		message := strings.Repeat("a", size)
+		if err := conn.WriteMessage([]byte(message)); err != nil {
+			t.Fatalf("WriteMessage error: %v", err)
+		}
+		echo, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("ReadMessage error: %v", err)
+		}
+		if string(echo) != message {
+			t.Errorf("unexpected echo of %d bytes: got %d bytes", size, len(echo))
+		}
+	}
+}
+
+func TestReadMessageAfterClose(t *testing.T) {
+	conn := dialTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		server, err := websocket.Accept(w, r)
+		if err != nil {
+			return
+		}
+		server.WriteMessage([]byte("bye")) //nolint:errcheck // checked by the client.
+		server.Close()
+	})
+
+	message, err := conn.ReadMessage()
+	if err != nil || string(message) != "bye" {
+		t.Fatalf("unexpected message %q: %v", message, err)
+	}
+	if _, err = conn.ReadMessage(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestDialBadHandshake(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"error":{"message":"nope"}}`, http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, resp, err := websocket.Dial(req)
+	if !errors.Is(err, websocket.ErrBadHandshake) {
+		t.Fatalf("expected ErrBadHandshake, got %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+}
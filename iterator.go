@@ -0,0 +1,132 @@
+package openai
+
+import "context"
+
+// Page is a page of the items of a list endpoint. LastID, the ID of the last
+// item, is the cursor of the next page, passed to WithAfter.
+type Page[T any] struct {
+	Data    []T
+	LastID  string
+	HasMore bool
+}
+
+// Iterator walks the items of a list endpoint, fetching the next page with
+// WithAfter when the current one is exhausted:
+//
+//	it := client.ListMessagesIterator(ctx, threadID, openai.WithOrder(openai.SortOrderAsc))
+//	for it.Next() {
+//		message := it.Current()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// An Iterator is not safe for concurrent use.
+type Iterator[T any] struct {
+	fetch   func(after string) (Page[T], error)
+	page    Page[T]
+	index   int
+	started bool
+	current T
+	err     error
+}
+
+func newIterator[T any](fetch func(after string) (Page[T], error)) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch}
+}
+
+// Next advances to the next item, fetching a page if needed, and reports
+// whether there is one. It returns false at the end of the list or on error.
+func (it *Iterator[T]) Next() bool {
+	for it.index >= len(it.page.Data) {
+		if it.err != nil {
+			return false
+		}
+		after := ""
+		if it.started {
+			if !it.page.HasMore || it.page.LastID == "" {
+				return false
+			}
+			after = it.page.LastID
+		}
+		it.page, it.err = it.fetch(after)
+		it.started = true
+		it.index = 0
+	}
+	it.current = it.page.Data[it.index]
+	it.index++
+	return true
+}
+
+// Current returns the item Next advanced to.
+func (it *Iterator[T]) Current() T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// pageOptions returns opts selecting the page after the given cursor, if any.
+func pageOptions(opts []RequestOption, after string) []RequestOption {
+	if after == "" {
+		return opts
+	}
+	return append(opts[:len(opts):len(opts)], WithAfter(after))
+}
+
+// newPage returns a page of data with the ID of its last item as cursor.
+func newPage[T any](data []T, hasMore bool, id func(T) string) Page[T] {
+	page := Page[T]{Data: data, HasMore: hasMore}
+	if len(data) > 0 {
+		page.LastID = id(data[len(data)-1])
+	}
+	return page
+}
+
+// ListRunsIterator iterates over the runs of a thread, following the pages of
+// ListRuns. Pages are selected with ListOptions such as WithLimit and
+// WithOrder.
+func (c *Client) ListRunsIterator(ctx context.Context, threadID string, opts ...RequestOption) *Iterator[Run] {
+	return newIterator(func(after string) (Page[Run], error) {
+		list, err := c.ListRuns(ctx, threadID, Pagination{}, pageOptions(opts, after)...)
+		if err != nil {
+			return Page[Run]{}, err
+		}
+		return newPage(list.Runs, list.HasMore, func(run Run) string { return run.ID }), nil
+	})
+}
+
+// ListRunStepsIterator iterates over the steps of a run, following the pages
+// of ListRunSteps.
+func (c *Client) ListRunStepsIterator(
+	ctx context.Context,
+	threadID, runID string,
+	opts ...RequestOption,
+) *Iterator[RunStep] {
+	return newIterator(func(after string) (Page[RunStep], error) {
+		list, err := c.ListRunSteps(ctx, threadID, runID, Pagination{}, pageOptions(opts, after)...)
+		if err != nil {
+			return Page[RunStep]{}, err
+		}
+		return newPage(list.RunSteps, list.HasMore, func(step RunStep) string { return step.ID }), nil
+	})
+}
+
+// ListMessagesIterator iterates over the messages of a thread, following the
+// pages of ListMessagesWithOptions.
+func (c *Client) ListMessagesIterator(
+	ctx context.Context,
+	threadID string,
+	opts ...RequestOption,
+) *Iterator[Message] {
+	return newIterator(func(after string) (Page[Message], error) {
+		list, err := c.ListMessagesWithOptions(ctx, threadID, pageOptions(opts, after)...)
+		if err != nil {
+			return Page[Message]{}, err
+		}
+		return newPage(list.Messages, list.HasMore, func(message Message) string { return message.ID }), nil
+	})
+}
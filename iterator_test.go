@@ -0,0 +1,99 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// pagedHandler serves ids as pages of two items, following the after cursor.
+func pagedHandler(t *testing.T, ids []string) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "2" {
+			t.Errorf("expected the limit to be kept on every page, got %q", r.URL.RawQuery)
+		}
+		start := 0
+		if after := r.URL.Query().Get("after"); after != "" {
+			for i, id := range ids {
+				if id == after {
+					start = i + 1
+				}
+			}
+		}
+		end := start + 2
+		if end > len(ids) {
+			end = len(ids)
+		}
+		items := make([]string, 0, end-start)
+		for _, id := range ids[start:end] {
+			items = append(items, fmt.Sprintf(`{"id":%q}`, id))
+		}
+		fmt.Fprintf(w, `{"object":"list","data":[%s],"has_more":%t}`, strings.Join(items, ","), end < len(ids))
+	}
+}
+
+func TestListIterators(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/threads/thread_abc123/runs", pagedHandler(t, []string{"run_1", "run_2", "run_3"}))
+	server.RegisterHandler("/v1/threads/thread_abc123/runs/run_1/steps",
+		pagedHandler(t, []string{"step_1", "step_2", "step_3", "step_4"}))
+	server.RegisterHandler("/v1/threads/thread_abc123/messages", pagedHandler(t, []string{"msg_1"}))
+
+	ctx := context.Background()
+	var ids []string
+	runs := client.ListRunsIterator(ctx, "thread_abc123", openai.WithLimit(2))
+	for runs.Next() {
+		ids = append(ids, runs.Current().ID)
+	}
+	steps := client.ListRunStepsIterator(ctx, "thread_abc123", "run_1", openai.WithLimit(2))
+	for steps.Next() {
+		ids = append(ids, steps.Current().ID)
+	}
+	messages := client.ListMessagesIterator(ctx, "thread_abc123", openai.WithLimit(2))
+	for messages.Next() {
+		ids = append(ids, messages.Current().ID)
+	}
+	checks.NoError(t, runs.Err(), "ListRunsIterator error")
+	checks.NoError(t, steps.Err(), "ListRunStepsIterator error")
+	checks.NoError(t, messages.Err(), "ListMessagesIterator error")
+
+	const expected = "[run_1 run_2 run_3 step_1 step_2 step_3 step_4 msg_1]"
+	if fmt.Sprint(ids) != expected {
+		t.Errorf("expected %s, got %v", expected, ids)
+	}
+}
+
+func TestListIteratorError(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/threads/thread_abc123/messages", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("after") != "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"error":{"message":"boom","type":"server_error"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"object":"list","data":[{"id":"msg_1"}],"has_more":true}`)
+	})
+
+	messages := client.ListMessagesIterator(context.Background(), "thread_abc123")
+	count := 0
+	for messages.Next() {
+		count++
+	}
+	var apiErr *openai.APIError
+	if count != 1 || !errors.As(messages.Err(), &apiErr) {
+		t.Errorf("expected one message then an APIError, got %d messages and %v", count, messages.Err())
+	}
+	if messages.Next() {
+		t.Error("expected Next to keep returning false after an error")
+	}
+}
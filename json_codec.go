@@ -0,0 +1,59 @@
+package openai
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONCodec encodes request bodies and decodes response bodies and stream
+// chunks, e.g. to use a faster implementation than encoding/json such as
+// jsoniter or sonic. Implementations must honor the json.Marshaler and
+// json.Unmarshaler methods of the request and response types. As with
+// json.Unmarshaler, Unmarshal must not retain data after returning: stream
+// chunks are decoded from a buffer that is reused for the next chunk.
+type JSONCodec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// standardJSON is the JSONCodec of encoding/json.
+type standardJSON struct{}
+
+func (standardJSON) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (standardJSON) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// codecMarshaller encodes request bodies with a JSONCodec, including the
+// requests with extra fields, whose MarshalJSON methods use encoding/json.
+type codecMarshaller struct {
+	codec JSONCodec
+}
+
+func (m codecMarshaller) Marshal(v any) ([]byte, error) {
+	if r, ok := v.(extraBodyRequest); ok {
+		return marshalWithExtraBody(m.codec, r)
+	}
+	return m.codec.Marshal(v)
+}
+
+// decode decodes the response body into v with the configured JSONCodec.
+func (c *Client) decode(body io.Reader, v any) error {
+	codec := c.config.JSONCodec
+	if codec == nil || v == nil {
+		return decodeResponse(body, v)
+	}
+	switch v.(type) {
+	case *string, *audioTextResponse:
+		return decodeResponse(body, v)
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	return codec.Unmarshal(data, v)
+}
@@ -0,0 +1,110 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+type countingCodec struct {
+	marshals, unmarshals int
+}
+
+func (c *countingCodec) Marshal(v any) ([]byte, error) {
+	c.marshals++
+	return json.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	c.unmarshals++
+	return json.Unmarshal(data, v)
+}
+
+func TestJSONCodec(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.ChatCompletionRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+		if request.Stream {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a\"}}]}\n\n")
+			fmt.Fprint(w, "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"b\"}}]}\n\n")
+			fmt.Fprint(w, "data: [DONE]\n\n")
+			return
+		}
+		fmt.Fprint(w, `{"choices":[{"index":0,"message":{"role":"assistant","content":"hi"}}]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	codec := &countingCodec{}
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.JSONCodec = codec
+	client := openai.NewClientWithConfig(config)
+
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hello"}},
+	}
+	response, err := client.CreateChatCompletion(context.Background(), request)
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if response.Choices[0].Message.Content != "hi" {
+		t.Errorf("unexpected response %+v", response)
+	}
+	if codec.marshals != 1 || codec.unmarshals != 1 {
+		t.Errorf("expected the codec to encode the request and decode the response, got %+v", codec)
+	}
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), request)
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	defer stream.Close()
+	for {
+		if _, err = stream.Recv(); err != nil {
+			break
+		}
+	}
+	if codec.marshals != 2 || codec.unmarshals != 3 {
+		t.Errorf("expected the codec to decode the stream chunks, got %+v", codec)
+	}
+}
+
+func TestJSONCodecExtraBody(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&body), "Decode error")
+		if body["top_k"] != float64(5) || body["model"] != openai.GPT4o {
+			t.Errorf("unexpected request body %v", body)
+		}
+		fmt.Fprint(w, `{"choices":[{"index":0,"message":{"role":"assistant","content":"hi"}}]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	codec := &countingCodec{}
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.JSONCodec = codec
+	client := openai.NewClientWithConfig(config)
+
+	_, err := client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+		Model:     openai.GPT4o,
+		Messages:  []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hello"}},
+		ExtraBody: map[string]any{"top_k": 5},
+	})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	// The request, the extra field and the merged object are marshalled, and
+	// the request is unmarshalled to be merged, all with the codec.
+	if codec.marshals != 3 || codec.unmarshals != 2 {
+		t.Errorf("expected the codec to merge the extra body, got %+v", codec)
+	}
+}
@@ -34,6 +34,9 @@ type Definition struct {
 	Required []string `json:"required,omitempty"`
 	// Items specifies which data type an array contains, if the schema type is Array.
 	Items *Definition `json:"items,omitempty"`
+	// AdditionalProperties is false to disallow properties that are not listed, as
+	// required by strict structured outputs, or a Definition of their schema.
+	AdditionalProperties any `json:"additionalProperties,omitempty"`
 }
 
 func (d Definition) MarshalJSON() ([]byte, error) {
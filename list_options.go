@@ -0,0 +1,91 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ErrInvalidListOption is returned, without sending the request, when a
+// ListOption is given a value the API does not accept.
+var ErrInvalidListOption = errors.New("invalid list option")
+
+// SortOrder is the order, by creation time, of the items returned by list endpoints.
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// ListOption sets a pagination parameter of a list call. List options are
+// RequestOptions, so they are passed to list methods along with any other
+// option, and override the Pagination argument of the methods that take one.
+type ListOption = RequestOption
+
+// WithLimit sets the number of items returned per page.
+func WithLimit(limit int) ListOption {
+	return withQuerySet("limit", strconv.Itoa(limit))
+}
+
+func (o SortOrder) valid() bool {
+	return o == SortOrderAsc || o == SortOrderDesc
+}
+
+// WithOrder sets the sort order of the items. Orders other than SortOrderAsc
+// and SortOrderDesc fail the call with ErrInvalidListOption, rather than
+// being ignored by the API.
+func WithOrder(order SortOrder) ListOption {
+	if !order.valid() {
+		return withOptionError(fmt.Errorf("%w: order %q", ErrInvalidListOption, order))
+	}
+	return withQuerySet("order", string(order))
+}
+
+// WithAfter returns the items after the one with the given ID.
+func WithAfter(id string) ListOption {
+	return withQuerySet("after", id)
+}
+
+// WithBefore returns the items before the one with the given ID.
+func WithBefore(id string) ListOption {
+	return withQuerySet("before", id)
+}
+
+func withQuerySet(key, value string) RequestOption {
+	return func(args *requestOptions) {
+		if args.query == nil {
+			args.query = make(url.Values)
+		}
+		args.query[key] = []string{value}
+	}
+}
+
+// withOptionError fails the call with err before the request is built.
+func withOptionError(err error) RequestOption {
+	return func(args *requestOptions) {
+		if args.err == nil {
+			args.err = err
+		}
+	}
+}
+
+// paginationOptions converts the pointer arguments of the deprecated list
+// methods to ListOptions, followed by opts.
+func paginationOptions(limit *int, order, after, before *string, opts []RequestOption) []RequestOption {
+	options := make([]RequestOption, 0, 4+len(opts))
+	if limit != nil {
+		options = append(options, WithLimit(*limit))
+	}
+	if order != nil {
+		options = append(options, WithOrder(SortOrder(*order)))
+	}
+	if after != nil {
+		options = append(options, WithAfter(*after))
+	}
+	if before != nil {
+		options = append(options, WithBefore(*before))
+	}
+	return append(options, opts...)
+}
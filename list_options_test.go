@@ -0,0 +1,105 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestListOptions(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var query string
+	server.RegisterHandler("/v1/vector_stores", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		fmt.Fprintln(w, `{"object":"list","data":[]}`)
+	})
+
+	_, err := client.ListVectorsWithOptions(context.Background(),
+		openai.WithLimit(20),
+		openai.WithOrder(openai.SortOrderDesc),
+		openai.WithAfter("vs_1"),
+		openai.WithBefore("vs_9"),
+	)
+	checks.NoError(t, err, "ListVectorsWithOptions error")
+	const expected = "after=vs_1&before=vs_9&limit=20&order=desc"
+	if query != expected {
+		t.Errorf("expected query %q, got %q", expected, query)
+	}
+
+	limit, order, after, before := 20, openai.SortOrderDesc, "vs_1", "vs_9"
+	_, err = client.ListVectors(context.Background(), &limit, &order, &after, &before)
+	checks.NoError(t, err, "ListVectors error")
+	if query != expected {
+		t.Errorf("expected deprecated method to send %q, got %q", expected, query)
+	}
+
+	// A later option for the same parameter wins.
+	_, err = client.ListVectors(context.Background(), &limit, nil, nil, nil, openai.WithLimit(5))
+	checks.NoError(t, err, "ListVectors error")
+	if query != "limit=5" {
+		t.Errorf("expected the option to override the argument, got %q", query)
+	}
+}
+
+func TestListOptionsValidation(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var query string
+	server.RegisterHandler("/v1/vector_stores", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		fmt.Fprintln(w, `{"object":"list","data":[{"id":"vs_1","status":"completed"},`+
+			`{"id":"vs_2","status":"expired"},{"id":"vs_3","status":"completed"}],"last_id":"vs_3","has_more":true}`)
+	})
+
+	vectors, err := client.ListVectorsWithOptions(context.Background(),
+		openai.WithVectorStatus(openai.VectorStatusCompleted), openai.WithLimit(3))
+	checks.NoError(t, err, "ListVectorsWithOptions error")
+	if query != "limit=3" {
+		t.Errorf("expected the status to be filtered after listing, got the query %q", query)
+	}
+	if len(vectors.Vectors) != 2 || vectors.Vectors[0].ID != "vs_1" || vectors.Vectors[1].ID != "vs_3" ||
+		!vectors.HasMore || *vectors.LastID != "vs_3" {
+		t.Errorf("expected the completed stores and the cursor of the page, got %+v", vectors)
+	}
+
+	query = ""
+	_, err = client.ListVectorsWithOptions(context.Background(), openai.WithOrder("descending"))
+	checks.ErrorIs(t, err, openai.ErrInvalidListOption, "invalid order should fail")
+	_, err = client.ListVectorsWithOptions(context.Background(), openai.WithVectorStatus("done"))
+	checks.ErrorIs(t, err, openai.ErrInvalidListOption, "invalid status should fail")
+	order := openai.SortOrder("DESC")
+	_, err = client.ListVectors(context.Background(), nil, &order, nil, nil)
+	checks.ErrorIs(t, err, openai.ErrInvalidListOption, "invalid order should fail")
+	_, err = client.ListVectrFiles(context.Background(), "vs_1", nil, &order, nil, nil)
+	checks.ErrorIs(t, err, openai.ErrInvalidListOption, "invalid file order should fail")
+	if query != "" {
+		t.Errorf("expected invalid options not to send a request, got %q", query)
+	}
+}
+
+func TestListOptionsOverridePagination(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/threads/thread_1/runs", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query()["limit"]; len(got) != 1 || got[0] != "5" {
+			t.Errorf("expected a single limit of 5, got %v", got)
+		}
+		if got := r.URL.Query().Get("order"); got != "asc" {
+			t.Errorf("expected order from Pagination, got %q", got)
+		}
+		fmt.Fprintln(w, `{"object":"list","data":[]}`)
+	})
+
+	limit, order := 10, "asc"
+	_, err := client.ListRuns(context.Background(), "thread_1",
+		openai.Pagination{Limit: &limit, Order: &order}, openai.WithLimit(5))
+	checks.NoError(t, err, "ListRuns error")
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
 )
 
 const (
@@ -37,32 +36,75 @@ type MessagesList struct {
 	httpHeader
 }
 
+// MessageContentType is the type of a part of a message's content.
+type MessageContentType string
+
+const (
+	MessageContentTypeText      MessageContentType = "text"
+	MessageContentTypeImageFile MessageContentType = "image_file"
+	MessageContentTypeImageURL  MessageContentType = "image_url"
+	MessageContentTypeRefusal   MessageContentType = "refusal"
+)
+
+// MessageContent is a part of a message's content. Type tells which one of
+// the other fields is set.
 type MessageContent struct {
-	Type      string       `json:"type"`
-	Text      *MessageText `json:"text,omitempty"`
-	ImageFile *ImageFile   `json:"image_file,omitempty"`
+	Type      MessageContentType `json:"type"`
+	Text      *MessageText       `json:"text,omitempty"`
+	ImageFile *ImageFile         `json:"image_file,omitempty"`
+	ImageURL  *MessageImageURL   `json:"image_url,omitempty"`
+	Refusal   string             `json:"refusal,omitempty"`
 }
+
 type MessageText struct {
 	Value       string        `json:"value"`
 	Annotations []*Annotation `json:"annotations"`
 }
 
+// AnnotationType is the type of an annotation of a message's text.
+type AnnotationType string
+
+const (
+	// AnnotationTypeFileCitation marks text that cites a file searched by file_search.
+	AnnotationTypeFileCitation AnnotationType = "file_citation"
+	// AnnotationTypeFilePath marks a link to a file generated by code_interpreter.
+	AnnotationTypeFilePath AnnotationType = "file_path"
+)
+
+// Annotation marks the part of a message's text, Value[StartIndex:EndIndex],
+// that refers to a file. Type tells whether FileCitation or FilePath is set.
 type Annotation struct {
+	Type AnnotationType `json:"type,omitempty"`
+	// Text is the marker in the message's text, e.g. "【4:0†source】".
+	Text         string        `json:"text,omitempty"`
 	StartIndex   int           `json:"start_index"`
 	EndIndex     int           `json:"end_index"`
 	FileCitation *FileCitation `json:"file_citation,omitempty"`
-	Text         string        `json:"text,omitempty"`
-	Type         string        `json:"type,omitempty"`
+	FilePath     *FilePath     `json:"file_path,omitempty"`
 }
 
 type FileCitation struct {
 	FileID string `json:"file_id"`
+	// Quote is the cited text, when the API returns it.
+	Quote string `json:"quote,omitempty"`
 }
 
-type ImageFile struct {
+// FilePath is a file generated by the code_interpreter tool.
+type FilePath struct {
 	FileID string `json:"file_id"`
 }
 
+type ImageFile struct {
+	FileID string         `json:"file_id"`
+	Detail ImageURLDetail `json:"detail,omitempty"`
+}
+
+// MessageImageURL is an image referenced by URL in a message.
+type MessageImageURL struct {
+	URL    string         `json:"url"`
+	Detail ImageURLDetail `json:"detail,omitempty"`
+}
+
 type MessageRequest struct {
 	Role     string         `json:"role"`
 	Content  string         `json:"content"`
@@ -70,6 +112,14 @@ type MessageRequest struct {
 	Metadata map[string]any `json:"metadata,omitempty"`
 }
 
+type MessageDeletionStatus struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+
+	httpHeader
+}
+
 type MessageFile struct {
 	ID        string `json:"id"`
 	Object    string `json:"object"`
@@ -86,10 +136,15 @@ type MessageFilesList struct {
 }
 
 // CreateMessage creates a new message.
-func (c *Client) CreateMessage(ctx context.Context, threadID string, request MessageRequest) (msg Message, err error) {
+func (c *Client) CreateMessage(
+	ctx context.Context,
+	threadID string,
+	request MessageRequest,
+	opts ...RequestOption,
+) (msg Message, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/%s", threadID, messagesSuffix)
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -99,33 +154,28 @@ func (c *Client) CreateMessage(ctx context.Context, threadID string, request Mes
 }
 
 // ListMessage fetches all messages in the thread.
+//
+// Deprecated: use ListMessagesWithOptions.
 func (c *Client) ListMessage(ctx context.Context, threadID string,
 	limit *int,
 	order *string,
 	after *string,
 	before *string,
+	opts ...RequestOption,
 ) (messages MessagesList, err error) {
-	urlValues := url.Values{}
-	if limit != nil {
-		urlValues.Add("limit", fmt.Sprintf("%d", *limit))
-	}
-	if order != nil {
-		urlValues.Add("order", *order)
-	}
-	if after != nil {
-		urlValues.Add("after", *after)
-	}
-	if before != nil {
-		urlValues.Add("before", *before)
-	}
-	encodedValues := ""
-	if len(urlValues) > 0 {
-		encodedValues = "?" + urlValues.Encode()
-	}
+	return c.ListMessagesWithOptions(ctx, threadID, paginationOptions(limit, order, after, before, opts)...)
+}
 
-	urlSuffix := fmt.Sprintf("/threads/%s/%s%s", threadID, messagesSuffix, encodedValues)
+// ListMessagesWithOptions fetches the messages in the thread. Pages are
+// selected with ListOptions such as WithLimit and WithAfter.
+func (c *Client) ListMessagesWithOptions(
+	ctx context.Context,
+	threadID string,
+	opts ...RequestOption,
+) (messages MessagesList, err error) {
+	urlSuffix := fmt.Sprintf("/threads/%s/%s", threadID, messagesSuffix)
 	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -138,10 +188,11 @@ func (c *Client) ListMessage(ctx context.Context, threadID string,
 func (c *Client) RetrieveMessage(
 	ctx context.Context,
 	threadID, messageID string,
+	opts ...RequestOption,
 ) (msg Message, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/%s/%s", threadID, messagesSuffix, messageID)
 	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -155,10 +206,12 @@ func (c *Client) ModifyMessage(
 	ctx context.Context,
 	threadID, messageID string,
 	metadata map[string]string,
+	opts ...RequestOption,
 ) (msg Message, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/%s/%s", threadID, messagesSuffix, messageID)
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix),
-		withBody(map[string]any{"metadata": metadata}), withBetaAssistantVersion(c.config.AssistantVersion))
+		withBody(map[string]any{"metadata": metadata}), withBetaAssistantVersion(c.config.AssistantVersion),
+		withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -167,14 +220,33 @@ func (c *Client) ModifyMessage(
 	return
 }
 
+// DeleteMessage deletes a message from a thread, e.g. to erase the data of a
+// user from stored conversations.
+func (c *Client) DeleteMessage(
+	ctx context.Context,
+	threadID, messageID string,
+	opts ...RequestOption,
+) (status MessageDeletionStatus, err error) {
+	urlSuffix := fmt.Sprintf("/threads/%s/%s/%s", threadID, messagesSuffix, messageID)
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix),
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &status)
+	return
+}
+
 // RetrieveMessageFile fetches a message file.
 func (c *Client) RetrieveMessageFile(
 	ctx context.Context,
 	threadID, messageID, fileID string,
+	opts ...RequestOption,
 ) (file MessageFile, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/%s/%s/files/%s", threadID, messagesSuffix, messageID, fileID)
 	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -187,10 +259,11 @@ func (c *Client) RetrieveMessageFile(
 func (c *Client) ListMessageFiles(
 	ctx context.Context,
 	threadID, messageID string,
+	opts ...RequestOption,
 ) (files MessageFilesList, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/%s/%s/files", threadID, messagesSuffix, messageID)
 	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -115,6 +115,13 @@ func TestMessages(t *testing.T) {
 						Metadata:    nil,
 					})
 				fmt.Fprintln(w, string(resBytes))
+			case http.MethodDelete:
+				resBytes, _ := json.Marshal(openai.MessageDeletionStatus{
+					ID:      messageID,
+					Object:  "thread.message.deleted",
+					Deleted: true,
+				})
+				fmt.Fprintln(w, string(resBytes))
 			default:
 				t.Fatalf("unsupported messages http method: %s", r.Method)
 			}
@@ -225,6 +232,13 @@ func TestMessages(t *testing.T) {
 		t.Fatalf("expected message metadata to get modified")
 	}
 
+	var status openai.MessageDeletionStatus
+	status, err = client.DeleteMessage(ctx, threadID, messageID)
+	checks.NoError(t, err, "DeleteMessage error")
+	if status.ID != messageID || !status.Deleted {
+		t.Fatalf("unexpected deletion status: %+v", status)
+	}
+
 	// message files
 	var msgFile openai.MessageFile
 	msgFile, err = client.RetrieveMessageFile(ctx, threadID, messageID, fileID)
@@ -243,3 +257,42 @@ func TestMessages(t *testing.T) {
 		t.Fatalf("unexpected message file id: '%s' in list message files", msgFiles.MessageFiles[0].ID)
 	}
 }
+
+func TestMessageContentUnmarshal(t *testing.T) {
+	const data = `{"id":"msg_abc123","content":[
+		{"type":"text","text":{"value":"See 【4:0†source】 and sandbox:/mnt/data/out.csv","annotations":[
+			{"type":"file_citation","text":"【4:0†source】","start_index":4,"end_index":17,
+				"file_citation":{"file_id":"file-doc","quote":"cited"}},
+			{"type":"file_path","text":"sandbox:/mnt/data/out.csv","start_index":22,"end_index":47,
+				"file_path":{"file_id":"file-out"}}]}},
+		{"type":"image_url","image_url":{"url":"https://example.com/a.png","detail":"low"}},
+		{"type":"refusal","refusal":"I can't help with that."}]}`
+
+	var msg openai.Message
+	checks.NoError(t, json.Unmarshal([]byte(data), &msg), "Unmarshal error")
+	if len(msg.Content) != 3 {
+		t.Fatalf("expected 3 content parts, got %d", len(msg.Content))
+	}
+
+	text := msg.Content[0]
+	if text.Type != openai.MessageContentTypeText || len(text.Text.Annotations) != 2 {
+		t.Fatalf("unexpected text content: %+v", text)
+	}
+	citation, path := text.Text.Annotations[0], text.Text.Annotations[1]
+	if citation.Type != openai.AnnotationTypeFileCitation || citation.FileCitation.FileID != "file-doc" ||
+		citation.FileCitation.Quote != "cited" {
+		t.Errorf("unexpected file citation: %+v", citation)
+	}
+	if path.Type != openai.AnnotationTypeFilePath || path.FilePath.FileID != "file-out" {
+		t.Errorf("unexpected file path: %+v", path)
+	}
+
+	if image := msg.Content[1]; image.Type != openai.MessageContentTypeImageURL ||
+		image.ImageURL.Detail != openai.ImageURLDetailLow {
+		t.Errorf("unexpected image content: %+v", image)
+	}
+	if refusal := msg.Content[2]; refusal.Type != openai.MessageContentTypeRefusal ||
+		refusal.Refusal != "I can't help with that." {
+		t.Errorf("unexpected refusal content: %+v", refusal)
+	}
+}
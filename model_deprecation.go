@@ -0,0 +1,81 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrModelShutDown is matched by errors.Is for every *ModelShutdownError.
+var ErrModelShutDown = errors.New("model has been shut down")
+
+// ModelDeprecation describes the retirement of a model.
+type ModelDeprecation struct {
+	// DeprecatedAt is when the deprecation was announced.
+	DeprecatedAt time.Time
+	// ShutdownAt is when the API stops serving the model, zero when not scheduled.
+	ShutdownAt time.Time
+	// Replacement is the recommended model to migrate to.
+	Replacement string
+}
+
+// IsShutDown reports whether the model is no longer served at t.
+func (d ModelDeprecation) IsShutDown(t time.Time) bool {
+	return !d.ShutdownAt.IsZero() && !t.Before(d.ShutdownAt)
+}
+
+// ModelShutdownError is returned when ClientConfig.RejectShutDownModels is set
+// and a request uses a model past its shutdown date.
+type ModelShutdownError struct {
+	Model string
+	ModelDeprecation
+}
+
+func (e *ModelShutdownError) Error() string {
+	msg := fmt.Sprintf("%s: %s was shut down on %s", ErrModelShutDown, e.Model, e.ShutdownAt.Format("2006-01-02"))
+	if e.Replacement != "" {
+		msg += ", use " + e.Replacement + " instead"
+	}
+	return msg
+}
+
+func (e *ModelShutdownError) Is(target error) bool {
+	return target == ErrModelShutDown
+}
+
+// checkModelDeprecation reports requests for deprecated models to
+// OnModelDeprecated and rejects the ones for shut down models when configured to.
+func (c *Client) checkModelDeprecation(model string) error {
+	if model == "" || (c.config.OnModelDeprecated == nil && !c.config.RejectShutDownModels) {
+		return nil
+	}
+	capabilities, ok := ModelInfo(model)
+	if !ok || capabilities.Deprecation == nil {
+		return nil
+	}
+	deprecation := *capabilities.Deprecation
+
+	if c.config.OnModelDeprecated != nil {
+		c.config.OnModelDeprecated(model, deprecation)
+	}
+	if c.config.RejectShutDownModels && deprecation.IsShutDown(time.Now()) {
+		return &ModelShutdownError{Model: model, ModelDeprecation: deprecation}
+	}
+	return nil
+}
+
+func deprecatedModel(deprecatedAt, shutdownAt, replacement string) *ModelDeprecation {
+	return &ModelDeprecation{
+		DeprecatedAt: mustParseDate(deprecatedAt),
+		ShutdownAt:   mustParseDate(shutdownAt),
+		Replacement:  replacement,
+	}
+}
+
+func mustParseDate(date string) time.Time {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
@@ -0,0 +1,74 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestModelDeprecation(t *testing.T) {
+	now := time.Now()
+	openai.RegisterModel("test-retiring-model", openai.ModelCapabilities{
+		ContextWindow: 1000,
+		Deprecation: &openai.ModelDeprecation{
+			DeprecatedAt: now.AddDate(0, -1, 0),
+			ShutdownAt:   now.AddDate(0, 1, 0),
+			Replacement:  "test-new-model",
+		},
+	})
+	openai.RegisterModel("test-retired-model", openai.ModelCapabilities{
+		ContextWindow: 1000,
+		Deprecation: &openai.ModelDeprecation{
+			DeprecatedAt: now.AddDate(0, -2, 0),
+			ShutdownAt:   now.AddDate(0, 0, -1),
+			Replacement:  "test-new-model",
+		},
+	})
+
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"id":"chatcmpl-1","choices":[{"message":{"role":"assistant","content":"hi"}}]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var warned []string
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.OnModelDeprecated = func(model string, deprecation openai.ModelDeprecation) {
+		warned = append(warned, model+"->"+deprecation.Replacement)
+	}
+	config.RejectShutDownModels = true
+	client := openai.NewClientWithConfig(config)
+
+	request := func(model string) error {
+		_, err := client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+			Model:    model,
+			Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hi"}},
+		})
+		return err
+	}
+
+	checks.NoError(t, request(openai.GPT4o), "current model should be accepted")
+	checks.NoError(t, request("test-retiring-model-2025"), "deprecated model should be accepted until shutdown")
+
+	err := request("test-retired-model")
+	checks.ErrorIs(t, err, openai.ErrModelShutDown, "shut down model should be rejected")
+	var shutdownErr *openai.ModelShutdownError
+	if !errors.As(err, &shutdownErr) || shutdownErr.Replacement != "test-new-model" {
+		t.Fatalf("expected *ModelShutdownError with the replacement, got %v", err)
+	}
+
+	if len(warned) != 2 || warned[0] != "test-retiring-model-2025->test-new-model" ||
+		warned[1] != "test-retired-model->test-new-model" {
+		t.Fatalf("unexpected warnings: %v", warned)
+	}
+}
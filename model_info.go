@@ -0,0 +1,129 @@
+package openai
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/sashabaranov/go-openai/internal/prefix"
+	"github.com/sashabaranov/go-openai/tokenizer"
+)
+
+// ModelCapabilities describes the limits and features of a model.
+type ModelCapabilities struct {
+	// ContextWindow is the number of tokens shared by the prompt and the completion.
+	ContextWindow int
+	// MaxOutputTokens is the largest completion the model generates, zero when unknown.
+	MaxOutputTokens int
+	// Vision reports whether the model accepts image inputs.
+	Vision bool
+	// Tools reports whether the model supports tool calls.
+	Tools bool
+	// JSONSchema reports whether the model supports structured outputs.
+	JSONSchema bool
+	// Reasoning reports whether the model is a reasoning model, which rejects
+	// sampling parameters such as temperature and logprobs.
+	Reasoning bool
+	// Tokenizer is the name of the model's encoding, e.g. tokenizer.O200kBase.
+	Tokenizer string
+	// Deprecation is set for models that are being retired.
+	Deprecation *ModelDeprecation
+}
+
+var (
+	modelRegistryMu sync.RWMutex
+	// modelRegistry holds model capabilities by model name prefix. The longest
+	// matching prefix wins, so dated snapshots inherit the capabilities of their
+	// family unless listed explicitly. Tokenizer is filled in from the tokenizer
+	// package when it is not set here.
+	modelRegistry = prefix.NewTable(map[string]ModelCapabilities{
+		"gpt-5": {ContextWindow: 400000, MaxOutputTokens: 128000, Vision: true, Tools: true, JSONSchema: true,
+			Reasoning: true},
+		// The chat models of ChatGPT are not reasoning models, unlike the gpt-5 family.
+		"gpt-5-chat":  {ContextWindow: 128000, MaxOutputTokens: 16384, Vision: true, JSONSchema: true},
+		"gpt-4.1":     {ContextWindow: 1047576, MaxOutputTokens: 32768, Vision: true, Tools: true, JSONSchema: true},
+		"gpt-4o":      {ContextWindow: 128000, MaxOutputTokens: 16384, Vision: true, Tools: true, JSONSchema: true},
+		GPT4o20240513: {ContextWindow: 128000, MaxOutputTokens: 4096, Vision: true, Tools: true},
+		"chatgpt-4o":  {ContextWindow: 128000, MaxOutputTokens: 16384, Vision: true},
+		"gpt-4-turbo": {ContextWindow: 128000, MaxOutputTokens: 4096, Vision: true, Tools: true},
+		GPT4Turbo0125: {ContextWindow: 128000, MaxOutputTokens: 4096, Tools: true},
+		GPT4Turbo1106: {ContextWindow: 128000, MaxOutputTokens: 4096, Tools: true},
+
+		GPT4VisionPreview: {ContextWindow: 128000, MaxOutputTokens: 4096, Vision: true,
+			Deprecation: deprecatedModel("2024-06-06", "2024-12-06", GPT4o)},
+		"gpt-4-1106-vision-preview": {ContextWindow: 128000, MaxOutputTokens: 4096, Vision: true,
+			Deprecation: deprecatedModel("2024-06-06", "2024-12-06", GPT4o)},
+		"gpt-4.5-preview": {ContextWindow: 128000, MaxOutputTokens: 16384, Vision: true, Tools: true, JSONSchema: true,
+			Deprecation: deprecatedModel("2025-04-14", "2025-07-14", "gpt-4.1")},
+		GPT432K: {ContextWindow: 32768, MaxOutputTokens: 32768, Tools: true,
+			Deprecation: deprecatedModel("2024-06-06", "2025-06-06", GPT4o)},
+		GPT4:          {ContextWindow: 8192, MaxOutputTokens: 8192, Tools: true},
+		GPT3Dot5Turbo: {ContextWindow: 16385, MaxOutputTokens: 4096, Tools: true},
+		GPT3Dot5Turbo0613: {ContextWindow: 4096, MaxOutputTokens: 4096, Tools: true,
+			Deprecation: deprecatedModel("2023-06-13", "2024-09-13", GPT3Dot5Turbo)},
+		GPT3Dot5Turbo16K0613: {ContextWindow: 16385, MaxOutputTokens: 4096, Tools: true,
+			Deprecation: deprecatedModel("2023-11-06", "2024-09-13", GPT3Dot5Turbo)},
+		GPT3Dot5Turbo0301: {ContextWindow: 4096, MaxOutputTokens: 4096,
+			Deprecation: deprecatedModel("2023-06-13", "2024-09-13", GPT3Dot5Turbo)},
+		GPT3Dot5TurboInstruct: {ContextWindow: 4096, MaxOutputTokens: 4096},
+
+		"o1": {ContextWindow: 200000, MaxOutputTokens: 100000, Vision: true, Tools: true, JSONSchema: true,
+			Reasoning: true},
+		"o1-mini": {ContextWindow: 128000, MaxOutputTokens: 65536, Reasoning: true,
+			Deprecation: deprecatedModel("2025-04-28", "2025-10-27", "o4-mini")},
+		"o1-preview": {ContextWindow: 128000, MaxOutputTokens: 32768, Reasoning: true,
+			Deprecation: deprecatedModel("2025-04-28", "2025-07-28", "o3")},
+		"o3": {ContextWindow: 200000, MaxOutputTokens: 100000, Vision: true, Tools: true, JSONSchema: true,
+			Reasoning: true},
+		// Unlike o3, o3-mini does not accept images.
+		"o3-mini": {ContextWindow: 200000, MaxOutputTokens: 100000, Tools: true, JSONSchema: true, Reasoning: true},
+		"o4-mini": {ContextWindow: 200000, MaxOutputTokens: 100000, Vision: true, Tools: true, JSONSchema: true,
+			Reasoning: true},
+
+		"text-davinci": {ContextWindow: 4097,
+			Deprecation: deprecatedModel("2023-07-06", "2024-01-04", GPT3Dot5TurboInstruct)},
+		"code-davinci": {ContextWindow: 8001,
+			Deprecation: deprecatedModel("2023-07-06", "2024-01-04", GPT3Dot5TurboInstruct)},
+
+		"text-embedding-3":       {ContextWindow: 8191},
+		"text-embedding-ada-002": {ContextWindow: 8191},
+	})
+)
+
+// RegisterModel sets the capabilities of models whose name starts with prefix.
+// Use it for new models, fine-tuned models or Azure deployment names. A
+// Tokenizer is also registered with the tokenizer package.
+func RegisterModel(prefix string, capabilities ModelCapabilities) {
+	if capabilities.Tokenizer != "" {
+		tokenizer.RegisterModel(prefix, capabilities.Tokenizer)
+	}
+
+	modelRegistryMu.Lock()
+	defer modelRegistryMu.Unlock()
+
+	modelRegistry.Set(prefix, capabilities)
+}
+
+// ModelInfo returns the capabilities of model. Fine-tuned model IDs resolve to
+// their base model.
+func ModelInfo(model string) (ModelCapabilities, bool) {
+	model = strings.TrimPrefix(model, "ft:")
+
+	capabilities, ok := lookupModel(model)
+	if ok && capabilities.Tokenizer == "" {
+		capabilities.Tokenizer, _ = tokenizer.EncodingForModel(model)
+	}
+	return capabilities, ok
+}
+
+func lookupModel(model string) (ModelCapabilities, bool) {
+	modelRegistryMu.RLock()
+	defer modelRegistryMu.RUnlock()
+
+	return lookupModelLocked(model)
+}
+
+// lookupModelLocked returns the entry with the longest prefix of model. The
+// caller must hold modelRegistryMu.
+func lookupModelLocked(model string) (ModelCapabilities, bool) {
+	return modelRegistry.Lookup(model)
+}
@@ -0,0 +1,80 @@
+package openai_test
+
+import (
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+	"github.com/sashabaranov/go-openai/tokenizer"
+)
+
+func TestModelInfo(t *testing.T) {
+	info, ok := openai.ModelInfo("gpt-4o-mini-2024-07-18")
+	if !ok || info.ContextWindow != 128000 || info.MaxOutputTokens != 16384 ||
+		!info.Vision || !info.Tools || !info.JSONSchema || info.Tokenizer != tokenizer.O200kBase {
+		t.Fatalf("unexpected gpt-4o-mini capabilities: %+v (%v)", info, ok)
+	}
+
+	info, _ = openai.ModelInfo(openai.GPT4o20240513)
+	if info.JSONSchema || info.MaxOutputTokens != 4096 {
+		t.Fatalf("expected the dated snapshot to override its family: %+v", info)
+	}
+
+	info, _ = openai.ModelInfo(openai.GPT3Dot5Turbo0125)
+	if info.Vision || info.Tokenizer != tokenizer.Cl100kBase {
+		t.Fatalf("unexpected gpt-3.5-turbo capabilities: %+v", info)
+	}
+
+	if info, _ = openai.ModelInfo("gpt-5-chat-latest"); info.Reasoning || info.ContextWindow != 128000 {
+		t.Fatalf("unexpected gpt-5-chat capabilities: %+v", info)
+	}
+	if info, _ = openai.ModelInfo("gpt-5-mini"); !info.Reasoning {
+		t.Fatalf("expected gpt-5-mini to be a reasoning model: %+v", info)
+	}
+
+	info, _ = openai.ModelInfo("o3-mini-2025-01-31")
+	if info.Vision || !info.Reasoning || !info.Tools || info.Tokenizer != tokenizer.O200kBase {
+		t.Fatalf("unexpected o3-mini capabilities: %+v", info)
+	}
+	if info, _ = openai.ModelInfo("o3-2025-04-16"); !info.Vision || !info.Reasoning {
+		t.Fatalf("expected o3 to accept images: %+v", info)
+	}
+
+	if _, ok = openai.ModelInfo("unknown-model"); ok {
+		t.Fatal("expected unknown model to have no capabilities")
+	}
+}
+
+func TestRegisterModel(t *testing.T) {
+	openai.RegisterModel("acme-llm", openai.ModelCapabilities{
+		ContextWindow:   32000,
+		MaxOutputTokens: 1000,
+		Tools:           true,
+		Tokenizer:       tokenizer.Cl100kBase,
+	})
+
+	info, ok := openai.ModelInfo("ft:acme-llm:org::abc123")
+	if !ok || info.ContextWindow != 32000 || !info.Tools {
+		t.Fatalf("unexpected registered capabilities: %+v (%v)", info, ok)
+	}
+	if encoding, err := tokenizer.EncodingForModel("acme-llm-large"); err != nil || encoding != tokenizer.Cl100kBase {
+		t.Fatalf("expected the tokenizer to be registered, got %q (%v)", encoding, err)
+	}
+
+	openai.RegisterContextWindow("acme-llm-large", 64000)
+	info, _ = openai.ModelInfo("acme-llm-large")
+	if info.ContextWindow != 64000 || info.MaxOutputTokens != 1000 || !info.Tools {
+		t.Fatalf("expected RegisterContextWindow to keep the other capabilities: %+v", info)
+	}
+
+	request := openai.ChatCompletionRequest{
+		Model:               "acme-llm",
+		Messages:            []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hi"}},
+		MaxCompletionTokens: 1001,
+	}
+	err := openai.ValidateRequest(request)
+	checks.ErrorIs(t, err, openai.ErrMaxOutputTokensExceeded, "expected ErrMaxOutputTokensExceeded")
+
+	request.MaxCompletionTokens = 1000
+	checks.NoError(t, openai.ValidateRequest(request), "request within the output limit should be valid")
+}
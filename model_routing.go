@@ -0,0 +1,43 @@
+package openai
+
+import (
+	"net/http"
+	"path"
+)
+
+// ModelRoute sends the requests for matching models to another
+// OpenAI-compatible endpoint, so that a single Client serves models hosted
+// by different backends.
+type ModelRoute struct {
+	// Pattern matches model names with the syntax of path.Match, e.g. "llama-*".
+	Pattern string
+	// BaseURL replaces ClientConfig.BaseURL, e.g. "http://vllm:8000/v1".
+	BaseURL string
+	// APIKey is sent as a bearer token instead of the client's key. When it
+	// is empty no Authorization header is sent, so that the client's key is
+	// not leaked to the other backend.
+	APIKey string
+}
+
+// matchModelRoute returns the first route of the client whose pattern
+// matches model.
+func (c *Client) matchModelRoute(model string) (ModelRoute, bool) {
+	if model == "" {
+		return ModelRoute{}, false
+	}
+	for _, route := range c.config.ModelRoutes {
+		if matched, _ := path.Match(route.Pattern, model); matched {
+			return route, true
+		}
+	}
+	return ModelRoute{}, false
+}
+
+func (route ModelRoute) setAuthorization(header http.Header) {
+	header.Del(AzureAPIKeyHeader)
+	if route.APIKey == "" {
+		header.Del("Authorization")
+		return
+	}
+	header.Set("Authorization", "Bearer "+route.APIKey)
+}
@@ -0,0 +1,55 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestModelRoutes(t *testing.T) {
+	var defaultPaths, routedAuth []string
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		defaultPaths = append(defaultPaths, r.URL.Path)
+		fmt.Fprint(w, `{"choices":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	vllm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("unexpected routed path %s", r.URL.Path)
+		}
+		routedAuth = append(routedAuth, r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"choices":[]}`)
+	}))
+	defer vllm.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.ModelRoutes = []openai.ModelRoute{
+		{Pattern: "llama-*", BaseURL: vllm.URL + "/v1", APIKey: "vllm-key"},
+		{Pattern: "qwen*", BaseURL: vllm.URL + "/v1"},
+	}
+	client := openai.NewClientWithConfig(config)
+	ctx := context.Background()
+
+	for _, model := range []string{openai.GPT4o, "llama-3-70b", "qwen2.5"} {
+		_, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{Model: model})
+		checks.NoError(t, err, "CreateChatCompletion error")
+	}
+
+	if len(defaultPaths) != 1 {
+		t.Errorf("expected 1 request to the default endpoint, got %d", len(defaultPaths))
+	}
+	if len(routedAuth) != 2 || routedAuth[0] != "Bearer vllm-key" || routedAuth[1] != "" {
+		t.Errorf("unexpected Authorization headers of routed requests: %q", routedAuth)
+	}
+}
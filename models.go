@@ -2,16 +2,27 @@ package openai
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
+const fineTunedModelPrefix = "ft:"
+
+// ErrNotFineTunedModel is returned by ParseFineTunedModelID for IDs that are
+// not fine-tuned model IDs.
+var ErrNotFineTunedModel = errors.New("not a fine-tuned model ID")
+
 // Model struct represents an OpenAPI model.
 type Model struct {
-	CreatedAt  int64        `json:"created"`
-	ID         string       `json:"id"`
-	Object     string       `json:"object"`
-	OwnedBy    string       `json:"owned_by"`
+	CreatedAt int64  `json:"created"`
+	ID        string `json:"id"`
+	Object    string `json:"object"`
+	// OwnedBy is "openai" or "system" for base models and the organization
+	// that created it, e.g. "org-abc123", for fine-tuned models.
+	OwnedBy string `json:"owned_by"`
+	// Permission, Root and Parent are only returned by older API versions.
 	Permission []Permission `json:"permission"`
 	Root       string       `json:"root"`
 	Parent     string       `json:"parent"`
@@ -19,6 +30,41 @@ type Model struct {
 	httpHeader
 }
 
+// IsFineTuned reports whether the model was created by a fine-tuning job.
+func (m Model) IsFineTuned() bool {
+	return strings.HasPrefix(m.ID, fineTunedModelPrefix)
+}
+
+// FineTunedModelID is the parsed ID of a fine-tuned model,
+// "ft:{base model}:{organization}:{suffix}:{id}".
+type FineTunedModelID struct {
+	BaseModel    string
+	Organization string
+	// Suffix is the optional name given in FineTuningJobRequest.Suffix.
+	Suffix string
+	ID     string
+}
+
+// ParseFineTunedModelID parses a fine-tuned model ID such as
+// "ft:gpt-4o-mini-2024-07-18:acme:support-bot:9a8b7c6d".
+func ParseFineTunedModelID(modelID string) (FineTunedModelID, error) {
+	parts := strings.Split(strings.TrimPrefix(modelID, fineTunedModelPrefix), ":")
+	if !strings.HasPrefix(modelID, fineTunedModelPrefix) || len(parts) != 4 || parts[0] == "" {
+		return FineTunedModelID{}, fmt.Errorf("%w: %q", ErrNotFineTunedModel, modelID)
+	}
+	return FineTunedModelID{
+		BaseModel:    parts[0],
+		Organization: parts[1],
+		Suffix:       parts[2],
+		ID:           parts[3],
+	}, nil
+}
+
+// String returns the model ID.
+func (id FineTunedModelID) String() string {
+	return fineTunedModelPrefix + strings.Join([]string{id.BaseModel, id.Organization, id.Suffix, id.ID}, ":")
+}
+
 // Permission struct represents an OpenAPI permission.
 type Permission struct {
 	CreatedAt          int64       `json:"created"`
@@ -53,8 +99,8 @@ type ModelsList struct {
 
 // ListModels Lists the currently available models,
 // and provides basic information about each model such as the model id and parent.
-func (c *Client) ListModels(ctx context.Context) (models ModelsList, err error) {
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL("/models"))
+func (c *Client) ListModels(ctx context.Context, opts ...RequestOption) (models ModelsList, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL("/models"), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -65,9 +111,9 @@ func (c *Client) ListModels(ctx context.Context) (models ModelsList, err error)
 
 // GetModel Retrieves a model instance, providing basic information about
 // the model such as the owner and permissioning.
-func (c *Client) GetModel(ctx context.Context, modelID string) (model Model, err error) {
+func (c *Client) GetModel(ctx context.Context, modelID string, opts ...RequestOption) (model Model, err error) {
 	urlSuffix := fmt.Sprintf("/models/%s", modelID)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix))
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -76,11 +122,36 @@ func (c *Client) GetModel(ctx context.Context, modelID string) (model Model, err
 	return
 }
 
-// DeleteFineTuneModel Deletes a fine-tune model. You must have the Owner
+// ListFineTunedModels lists the models created by fine-tuning jobs of the organization.
+func (c *Client) ListFineTunedModels(ctx context.Context, opts ...RequestOption) (models ModelsList, err error) {
+	models, err = c.ListModels(ctx, opts...)
+	if err != nil {
+		return
+	}
+
+	fineTuned := models.Models[:0]
+	for _, model := range models.Models {
+		if model.IsFineTuned() {
+			fineTuned = append(fineTuned, model)
+		}
+	}
+	models.Models = fineTuned
+	return
+}
+
+// DeleteFineTuneModel Deletes a fine-tune model.
+//
+// Deprecated: use DeleteFineTunedModel.
+func (c *Client) DeleteFineTuneModel(ctx context.Context, modelID string, opts ...RequestOption) (
+	response FineTuneModelDeleteResponse, err error) {
+	return c.DeleteFineTunedModel(ctx, modelID, opts...)
+}
+
+// DeleteFineTunedModel deletes a fine-tuned model. You must have the Owner
 // role in your organization to delete a model.
-func (c *Client) DeleteFineTuneModel(ctx context.Context, modelID string) (
+func (c *Client) DeleteFineTunedModel(ctx context.Context, modelID string, opts ...RequestOption) (
 	response FineTuneModelDeleteResponse, err error) {
-	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL("/models/"+modelID))
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL("/models/"+modelID), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
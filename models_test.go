@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
 	"github.com/sashabaranov/go-openai/internal/test/checks"
 )
 
@@ -24,6 +25,41 @@ func TestListModels(t *testing.T) {
 	checks.NoError(t, err, "ListModels error")
 }
 
+func TestGetModelRawJSON(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models/custom-model", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"id":"custom-model","object":"model","provider_extension":{"region":"eu"}}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+
+	model, err := openai.NewClientWithConfig(config).GetModel(context.Background(), "custom-model")
+	checks.NoError(t, err, "GetModel error")
+	if model.RawJSON() != nil {
+		t.Errorf("expected no raw body without KeepRawResponse, got %s", model.RawJSON())
+	}
+
+	config.KeepRawResponse = true
+	model, err = openai.NewClientWithConfig(config).GetModel(context.Background(), "custom-model")
+	checks.NoError(t, err, "GetModel error")
+	if model.ID != "custom-model" {
+		t.Errorf("expected the response to be decoded, got ID %q", model.ID)
+	}
+	var extension struct {
+		ProviderExtension struct {
+			Region string `json:"region"`
+		} `json:"provider_extension"`
+	}
+	checks.NoError(t, json.Unmarshal(model.RawJSON(), &extension), "Unmarshal error")
+	if extension.ProviderExtension.Region != "eu" {
+		t.Errorf("unexpected raw body: %s", model.RawJSON())
+	}
+}
+
 func TestAzureListModels(t *testing.T) {
 	client, server, teardown := setupAzureTestServer()
 	defer teardown()
@@ -88,6 +124,62 @@ func TestDeleteFineTuneModel(t *testing.T) {
 	checks.NoError(t, err, "DeleteFineTuneModel error")
 }
 
+func TestDeleteFineTunedModel(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	modelID := "ft:gpt-4o-mini-2024-07-18:acme::9a8b7c6d"
+	server.RegisterHandler("/v1/models/"+modelID, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		fmt.Fprintf(w, `{"id":%q,"object":"model","deleted":true}`, modelID)
+	})
+	resp, err := client.DeleteFineTunedModel(context.Background(), modelID)
+	checks.NoError(t, err, "DeleteFineTunedModel error")
+	if !resp.Deleted || resp.ID != modelID {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestListFineTunedModels(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"object":"list","data":[
+			{"id":"gpt-4o","owned_by":"system"},
+			{"id":"ft:gpt-4o-mini-2024-07-18:acme:bot:abc123","owned_by":"acme"}
+		]}`)
+	})
+	models, err := client.ListFineTunedModels(context.Background())
+	checks.NoError(t, err, "ListFineTunedModels error")
+	if len(models.Models) != 1 || models.Models[0].OwnedBy != "acme" {
+		t.Fatalf("expected only the fine-tuned model, got %+v", models.Models)
+	}
+}
+
+func TestParseFineTunedModelID(t *testing.T) {
+	id, err := openai.ParseFineTunedModelID("ft:gpt-4o-mini-2024-07-18:acme:support-bot:9a8b7c6d")
+	checks.NoError(t, err, "ParseFineTunedModelID error")
+	expected := openai.FineTunedModelID{
+		BaseModel:    "gpt-4o-mini-2024-07-18",
+		Organization: "acme",
+		Suffix:       "support-bot",
+		ID:           "9a8b7c6d",
+	}
+	if id != expected {
+		t.Fatalf("expected %+v, got %+v", expected, id)
+	}
+	if id.String() != "ft:gpt-4o-mini-2024-07-18:acme:support-bot:9a8b7c6d" {
+		t.Fatalf("unexpected String: %s", id)
+	}
+
+	for _, modelID := range []string{"gpt-4o", "ft:gpt-4o", "davinci:ft-acme-2023-01-01"} {
+		_, err = openai.ParseFineTunedModelID(modelID)
+		checks.ErrorIs(t, err, openai.ErrNotFineTunedModel, modelID)
+	}
+}
+
 func handleDeleteFineTuneModelEndpoint(w http.ResponseWriter, _ *http.Request) {
 	resBytes, _ := json.Marshal(openai.FineTuneModelDeleteResponse{})
 	fmt.Fprintln(w, string(resBytes))
@@ -83,12 +83,17 @@ type ModerationResponse struct {
 
 // Moderations — perform a moderation api call over a string.
 // Input can be an array or slice but a string will reduce the complexity.
-func (c *Client) Moderations(ctx context.Context, request ModerationRequest) (response ModerationResponse, err error) {
+func (c *Client) Moderations(
+	ctx context.Context,
+	request ModerationRequest,
+	opts ...RequestOption,
+) (response ModerationResponse, err error) {
 	if _, ok := validModerationModel[request.Model]; len(request.Model) > 0 && !ok {
 		err = ErrModerationInvalidModel
 		return
 	}
-	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/moderations", request.Model), withBody(&request))
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/moderations", request.Model), withModel(request.Model),
+		withBody(&request), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
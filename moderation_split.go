@@ -0,0 +1,219 @@
+package openai
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	defaultModerationChunkLength = 8000
+	defaultModerationConcurrency = 4
+)
+
+// ModerationSplitOptions configures ModerateLongText.
+type ModerationSplitOptions struct {
+	// MaxChunkLength is the maximum number of characters moderated per
+	// request. Defaults to 8000.
+	MaxChunkLength int
+	// Concurrency is the maximum number of requests in flight. Defaults to 4.
+	Concurrency int
+}
+
+// ModerateLongText moderates request.Input in chunks of at most
+// options.MaxChunkLength characters, split on sentence boundaries, so that long
+// documents stay within the input limits of the endpoint. The chunks are
+// moderated concurrently and their results merged into a single Result: a
+// category is flagged when it is flagged in any chunk and its score is the
+// highest score of all chunks.
+func (c *Client) ModerateLongText(
+	ctx context.Context,
+	request ModerationRequest,
+	options ModerationSplitOptions,
+	opts ...RequestOption,
+) (response ModerationResponse, err error) {
+	if options.MaxChunkLength <= 0 {
+		options.MaxChunkLength = defaultModerationChunkLength
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = defaultModerationConcurrency
+	}
+	chunks := splitSentences(request.Input, options.MaxChunkLength)
+	if len(chunks) <= 1 {
+		return c.Moderations(ctx, request, opts...)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg        sync.WaitGroup
+		errOnce   sync.Once
+		slots     = make(chan struct{}, options.Concurrency)
+		responses = make([]ModerationResponse, len(chunks))
+	)
+	for i, chunk := range chunks {
+		slots <- struct{}{}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int, chunk string) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			chunkRequest := request
+			chunkRequest.Input = chunk
+			chunkResponse, chunkErr := c.Moderations(ctx, chunkRequest, opts...)
+			if chunkErr != nil {
+				errOnce.Do(func() {
+					err = chunkErr
+					cancel()
+				})
+				return
+			}
+			responses[i] = chunkResponse
+		}(i, chunk)
+	}
+	wg.Wait()
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		return
+	}
+	return mergeModerationResponses(responses), nil
+}
+
+// mergeModerationResponses merges the results of the responses into one.
+func mergeModerationResponses(responses []ModerationResponse) ModerationResponse {
+	merged := responses[0]
+	var result Result
+	for _, response := range responses {
+		for _, chunkResult := range response.Results {
+			result.merge(chunkResult)
+		}
+	}
+	merged.Results = []Result{result}
+	return merged
+}
+
+func (r *Result) merge(other Result) {
+	r.Flagged = r.Flagged || other.Flagged
+
+	categories, otherCategories := &r.Categories, other.Categories
+	categories.Hate = categories.Hate || otherCategories.Hate
+	categories.HateThreatening = categories.HateThreatening || otherCategories.HateThreatening
+	categories.Harassment = categories.Harassment || otherCategories.Harassment
+	categories.HarassmentThreatening = categories.HarassmentThreatening || otherCategories.HarassmentThreatening
+	categories.SelfHarm = categories.SelfHarm || otherCategories.SelfHarm
+	categories.SelfHarmIntent = categories.SelfHarmIntent || otherCategories.SelfHarmIntent
+	categories.SelfHarmInstructions = categories.SelfHarmInstructions || otherCategories.SelfHarmInstructions
+	categories.Sexual = categories.Sexual || otherCategories.Sexual
+	categories.SexualMinors = categories.SexualMinors || otherCategories.SexualMinors
+	categories.Violence = categories.Violence || otherCategories.Violence
+	categories.ViolenceGraphic = categories.ViolenceGraphic || otherCategories.ViolenceGraphic
+
+	scores, otherScores := &r.CategoryScores, other.CategoryScores
+	maxScore(&scores.Hate, otherScores.Hate)
+	maxScore(&scores.HateThreatening, otherScores.HateThreatening)
+	maxScore(&scores.Harassment, otherScores.Harassment)
+	maxScore(&scores.HarassmentThreatening, otherScores.HarassmentThreatening)
+	maxScore(&scores.SelfHarm, otherScores.SelfHarm)
+	maxScore(&scores.SelfHarmIntent, otherScores.SelfHarmIntent)
+	maxScore(&scores.SelfHarmInstructions, otherScores.SelfHarmInstructions)
+	maxScore(&scores.Sexual, otherScores.Sexual)
+	maxScore(&scores.SexualMinors, otherScores.SexualMinors)
+	maxScore(&scores.Violence, otherScores.Violence)
+	maxScore(&scores.ViolenceGraphic, otherScores.ViolenceGraphic)
+}
+
+func maxScore(score *float32, other float32) {
+	if other > *score {
+		*score = other
+	}
+}
+
+// splitSentences packs the sentences of text into chunks of at most maxLength
+// characters. Sentences longer than maxLength are split between words, or
+// anywhere when a word is longer than maxLength.
+func splitSentences(text string, maxLength int) []string {
+	if utf8.RuneCountInString(text) <= maxLength {
+		return []string{text}
+	}
+
+	var (
+		chunks       []string
+		chunk        strings.Builder
+		chunkLength  int
+		appendPieces func(piece string, length int)
+	)
+	flush := func() {
+		if chunkLength > 0 {
+			chunks = append(chunks, chunk.String())
+			chunk.Reset()
+			chunkLength = 0
+		}
+	}
+	appendPieces = func(piece string, length int) {
+		if chunkLength+length > maxLength {
+			flush()
+		}
+		if length <= maxLength {
+			chunk.WriteString(piece)
+			chunkLength += length
+			return
+		}
+		// The piece alone is too long: split it into words, or cut it when it
+		// is a single word.
+		parts := splitAfterFunc(piece, unicode.IsSpace)
+		if len(parts) == 1 {
+			runes := []rune(piece)
+			for len(runes) > maxLength {
+				appendPieces(string(runes[:maxLength]), maxLength)
+				runes = runes[maxLength:]
+			}
+			appendPieces(string(runes), len(runes))
+			return
+		}
+		for _, part := range parts {
+			appendPieces(part, utf8.RuneCountInString(part))
+		}
+	}
+
+	for _, sentence := range splitAfterFunc(text, isSentenceEnd) {
+		appendPieces(sentence, utf8.RuneCountInString(sentence))
+	}
+	flush()
+	return chunks
+}
+
+func isSentenceEnd(r rune) bool {
+	return r == '.' || r == '!' || r == '?' || r == '\n'
+}
+
+// splitAfterFunc splits text after each rune satisfying end that is followed
+// by whitespace, keeping the whitespace with the preceding piece.
+func splitAfterFunc(text string, end func(rune) bool) []string {
+	var pieces []string
+	start, ended, spaced := 0, false, false
+	for i, r := range text {
+		space := unicode.IsSpace(r)
+		if spaced && !space {
+			pieces = append(pieces, text[start:i])
+			start, ended, spaced = i, false, false
+		}
+		switch {
+		case end(r):
+			ended = true
+			spaced = space
+		case ended && space:
+			spaced = true
+		case !space:
+			ended = false
+		}
+	}
+	return append(pieces, text[start:])
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -151,3 +153,52 @@ func getModerationBody(r *http.Request) (openai.ModerationRequest, error) {
 	}
 	return moderation, nil
 }
+
+func TestModerateLongText(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var (
+		mu     sync.Mutex
+		inputs []string
+	)
+	server.RegisterHandler("/v1/moderations", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.ModerationRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+		mu.Lock()
+		inputs = append(inputs, request.Input)
+		mu.Unlock()
+
+		var result openai.Result
+		if strings.Contains(request.Input, "kill") {
+			result.Flagged = true
+			result.Categories.Violence = true
+			result.CategoryScores.Violence = 0.9
+		}
+		result.CategoryScores.Hate = float32(len(request.Input)) / 100
+		resBytes, _ := json.Marshal(openai.ModerationResponse{Model: "omni", Results: []openai.Result{result}})
+		fmt.Fprintln(w, string(resBytes))
+	})
+
+	text := "Hello there. I want to kill them! Nothing else? " + strings.Repeat("x", 25)
+	response, err := client.ModerateLongText(context.Background(), openai.ModerationRequest{Input: text},
+		openai.ModerationSplitOptions{MaxChunkLength: 21, Concurrency: 2})
+	checks.NoError(t, err, "ModerateLongText error")
+
+	sort.Strings(inputs)
+	expected := []string{"Hello there. ", "I want to kill them! ", "Nothing else? ", "xxxx", strings.Repeat("x", 21)}
+	sort.Strings(expected)
+	if strings.Join(inputs, "|") != strings.Join(expected, "|") {
+		t.Errorf("unexpected chunks %q", inputs)
+	}
+	if len(response.Results) != 1 {
+		t.Fatalf("expected a single merged result, got %d", len(response.Results))
+	}
+	result := response.Results[0]
+	if !result.Flagged || !result.Categories.Violence || result.CategoryScores.Violence != 0.9 {
+		t.Errorf("expected the flags of the violent chunk, got %+v", result)
+	}
+	if result.CategoryScores.Hate != 0.21 {
+		t.Errorf("expected the highest score, got %v", result.CategoryScores.Hate)
+	}
+}
@@ -0,0 +1,101 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Ptr returns a pointer to v, for the optional fields of request types.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// String returns a pointer to v.
+func String(v string) *string {
+	return &v
+}
+
+// Int returns a pointer to v.
+func Int(v int) *int {
+	return &v
+}
+
+// Bool returns a pointer to v.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// Float returns a pointer to v. Sampling parameters such as Temperature are float32.
+func Float(v float32) *float32 {
+	return &v
+}
+
+// Float64 returns a pointer to v.
+func Float64(v float64) *float64 {
+	return &v
+}
+
+// Nullable is a request field that distinguishes being omitted, being sent as
+// JSON null and being sent with a value. Modify endpoints clear a field when it
+// is null and leave it unchanged when it is omitted.
+//
+// Fields must be tagged with omitempty. The zero value is omitted; use
+// NewNullable and NewNull to set a value or null:
+//
+//	Name Nullable[string] `json:"name,omitempty"`
+//
+// Nullable is a map so that omitempty applies to it: an empty map is omitted,
+// the key true holds a value and the key false marks null.
+//
+// Nullable values can also be put in the ExtraBody of a request. The modify
+// requests whose fields are pointers, such as AssistantRequest, clear them
+// with ClearFields instead.
+type Nullable[T any] map[bool]T
+
+// NewNullable returns a Nullable set to v.
+func NewNullable[T any](v T) Nullable[T] {
+	return Nullable[T]{true: v}
+}
+
+// NewNull returns a Nullable set to null.
+func NewNull[T any]() Nullable[T] {
+	var zero T
+	return Nullable[T]{false: zero}
+}
+
+// Get returns the value and true if n is set to a value.
+func (n Nullable[T]) Get() (T, bool) {
+	v, ok := n[true]
+	return v, ok
+}
+
+// IsNull reports whether n is set to null.
+func (n Nullable[T]) IsNull() bool {
+	_, ok := n[false]
+	return ok
+}
+
+// IsSpecified reports whether n is set, to a value or to null.
+func (n Nullable[T]) IsSpecified() bool {
+	return len(n) != 0
+}
+
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if v, ok := n.Get(); ok {
+		return json.Marshal(v)
+	}
+	return []byte("null"), nil
+}
+
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = NewNull[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*n = NewNullable(v)
+	return nil
+}
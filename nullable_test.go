@@ -0,0 +1,75 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestPointerHelpers(t *testing.T) {
+	if *openai.String("a") != "a" || *openai.Int(1) != 1 || !*openai.Bool(true) ||
+		*openai.Float(0.5) != 0.5 || *openai.Float64(0.25) != 0.25 || *openai.Ptr(uint8(3)) != 3 {
+		t.Error("pointer helpers returned unexpected values")
+	}
+}
+
+func TestNullableMarshal(t *testing.T) {
+	type request struct {
+		Name  openai.Nullable[string] `json:"name,omitempty"`
+		Limit openai.Nullable[int]    `json:"limit,omitempty"`
+	}
+
+	cases := []struct {
+		name     string
+		request  request
+		expected string
+	}{
+		{"omitted", request{}, `{}`},
+		{"null", request{Name: openai.NewNull[string]()}, `{"name":null}`},
+		{"value", request{Name: openai.NewNullable("x"), Limit: openai.NewNullable(0)}, `{"name":"x","limit":0}`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data, err := json.Marshal(c.request)
+			checks.NoError(t, err)
+			if string(data) != c.expected {
+				t.Errorf("expected %s, got %s", c.expected, data)
+			}
+		})
+	}
+}
+
+func TestNullableUnmarshal(t *testing.T) {
+	var v struct {
+		Omitted openai.Nullable[string] `json:"omitted"`
+		Null    openai.Nullable[string] `json:"null"`
+		Value   openai.Nullable[string] `json:"value"`
+	}
+	checks.NoError(t, json.Unmarshal([]byte(`{"null":null,"value":"x"}`), &v))
+
+	if v.Omitted.IsSpecified() {
+		t.Error("expected omitted field to be unspecified")
+	}
+	if !v.Null.IsNull() || !v.Null.IsSpecified() {
+		t.Error("expected null field to be null")
+	}
+	if value, ok := v.Value.Get(); !ok || value != "x" || v.Value.IsNull() {
+		t.Errorf("expected value field to be x, got %q", value)
+	}
+}
+
+func TestNullableExtraBody(t *testing.T) {
+	data, err := json.Marshal(openai.VectorRequest{
+		Name: openai.String("docs"),
+		ExtraBody: map[string]any{
+			"expires_after": openai.NewNull[map[string]any](),
+			"description":   openai.NewNullable("kept"),
+		},
+	})
+	checks.NoError(t, err)
+	if string(data) != `{"description":"kept","expires_after":null,"name":"docs"}` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+}
@@ -0,0 +1,192 @@
+package openaitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+func defaultChatReply(request openai.ChatCompletionRequest) string {
+	for i := len(request.Messages) - 1; i >= 0; i-- {
+		if request.Messages[i].Role == openai.ChatMessageRoleUser {
+			return "This is a mock response to: " + messageText(request.Messages[i])
+		}
+	}
+	return "This is a mock response."
+}
+
+func messageText(message openai.ChatCompletionMessage) string {
+	if len(message.MultiContent) == 0 {
+		return message.Content
+	}
+	texts := make([]string, 0, len(message.MultiContent))
+	for _, part := range message.MultiContent {
+		if part.Type == openai.ChatMessagePartTypeText {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, " ")
+}
+
+// countTokens approximates token counts by counting words, which is enough for
+// tests that assert usage is reported.
+func countTokens(text string) int {
+	return len(strings.Fields(text))
+}
+
+func (s *Server) handleChatCompletion(w http.ResponseWriter, r *http.Request) {
+	var request openai.ChatCompletionRequest
+	if !decodeBody(w, r, &request) {
+		return
+	}
+	if request.Model == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "you must provide a model parameter")
+		return
+	}
+	if len(request.Messages) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "'messages' must contain at least one message")
+		return
+	}
+
+	if request.Stream {
+		s.streamChatCompletion(w, request)
+		return
+	}
+
+	s.mu.Lock()
+	if len(s.chatResponses) > 0 {
+		response := s.chatResponses[0]
+		s.chatResponses = s.chatResponses[1:]
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, response)
+		return
+	}
+	s.mu.Unlock()
+
+	reply := s.chatReply(request)
+	n := request.N
+	if n < 1 {
+		n = 1
+	}
+	choices := make([]openai.ChatCompletionChoice, n)
+	for i := range choices {
+		choices[i] = openai.ChatCompletionChoice{
+			Index: i,
+			Message: openai.ChatCompletionMessage{
+				Role:    openai.ChatMessageRoleAssistant,
+				Content: reply,
+			},
+			FinishReason: openai.FinishReasonStop,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, openai.ChatCompletionResponse{
+		ID:      s.newID("chatcmpl-mock"),
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   request.Model,
+		Choices: choices,
+		Usage:   chatUsage(request, reply, n),
+	})
+}
+
+func chatUsage(request openai.ChatCompletionRequest, reply string, n int) openai.Usage {
+	prompt := 0
+	for _, message := range request.Messages {
+		prompt += countTokens(messageText(message))
+	}
+	completion := countTokens(reply) * n
+	return openai.Usage{
+		PromptTokens:     prompt,
+		CompletionTokens: completion,
+		TotalTokens:      prompt + completion,
+	}
+}
+
+func (s *Server) streamChatCompletion(w http.ResponseWriter, request openai.ChatCompletionRequest) {
+	s.mu.Lock()
+	var chunks []openai.ChatCompletionStreamResponse
+	if len(s.chatStreams) > 0 {
+		chunks = s.chatStreams[0]
+		s.chatStreams = s.chatStreams[1:]
+	}
+	s.mu.Unlock()
+
+	if chunks == nil {
+		chunks = s.chatStreamChunks(request)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+
+	for _, chunk := range chunks {
+		data, err := json.Marshal(chunk)
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	fmt.Fprint(w, "data: [DONE]\n\n")
+	if flusher != nil {
+		flusher.Flush()
+	}
+}
+
+// chatStreamChunks splits the generated reply into one chunk per word for every
+// requested choice, mirroring the shape of real streamed completions.
+func (s *Server) chatStreamChunks(request openai.ChatCompletionRequest) []openai.ChatCompletionStreamResponse {
+	id := s.newID("chatcmpl-mock")
+	created := time.Now().Unix()
+	reply := s.chatReply(request)
+	words := strings.SplitAfter(reply, " ")
+	n := request.N
+	if n < 1 {
+		n = 1
+	}
+
+	chunk := func(choices ...openai.ChatCompletionStreamChoice) openai.ChatCompletionStreamResponse {
+		return openai.ChatCompletionStreamResponse{
+			ID:      id,
+			Object:  "chat.completion.chunk",
+			Created: created,
+			Model:   request.Model,
+			Choices: choices,
+		}
+	}
+
+	var chunks []openai.ChatCompletionStreamResponse
+	for i := 0; i < n; i++ {
+		chunks = append(chunks, chunk(openai.ChatCompletionStreamChoice{
+			Index: i,
+			Delta: openai.ChatCompletionStreamChoiceDelta{Role: openai.ChatMessageRoleAssistant},
+		}))
+		for _, word := range words {
+			chunks = append(chunks, chunk(openai.ChatCompletionStreamChoice{
+				Index: i,
+				Delta: openai.ChatCompletionStreamChoiceDelta{Content: word},
+			}))
+		}
+		chunks = append(chunks, chunk(openai.ChatCompletionStreamChoice{
+			Index:        i,
+			FinishReason: openai.FinishReasonStop,
+		}))
+	}
+
+	if request.StreamOptions != nil && request.StreamOptions.IncludeUsage {
+		usage := chatUsage(request, reply, n)
+		last := chunk()
+		last.Choices = []openai.ChatCompletionStreamChoice{}
+		last.Usage = &usage
+		chunks = append(chunks, last)
+	}
+	return chunks
+}
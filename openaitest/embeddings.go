@@ -0,0 +1,136 @@
+package openaitest
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"net/http"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+type embeddingRequest struct {
+	Input          json.RawMessage                `json:"input"`
+	Model          string                         `json:"model"`
+	EncodingFormat openai.EmbeddingEncodingFormat `json:"encoding_format"`
+	Dimensions     int                            `json:"dimensions"`
+}
+
+// embeddingInputs normalizes the four accepted input shapes (string, []string,
+// []int and [][]int) into one key per embedding to generate.
+func embeddingInputs(raw json.RawMessage) ([]string, error) {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return []string{text}, nil
+	}
+	var texts []string
+	if err := json.Unmarshal(raw, &texts); err == nil {
+		return texts, nil
+	}
+	var tokens []int
+	if err := json.Unmarshal(raw, &tokens); err == nil {
+		return []string{fmt.Sprint(tokens)}, nil
+	}
+	var tokenLists [][]int
+	if err := json.Unmarshal(raw, &tokenLists); err == nil {
+		keys := make([]string, len(tokenLists))
+		for i, t := range tokenLists {
+			keys[i] = fmt.Sprint(t)
+		}
+		return keys, nil
+	}
+	return nil, fmt.Errorf("'input' must be a string, an array of strings or an array of tokens")
+}
+
+// embed returns a deterministic unit vector for input, so equal inputs always
+// produce equal embeddings.
+func embed(input string, dimensions int) []float32 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(input))
+	rng := rand.New(rand.NewSource(int64(h.Sum64()))) //nolint:gosec // deterministic test data
+
+	vector := make([]float32, dimensions)
+	var norm float64
+	for i := range vector {
+		v := rng.Float64()*2 - 1
+		vector[i] = float32(v)
+		norm += v * v
+	}
+	norm = math.Sqrt(norm)
+	for i := range vector {
+		vector[i] = float32(float64(vector[i]) / norm)
+	}
+	return vector
+}
+
+func encodeBase64(vector []float32) string {
+	buf := make([]byte, 4*len(vector))
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
+	}
+	return base64.StdEncoding.EncodeToString(buf)
+}
+
+func (s *Server) handleEmbeddings(w http.ResponseWriter, r *http.Request) {
+	var request embeddingRequest
+	if !decodeBody(w, r, &request) {
+		return
+	}
+	if request.Model == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "you must provide a model parameter")
+		return
+	}
+	inputs, err := embeddingInputs(request.Input)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	dimensions := request.Dimensions
+	if dimensions <= 0 {
+		dimensions = s.embeddingDimensions
+	}
+
+	tokens := 0
+	for _, input := range inputs {
+		tokens += countTokens(input)
+	}
+	usage := openai.Usage{PromptTokens: tokens, TotalTokens: tokens}
+
+	if request.EncodingFormat == openai.EmbeddingEncodingFormatBase64 {
+		data := make([]map[string]any, len(inputs))
+		for i, input := range inputs {
+			data[i] = map[string]any{
+				"object":    "embedding",
+				"index":     i,
+				"embedding": encodeBase64(embed(input, dimensions)),
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"object": "list",
+			"model":  request.Model,
+			"data":   data,
+			"usage":  usage,
+		})
+		return
+	}
+
+	data := make([]openai.Embedding, len(inputs))
+	for i, input := range inputs {
+		data[i] = openai.Embedding{
+			Object:    "embedding",
+			Index:     i,
+			Embedding: embed(input, dimensions),
+		}
+	}
+	writeJSON(w, http.StatusOK, openai.EmbeddingResponse{
+		Object: "list",
+		Model:  openai.EmbeddingModel(request.Model),
+		Data:   data,
+		Usage:  usage,
+	})
+}
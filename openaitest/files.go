@@ -0,0 +1,139 @@
+package openaitest
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+type storedFile struct {
+	file    openai.File
+	content []byte
+}
+
+// AddFile stores a file on the server as if it had been uploaded, and returns it.
+func (s *Server) AddFile(name string, purpose openai.PurposeType, content []byte) openai.File {
+	file := openai.File{
+		ID:        s.newID("file-mock"),
+		Object:    "file",
+		Bytes:     len(content),
+		CreatedAt: time.Now().Unix(),
+		FileName:  name,
+		Purpose:   string(purpose),
+		Status:    "processed",
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.files[file.ID] = &storedFile{file: file, content: content}
+	s.fileOrder = append(s.fileOrder, file.ID)
+	return file
+}
+
+func (s *Server) lookupFile(id string) (*storedFile, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.files[id]
+	return f, ok
+}
+
+// routeFiles handles /v1/files and its sub-resources. It reports whether the
+// request matched a known route.
+func (s *Server) routeFiles(w http.ResponseWriter, r *http.Request, parts []string) bool {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		s.handleUploadFile(w, r)
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.handleListFiles(w)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		f, ok := s.lookupFile(parts[0])
+		if !ok {
+			writeNotFound(w, "file", parts[0])
+			return true
+		}
+		writeJSON(w, http.StatusOK, f.file)
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.handleDeleteFile(w, parts[0])
+	case len(parts) == 2 && parts[1] == "content" && r.Method == http.MethodGet:
+		f, ok := s.lookupFile(parts[0])
+		if !ok {
+			writeNotFound(w, "file", parts[0])
+			return true
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(f.content)
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Server) handleUploadFile(w http.ResponseWriter, r *http.Request) {
+	upload, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "'file' is a required property")
+		return
+	}
+	defer upload.Close()
+
+	content, err := io.ReadAll(upload)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	purpose := r.FormValue("purpose")
+	if purpose == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "'purpose' is a required property")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.AddFile(header.Filename, openai.PurposeType(purpose), content))
+}
+
+func (s *Server) handleListFiles(w http.ResponseWriter) {
+	s.mu.Lock()
+	files := make([]openai.File, 0, len(s.fileOrder))
+	for _, id := range s.fileOrder {
+		files = append(files, s.files[id].file)
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"object": "list",
+		"data":   files,
+	})
+}
+
+func (s *Server) handleDeleteFile(w http.ResponseWriter, id string) {
+	s.mu.Lock()
+	_, ok := s.files[id]
+	if ok {
+		delete(s.files, id)
+		s.fileOrder = removeID(s.fileOrder, id)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeNotFound(w, "file", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":      id,
+		"object":  "file",
+		"deleted": true,
+	})
+}
+
+func removeID(ids []string, id string) []string {
+	for i, v := range ids {
+		if v == id {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,394 @@
+package openaitest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ErrInteractionNotFound is returned by a replaying Recorder when no recorded
+// interaction matches a request.
+var ErrInteractionNotFound = errors.New("openaitest: no recorded interaction matches request")
+
+// Mode selects whether a Recorder talks to the real API or replays fixtures.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the fixture file and never touches the network.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the real API and records them. Call Save to write the fixture file.
+	ModeRecord
+)
+
+// sensitiveHeaders are removed from every recorded interaction.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Api-Key",
+	"Openai-Organization",
+	"Openai-Project",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// RecordedRequest is the request half of an Interaction.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body"`
+}
+
+// RecordedResponse is the response half of an Interaction. Streaming responses
+// are stored as the chunks the client received, so that replay reproduces the
+// original boundaries of server-sent events.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body"`
+	Chunks     []Body      `json:"chunks,omitempty"`
+}
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// Body is a request or response body. It is stored as text when it is valid
+// UTF-8, which keeps JSON fixtures readable, and as base64 otherwise.
+type Body []byte
+
+type encodedBody struct {
+	Text   *string `json:"text,omitempty"`
+	Base64 *string `json:"base64,omitempty"`
+}
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		s := string(b)
+		return json.Marshal(encodedBody{Text: &s})
+	}
+	s := base64.StdEncoding.EncodeToString(b)
+	return json.Marshal(encodedBody{Base64: &s})
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var e encodedBody
+	if err := json.Unmarshal(data, &e); err != nil {
+		return err
+	}
+	switch {
+	case e.Text != nil:
+		*b = Body(*e.Text)
+	case e.Base64 != nil:
+		decoded, err := base64.StdEncoding.DecodeString(*e.Base64)
+		if err != nil {
+			return err
+		}
+		*b = decoded
+	default:
+		*b = nil
+	}
+	return nil
+}
+
+type cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Scrubber removes sensitive data from an interaction before it is saved. In
+// replay mode scrubbers are also applied to incoming requests before matching,
+// so a scrubber that rewrites request bodies keeps matching stable.
+type Scrubber func(*Interaction)
+
+// Matcher reports whether an incoming request, already converted and scrubbed,
+// matches a recorded one.
+type Matcher func(incoming, recorded RecordedRequest) bool
+
+// DefaultMatcher matches on method, path, query and body.
+func DefaultMatcher(incoming, recorded RecordedRequest) bool {
+	return incoming.Method == recorded.Method &&
+		stripHost(incoming.URL) == stripHost(recorded.URL) &&
+		bytes.Equal(incoming.Body, recorded.Body)
+}
+
+func stripHost(rawURL string) string {
+	if i := strings.Index(rawURL, "://"); i >= 0 {
+		rest := rawURL[i+3:]
+		if j := strings.Index(rest, "/"); j >= 0 {
+			return rest[j:]
+		}
+		return "/"
+	}
+	return rawURL
+}
+
+// RecorderOption configures a Recorder.
+type RecorderOption func(*Recorder)
+
+// WithTransport sets the transport used to reach the real API in record mode.
+// Defaults to http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithScrubber adds a scrubber applied to every interaction.
+func WithScrubber(scrubber Scrubber) RecorderOption {
+	return func(r *Recorder) {
+		r.scrubbers = append(r.scrubbers, scrubber)
+	}
+}
+
+// WithMatcher replaces DefaultMatcher.
+func WithMatcher(matcher Matcher) RecorderOption {
+	return func(r *Recorder) {
+		r.matcher = matcher
+	}
+}
+
+// Recorder is an http.RoundTripper that records API interactions to a fixture
+// file and replays them, so tests can run deterministically without network
+// access or credentials:
+//
+//	rec, err := openaitest.NewRecorder("testdata/chat.json", openaitest.ModeReplay)
+//	config := openai.DefaultConfig(os.Getenv("OPENAI_API_KEY"))
+//	config.HTTPClient = &http.Client{Transport: rec}
+//
+// Credentials are never written to fixtures.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	scrubbers []Scrubber
+	matcher   Matcher
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+// NewRecorder creates a Recorder backed by the fixture file at path. In replay
+// mode the file must exist.
+func NewRecorder(path string, mode Mode, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		matcher:   DefaultMatcher,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var c cassette
+		if err = json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("openaitest: decoding %s: %w", path, err)
+		}
+		r.interactions = c.Interactions
+		r.used = make([]bool, len(c.Interactions))
+	}
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	incoming := &Interaction{Request: RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: req.Header.Clone(),
+		Body:   body,
+	}}
+	r.scrub(incoming)
+
+	r.mu.Lock()
+	var found *Interaction
+	for i, interaction := range r.interactions {
+		if !r.used[i] && r.matcher(incoming.Request, interaction.Request) {
+			r.used[i] = true
+			found = interaction
+			break
+		}
+	}
+	r.mu.Unlock()
+
+	if found == nil {
+		return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, req.Method, req.URL)
+	}
+
+	resp := &http.Response{
+		StatusCode:    found.Response.StatusCode,
+		Status:        fmt.Sprintf("%d %s", found.Response.StatusCode, http.StatusText(found.Response.StatusCode)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        found.Response.Header.Clone(),
+		Request:       req,
+		ContentLength: -1,
+	}
+	if resp.Header == nil {
+		resp.Header = http.Header{}
+	}
+	if found.Response.Chunks != nil {
+		resp.Body = &chunkReader{chunks: found.Response.Chunks}
+	} else {
+		resp.Body = io.NopCloser(bytes.NewReader(found.Response.Body))
+		resp.ContentLength = int64(len(found.Response.Body))
+	}
+	return resp, nil
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: req.Header.Clone(),
+			Body:   body,
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+		},
+	}
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		// Record chunks as the client reads them instead of buffering, so that
+		// streaming behaves the same while recording.
+		interaction.Response.Chunks = []Body{}
+		resp.Body = &recordingReader{ReadCloser: resp.Body, recorder: r, interaction: interaction}
+	} else {
+		var respBody []byte
+		respBody, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		interaction.Response.Body = respBody
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) scrub(interaction *Interaction) {
+	for _, h := range sensitiveHeaders {
+		interaction.Request.Header.Del(h)
+		interaction.Response.Header.Del(h)
+	}
+	for _, scrubber := range r.scrubbers {
+		scrubber(interaction)
+	}
+}
+
+// Save writes the recorded interactions to the fixture file, scrubbing them
+// first. It does nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := cassette{Interactions: make([]*Interaction, len(r.interactions))}
+	for i, interaction := range r.interactions {
+		scrubbed := *interaction
+		scrubbed.Request.Header = interaction.Request.Header.Clone()
+		scrubbed.Response.Header = interaction.Response.Header.Clone()
+		r.scrub(&scrubbed)
+		c.Interactions[i] = &scrubbed
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, data, 0o600)
+}
+
+// recordingReader appends every chunk read from a streaming response to the interaction.
+type recordingReader struct {
+	io.ReadCloser
+	recorder    *Recorder
+	interaction *Interaction
+}
+
+func (rr *recordingReader) Read(p []byte) (int, error) {
+	n, err := rr.ReadCloser.Read(p)
+	if n > 0 {
+		chunk := make(Body, n)
+		copy(chunk, p[:n])
+		rr.recorder.mu.Lock()
+		rr.interaction.Response.Chunks = append(rr.interaction.Response.Chunks, chunk)
+		rr.recorder.mu.Unlock()
+	}
+	return n, err
+}
+
+// chunkReader replays recorded chunks, returning at most one chunk per Read.
+type chunkReader struct {
+	chunks []Body
+	offset int
+}
+
+func (cr *chunkReader) Read(p []byte) (int, error) {
+	if len(cr.chunks) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, cr.chunks[0][cr.offset:])
+	cr.offset += n
+	if cr.offset == len(cr.chunks[0]) {
+		cr.chunks = cr.chunks[1:]
+		cr.offset = 0
+	}
+	return n, nil
+}
+
+func (cr *chunkReader) Close() error {
+	return nil
+}
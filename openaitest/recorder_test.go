@@ -0,0 +1,144 @@
+package openaitest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+	"github.com/sashabaranov/go-openai/openaitest"
+)
+
+func recorderClient(rec *openaitest.Recorder, baseURL string) *openai.Client {
+	config := openai.DefaultConfig(openaitest.DefaultToken)
+	config.BaseURL = baseURL
+	config.HTTPClient = &http.Client{Transport: rec}
+	return openai.NewClientWithConfig(config)
+}
+
+func readStream(t *testing.T, stream *openai.ChatCompletionStream) string {
+	t.Helper()
+	defer stream.Close()
+
+	var content strings.Builder
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return content.String()
+		}
+		checks.NoError(t, err, "Recv error")
+		for _, choice := range chunk.Choices {
+			content.WriteString(choice.Delta.Content)
+		}
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "fixtures", "chat.json")
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "secret-user-id says hi"}},
+	}
+	scrubber := openaitest.WithScrubber(func(i *openaitest.Interaction) {
+		i.Request.Body = openaitest.Body(strings.ReplaceAll(string(i.Request.Body), "secret-user-id", "REDACTED"))
+	})
+
+	srv := openaitest.NewServer(openaitest.WithChatReply(func(openai.ChatCompletionRequest) string {
+		return "hello back"
+	}))
+	rec, err := openaitest.NewRecorder(fixture, openaitest.ModeRecord, scrubber)
+	checks.NoError(t, err, "NewRecorder error")
+	client := recorderClient(rec, srv.URL+"/v1")
+
+	recorded, err := client.CreateChatCompletion(context.Background(), request)
+	checks.NoError(t, err, "CreateChatCompletion error")
+
+	streamRequest := request
+	streamRequest.Stream = true
+	stream, err := client.CreateChatCompletionStream(context.Background(), streamRequest)
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	recordedStream := readStream(t, stream)
+
+	checks.NoError(t, rec.Save(), "Save error")
+	srv.Close()
+
+	data, err := os.ReadFile(fixture)
+	checks.NoError(t, err, "ReadFile error")
+	if strings.Contains(string(data), openaitest.DefaultToken) {
+		t.Error("fixture contains the API key")
+	}
+	if strings.Contains(string(data), "secret-user-id") {
+		t.Error("fixture was not scrubbed")
+	}
+
+	rec, err = openaitest.NewRecorder(fixture, openaitest.ModeReplay, scrubber)
+	checks.NoError(t, err, "NewRecorder replay error")
+	client = recorderClient(rec, "https://api.invalid/v1")
+
+	replayed, err := client.CreateChatCompletion(context.Background(), request)
+	checks.NoError(t, err, "replayed CreateChatCompletion error")
+	if replayed.Choices[0].Message.Content != recorded.Choices[0].Message.Content {
+		t.Errorf("replayed content %q, recorded %q",
+			replayed.Choices[0].Message.Content, recorded.Choices[0].Message.Content)
+	}
+
+	stream, err = client.CreateChatCompletionStream(context.Background(), streamRequest)
+	checks.NoError(t, err, "replayed CreateChatCompletionStream error")
+	if got := readStream(t, stream); got != recordedStream {
+		t.Errorf("replayed stream %q, recorded %q", got, recordedStream)
+	}
+
+	_, err = client.CreateChatCompletion(context.Background(), request)
+	if !errors.Is(err, openaitest.ErrInteractionNotFound) {
+		t.Errorf("expected ErrInteractionNotFound once interactions are used up, got %v", err)
+	}
+}
+
+func TestRecorderReplaysChunkBoundaries(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "stream.json")
+	err := os.WriteFile(fixture, []byte(`{"interactions": [{
+		"request": {"method": "GET", "url": "https://example.com/stream", "body": {"text": ""}},
+		"response": {
+			"status_code": 200,
+			"header": {"Content-Type": ["text/event-stream"]},
+			"body": {"text": ""},
+			"chunks": [{"text": "data: one\n\n"}, {"text": "data: two\n\n"}, {"base64": "AAE="}]
+		}
+	}]}`), 0o600)
+	checks.NoError(t, err, "WriteFile error")
+
+	rec, err := openaitest.NewRecorder(fixture, openaitest.ModeReplay)
+	checks.NoError(t, err, "NewRecorder error")
+
+	req, _ := http.NewRequest(http.MethodGet, "http://other-host/stream", nil)
+	resp, err := rec.RoundTrip(req)
+	checks.NoError(t, err, "RoundTrip error")
+	defer resp.Body.Close()
+
+	buf := make([]byte, 1024)
+	var reads []string
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			reads = append(reads, string(buf[:n]))
+		}
+		if readErr != nil {
+			break
+		}
+	}
+	expected := []string{"data: one\n\n", "data: two\n\n", "\x00\x01"}
+	if len(reads) != len(expected) {
+		t.Fatalf("expected %d reads, got %q", len(expected), reads)
+	}
+	for i := range expected {
+		if reads[i] != expected[i] {
+			t.Errorf("read %d: expected %q, got %q", i, expected[i], reads[i])
+		}
+	}
+}
@@ -0,0 +1,333 @@
+// Package openaitest provides an in-process mock of the OpenAI API for use in tests.
+//
+// The mock implements realistic handlers for chat completions (including SSE
+// streaming), embeddings, files and vector stores. Responses can be replaced
+// with canned values and failures can be injected per route:
+//
+//	srv := openaitest.NewServer()
+//	defer srv.Close()
+//
+//	srv.InjectFailure(openaitest.Failure{Path: "/v1/chat/completions", StatusCode: 429, Times: 1})
+//	client := srv.Client()
+package openaitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// DefaultToken is the API token accepted by a Server unless WithToken is used.
+const DefaultToken = "openaitest-token"
+
+// Request is a request received by the Server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   []byte
+}
+
+// Failure describes an error the Server returns instead of handling a request.
+type Failure struct {
+	// Method restricts the failure to one HTTP method. Empty matches any method.
+	Method string
+	// Path is the request path the failure applies to, e.g. "/v1/chat/completions".
+	// A trailing "*" matches any path with the given prefix.
+	Path string
+	// StatusCode is the HTTP status returned. Defaults to 500.
+	StatusCode int
+	// Error is encoded as the error response body. Defaults to a generic server_error.
+	Error *openai.APIError
+	// Header is added to the failed response, e.g. to set Retry-After.
+	Header http.Header
+	// Times is the number of requests to fail. Zero or less fails every matching request.
+	Times int
+	// Delay is waited before the failure is written, e.g. to trigger client timeouts.
+	Delay time.Duration
+}
+
+func (f *Failure) matches(r *http.Request) bool {
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+	if strings.HasSuffix(f.Path, "*") {
+		return strings.HasPrefix(r.URL.Path, strings.TrimSuffix(f.Path, "*"))
+	}
+	return f.Path == r.URL.Path
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithToken sets the bearer token the Server accepts.
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithChatReply sets the function used to generate the assistant reply for chat
+// completion requests that have no canned response queued.
+func WithChatReply(reply func(openai.ChatCompletionRequest) string) Option {
+	return func(s *Server) {
+		s.chatReply = reply
+	}
+}
+
+// WithEmbeddingDimensions sets the size of generated embeddings when the
+// request does not specify dimensions.
+func WithEmbeddingDimensions(dimensions int) Option {
+	return func(s *Server) {
+		s.embeddingDimensions = dimensions
+	}
+}
+
+// Server is a mock OpenAI API server. It is safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the server, without the /v1 prefix.
+	URL string
+
+	server              *httptest.Server
+	token               string
+	chatReply           func(openai.ChatCompletionRequest) string
+	embeddingDimensions int
+
+	mu            sync.Mutex
+	nextID        int
+	requests      []Request
+	failures      []*Failure
+	chatResponses []openai.ChatCompletionResponse
+	chatStreams   [][]openai.ChatCompletionStreamResponse
+	files         map[string]*storedFile
+	fileOrder     []string
+	vectors       map[string]*storedVector
+	vectorOrder   []string
+}
+
+// NewServer starts a mock server. Close must be called when it is no longer needed.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		token:               DefaultToken,
+		chatReply:           defaultChatReply,
+		embeddingDimensions: 16,
+		files:               make(map[string]*storedFile),
+		vectors:             make(map[string]*storedVector),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Config returns a client configuration pointed at the server.
+func (s *Server) Config() openai.ClientConfig {
+	config := openai.DefaultConfig(s.token)
+	config.BaseURL = s.URL + "/v1"
+	config.HTTPClient = s.server.Client()
+	return config
+}
+
+// Client returns a client pointed at the server.
+func (s *Server) Client() *openai.Client {
+	return openai.NewClientWithConfig(s.Config())
+}
+
+// Requests returns the requests received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := make([]Request, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+// InjectFailure makes the server fail matching requests. Failures are checked
+// in the order they were injected.
+func (s *Server) InjectFailure(f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, &f)
+}
+
+// ClearFailures removes all injected failures.
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = nil
+}
+
+// EnqueueChatCompletion queues canned responses for non-streaming chat
+// completion requests. Each response is used once, in order.
+func (s *Server) EnqueueChatCompletion(responses ...openai.ChatCompletionResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.chatResponses = append(s.chatResponses, responses...)
+}
+
+// EnqueueChatCompletionStream queues the chunks of one canned streaming chat
+// completion. The chunks are sent as server-sent events followed by [DONE].
+func (s *Server) EnqueueChatCompletionStream(chunks ...openai.ChatCompletionStreamResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.chatStreams = append(s.chatStreams, chunks)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.RawQuery,
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	failure := s.takeFailure(r)
+	s.mu.Unlock()
+
+	if failure != nil {
+		writeFailure(w, r, failure)
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		writeError(w, http.StatusUnauthorized, "invalid_request_error", "Incorrect API key provided.")
+		return
+	}
+
+	s.route(w, r)
+}
+
+// takeFailure returns the first failure matching r, consuming one of its uses.
+// s.mu must be held.
+func (s *Server) takeFailure(r *http.Request) *Failure {
+	for i, f := range s.failures {
+		if !f.matches(r) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1"), "/"), "/")
+	switch parts[0] {
+	case "chat":
+		if len(parts) == 2 && parts[1] == "completions" && r.Method == http.MethodPost {
+			s.handleChatCompletion(w, r)
+			return
+		}
+	case "embeddings":
+		if len(parts) == 1 && r.Method == http.MethodPost {
+			s.handleEmbeddings(w, r)
+			return
+		}
+	case "files":
+		if s.routeFiles(w, r, parts[1:]) {
+			return
+		}
+	case "vector_stores":
+		if s.routeVectorStores(w, r, parts[1:]) {
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "invalid_request_error",
+		fmt.Sprintf("Unrecognized request URL (%s: %s).", r.Method, r.URL.Path))
+}
+
+// newID returns a unique object ID with the given prefix.
+func (s *Server) newID(prefix string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	return fmt.Sprintf("%s%d", prefix, s.nextID)
+}
+
+func writeFailure(w http.ResponseWriter, r *http.Request, f *Failure) {
+	if f.Delay > 0 {
+		select {
+		case <-time.After(f.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	for k, values := range f.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+
+	status := f.StatusCode
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	apiErr := f.Error
+	if apiErr == nil {
+		apiErr = &openai.APIError{
+			Type:    "server_error",
+			Message: "The server had an error while processing your request.",
+		}
+	}
+	writeJSON(w, status, openai.ErrorResponse{Error: apiErr})
+}
+
+func writeError(w http.ResponseWriter, status int, errType, message string) {
+	writeJSON(w, status, openai.ErrorResponse{Error: &openai.APIError{Type: errType, Message: message}})
+}
+
+func writeNotFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, "invalid_request_error", fmt.Sprintf("No such %s: '%s'", kind, id))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error",
+			fmt.Sprintf("We could not parse the JSON body of your request: %s", err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,238 @@
+package openaitest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+	"github.com/sashabaranov/go-openai/openaitest"
+)
+
+func TestChatCompletion(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	resp, err := client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		N:        2,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "Hello there"}},
+	})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if len(resp.Choices) != 2 {
+		t.Fatalf("expected 2 choices, got %d", len(resp.Choices))
+	}
+	if resp.Choices[0].Message.Content != "This is a mock response to: Hello there" {
+		t.Errorf("unexpected content: %q", resp.Choices[0].Message.Content)
+	}
+	if resp.Usage.PromptTokens != 2 || resp.Usage.TotalTokens == 0 {
+		t.Errorf("unexpected usage: %+v", resp.Usage)
+	}
+
+	srv.EnqueueChatCompletion(openai.ChatCompletionResponse{
+		ID:      "canned",
+		Choices: []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{Content: "canned"}}},
+	})
+	resp, err = client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "Hi"}},
+	})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if resp.ID != "canned" {
+		t.Errorf("expected canned response, got %q", resp.ID)
+	}
+}
+
+func TestChatCompletionStream(t *testing.T) {
+	srv := openaitest.NewServer(openaitest.WithChatReply(func(openai.ChatCompletionRequest) string {
+		return "one two three"
+	}))
+	defer srv.Close()
+
+	stream, err := srv.Client().CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model:         openai.GPT4o,
+		Messages:      []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "count"}},
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
+	})
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	defer stream.Close()
+
+	var content strings.Builder
+	var usage *openai.Usage
+	for {
+		chunk, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			break
+		}
+		checks.NoError(t, recvErr, "Recv error")
+		for _, choice := range chunk.Choices {
+			content.WriteString(choice.Delta.Content)
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+	}
+	if content.String() != "one two three" {
+		t.Errorf("unexpected streamed content: %q", content.String())
+	}
+	if usage == nil || usage.CompletionTokens != 3 {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+}
+
+func TestEmbeddings(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	resp, err := client.CreateEmbeddings(context.Background(), openai.EmbeddingRequestStrings{
+		Input:      []string{"a", "b", "a"},
+		Model:      openai.SmallEmbedding3,
+		Dimensions: 4,
+	})
+	checks.NoError(t, err, "CreateEmbeddings error")
+	if len(resp.Data) != 3 || len(resp.Data[0].Embedding) != 4 {
+		t.Fatalf("unexpected embeddings: %+v", resp.Data)
+	}
+	for i := range resp.Data[0].Embedding {
+		if resp.Data[0].Embedding[i] != resp.Data[2].Embedding[i] {
+			t.Fatalf("equal inputs produced different embeddings")
+		}
+	}
+
+	base64Resp, err := client.CreateEmbeddings(context.Background(), openai.EmbeddingRequest{
+		Input:          "a",
+		Model:          openai.SmallEmbedding3,
+		Dimensions:     4,
+		EncodingFormat: openai.EmbeddingEncodingFormatBase64,
+	})
+	checks.NoError(t, err, "CreateEmbeddings base64 error")
+	for i, v := range base64Resp.Data[0].Embedding {
+		if v != resp.Data[0].Embedding[i] {
+			t.Fatalf("base64 embedding differs from float embedding")
+		}
+	}
+}
+
+func TestFilesAndVectorStores(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	file, err := client.CreateFileBytes(ctx, openai.FileBytesRequest{
+		Name:    "notes.txt",
+		Bytes:   []byte("hello"),
+		Purpose: openai.PurposeAssistants,
+	})
+	checks.NoError(t, err, "CreateFileBytes error")
+
+	files, err := client.ListFiles(ctx)
+	checks.NoError(t, err, "ListFiles error")
+	if len(files.Files) != 1 || files.Files[0].FileName != "notes.txt" {
+		t.Fatalf("unexpected files: %+v", files.Files)
+	}
+
+	content, err := client.GetFileContent(ctx, file.ID)
+	checks.NoError(t, err, "GetFileContent error")
+	data, _ := io.ReadAll(content)
+	content.Close()
+	if string(data) != "hello" {
+		t.Errorf("unexpected content: %q", data)
+	}
+
+	name := "docs"
+	vector, err := client.CreateVector(ctx, openai.VectorRequest{Name: &name, FileIDs: &[]string{file.ID}})
+	checks.NoError(t, err, "CreateVector error")
+	if vector.FileCounts == nil || vector.FileCounts.Completed != 1 {
+		t.Errorf("unexpected file counts: %+v", vector.FileCounts)
+	}
+
+	other := srv.AddFile("more.txt", openai.PurposeAssistants, []byte("more"))
+	_, err = client.CreateVectorFile(ctx, vector.ID, openai.VectorFileRequest{FileID: other.ID})
+	checks.NoError(t, err, "CreateVectorFile error")
+
+	limit := 1
+	vectorFiles, err := client.ListVectrFiles(ctx, vector.ID, &limit, nil, nil, nil)
+	checks.NoError(t, err, "ListVectrFiles error")
+	if len(vectorFiles.VectorFiles) != 1 || vectorFiles.VectorFiles[0].ID != other.ID {
+		t.Errorf("unexpected vector files: %+v", vectorFiles.VectorFiles)
+	}
+
+	err = client.DeleteVectorFile(ctx, vector.ID, file.ID)
+	checks.NoError(t, err, "DeleteVectorFile error")
+
+	_, err = client.CreateVectorFile(ctx, vector.ID, openai.VectorFileRequest{FileID: "file-missing"})
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 APIError, got %v", err)
+	}
+
+	_, err = client.DeleteVector(ctx, vector.ID)
+	checks.NoError(t, err, "DeleteVector error")
+
+	vectors, err := client.ListVectors(ctx, nil, nil, nil, nil)
+	checks.NoError(t, err, "ListVectors error")
+	if len(vectors.Vectors) != 0 {
+		t.Errorf("expected no vector stores, got %d", len(vectors.Vectors))
+	}
+}
+
+func TestInjectFailure(t *testing.T) {
+	srv := openaitest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	srv.InjectFailure(openaitest.Failure{
+		Path:       "/v1/chat/completions",
+		StatusCode: http.StatusTooManyRequests,
+		Error:      &openai.APIError{Type: "rate_limit_error", Message: "slow down"},
+		Header:     http.Header{"Retry-After": []string{"1"}},
+		Times:      1,
+	})
+
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "Hi"}},
+	}
+	_, err := client.CreateChatCompletion(context.Background(), request)
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusTooManyRequests || apiErr.Message != "slow down" {
+		t.Fatalf("expected injected rate limit error, got %v", err)
+	}
+
+	_, err = client.CreateChatCompletion(context.Background(), request)
+	checks.NoError(t, err, "request after failure was consumed should succeed")
+
+	if got := len(srv.Requests()); got != 2 {
+		t.Errorf("expected 2 recorded requests, got %d", got)
+	}
+
+	srv.InjectFailure(openaitest.Failure{Path: "/v1/files*"})
+	_, err = client.ListFiles(context.Background())
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusInternalServerError {
+		t.Errorf("expected injected server error, got %v", err)
+	}
+
+	srv.ClearFailures()
+	_, err = client.ListFiles(context.Background())
+	checks.NoError(t, err, "ListFiles error after ClearFailures")
+}
+
+func TestUnauthorized(t *testing.T) {
+	srv := openaitest.NewServer(openaitest.WithToken("secret"))
+	defer srv.Close()
+
+	config := openai.DefaultConfig("wrong")
+	config.BaseURL = srv.URL + "/v1"
+	_, err := openai.NewClientWithConfig(config).ListFiles(context.Background())
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 APIError, got %v", err)
+	}
+}
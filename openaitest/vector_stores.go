@@ -0,0 +1,266 @@
+package openaitest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+type storedVector struct {
+	vector    openai.Vector
+	files     map[string]openai.VectorFile
+	fileOrder []string
+}
+
+func (v *storedVector) snapshot() openai.Vector {
+	vector := v.vector
+	vector.FileCounts = &openai.FileCounts{
+		Completed: len(v.fileOrder),
+		Total:     len(v.fileOrder),
+	}
+	var bytes int64
+	for _, f := range v.files {
+		bytes += f.UsageBytes
+	}
+	vector.Bytes = bytes
+	return vector
+}
+
+// routeVectorStores handles /v1/vector_stores and its sub-resources. It reports
+// whether the request matched a known route.
+func (s *Server) routeVectorStores(w http.ResponseWriter, r *http.Request, parts []string) bool {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		s.handleCreateVectorStore(w, r)
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.handleListVectorStores(w, r)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		s.withVectorStore(w, parts[0], func(v *storedVector) {
+			writeJSON(w, http.StatusOK, v.snapshot())
+		})
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		var request openai.VectorRequest
+		if !decodeBody(w, r, &request) {
+			return true
+		}
+		s.withVectorStore(w, parts[0], func(v *storedVector) {
+			if request.Name != nil {
+				v.vector.Name = request.Name
+			}
+			writeJSON(w, http.StatusOK, v.snapshot())
+		})
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.handleDeleteVectorStore(w, parts[0])
+	case len(parts) == 2 && parts[1] == "files" && r.Method == http.MethodPost:
+		s.handleCreateVectorStoreFile(w, r, parts[0])
+	case len(parts) == 2 && parts[1] == "files" && r.Method == http.MethodGet:
+		s.withVectorStore(w, parts[0], func(v *storedVector) {
+			files := make([]openai.VectorFile, len(v.fileOrder))
+			for i, id := range v.fileOrder {
+				files[i] = v.files[id]
+			}
+			writeJSON(w, http.StatusOK, listPage(r, files, func(f openai.VectorFile) string { return f.ID }))
+		})
+	case len(parts) == 3 && parts[1] == "files" && r.Method == http.MethodGet:
+		s.withVectorStore(w, parts[0], func(v *storedVector) {
+			f, ok := v.files[parts[2]]
+			if !ok {
+				writeNotFound(w, "vector store file", parts[2])
+				return
+			}
+			writeJSON(w, http.StatusOK, f)
+		})
+	case len(parts) == 3 && parts[1] == "files" && r.Method == http.MethodDelete:
+		s.withVectorStore(w, parts[0], func(v *storedVector) {
+			if _, ok := v.files[parts[2]]; !ok {
+				writeNotFound(w, "vector store file", parts[2])
+				return
+			}
+			delete(v.files, parts[2])
+			v.fileOrder = removeID(v.fileOrder, parts[2])
+			writeJSON(w, http.StatusOK, map[string]any{
+				"id":      parts[2],
+				"object":  "vector_store.file.deleted",
+				"deleted": true,
+			})
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+// withVectorStore calls fn with the vector store while holding the server lock,
+// or writes a 404 if it does not exist.
+func (s *Server) withVectorStore(w http.ResponseWriter, id string, fn func(*storedVector)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.vectors[id]
+	if !ok {
+		writeNotFound(w, "vector store", id)
+		return
+	}
+	fn(v)
+}
+
+func (s *Server) handleCreateVectorStore(w http.ResponseWriter, r *http.Request) {
+	var request openai.VectorRequest
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	v := &storedVector{
+		vector: openai.Vector{
+			ID:        s.newID("vs_mock"),
+			Object:    "vector_store",
+			CreatedAt: time.Now().Unix(),
+			Name:      request.Name,
+		},
+		files: make(map[string]openai.VectorFile),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if request.FileIDs != nil {
+		for _, fileID := range *request.FileIDs {
+			f, ok := s.files[fileID]
+			if !ok {
+				writeNotFound(w, "file", fileID)
+				return
+			}
+			v.files[fileID] = vectorFile(v.vector.ID, f)
+			v.fileOrder = append(v.fileOrder, fileID)
+		}
+	}
+	s.vectors[v.vector.ID] = v
+	s.vectorOrder = append(s.vectorOrder, v.vector.ID)
+	writeJSON(w, http.StatusOK, v.snapshot())
+}
+
+func (s *Server) handleListVectorStores(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	vectors := make([]openai.Vector, len(s.vectorOrder))
+	for i, id := range s.vectorOrder {
+		vectors[i] = s.vectors[id].snapshot()
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, listPage(r, vectors, func(v openai.Vector) string { return v.ID }))
+}
+
+func (s *Server) handleDeleteVectorStore(w http.ResponseWriter, id string) {
+	s.mu.Lock()
+	_, ok := s.vectors[id]
+	if ok {
+		delete(s.vectors, id)
+		s.vectorOrder = removeID(s.vectorOrder, id)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeNotFound(w, "vector store", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, openai.VectorDeleteResponse{
+		ID:      id,
+		Object:  "vector_store.deleted",
+		Deleted: true,
+	})
+}
+
+func (s *Server) handleCreateVectorStoreFile(w http.ResponseWriter, r *http.Request, vectorID string) {
+	var request openai.VectorFileRequest
+	if !decodeBody(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.vectors[vectorID]
+	if !ok {
+		writeNotFound(w, "vector store", vectorID)
+		return
+	}
+	f, ok := s.files[request.FileID]
+	if !ok {
+		writeNotFound(w, "file", request.FileID)
+		return
+	}
+	if _, exists := v.files[request.FileID]; !exists {
+		v.fileOrder = append(v.fileOrder, request.FileID)
+	}
+	v.files[request.FileID] = vectorFile(vectorID, f)
+	writeJSON(w, http.StatusOK, v.files[request.FileID])
+}
+
+// vectorFile attaches a stored file to a vector store. Files are indexed
+// immediately, so the status is always completed.
+func vectorFile(vectorID string, f *storedFile) openai.VectorFile {
+	return openai.VectorFile{
+		ID:            f.file.ID,
+		Object:        "vector_store.file",
+		CreatedAt:     time.Now().Unix(),
+		UsageBytes:    int64(len(f.content)),
+		VectorStoreID: vectorID,
+		Status:        "completed",
+	}
+}
+
+// listPage applies the limit, order, after and before query parameters to
+// items, which must be in creation order, and returns a list object.
+func listPage[T any](r *http.Request, items []T, id func(T) string) map[string]any {
+	query := r.URL.Query()
+
+	// The API lists newest first unless order=asc is requested.
+	if query.Get("order") != "asc" {
+		reversed := make([]T, len(items))
+		for i, item := range items {
+			reversed[len(items)-1-i] = item
+		}
+		items = reversed
+	}
+
+	if after := query.Get("after"); after != "" {
+		for i, item := range items {
+			if id(item) == after {
+				items = items[i+1:]
+				break
+			}
+		}
+	}
+	if before := query.Get("before"); before != "" {
+		for i, item := range items {
+			if id(item) == before {
+				items = items[:i]
+				break
+			}
+		}
+	}
+
+	limit := 20
+	if l, err := strconv.Atoi(query.Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	page := map[string]any{
+		"object":   "list",
+		"data":     items,
+		"has_more": hasMore,
+		"first_id": nil,
+		"last_id":  nil,
+	}
+	if len(items) > 0 {
+		page["first_id"] = id(items[0])
+		page["last_id"] = id(items[len(items)-1])
+	}
+	return page
+}
@@ -0,0 +1,74 @@
+package openai
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OperationTimeouts sets default timeouts by kind of operation, so that quick
+// calls fail fast while generations and uploads get the time they need. A
+// timeout applies only when the caller's context has no deadline and the call
+// has no WithTimeout option. For streams it bounds the whole stream. Zero
+// fields disable the timeout.
+type OperationTimeouts struct {
+	// Fast applies to embeddings and moderations.
+	Fast time.Duration
+	// Slow applies to chat and text completions and to image, audio and video generation.
+	Slow time.Duration
+	// VerySlow applies to file uploads, such as fine-tuning training files.
+	VerySlow time.Duration
+	// Default applies to all other calls.
+	Default time.Duration
+	// Flex applies instead of the timeouts above to requests with
+	// ServiceTierFlex, which can take much longer. Zero uses DefaultFlexTimeout.
+	Flex time.Duration
+}
+
+var slowOperationSuffixes = []string{
+	"/chat/completions",
+	"/completions",
+	"/edits",
+	"/images/generations",
+	"/images/edits",
+	"/images/variations",
+	"/audio/speech",
+	"/audio/transcriptions",
+	"/audio/translations",
+	"/videos",
+	"/remix",
+}
+
+// timeout returns the default timeout of a request to url.
+func (t OperationTimeouts) timeout(method, url string) time.Duration {
+	path, _, _ := strings.Cut(url, "?")
+	path = strings.TrimRight(path, "/")
+
+	switch {
+	case strings.HasSuffix(path, "/embeddings"), strings.HasSuffix(path, "/moderations"):
+		return t.Fast
+	case method == http.MethodPost && hasAnySuffix(path, slowOperationSuffixes):
+		return t.Slow
+	case method == http.MethodPost && strings.HasSuffix(path, "/files") && !strings.Contains(path, vectorSuffix+"/"):
+		// Attaching a file to a vector store does not upload it.
+		return t.VerySlow
+	default:
+		return t.Default
+	}
+}
+
+func (t OperationTimeouts) flexTimeout() time.Duration {
+	if t.Flex > 0 {
+		return t.Flex
+	}
+	return DefaultFlexTimeout
+}
+
+func hasAnySuffix(s string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
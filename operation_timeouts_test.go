@@ -0,0 +1,58 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestOperationTimeouts(t *testing.T) {
+	server := test.NewTestServer()
+	slow := func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+		fmt.Fprintln(w, `{"object":"list","data":[]}`)
+	}
+	server.RegisterHandler("/v1/embeddings", slow)
+	server.RegisterHandler("/v1/models", slow)
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.OperationTimeouts = openai.OperationTimeouts{
+		Fast: 20 * time.Millisecond,
+		Slow: time.Hour,
+	}
+	client := openai.NewClientWithConfig(config)
+
+	request := openai.EmbeddingRequest{Input: []string{"hello"}, Model: openai.SmallEmbedding3}
+	_, err := client.CreateEmbeddings(context.Background(), request)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the fast timeout to apply to embeddings, got %v", err)
+	}
+
+	// A deadline set by the caller takes precedence.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = client.CreateEmbeddings(ctx, request)
+	checks.NoError(t, err, "CreateEmbeddings error")
+
+	// So does WithTimeout.
+	_, err = client.CreateEmbeddings(context.Background(), request, openai.WithTimeout(time.Second))
+	checks.NoError(t, err, "CreateEmbeddings error")
+
+	// Other operations use Default, which is unset.
+	_, err = client.ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+}
@@ -0,0 +1,118 @@
+// Package pricing estimates the dollar cost of API calls from their token
+// usage, so that costs can be logged and aggregated per request.
+//
+// The built-in prices are OpenAI's list prices for standard processing and
+// change over time. Override or extend them with Register:
+//
+//	pricing.Register("ft:gpt-4o-mini", pricing.Price{Input: 0.30, Output: 1.20})
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// ErrUnknownModel is returned when no price is known for a model.
+var ErrUnknownModel = errors.New("pricing: no price known for model")
+
+// Price is the price of a model in US dollars per million tokens.
+type Price struct {
+	Input float64
+	// CachedInput applies to prompt tokens read from the prompt cache. Zero
+	// charges them at the Input price.
+	CachedInput float64
+	Output      float64
+}
+
+var (
+	mu sync.RWMutex
+
+	// prices maps model name prefixes to prices. The longest matching prefix
+	// wins, so "gpt-4o-mini" is not priced as "gpt-4o".
+	prices = map[string]Price{
+		"gpt-5":                  {Input: 1.25, CachedInput: 0.125, Output: 10},
+		"gpt-5-mini":             {Input: 0.25, CachedInput: 0.025, Output: 2},
+		"gpt-5-nano":             {Input: 0.05, CachedInput: 0.005, Output: 0.40},
+		"gpt-4.5-preview":        {Input: 75, CachedInput: 37.5, Output: 150},
+		"gpt-4.1":                {Input: 2, CachedInput: 0.50, Output: 8},
+		"gpt-4.1-mini":           {Input: 0.40, CachedInput: 0.10, Output: 1.60},
+		"gpt-4.1-nano":           {Input: 0.10, CachedInput: 0.025, Output: 0.40},
+		"gpt-4o":                 {Input: 2.50, CachedInput: 1.25, Output: 10},
+		"gpt-4o-2024-05-13":      {Input: 5, Output: 15},
+		"gpt-4o-mini":            {Input: 0.15, CachedInput: 0.075, Output: 0.60},
+		"o1":                     {Input: 15, CachedInput: 7.50, Output: 60},
+		"o1-mini":                {Input: 1.10, CachedInput: 0.55, Output: 4.40},
+		"o3":                     {Input: 2, CachedInput: 0.50, Output: 8},
+		"o3-mini":                {Input: 1.10, CachedInput: 0.55, Output: 4.40},
+		"o4-mini":                {Input: 1.10, CachedInput: 0.275, Output: 4.40},
+		"gpt-4-turbo":            {Input: 10, Output: 30},
+		"gpt-4-0125-preview":     {Input: 10, Output: 30},
+		"gpt-4-1106-preview":     {Input: 10, Output: 30},
+		"gpt-4":                  {Input: 30, Output: 60},
+		"gpt-4-32k":              {Input: 60, Output: 120},
+		"gpt-3.5-turbo":          {Input: 0.50, Output: 1.50},
+		"gpt-3.5-turbo-instruct": {Input: 1.50, Output: 2},
+		"text-embedding-3-small": {Input: 0.02},
+		"text-embedding-3-large": {Input: 0.13},
+		"text-embedding-ada-002": {Input: 0.10},
+	}
+)
+
+// Register sets the price of the models whose names start with prefix, such
+// as a model family, a fine-tuned model or an Azure deployment name.
+func Register(prefix string, price Price) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	prices[prefix] = price
+}
+
+// PriceOf returns the price of model.
+func PriceOf(model string) (Price, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	prefixes := make([]string, 0, len(prices))
+	for prefix := range prices {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(model, prefix) {
+			return prices[prefix], nil
+		}
+	}
+	return Price{}, fmt.Errorf("%w: %s", ErrUnknownModel, model)
+}
+
+// CostOf returns the cost in US dollars of a request to model that used
+// usage. Cached prompt tokens are charged at the cached input price.
+func CostOf(usage openai.Usage, model string) (float64, error) {
+	price, err := PriceOf(model)
+	if err != nil {
+		return 0, err
+	}
+	return price.Cost(usage), nil
+}
+
+// Cost returns the cost in US dollars of usage at price p.
+func (p Price) Cost(usage openai.Usage) float64 {
+	cached := 0
+	if usage.PromptTokensDetails != nil {
+		cached = usage.PromptTokensDetails.CachedTokens
+	}
+	cachedPrice := p.CachedInput
+	if cachedPrice == 0 {
+		cachedPrice = p.Input
+	}
+	cost := float64(usage.PromptTokens-cached)*p.Input +
+		float64(cached)*cachedPrice +
+		float64(usage.CompletionTokens)*p.Output
+	return cost / 1e6
+}
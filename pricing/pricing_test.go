@@ -0,0 +1,47 @@
+package pricing_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/pricing"
+)
+
+func TestCostOf(t *testing.T) {
+	usage := openai.Usage{
+		PromptTokens:        1_000_000,
+		CompletionTokens:    100_000,
+		PromptTokensDetails: &openai.PromptTokensDetails{CachedTokens: 400_000},
+	}
+	cases := []struct {
+		model string
+		cost  float64
+	}{
+		// 600k input at $2.50, 400k cached at $1.25 and 100k output at $10.
+		{"gpt-4o-2024-08-06", 1.5 + 0.5 + 1},
+		// Cached tokens are charged at the input price when no cached price is known.
+		{"gpt-4o-2024-05-13", 5 + 1.5},
+		{"gpt-4o-mini", 0.09 + 0.03 + 0.06},
+	}
+	for _, c := range cases {
+		cost, err := pricing.CostOf(usage, c.model)
+		if err != nil {
+			t.Fatalf("%s: %v", c.model, err)
+		}
+		if math.Abs(cost-c.cost) > 1e-9 {
+			t.Errorf("%s: cost %f, want %f", c.model, cost, c.cost)
+		}
+	}
+
+	if _, err := pricing.CostOf(usage, "llama-3"); !errors.Is(err, pricing.ErrUnknownModel) {
+		t.Errorf("expected ErrUnknownModel, got %v", err)
+	}
+
+	pricing.Register("llama-3", pricing.Price{Input: 1, Output: 2})
+	cost, err := pricing.CostOf(openai.Usage{PromptTokens: 500_000, CompletionTokens: 500_000}, "llama-3-70b")
+	if err != nil || math.Abs(cost-1.5) > 1e-9 {
+		t.Errorf("registered price: cost %f, error %v", cost, err)
+	}
+}
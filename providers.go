@@ -0,0 +1,119 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	openRouterAPIURLv1 = "https://openrouter.ai/api/v1"
+	groqAPIURLv1       = "https://api.groq.com/openai/v1"
+	ollamaAPIURLv1     = "http://localhost:11434/v1"
+)
+
+// ErrUnsupportedByProvider is matched by errors.Is for every
+// *UnsupportedByProviderError.
+var ErrUnsupportedByProvider = errors.New("endpoint not supported by provider")
+
+// Provider is an OpenAI-compatible backend. It selects which endpoints the
+// client sends requests to, since most backends implement only a part of the
+// OpenAI API.
+type Provider string
+
+const (
+	// ProviderOpenAI is the OpenAI API, which supports all endpoints.
+	ProviderOpenAI     Provider = ""
+	ProviderOpenRouter Provider = "openrouter"
+	ProviderGroq       Provider = "groq"
+	ProviderOllama     Provider = "ollama"
+	ProviderVLLM       Provider = "vllm"
+)
+
+// providerEndpoints lists the path prefixes, relative to the base URL, that
+// each provider serves. Providers that are not listed serve all endpoints.
+var providerEndpoints = map[Provider][]string{
+	ProviderOpenRouter: {"/chat/completions", "/completions", "/embeddings", "/models"},
+	ProviderGroq: {
+		"/chat/completions", "/models", "/audio/transcriptions", "/audio/translations", "/audio/speech",
+		"/files", "/batches",
+	},
+	ProviderOllama: {"/chat/completions", "/completions", "/embeddings", "/models"},
+	ProviderVLLM: {
+		"/chat/completions", "/completions", "/embeddings", "/models", "/audio/transcriptions",
+		"/audio/translations",
+	},
+}
+
+// UnsupportedByProviderError is returned without sending the request when a
+// method calls an endpoint that ClientConfig.Provider does not serve.
+type UnsupportedByProviderError struct {
+	Provider Provider
+	Method   string
+	Path     string
+}
+
+func (e *UnsupportedByProviderError) Error() string {
+	return fmt.Sprintf("%s: %s does not serve %s %s", ErrUnsupportedByProvider, e.Provider, e.Method, e.Path)
+}
+
+func (e *UnsupportedByProviderError) Is(target error) bool {
+	return target == ErrUnsupportedByProvider
+}
+
+// checkProviderSupport rejects requests to url for endpoints that the
+// configured provider does not serve.
+func (c *Client) checkProviderSupport(method, url string) error {
+	endpoints, ok := providerEndpoints[c.config.Provider]
+	if !ok {
+		return nil
+	}
+	path, _, _ := strings.Cut(url, "?")
+	path = strings.TrimPrefix(path, c.apiBaseURL())
+	for _, endpoint := range endpoints {
+		if path == endpoint || strings.HasPrefix(path, endpoint+"/") {
+			return nil
+		}
+	}
+	return &UnsupportedByProviderError{Provider: c.config.Provider, Method: method, Path: path}
+}
+
+// DefaultOpenRouterConfig returns a config for OpenRouter. Requests may be
+// attributed to an app with the HTTP-Referer and X-Title headers, set with
+// WithHeader.
+func DefaultOpenRouterConfig(apiKey string) ClientConfig {
+	config := DefaultConfig(apiKey)
+	config.BaseURL = openRouterAPIURLv1
+	config.Provider = ProviderOpenRouter
+	return config
+}
+
+// DefaultGroqConfig returns a config for GroqCloud.
+func DefaultGroqConfig(apiKey string) ClientConfig {
+	config := DefaultConfig(apiKey)
+	config.BaseURL = groqAPIURLv1
+	config.Provider = ProviderGroq
+	return config
+}
+
+// DefaultOllamaConfig returns a config for a local Ollama server, which needs
+// no API key. baseURL defaults to http://localhost:11434/v1.
+func DefaultOllamaConfig(baseURL string) ClientConfig {
+	if baseURL == "" {
+		baseURL = ollamaAPIURLv1
+	}
+	config := DefaultConfig("")
+	config.BaseURL = baseURL
+	config.Provider = ProviderOllama
+	return config
+}
+
+// DefaultVLLMConfig returns a config for a vLLM server at baseURL, e.g.
+// http://localhost:8000/v1. apiKey is only needed when the server was started
+// with --api-key, and no Authorization header is sent when it is empty.
+func DefaultVLLMConfig(apiKey, baseURL string) ClientConfig {
+	config := DefaultConfig(apiKey)
+	config.BaseURL = baseURL
+	config.Provider = ProviderVLLM
+	return config
+}
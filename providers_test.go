@@ -0,0 +1,59 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestProviderConfigs(t *testing.T) {
+	cases := []struct {
+		config  openai.ClientConfig
+		baseURL string
+	}{
+		{openai.DefaultOpenRouterConfig("key"), "https://openrouter.ai/api/v1"},
+		{openai.DefaultGroqConfig("key"), "https://api.groq.com/openai/v1"},
+		{openai.DefaultOllamaConfig(""), "http://localhost:11434/v1"},
+		{openai.DefaultOllamaConfig("http://gpu:11434/v1"), "http://gpu:11434/v1"},
+		{openai.DefaultVLLMConfig("", "http://localhost:8000/v1"), "http://localhost:8000/v1"},
+	}
+	for _, c := range cases {
+		if c.config.BaseURL != c.baseURL {
+			t.Errorf("%s: base URL %q, want %q", c.config.Provider, c.config.BaseURL, c.baseURL)
+		}
+	}
+}
+
+func TestProviderUnsupportedEndpoints(t *testing.T) {
+	var authorization []string
+	// The test server of the other tests requires an API key, which Ollama
+	// does not use.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = append(authorization, r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"id":"llama3"}`)
+	}))
+	defer ts.Close()
+
+	client := openai.NewClientWithConfig(openai.DefaultOllamaConfig(ts.URL + "/v1"))
+	ctx := context.Background()
+
+	_, err := client.GetModel(ctx, "llama3")
+	checks.NoError(t, err, "GetModel error")
+	if len(authorization) != 1 || authorization[0] != "" {
+		t.Errorf("expected no Authorization header, got %q", authorization)
+	}
+
+	_, err = client.CreateAssistant(ctx, openai.AssistantRequest{Model: "llama3"})
+	checks.ErrorIs(t, err, openai.ErrUnsupportedByProvider, "assistants should be unsupported")
+	if err.Error() != "endpoint not supported by provider: ollama does not serve POST /assistants" {
+		t.Errorf("unexpected error message %q", err)
+	}
+	if len(authorization) != 1 {
+		t.Errorf("unsupported requests should not be sent")
+	}
+}
@@ -0,0 +1,75 @@
+package openai
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ProxyConfig routes requests through an HTTP, HTTPS or SOCKS5 proxy. Set it
+// as TransportConfig.Proxy. Without it, the HTTP_PROXY, HTTPS_PROXY and
+// NO_PROXY environment variables are used.
+type ProxyConfig struct {
+	// URL of the proxy, e.g. "http://proxy.corp:3128" or "socks5://127.0.0.1:1080".
+	URL string
+	// Username and Password authenticate with the proxy. They replace any
+	// credentials in URL.
+	Username string
+	Password string
+	// NoProxy lists hosts reached directly: host names, domain suffixes
+	// starting with ".", IP addresses, CIDR ranges, or "*" for all hosts.
+	NoProxy []string
+}
+
+// proxyFunc returns the function used as http.Transport.Proxy.
+func (p *ProxyConfig) proxyFunc() func(*http.Request) (*url.URL, error) {
+	proxyURL, err := url.Parse(p.URL)
+	if err == nil {
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			err = fmt.Errorf("unsupported proxy scheme %q", proxyURL.Scheme)
+		}
+	}
+	if err == nil && p.Username != "" {
+		proxyURL.User = url.UserPassword(p.Username, p.Password)
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if p.bypass(req.URL.Hostname()) {
+			return nil, nil //nolint:nilnil // a nil URL means no proxy to http.Transport
+		}
+		return proxyURL, nil
+	}
+}
+
+// bypass reports whether host matches NoProxy.
+func (p *ProxyConfig) bypass(host string) bool {
+	host = strings.ToLower(host)
+	ip := net.ParseIP(host)
+	for _, pattern := range p.NoProxy {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		switch {
+		case pattern == "":
+		case pattern == "*":
+			return true
+		case strings.Contains(pattern, "/"):
+			_, network, err := net.ParseCIDR(pattern)
+			if err == nil && ip != nil && network.Contains(ip) {
+				return true
+			}
+		case strings.HasPrefix(pattern, "."):
+			if strings.HasSuffix(host, pattern) || host == pattern[1:] {
+				return true
+			}
+		case host == pattern:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestProxyConfig(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "api.openai.invalid" {
+			t.Errorf("expected a proxied request for api.openai.invalid, got %q", r.URL.String())
+		}
+		expected := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:secret"))
+		if got := r.Header.Get("Proxy-Authorization"); got != expected {
+			t.Errorf("expected proxy credentials, got %q", got)
+		}
+		fmt.Fprintln(w, `{"object":"list","data":[]}`)
+	}))
+	defer proxy.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = "http://api.openai.invalid/v1"
+	config.Transport.Proxy = &openai.ProxyConfig{
+		URL:      proxy.URL,
+		Username: "user",
+		Password: "secret",
+	}
+	client := openai.NewClientWithConfig(config)
+
+	_, err := client.ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+}
+
+func TestProxyConfigNoProxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		t.Errorf("request for %s was not expected to use the proxy", r.URL)
+	}))
+	defer proxy.Close()
+
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `{"object":"list","data":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	for _, noProxy := range []string{"127.0.0.1", "127.0.0.0/8", "*"} {
+		config := openai.DefaultConfig(test.GetTestToken())
+		config.BaseURL = ts.URL + "/v1"
+		config.Transport.Proxy = &openai.ProxyConfig{URL: proxy.URL, NoProxy: []string{"example.com", noProxy}}
+		_, err := openai.NewClientWithConfig(config).ListModels(context.Background())
+		checks.NoError(t, err, "ListModels error")
+	}
+}
+
+func TestProxyConfigInvalidURL(t *testing.T) {
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = "http://api.openai.invalid/v1"
+	config.Transport.Proxy = &openai.ProxyConfig{URL: "ftp://proxy.corp"}
+
+	_, err := openai.NewClientWithConfig(config).ListModels(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "unsupported proxy scheme") {
+		t.Errorf("expected an unsupported scheme error, got %v", err)
+	}
+}
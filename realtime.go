@@ -0,0 +1,662 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sashabaranov/go-openai/internal/websocket"
+)
+
+const (
+	realtimeSuffix         = "/realtime"
+	realtimeSessionsSuffix = "/realtime/sessions"
+
+	realtimeTranscriptionSessionsSuffix = "/realtime/transcription_sessions"
+)
+
+// RealtimeModality is a kind of output of a realtime session.
+type RealtimeModality string
+
+const (
+	RealtimeModalityText  RealtimeModality = "text"
+	RealtimeModalityAudio RealtimeModality = "audio"
+)
+
+// RealtimeAudioFormat is the format of the audio sent to or received from a
+// realtime session.
+type RealtimeAudioFormat string
+
+const (
+	RealtimeAudioFormatPCM16    RealtimeAudioFormat = "pcm16"
+	RealtimeAudioFormatG711ULaw RealtimeAudioFormat = "g711_ulaw"
+	RealtimeAudioFormatG711ALaw RealtimeAudioFormat = "g711_alaw"
+)
+
+// RealtimeMaxTokens bounds the output tokens of a realtime response. Zero
+// leaves it unbounded, which the API reports as "inf".
+type RealtimeMaxTokens int
+
+func (m *RealtimeMaxTokens) UnmarshalJSON(data []byte) error {
+	if string(data) == `"inf"` || string(data) == "null" {
+		*m = 0
+		return nil
+	}
+	return json.Unmarshal(data, (*int)(m))
+}
+
+// RealtimeSession is the configuration of a realtime session, updated with
+// RealtimeSessionUpdateEvent. Only the fields that are set are changed.
+type RealtimeSession struct {
+	ID                      string              `json:"id,omitempty"`
+	Model                   string              `json:"model,omitempty"`
+	Modalities              []RealtimeModality  `json:"modalities,omitempty"`
+	Instructions            string              `json:"instructions,omitempty"`
+	Voice                   string              `json:"voice,omitempty"`
+	InputAudioFormat        RealtimeAudioFormat `json:"input_audio_format,omitempty"`
+	OutputAudioFormat       RealtimeAudioFormat `json:"output_audio_format,omitempty"`
+	Temperature             *float32            `json:"temperature,omitempty"`
+	MaxResponseOutputTokens RealtimeMaxTokens   `json:"max_response_output_tokens,omitempty"`
+	Tools                   []RealtimeTool      `json:"tools,omitempty"`
+	// ToolChoice is "auto", "none", "required" or a ToolChoice naming a
+	// function.
+	ToolChoice any `json:"tool_choice,omitempty"`
+	// InputAudioTranscription transcribes the input audio alongside the
+	// conversation.
+	InputAudioTranscription *RealtimeInputAudioTranscription `json:"input_audio_transcription,omitempty"`
+	// TurnDetection is left unchanged when nil.
+	TurnDetection *RealtimeTurnDetection `json:"turn_detection,omitempty"`
+}
+
+// RealtimeInputAudioTranscription configures the transcription of the input
+// audio of a realtime session.
+type RealtimeInputAudioTranscription struct {
+	// Model is e.g. "gpt-4o-transcribe" or "whisper-1".
+	Model string `json:"model,omitempty"`
+	// Language is the ISO-639-1 code of the language of the audio, which
+	// improves accuracy and latency.
+	Language string `json:"language,omitempty"`
+	// Prompt guides the transcription, e.g. with expected keywords.
+	Prompt string `json:"prompt,omitempty"`
+}
+
+// RealtimeTranscriptionSession is the configuration of a transcription-only
+// realtime session, opened with ConnectRealtimeTranscription and updated with
+// RealtimeTranscriptionSessionUpdateEvent. Such a session transcribes the
+// input audio without generating responses.
+type RealtimeTranscriptionSession struct {
+	ID                      string                           `json:"id,omitempty"`
+	InputAudioFormat        RealtimeAudioFormat              `json:"input_audio_format,omitempty"`
+	InputAudioTranscription *RealtimeInputAudioTranscription `json:"input_audio_transcription,omitempty"`
+	TurnDetection           *RealtimeTurnDetection           `json:"turn_detection,omitempty"`
+}
+
+// RealtimeTool is a function the model of a realtime session may call. Its
+// calls are answered with DispatchFunctionCalls, or by sending the item
+// returned by NewRealtimeFunctionCallOutput.
+type RealtimeTool struct {
+	Type        ToolType `json:"type"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	// Parameters is the JSON schema of the arguments, e.g. a
+	// jsonschema.Definition.
+	Parameters any `json:"parameters,omitempty"`
+}
+
+// RealtimeItemType is the type of an item of a realtime conversation.
+type RealtimeItemType string
+
+const (
+	RealtimeItemTypeMessage            RealtimeItemType = "message"
+	RealtimeItemTypeFunctionCall       RealtimeItemType = "function_call"
+	RealtimeItemTypeFunctionCallOutput RealtimeItemType = "function_call_output"
+)
+
+// RealtimeContentType is the type of a content part of a realtime message.
+type RealtimeContentType string
+
+const (
+	RealtimeContentTypeInputText     RealtimeContentType = "input_text"
+	RealtimeContentTypeInputAudio    RealtimeContentType = "input_audio"
+	RealtimeContentTypeItemReference RealtimeContentType = "item_reference"
+	RealtimeContentTypeText          RealtimeContentType = "text"
+	RealtimeContentTypeAudio         RealtimeContentType = "audio"
+)
+
+// RealtimeContent is a content part of a realtime message.
+type RealtimeContent struct {
+	Type RealtimeContentType `json:"type"`
+	Text string              `json:"text,omitempty"`
+	// Audio is base64 encoded in the audio format of the session, and
+	// Transcript is its transcription.
+	Audio      string `json:"audio,omitempty"`
+	Transcript string `json:"transcript,omitempty"`
+	// ID is the item referenced by an item_reference part.
+	ID string `json:"id,omitempty"`
+}
+
+// RealtimeItem is an item of a realtime conversation.
+type RealtimeItem struct {
+	ID      string            `json:"id,omitempty"`
+	Type    RealtimeItemType  `json:"type"`
+	Object  string            `json:"object,omitempty"`
+	Status  string            `json:"status,omitempty"`
+	Role    string            `json:"role,omitempty"`
+	Content []RealtimeContent `json:"content,omitempty"`
+
+	// CallID identifies a function call, and the output that answers it.
+	CallID    string `json:"call_id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+	Output    string `json:"output,omitempty"`
+}
+
+// NewRealtimeTextMessage returns a message item of role with text.
+func NewRealtimeTextMessage(role, text string) RealtimeItem {
+	contentType := RealtimeContentTypeInputText
+	if role == ChatMessageRoleAssistant {
+		contentType = RealtimeContentTypeText
+	}
+	return RealtimeItem{
+		Type:    RealtimeItemTypeMessage,
+		Role:    role,
+		Content: []RealtimeContent{{Type: contentType, Text: text}},
+	}
+}
+
+// NewRealtimeFunctionCallOutput returns the item that answers the function
+// call callID with output.
+func NewRealtimeFunctionCallOutput(callID, output string) RealtimeItem {
+	return RealtimeItem{Type: RealtimeItemTypeFunctionCallOutput, CallID: callID, Output: output}
+}
+
+// NewRealtimeItemReference returns a message item referencing the item itemID
+// of the conversation, e.g. to give the input of an out-of-band response.
+func NewRealtimeItemReference(itemID string) RealtimeItem {
+	return RealtimeItem{
+		Type:    RealtimeItemTypeMessage,
+		Role:    ChatMessageRoleUser,
+		Content: []RealtimeContent{{Type: RealtimeContentTypeItemReference, ID: itemID}},
+	}
+}
+
+const (
+	// RealtimeConversationAuto adds a response to the conversation.
+	RealtimeConversationAuto = "auto"
+	// RealtimeConversationNone keeps a response out of the conversation.
+	RealtimeConversationNone = "none"
+)
+
+// RealtimeResponseConfig configures a response created with
+// RealtimeResponseCreateEvent, overriding the session for this response only.
+//
+// A response with Conversation set to RealtimeConversationNone is out of band:
+// it is not added to the conversation, and runs on Input instead of the
+// conversation when Input is set. Responses run concurrently, so Metadata is
+// the way to tell the events of an out-of-band response apart.
+type RealtimeResponseConfig struct {
+	Conversation            string              `json:"conversation,omitempty"`
+	Metadata                map[string]string   `json:"metadata,omitempty"`
+	Input                   []RealtimeItem      `json:"input,omitempty"`
+	Modalities              []RealtimeModality  `json:"modalities,omitempty"`
+	Instructions            string              `json:"instructions,omitempty"`
+	Voice                   string              `json:"voice,omitempty"`
+	OutputAudioFormat       RealtimeAudioFormat `json:"output_audio_format,omitempty"`
+	Temperature             *float32            `json:"temperature,omitempty"`
+	MaxResponseOutputTokens RealtimeMaxTokens   `json:"max_response_output_tokens,omitempty"`
+	Tools                   []RealtimeTool      `json:"tools,omitempty"`
+	ToolChoice              any                 `json:"tool_choice,omitempty"`
+}
+
+// RealtimeResponseStatusDetails tells why a realtime response is not
+// completed.
+type RealtimeResponseStatusDetails struct {
+	Type   string    `json:"type"`
+	Reason string    `json:"reason,omitempty"`
+	Error  *APIError `json:"error,omitempty"`
+}
+
+// RealtimeUsage is the token usage of a realtime response.
+type RealtimeUsage struct {
+	TotalTokens  int `json:"total_tokens"`
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// RealtimeResponse is a response of a realtime session.
+type RealtimeResponse struct {
+	ID            string                         `json:"id"`
+	Object        string                         `json:"object"`
+	Status        string                         `json:"status"`
+	StatusDetails *RealtimeResponseStatusDetails `json:"status_details,omitempty"`
+	Output        []RealtimeItem                 `json:"output"`
+	Metadata      map[string]string              `json:"metadata,omitempty"`
+	Usage         *RealtimeUsage                 `json:"usage,omitempty"`
+}
+
+// OutputText returns the text of the output messages of the response, using
+// the transcript of audio parts.
+func (r RealtimeResponse) OutputText() string {
+	var text strings.Builder
+	for _, item := range r.Output {
+		for _, content := range item.Content {
+			switch content.Type {
+			case RealtimeContentTypeText:
+				text.WriteString(content.Text)
+			case RealtimeContentTypeAudio:
+				text.WriteString(content.Transcript)
+			}
+		}
+	}
+	return text.String()
+}
+
+// RealtimeClientEvent is an event sent to a realtime session with
+// RealtimeConn.Send.
+type RealtimeClientEvent interface {
+	realtimeEventType() string
+}
+
+// RealtimeSessionUpdateEvent updates the configuration of the session.
+type RealtimeSessionUpdateEvent struct {
+	EventID string          `json:"event_id,omitempty"`
+	Session RealtimeSession `json:"session"`
+}
+
+// RealtimeTranscriptionSessionUpdateEvent updates the configuration of a
+// transcription session.
+type RealtimeTranscriptionSessionUpdateEvent struct {
+	EventID string                       `json:"event_id,omitempty"`
+	Session RealtimeTranscriptionSession `json:"session"`
+}
+
+// RealtimeInputAudioBufferAppendEvent appends base64 encoded audio to the
+// input audio buffer.
+type RealtimeInputAudioBufferAppendEvent struct {
+	EventID string `json:"event_id,omitempty"`
+	Audio   string `json:"audio"`
+}
+
+// RealtimeInputAudioBufferCommitEvent commits the input audio buffer as a
+// user message, when the session has no turn detection.
+type RealtimeInputAudioBufferCommitEvent struct {
+	EventID string `json:"event_id,omitempty"`
+}
+
+// RealtimeInputAudioBufferClearEvent clears the input audio buffer.
+type RealtimeInputAudioBufferClearEvent struct {
+	EventID string `json:"event_id,omitempty"`
+}
+
+// RealtimeConversationItemCreateEvent adds an item to the conversation, after
+// PreviousItemID or at the end.
+type RealtimeConversationItemCreateEvent struct {
+	EventID        string       `json:"event_id,omitempty"`
+	PreviousItemID string       `json:"previous_item_id,omitempty"`
+	Item           RealtimeItem `json:"item"`
+}
+
+// RealtimeResponseCreateEvent creates a response, configured by Response or
+// by the session.
+type RealtimeResponseCreateEvent struct {
+	EventID  string                  `json:"event_id,omitempty"`
+	Response *RealtimeResponseConfig `json:"response,omitempty"`
+}
+
+// RealtimeResponseCancelEvent cancels the response ResponseID, or the
+// response in progress of the conversation.
+type RealtimeResponseCancelEvent struct {
+	EventID    string `json:"event_id,omitempty"`
+	ResponseID string `json:"response_id,omitempty"`
+}
+
+func (RealtimeSessionUpdateEvent) realtimeEventType() string {
+	return "session.update"
+}
+
+func (RealtimeTranscriptionSessionUpdateEvent) realtimeEventType() string {
+	return "transcription_session.update"
+}
+
+func (RealtimeInputAudioBufferAppendEvent) realtimeEventType() string {
+	return "input_audio_buffer.append"
+}
+
+func (RealtimeInputAudioBufferCommitEvent) realtimeEventType() string {
+	return "input_audio_buffer.commit"
+}
+
+func (RealtimeInputAudioBufferClearEvent) realtimeEventType() string {
+	return "input_audio_buffer.clear"
+}
+
+func (RealtimeConversationItemCreateEvent) realtimeEventType() string {
+	return "conversation.item.create"
+}
+
+func (RealtimeResponseCreateEvent) realtimeEventType() string {
+	return "response.create"
+}
+
+func (RealtimeResponseCancelEvent) realtimeEventType() string {
+	return "response.cancel"
+}
+
+// RealtimeServerEventType is the type of an event received from a realtime
+// session.
+type RealtimeServerEventType string
+
+const (
+	RealtimeEventError          RealtimeServerEventType = "error"
+	RealtimeEventSessionCreated RealtimeServerEventType = "session.created"
+	RealtimeEventSessionUpdated RealtimeServerEventType = "session.updated"
+	RealtimeEventRateLimits     RealtimeServerEventType = "rate_limits.updated"
+
+	RealtimeEventConversationItemCreated RealtimeServerEventType = "conversation.item.created"
+	RealtimeEventInputAudioCommitted     RealtimeServerEventType = "input_audio_buffer.committed"
+	RealtimeEventInputAudioCleared       RealtimeServerEventType = "input_audio_buffer.cleared"
+	RealtimeEventSpeechStarted           RealtimeServerEventType = "input_audio_buffer.speech_started"
+	RealtimeEventSpeechStopped           RealtimeServerEventType = "input_audio_buffer.speech_stopped"
+
+	RealtimeEventResponseCreated         RealtimeServerEventType = "response.created"
+	RealtimeEventResponseDone            RealtimeServerEventType = "response.done"
+	RealtimeEventOutputItemAdded         RealtimeServerEventType = "response.output_item.added"
+	RealtimeEventOutputItemDone          RealtimeServerEventType = "response.output_item.done"
+	RealtimeEventContentPartAdded        RealtimeServerEventType = "response.content_part.added"
+	RealtimeEventContentPartDone         RealtimeServerEventType = "response.content_part.done"
+	RealtimeEventTextDelta               RealtimeServerEventType = "response.text.delta"
+	RealtimeEventTextDone                RealtimeServerEventType = "response.text.done"
+	RealtimeEventAudioDelta              RealtimeServerEventType = "response.audio.delta"
+	RealtimeEventAudioDone               RealtimeServerEventType = "response.audio.done"
+	RealtimeEventAudioTranscriptDelta    RealtimeServerEventType = "response.audio_transcript.delta"
+	RealtimeEventAudioTranscriptDone     RealtimeServerEventType = "response.audio_transcript.done"
+	RealtimeEventConversationItemDeleted RealtimeServerEventType = "conversation.item.deleted"
+
+	RealtimeEventFunctionCallArgumentsDelta RealtimeServerEventType = "response.function_call_arguments.delta"
+	RealtimeEventFunctionCallArgumentsDone  RealtimeServerEventType = "response.function_call_arguments.done"
+
+	RealtimeEventTranscriptionSessionCreated RealtimeServerEventType = "transcription_session.created"
+	RealtimeEventTranscriptionSessionUpdated RealtimeServerEventType = "transcription_session.updated"
+)
+
+// The input audio transcription events are about the user message ItemID of
+// the committed audio.
+const (
+	RealtimeEventTranscriptionDelta     RealtimeServerEventType = "conversation.item.input_audio_transcription.delta"
+	RealtimeEventTranscriptionCompleted RealtimeServerEventType = "conversation.item.input_audio_transcription.completed"
+	RealtimeEventTranscriptionFailed    RealtimeServerEventType = "conversation.item.input_audio_transcription.failed"
+)
+
+// RealtimeServerEvent is an event received from a realtime session. Which
+// fields are set depends on Type.
+type RealtimeServerEvent struct {
+	Type    RealtimeServerEventType `json:"type"`
+	EventID string                  `json:"event_id"`
+
+	// Session is set by the session events, including those of transcription
+	// sessions.
+	Session *RealtimeSession `json:"session,omitempty"`
+	// Item and PreviousItemID are set by the conversation item events, and
+	// Item by the output item events.
+	Item           *RealtimeItem `json:"item,omitempty"`
+	PreviousItemID string        `json:"previous_item_id,omitempty"`
+	// Response is set by the response lifecycle events.
+	Response *RealtimeResponse `json:"response,omitempty"`
+
+	// ResponseID, ItemID, OutputIndex and ContentIndex locate the part of a
+	// response the event is about.
+	ResponseID   string           `json:"response_id,omitempty"`
+	ItemID       string           `json:"item_id,omitempty"`
+	OutputIndex  int              `json:"output_index"`
+	ContentIndex int              `json:"content_index"`
+	Part         *RealtimeContent `json:"part,omitempty"`
+	// Delta is the text or base64 audio added by the delta events, and Text
+	// and Transcript the whole text of the done events.
+	Delta      string `json:"delta,omitempty"`
+	Text       string `json:"text,omitempty"`
+	Transcript string `json:"transcript,omitempty"`
+	// CallID, Name and Arguments are set by the function call arguments
+	// events, Delta being the arguments added.
+	CallID    string `json:"call_id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+
+	// AudioStartMS and AudioEndMS locate speech in the input audio buffer.
+	AudioStartMS int `json:"audio_start_ms,omitempty"`
+	AudioEndMS   int `json:"audio_end_ms,omitempty"`
+
+	// Error is set by RealtimeEventError, and by
+	// RealtimeEventTranscriptionFailed without ending the session.
+	Error *APIError `json:"error,omitempty"`
+}
+
+// RealtimeConn is a connection to a realtime session, opened with
+// ConnectRealtime. Events are sent with Send, concurrently with Recv if
+// needed, and received with Recv.
+type RealtimeConn struct {
+	conn *websocket.Conn
+
+	httpHeader
+}
+
+// ConnectRealtime opens a realtime session with model over a WebSocket. The
+// context bounds the opening handshake only; the session lasts until Close.
+func (c *Client) ConnectRealtime(ctx context.Context, model string, opts ...RequestOption) (*RealtimeConn, error) {
+	return c.connectRealtime(ctx, model, url.Values{"model": {model}}, opts)
+}
+
+// ConnectRealtimeTranscription opens a transcription-only realtime session,
+// configured with RealtimeTranscriptionSessionUpdateEvent. The transcripts of
+// the input audio are received with RealtimeEventTranscriptionDelta and
+// RealtimeEventTranscriptionCompleted.
+func (c *Client) ConnectRealtimeTranscription(ctx context.Context, opts ...RequestOption) (*RealtimeConn, error) {
+	return c.connectRealtime(ctx, "", url.Values{"intent": {"transcription"}}, opts)
+}
+
+// connectRealtime opens a realtime session at the realtime URL with query.
+func (c *Client) connectRealtime(
+	ctx context.Context,
+	model string,
+	query url.Values,
+	opts []RequestOption,
+) (*RealtimeConn, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(realtimeSuffix, model),
+		withModel(model), withRequestOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	if cancel, ok := req.Context().Value(requestCancelKey{}).(context.CancelFunc); ok {
+		defer cancel()
+	}
+	// The query of the options wins, as for the other methods.
+	values := req.URL.Query()
+	for key, value := range query {
+		if _, set := values[key]; !set {
+			values[key] = value
+		}
+	}
+	req.URL.RawQuery = values.Encode()
+	if req.Header.Get("OpenAI-Beta") == "" {
+		req.Header.Set("OpenAI-Beta", "realtime=v1")
+	}
+
+	conn, resp, err := websocket.Dial(req)
+	if errors.Is(err, websocket.ErrBadHandshake) {
+		defer resp.Body.Close()
+		if isFailureStatusCode(resp) {
+			return nil, c.handleErrorResp(resp)
+		}
+		return nil, &RequestError{HTTPStatusCode: resp.StatusCode, Err: err, Header: resp.Header}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &RealtimeConn{conn: conn, httpHeader: httpHeader{header: resp.Header}}, nil
+}
+
+// Send sends event to the session. A session update with an invalid turn
+// detection is not sent and returns ErrInvalidTurnDetection.
+func (c *RealtimeConn) Send(event RealtimeClientEvent) error {
+	var turnDetection *RealtimeTurnDetection
+	switch update := event.(type) {
+	case RealtimeSessionUpdateEvent:
+		turnDetection = update.Session.TurnDetection
+	case RealtimeTranscriptionSessionUpdateEvent:
+		turnDetection = update.Session.TurnDetection
+	}
+	if turnDetection != nil {
+		if err := turnDetection.Validate(); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	fields["type"], err = json.Marshal(event.realtimeEventType())
+	if err != nil {
+		return err
+	}
+	if data, err = json.Marshal(fields); err != nil {
+		return err
+	}
+	return c.conn.WriteMessage(data)
+}
+
+// Recv returns the next event of the session, or io.EOF once the session is
+// closed. An error event is returned with its *APIError, and the session
+// stays open.
+func (c *RealtimeConn) Recv() (event RealtimeServerEvent, err error) {
+	data, err := c.conn.ReadMessage()
+	if err != nil {
+		return RealtimeServerEvent{}, err
+	}
+	if err = json.Unmarshal(data, &event); err != nil {
+		return RealtimeServerEvent{}, err
+	}
+	if event.Type == RealtimeEventError && event.Error != nil {
+		return event, event.Error
+	}
+	return event, nil
+}
+
+// RealtimeFunctionHandler answers a call of a function with the JSON
+// arguments of the call.
+type RealtimeFunctionHandler func(ctx context.Context, arguments string) (output string, err error)
+
+// ErrRealtimeUnknownFunction is returned by DispatchFunctionCalls for a call of
+// a function without a handler.
+var ErrRealtimeUnknownFunction = errors.New("realtime: no handler for function")
+
+// DispatchFunctionCalls answers the function calls of response, typically
+// received with RealtimeEventResponseDone, with the handlers of the functions
+// by name. The outputs are added to the conversation, then a response is
+// created for the model to go on. It returns the number of calls answered,
+// and sends nothing when a function has no handler or a handler fails.
+func (c *RealtimeConn) DispatchFunctionCalls(
+	ctx context.Context,
+	response RealtimeResponse,
+	handlers map[string]RealtimeFunctionHandler,
+) (int, error) {
+	var calls []RealtimeItem
+	for _, item := range response.Output {
+		if item.Type != RealtimeItemTypeFunctionCall {
+			continue
+		}
+		if _, ok := handlers[item.Name]; !ok {
+			return 0, fmt.Errorf("%w %s", ErrRealtimeUnknownFunction, item.Name)
+		}
+		calls = append(calls, item)
+	}
+	if len(calls) == 0 {
+		return 0, nil
+	}
+
+	outputs := make([]RealtimeItem, len(calls))
+	for i, call := range calls {
+		output, err := handlers[call.Name](ctx, call.Arguments)
+		if err != nil {
+			return 0, fmt.Errorf("function %s: %w", call.Name, err)
+		}
+		outputs[i] = NewRealtimeFunctionCallOutput(call.CallID, output)
+	}
+	for i, output := range outputs {
+		if err := c.Send(RealtimeConversationItemCreateEvent{Item: output}); err != nil {
+			return i, err
+		}
+	}
+	return len(outputs), c.Send(RealtimeResponseCreateEvent{})
+}
+
+// Close closes the session. It is safe to call more than once.
+func (c *RealtimeConn) Close() error {
+	return c.conn.Close()
+}
+
+// RealtimeClientSecret is an ephemeral key that authenticates a client, such
+// as a browser, to a realtime session.
+type RealtimeClientSecret struct {
+	Value     string `json:"value"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// RealtimeSessionResponse is a realtime session created with
+// CreateRealtimeSession.
+type RealtimeSessionResponse struct {
+	RealtimeSession
+	ClientSecret RealtimeClientSecret `json:"client_secret"`
+
+	httpHeader
+}
+
+// CreateRealtimeSession creates a realtime session configured by request and
+// returns its ephemeral client secret, for clients that must not hold the API
+// key to connect with.
+func (c *Client) CreateRealtimeSession(
+	ctx context.Context,
+	request RealtimeSession,
+	opts ...RequestOption,
+) (response RealtimeSessionResponse, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(realtimeSessionsSuffix, request.Model),
+		withModel(request.Model), withBody(request), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// RealtimeTranscriptionSessionResponse is a transcription session created with
+// CreateRealtimeTranscriptionSession.
+type RealtimeTranscriptionSessionResponse struct {
+	RealtimeTranscriptionSession
+	ClientSecret RealtimeClientSecret `json:"client_secret"`
+
+	httpHeader
+}
+
+// CreateRealtimeTranscriptionSession creates a transcription session
+// configured by request and returns its ephemeral client secret.
+func (c *Client) CreateRealtimeTranscriptionSession(
+	ctx context.Context,
+	request RealtimeTranscriptionSession,
+	opts ...RequestOption,
+) (response RealtimeTranscriptionSessionResponse, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(realtimeTranscriptionSessionsSuffix),
+		withBody(request), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
@@ -0,0 +1,321 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+	"github.com/sashabaranov/go-openai/internal/websocket"
+)
+
+// realtimeTestServer accepts a realtime session and hands it to serve.
+func realtimeTestServer(t *testing.T, serve func(conn *websocket.Conn)) (*openai.Client, func()) {
+	t.Helper()
+	client, server, teardown := setupOpenAITestServer()
+	server.RegisterHandler("/v1/realtime", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("OpenAI-Beta") != "realtime=v1" {
+			t.Errorf("unexpected OpenAI-Beta header: %q", r.Header.Get("OpenAI-Beta"))
+		}
+		conn, err := websocket.Accept(w, r)
+		if err != nil {
+			t.Errorf("Accept error: %v", err)
+			return
+		}
+		defer conn.Close()
+		serve(conn)
+	})
+	return client, teardown
+}
+
+// readRealtimeEvent reads a client event from conn into a map.
+func readRealtimeEvent(t *testing.T, conn *websocket.Conn) map[string]any {
+	t.Helper()
+	data, err := conn.ReadMessage()
+	if err != nil {
+		t.Errorf("ReadMessage error: %v", err)
+		return nil
+	}
+	var event map[string]any
+	if err = json.Unmarshal(data, &event); err != nil {
+		t.Errorf("Unmarshal error: %v", err)
+	}
+	return event
+}
+
+// writeRealtimeEvent writes a server event to conn.
+func writeRealtimeEvent(t *testing.T, conn *websocket.Conn, event string) {
+	t.Helper()
+	if err := conn.WriteMessage([]byte(event)); err != nil {
+		t.Errorf("WriteMessage error: %v", err)
+	}
+}
+
+func TestRealtimeOutOfBandResponse(t *testing.T) {
+	client, teardown := realtimeTestServer(t, func(conn *websocket.Conn) {
+		writeRealtimeEvent(t, conn, `{"type":"session.created","event_id":"ev_1",`+
+			`"session":{"id":"sess_1","model":"gpt-4o-realtime-preview","max_response_output_tokens":"inf"}}`)
+
+		update := readRealtimeEvent(t, conn)
+		if update["type"] != "session.update" {
+			t.Errorf("unexpected event: %v", update)
+		}
+		create := readRealtimeEvent(t, conn)
+		response, _ := create["response"].(map[string]any)
+		input, _ := response["input"].([]any)
+		if create["type"] != "response.create" || response["conversation"] != "none" || len(input) != 1 {
+			t.Errorf("unexpected event: %v", create)
+		}
+
+		writeRealtimeEvent(t, conn, `{"type":"response.done","event_id":"ev_2","response":{"id":"resp_1",`+
+			`"status":"completed","metadata":{"topic":"classification"},"output":[{"type":"message",`+
+			`"role":"assistant","content":[{"type":"text","text":"billing"}]}]}}`)
+		writeRealtimeEvent(t, conn, `{"type":"error","event_id":"ev_3",`+
+			`"error":{"type":"invalid_request_error","code":"unknown_event","message":"Unknown event."}}`)
+	})
+	defer teardown()
+
+	conn, err := client.ConnectRealtime(context.Background(), "gpt-4o-realtime-preview")
+	checks.NoError(t, err, "ConnectRealtime error")
+	defer conn.Close()
+
+	event, err := conn.Recv()
+	checks.NoError(t, err, "Recv error")
+	if event.Type != openai.RealtimeEventSessionCreated || event.Session.ID != "sess_1" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+
+	err = conn.Send(openai.RealtimeSessionUpdateEvent{Session: openai.RealtimeSession{Instructions: "Be brief."}})
+	checks.NoError(t, err, "Send error")
+	err = conn.Send(openai.RealtimeResponseCreateEvent{Response: &openai.RealtimeResponseConfig{
+		Conversation: openai.RealtimeConversationNone,
+		Metadata:     map[string]string{"topic": "classification"},
+		Modalities:   []openai.RealtimeModality{openai.RealtimeModalityText},
+		Instructions: "Classify the request of the user.",
+		Input:        []openai.RealtimeItem{openai.NewRealtimeItemReference("item_1")},
+	}})
+	checks.NoError(t, err, "Send error")
+
+	event, err = conn.Recv()
+	checks.NoError(t, err, "Recv error")
+	if event.Response.Metadata["topic"] != "classification" || event.Response.OutputText() != "billing" {
+		t.Errorf("unexpected response: %+v", event.Response)
+	}
+
+	_, err = conn.Recv()
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "unknown_event" {
+		t.Errorf("expected an *APIError, got %v", err)
+	}
+	if _, err = conn.Recv(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestConnectRealtimeUnauthorized(t *testing.T) {
+	server := test.NewTestServer()
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig("wrong-token")
+	config.BaseURL = ts.URL + "/v1"
+	_, err := openai.NewClientWithConfig(config).ConnectRealtime(context.Background(), "gpt-4o-realtime-preview")
+	var reqErr *openai.RequestError
+	if !errors.As(err, &reqErr) || reqErr.HTTPStatusCode != http.StatusUnauthorized {
+		t.Errorf("expected an unauthorized RequestError, got %v", err)
+	}
+}
+
+func TestCreateRealtimeSession(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/realtime/sessions", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.RealtimeSession
+		err := json.NewDecoder(r.Body).Decode(&request)
+		checks.NoError(t, err, "Decode error")
+		fmt.Fprintf(w, `{"id":"sess_1","model":%q,"client_secret":{"value":"ek_1","expires_at":1700000000}}`,
+			request.Model)
+	})
+
+	session, err := client.CreateRealtimeSession(context.Background(), openai.RealtimeSession{
+		Model: "gpt-4o-realtime-preview",
+	})
+	checks.NoError(t, err, "CreateRealtimeSession error")
+	if session.ID != "sess_1" || session.ClientSecret.Value != "ek_1" {
+		t.Errorf("unexpected session: %+v", session)
+	}
+}
+
+func TestRealtimeFunctionCalls(t *testing.T) {
+	client, teardown := realtimeTestServer(t, func(conn *websocket.Conn) {
+		update := readRealtimeEvent(t, conn)
+		session, _ := update["session"].(map[string]any)
+		tools, _ := session["tools"].([]any)
+		if len(tools) != 1 {
+			t.Errorf("unexpected session: %v", session)
+		}
+
+		for _, delta := range []string{`{\"city\":`, `\"Paris\"}`} {
+			writeRealtimeEvent(t, conn, `{"type":"response.function_call_arguments.delta",`+
+				`"call_id":"call_1","delta":"`+delta+`"}`)
+		}
+		writeRealtimeEvent(t, conn, `{"type":"response.done","response":{"id":"resp_1","status":"completed",`+
+			`"output":[{"type":"function_call","call_id":"call_1","name":"get_weather",`+
+			`"arguments":"{\"city\":\"Paris\"}"}]}}`)
+
+		output := readRealtimeEvent(t, conn)
+		item, _ := output["item"].(map[string]any)
+		if output["type"] != "conversation.item.create" || item["type"] != "function_call_output" ||
+			item["call_id"] != "call_1" || item["output"] != `{"temperature":21}` {
+			t.Errorf("unexpected event: %v", output)
+		}
+		if create := readRealtimeEvent(t, conn); create["type"] != "response.create" {
+			t.Errorf("unexpected event: %v", create)
+		}
+	})
+	defer teardown()
+
+	conn, err := client.ConnectRealtime(context.Background(), "gpt-4o-realtime-preview")
+	checks.NoError(t, err, "ConnectRealtime error")
+	defer conn.Close()
+
+	err = conn.Send(openai.RealtimeSessionUpdateEvent{Session: openai.RealtimeSession{
+		Tools: []openai.RealtimeTool{{
+			Type:       openai.ToolTypeFunction,
+			Name:       "get_weather",
+			Parameters: json.RawMessage(`{"type":"object","properties":{"city":{"type":"string"}}}`),
+		}},
+		ToolChoice: "auto",
+	}})
+	checks.NoError(t, err, "Send error")
+
+	var arguments string
+	event, err := conn.Recv()
+	for err == nil && event.Type == openai.RealtimeEventFunctionCallArgumentsDelta {
+		arguments += event.Delta
+		event, err = conn.Recv()
+	}
+	checks.NoError(t, err, "Recv error")
+	if arguments != `{"city":"Paris"}` {
+		t.Errorf("unexpected arguments: %q", arguments)
+	}
+
+	ctx := context.Background()
+	_, err = conn.DispatchFunctionCalls(ctx, *event.Response, nil)
+	checks.ErrorIs(t, err, openai.ErrRealtimeUnknownFunction, "DispatchFunctionCalls error")
+	dispatched, err := conn.DispatchFunctionCalls(ctx, *event.Response, map[string]openai.RealtimeFunctionHandler{
+		"get_weather": func(_ context.Context, _ string) (string, error) {
+			return `{"temperature":21}`, nil
+		},
+	})
+	checks.NoError(t, err, "DispatchFunctionCalls error")
+	if dispatched != 1 {
+		t.Errorf("expected 1 call to be dispatched, got %d", dispatched)
+	}
+	// The server closes the session once it has read the output.
+	if _, err = conn.Recv(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestRealtimeTranscriptionSession(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/realtime", func(w http.ResponseWriter, r *http.Request) {
+		if intent := r.URL.Query().Get("intent"); intent != "transcription" {
+			t.Errorf("unexpected intent: %q", intent)
+		}
+		conn, err := websocket.Accept(w, r)
+		if err != nil {
+			t.Errorf("Accept error: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		update := readRealtimeEvent(t, conn)
+		session, _ := update["session"].(map[string]any)
+		transcription, _ := session["input_audio_transcription"].(map[string]any)
+		if update["type"] != "transcription_session.update" || transcription["language"] != "fr" {
+			t.Errorf("unexpected event: %v", update)
+		}
+		if appended := readRealtimeEvent(t, conn); appended["audio"] != "AAAA" {
+			t.Errorf("unexpected event: %v", appended)
+		}
+		for _, delta := range []string{"Bon", "jour"} {
+			writeRealtimeEvent(t, conn, `{"type":"conversation.item.input_audio_transcription.delta",`+
+				`"item_id":"item_1","content_index":0,"delta":"`+delta+`"}`)
+		}
+		writeRealtimeEvent(t, conn, `{"type":"conversation.item.input_audio_transcription.completed",`+
+			`"item_id":"item_1","content_index":0,"transcript":"Bonjour"}`)
+		writeRealtimeEvent(t, conn, `{"type":"conversation.item.input_audio_transcription.failed",`+
+			`"item_id":"item_2","error":{"type":"transcription_error","message":"Audio too short."}}`)
+	})
+
+	conn, err := client.ConnectRealtimeTranscription(context.Background())
+	checks.NoError(t, err, "ConnectRealtimeTranscription error")
+	defer conn.Close()
+
+	err = conn.Send(openai.RealtimeTranscriptionSessionUpdateEvent{Session: openai.RealtimeTranscriptionSession{
+		InputAudioFormat: openai.RealtimeAudioFormatPCM16,
+		InputAudioTranscription: &openai.RealtimeInputAudioTranscription{
+			Model:    "gpt-4o-transcribe",
+			Language: "fr",
+			Prompt:   "Greetings.",
+		},
+	}})
+	checks.NoError(t, err, "Send error")
+	err = conn.Send(openai.RealtimeInputAudioBufferAppendEvent{Audio: "AAAA"})
+	checks.NoError(t, err, "Send error")
+
+	var captions, transcript string
+	for {
+		event, recvErr := conn.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			break
+		}
+		checks.NoError(t, recvErr, "Recv error")
+		switch event.Type {
+		case openai.RealtimeEventTranscriptionDelta:
+			captions += event.Delta
+		case openai.RealtimeEventTranscriptionCompleted:
+			transcript = event.Transcript
+		case openai.RealtimeEventTranscriptionFailed:
+			if event.ItemID != "item_2" || event.Error.Message != "Audio too short." {
+				t.Errorf("unexpected failure: %+v", event)
+			}
+		}
+	}
+	if captions != "Bonjour" || transcript != "Bonjour" {
+		t.Errorf("unexpected transcription: captions %q, transcript %q", captions, transcript)
+	}
+}
+
+func TestCreateRealtimeTranscriptionSession(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/realtime/transcription_sessions", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.RealtimeTranscriptionSession
+		err := json.NewDecoder(r.Body).Decode(&request)
+		checks.NoError(t, err, "Decode error")
+		fmt.Fprintf(w, `{"id":"sess_1","input_audio_transcription":{"model":%q},`+
+			`"client_secret":{"value":"ek_1","expires_at":1700000000}}`, request.InputAudioTranscription.Model)
+	})
+
+	session, err := client.CreateRealtimeTranscriptionSession(context.Background(), openai.RealtimeTranscriptionSession{
+		InputAudioTranscription: &openai.RealtimeInputAudioTranscription{Model: "gpt-4o-transcribe"},
+	})
+	checks.NoError(t, err, "CreateRealtimeTranscriptionSession error")
+	if session.ClientSecret.Value != "ek_1" || session.InputAudioTranscription.Model != "gpt-4o-transcribe" {
+		t.Errorf("unexpected session: %+v", session)
+	}
+}
@@ -0,0 +1,96 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidTurnDetection is returned, without sending the event, for a
+// session update with a turn detection that the API would reject.
+var ErrInvalidTurnDetection = errors.New("invalid turn detection")
+
+// RealtimeTurnDetectionType selects how a realtime session detects the end of
+// a turn of the user.
+type RealtimeTurnDetectionType string
+
+const (
+	// RealtimeTurnDetectionServerVAD ends turns after a period of silence.
+	RealtimeTurnDetectionServerVAD RealtimeTurnDetectionType = "server_vad"
+	// RealtimeTurnDetectionSemanticVAD ends turns when the words of the user
+	// sound complete.
+	RealtimeTurnDetectionSemanticVAD RealtimeTurnDetectionType = "semantic_vad"
+)
+
+// RealtimeEagerness is how soon semantic VAD ends a turn.
+type RealtimeEagerness string
+
+const (
+	RealtimeEagernessLow    RealtimeEagerness = "low"
+	RealtimeEagernessMedium RealtimeEagerness = "medium"
+	RealtimeEagernessHigh   RealtimeEagerness = "high"
+	RealtimeEagernessAuto   RealtimeEagerness = "auto"
+)
+
+// RealtimeTurnDetection configures the turn detection of a realtime session.
+// A RealtimeTurnDetection without a Type disables turn detection, leaving the
+// client to commit the input audio buffer and create responses.
+type RealtimeTurnDetection struct {
+	Type RealtimeTurnDetectionType `json:"type"`
+
+	// Threshold is the activation threshold of server VAD, from 0 to 1: higher
+	// thresholds need louder audio. PrefixPaddingMS is the audio kept before
+	// speech and SilenceDurationMS the silence that ends a turn.
+	Threshold         *float64 `json:"threshold,omitempty"`
+	PrefixPaddingMS   int      `json:"prefix_padding_ms,omitempty"`
+	SilenceDurationMS int      `json:"silence_duration_ms,omitempty"`
+
+	// Eagerness is set for semantic VAD only.
+	Eagerness RealtimeEagerness `json:"eagerness,omitempty"`
+
+	// CreateResponse creates a response at the end of each turn, and
+	// InterruptResponse cancels the response in progress when the user starts
+	// speaking. Both default to true.
+	CreateResponse    *bool `json:"create_response,omitempty"`
+	InterruptResponse *bool `json:"interrupt_response,omitempty"`
+}
+
+func (d RealtimeTurnDetection) MarshalJSON() ([]byte, error) {
+	if d.Type == "" {
+		return []byte("null"), nil
+	}
+	type Alias RealtimeTurnDetection
+	return json.Marshal(Alias(d))
+}
+
+// Validate checks that the settings match the type of turn detection.
+func (d RealtimeTurnDetection) Validate() error {
+	switch d.Type {
+	case "":
+		if d != (RealtimeTurnDetection{}) {
+			return fmt.Errorf("%w: settings without a type", ErrInvalidTurnDetection)
+		}
+	case RealtimeTurnDetectionServerVAD:
+		if d.Threshold != nil && (*d.Threshold < 0 || *d.Threshold > 1) {
+			return fmt.Errorf("%w: threshold %g is not between 0 and 1", ErrInvalidTurnDetection, *d.Threshold)
+		}
+		if d.PrefixPaddingMS < 0 || d.SilenceDurationMS < 0 {
+			return fmt.Errorf("%w: negative duration", ErrInvalidTurnDetection)
+		}
+		if d.Eagerness != "" {
+			return fmt.Errorf("%w: eagerness is for semantic_vad", ErrInvalidTurnDetection)
+		}
+	case RealtimeTurnDetectionSemanticVAD:
+		if d.Threshold != nil || d.PrefixPaddingMS != 0 || d.SilenceDurationMS != 0 {
+			return fmt.Errorf("%w: threshold and durations are for server_vad", ErrInvalidTurnDetection)
+		}
+		switch d.Eagerness {
+		case "", RealtimeEagernessLow, RealtimeEagernessMedium, RealtimeEagernessHigh, RealtimeEagernessAuto:
+		default:
+			return fmt.Errorf("%w: unknown eagerness %q", ErrInvalidTurnDetection, d.Eagerness)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidTurnDetection, d.Type)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+	"github.com/sashabaranov/go-openai/internal/websocket"
+)
+
+func TestRealtimeTurnDetectionValidate(t *testing.T) {
+	threshold := 0.6
+	tooHigh := 1.5
+	for _, testCase := range []struct {
+		name  string
+		valid bool
+		turn  openai.RealtimeTurnDetection
+	}{
+		{"disabled", true, openai.RealtimeTurnDetection{}},
+		{"server VAD", true, openai.RealtimeTurnDetection{
+			Type:              openai.RealtimeTurnDetectionServerVAD,
+			Threshold:         &threshold,
+			PrefixPaddingMS:   300,
+			SilenceDurationMS: 500,
+		}},
+		{"semantic VAD", true, openai.RealtimeTurnDetection{
+			Type:      openai.RealtimeTurnDetectionSemanticVAD,
+			Eagerness: openai.RealtimeEagernessLow,
+		}},
+		{"settings without a type", false, openai.RealtimeTurnDetection{SilenceDurationMS: 500}},
+		{"unknown type", false, openai.RealtimeTurnDetection{Type: "client_vad"}},
+		{"threshold above 1", false, openai.RealtimeTurnDetection{
+			Type:      openai.RealtimeTurnDetectionServerVAD,
+			Threshold: &tooHigh,
+		}},
+		{"negative duration", false, openai.RealtimeTurnDetection{
+			Type:            openai.RealtimeTurnDetectionServerVAD,
+			PrefixPaddingMS: -1,
+		}},
+		{"eagerness of server VAD", false, openai.RealtimeTurnDetection{
+			Type:      openai.RealtimeTurnDetectionServerVAD,
+			Eagerness: openai.RealtimeEagernessHigh,
+		}},
+		{"threshold of semantic VAD", false, openai.RealtimeTurnDetection{
+			Type:      openai.RealtimeTurnDetectionSemanticVAD,
+			Threshold: &threshold,
+		}},
+		{"unknown eagerness", false, openai.RealtimeTurnDetection{
+			Type:      openai.RealtimeTurnDetectionSemanticVAD,
+			Eagerness: "eager",
+		}},
+	} {
+		err := testCase.turn.Validate()
+		if testCase.valid {
+			checks.NoError(t, err, testCase.name)
+		} else {
+			checks.ErrorIs(t, err, openai.ErrInvalidTurnDetection, testCase.name)
+		}
+	}
+}
+
+func TestRealtimeTurnDetectionDisabled(t *testing.T) {
+	data, err := json.Marshal(openai.RealtimeSession{TurnDetection: &openai.RealtimeTurnDetection{}})
+	checks.NoError(t, err, "Marshal error")
+	if string(data) != `{"turn_detection":null}` {
+		t.Errorf("unexpected session: %s", data)
+	}
+
+	var session openai.RealtimeSession
+	err = json.Unmarshal([]byte(`{"turn_detection":{"type":"semantic_vad","eagerness":"auto"}}`), &session)
+	checks.NoError(t, err, "Unmarshal error")
+	if session.TurnDetection.Eagerness != openai.RealtimeEagernessAuto {
+		t.Errorf("unexpected turn detection: %+v", session.TurnDetection)
+	}
+}
+
+func TestRealtimeSendValidatesTurnDetection(t *testing.T) {
+	client, teardown := realtimeTestServer(t, func(conn *websocket.Conn) {
+		// Only the valid update reaches the server.
+		update := readRealtimeEvent(t, conn)
+		session, _ := update["session"].(map[string]any)
+		turnDetection, _ := session["turn_detection"].(map[string]any)
+		if turnDetection["type"] != "semantic_vad" {
+			t.Errorf("unexpected event: %v", update)
+		}
+	})
+	defer teardown()
+
+	conn, err := client.ConnectRealtime(context.Background(), "gpt-4o-realtime-preview")
+	checks.NoError(t, err, "ConnectRealtime error")
+	defer conn.Close()
+
+	err = conn.Send(openai.RealtimeTranscriptionSessionUpdateEvent{Session: openai.RealtimeTranscriptionSession{
+		TurnDetection: &openai.RealtimeTurnDetection{Type: openai.RealtimeTurnDetectionSemanticVAD, PrefixPaddingMS: 300},
+	}})
+	checks.ErrorIs(t, err, openai.ErrInvalidTurnDetection, "Send error")
+	err = conn.Send(openai.RealtimeSessionUpdateEvent{Session: openai.RealtimeSession{
+		TurnDetection: &openai.RealtimeTurnDetection{Type: openai.RealtimeTurnDetectionSemanticVAD},
+	}})
+	checks.NoError(t, err, "Send error")
+	if _, err = conn.Recv(); err == nil {
+		t.Error("expected the session to be closed")
+	}
+}
@@ -0,0 +1,91 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"time"
+)
+
+// RequestOption customizes a single API call without changing the client's
+// configuration. Every client method accepts RequestOptions as its last
+// arguments.
+type RequestOption func(*requestOptions)
+
+// WithHeader sets a header on the request, e.g. a beta or tracing header. It
+// replaces headers set by the client, including Authorization.
+func WithHeader(key, value string) RequestOption {
+	return func(args *requestOptions) {
+		args.extraHeader.Set(key, value)
+	}
+}
+
+// WithQueryParam adds a query parameter to the request URL. Values given with
+// options replace those the method sets for the same key.
+func WithQueryParam(key, value string) RequestOption {
+	return func(args *requestOptions) {
+		if args.query == nil {
+			args.query = make(url.Values)
+		}
+		args.query.Add(key, value)
+	}
+}
+
+// WithTimeout bounds the duration of the call, including reading the
+// response. For streams it bounds the whole stream.
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(args *requestOptions) {
+		args.timeout = timeout
+	}
+}
+
+// WithBaseURL sends the request to baseURL instead of ClientConfig.BaseURL,
+// e.g. to route a single call through a proxy. The rest of the URL, including
+// Azure deployment paths and the api-version, is unchanged.
+func WithBaseURL(baseURL string) RequestOption {
+	return func(args *requestOptions) {
+		args.baseURL = baseURL
+	}
+}
+
+// WithClientTrace calls the hooks of trace during the request, e.g. to time
+// DNS lookups, connection setup, TLS handshakes and the first response byte
+// apart from the time the API takes. Hooks already set on the context with
+// httptrace.WithClientTrace are called too. Retries and streams are traced
+// as well.
+func WithClientTrace(trace *httptrace.ClientTrace) RequestOption {
+	return func(args *requestOptions) {
+		args.trace = trace
+	}
+}
+
+type requestCancelKey struct{}
+
+// releaseOnClose ties the context created by WithTimeout to the response, so
+// that it is released when the body is closed or right away if the request
+// failed.
+func releaseOnClose(req *http.Request, resp *http.Response, err error) (*http.Response, error) {
+	cancel, ok := req.Context().Value(requestCancelKey{}).(context.CancelFunc)
+	if !ok {
+		return resp, err
+	}
+	if err != nil || resp == nil {
+		cancel()
+		return resp, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
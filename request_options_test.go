@@ -0,0 +1,137 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestRequestOptionsHeaderAndQuery(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Trace-Id"); got != "trace-1" {
+			t.Errorf("expected trace header, got %q", got)
+		}
+		if got := r.Header.Get("OpenAI-Organization"); got != "org-override" {
+			t.Errorf("expected organization override, got %q", got)
+		}
+		if got := r.URL.Query()["tag"]; len(got) != 2 || got[0] != "a" || got[1] != "b" {
+			t.Errorf("expected tag query params, got %v", got)
+		}
+		fmt.Fprintln(w, `{"object":"list","data":[]}`)
+	})
+
+	_, err := client.ListModels(context.Background(),
+		openai.WithHeader("X-Trace-Id", "trace-1"),
+		openai.WithHeader("OpenAI-Organization", "org-override"),
+		openai.WithQueryParam("tag", "a"),
+		openai.WithQueryParam("tag", "b"),
+	)
+	checks.NoError(t, err, "ListModels error")
+
+	// Options apply to a single call only.
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Trace-Id") != "" || r.URL.RawQuery != "" {
+			t.Errorf("options leaked into another call: %v %q", r.Header, r.URL.RawQuery)
+		}
+		fmt.Fprintln(w, `{"object":"list","data":[]}`)
+	})
+	_, err = client.ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+}
+
+func TestRequestOptionsClientTrace(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `{"object":"list","data":[]}`)
+	})
+
+	var gotConn, firstByte, contextHook bool
+	ctx := httptrace.WithClientTrace(context.Background(), &httptrace.ClientTrace{
+		WroteRequest: func(httptrace.WroteRequestInfo) { contextHook = true },
+	})
+	_, err := client.ListModels(ctx, openai.WithClientTrace(&httptrace.ClientTrace{
+		GotConn:              func(httptrace.GotConnInfo) { gotConn = true },
+		GotFirstResponseByte: func() { firstByte = true },
+	}))
+	checks.NoError(t, err, "ListModels error")
+	if !gotConn || !firstByte || !contextHook {
+		t.Errorf("expected all hooks to be called: GotConn %v, GotFirstResponseByte %v, context hook %v",
+			gotConn, firstByte, contextHook)
+	}
+}
+
+func TestRequestOptionsTimeout(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("slow") != "" {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		}
+		fmt.Fprintln(w, `{"object":"list","data":[]}`)
+	})
+
+	_, err := client.ListModels(context.Background(),
+		openai.WithTimeout(20*time.Millisecond), openai.WithQueryParam("slow", "1"))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	_, err = client.ListModels(context.Background(), openai.WithTimeout(time.Second))
+	checks.NoError(t, err, "ListModels error")
+}
+
+func TestListFineTuningJobEventsWithRequestOptions(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/fine_tuning/jobs/ftjob-1/events", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Trace-Id") != "trace-1" || r.URL.Query().Get("limit") != "5" {
+			t.Errorf("unexpected request: %v %q", r.Header, r.URL.RawQuery)
+		}
+		fmt.Fprintln(w, `{"object":"list","data":[]}`)
+	})
+
+	_, err := client.ListFineTuningJobEvents(context.Background(), "ftjob-1",
+		openai.ListFineTuningJobEventsWithLimit(5),
+		openai.ListFineTuningJobEventsWithRequestOptions(openai.WithHeader("X-Trace-Id", "trace-1")),
+	)
+	checks.NoError(t, err, "ListFineTuningJobEvents error")
+}
+
+func TestRequestOptionsBaseURL(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/models", func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("request was sent to the configured base URL")
+	})
+
+	proxy := test.NewTestServer()
+	proxy.RegisterHandler("/proxy/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `{"object":"list","data":[{"id":"proxied"}]}`)
+	})
+	ts := proxy.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	models, err := client.ListModels(context.Background(), openai.WithBaseURL(ts.URL+"/proxy/v1/"))
+	checks.NoError(t, err, "ListModels error")
+	if len(models.Models) != 1 || models.Models[0].ID != "proxied" {
+		t.Errorf("unexpected models: %+v", models.Models)
+	}
+}
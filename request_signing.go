@@ -0,0 +1,32 @@
+package openai
+
+import (
+	"io"
+	"net/http"
+)
+
+// RequestSigner is called with every request right before it is sent, after
+// its URL, headers and body are final, e.g. to add an HMAC signature header
+// required by an API gateway. body is the exact content that is sent, after
+// compression, or nil when the request has no body or streams it, as file
+// uploads do. An error fails the call without sending it.
+type RequestSigner func(req *http.Request, body []byte) error
+
+// signRequest calls the configured RequestSigner with the body of req.
+func (c *Client) signRequest(req *http.Request) error {
+	if c.config.SignRequest == nil {
+		return nil
+	}
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody != nil {
+		reader, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		if body, err = io.ReadAll(reader); err != nil {
+			return err
+		}
+	}
+	return c.config.SignRequest(req, body)
+}
@@ -0,0 +1,103 @@
+package openai_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestSignRequest(t *testing.T) {
+	secret := []byte("secret")
+	sign := func(method, url string, body []byte) string {
+		mac := hmac.New(sha256.New, secret)
+		fmt.Fprintf(mac, "%s\n%s\n", method, url)
+		mac.Write(body)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	requests := 0
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, err := io.ReadAll(r.Body)
+		checks.NoError(t, err, "ReadAll error")
+		url := "http://" + r.Host + r.URL.String()
+		if got, want := r.Header.Get("X-Signature"), sign(r.Method, url, body); got != want {
+			t.Errorf("signature %q, want %q", got, want)
+		}
+		fmt.Fprint(w, `{"data":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	errRefused := errors.New("refused")
+	config.SignRequest = func(req *http.Request, body []byte) error {
+		if req.Header.Get("X-Refuse") != "" {
+			return errRefused
+		}
+		req.Header.Set("X-Signature", sign(req.Method, req.URL.String(), body))
+		return nil
+	}
+	client := openai.NewClientWithConfig(config)
+
+	request := openai.EmbeddingRequest{Input: []string{"hello"}, Model: openai.SmallEmbedding3}
+	_, err := client.CreateEmbeddings(context.Background(), request, openai.WithQueryParam("trace", "1"))
+	checks.NoError(t, err, "CreateEmbeddings error")
+
+	_, err = client.CreateEmbeddings(context.Background(), request, openai.WithHeader("X-Refuse", "1"))
+	checks.ErrorIs(t, err, errRefused, "signer errors should fail the call")
+	if requests != 1 {
+		t.Errorf("expected 1 request to be sent, got %d", requests)
+	}
+}
+
+func TestSignRequestSeesContentHeaders(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Signed-Accept") != r.Header.Get("Accept") ||
+			r.Header.Get("X-Signed-Content-Type") != r.Header.Get("Content-Type") {
+			t.Errorf("headers changed after signing: %v", r.Header)
+		}
+		if r.Header.Get("Accept") == "text/event-stream" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: [DONE]\n\n")
+			return
+		}
+		fmt.Fprint(w, `{"choices":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.SignRequest = func(req *http.Request, _ []byte) error {
+		req.Header.Set("X-Signed-Accept", req.Header.Get("Accept"))
+		req.Header.Set("X-Signed-Content-Type", req.Header.Get("Content-Type"))
+		return nil
+	}
+	client := openai.NewClientWithConfig(config)
+
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "Hello!"}},
+	}
+	_, err := client.CreateChatCompletion(context.Background(), request)
+	checks.NoError(t, err, "CreateChatCompletion error")
+	stream, err := client.CreateChatCompletionStream(context.Background(), request)
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	stream.Close()
+}
@@ -0,0 +1,488 @@
+package openai
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	responsesSuffix = "/responses"
+)
+
+// ResponseStatus is the lifecycle state of a response.
+type ResponseStatus string
+
+const (
+	ResponseStatusQueued     ResponseStatus = "queued"
+	ResponseStatusInProgress ResponseStatus = "in_progress"
+	ResponseStatusCompleted  ResponseStatus = "completed"
+	ResponseStatusFailed     ResponseStatus = "failed"
+	ResponseStatusIncomplete ResponseStatus = "incomplete"
+	ResponseStatusCancelled  ResponseStatus = "cancelled"
+)
+
+// ResponseItemType is the type of an input or output item of a response.
+type ResponseItemType string
+
+const (
+	ResponseItemTypeMessage            ResponseItemType = "message"
+	ResponseItemTypeReasoning          ResponseItemType = "reasoning"
+	ResponseItemTypeFunctionCall       ResponseItemType = "function_call"
+	ResponseItemTypeFunctionCallOutput ResponseItemType = "function_call_output"
+	ResponseItemTypeFileSearchCall     ResponseItemType = "file_search_call"
+	ResponseItemTypeWebSearchCall      ResponseItemType = "web_search_call"
+	ResponseItemTypeItemReference      ResponseItemType = "item_reference"
+)
+
+// ResponseContentType is the type of a content part of a message item.
+type ResponseContentType string
+
+const (
+	ResponseContentTypeInputText  ResponseContentType = "input_text"
+	ResponseContentTypeOutputText ResponseContentType = "output_text"
+	ResponseContentTypeRefusal    ResponseContentType = "refusal"
+	ResponseContentTypeInputImage ResponseContentType = "input_image"
+	ResponseContentTypeInputFile  ResponseContentType = "input_file"
+)
+
+// ResponseInclude names data that responses omit unless it is listed in the
+// Include of the request. RetrieveResponse takes them with WithInclude.
+type ResponseInclude string
+
+const (
+	// ResponseIncludeFileSearchResults returns the Results of file_search_call
+	// items.
+	ResponseIncludeFileSearchResults ResponseInclude = "file_search_call.results"
+	// ResponseIncludeInputImageURL returns the ImageURL of the input images.
+	ResponseIncludeInputImageURL ResponseInclude = "message.input_image.image_url"
+	// ResponseIncludeReasoningEncryptedContent returns the EncryptedContent of
+	// reasoning items.
+	ResponseIncludeReasoningEncryptedContent ResponseInclude = "reasoning.encrypted_content"
+)
+
+// ReasoningEffort bounds how much a reasoning model thinks before answering.
+type ReasoningEffort string
+
+const (
+	ReasoningEffortMinimal ReasoningEffort = "minimal"
+	ReasoningEffortLow     ReasoningEffort = "low"
+	ReasoningEffortMedium  ReasoningEffort = "medium"
+	ReasoningEffortHigh    ReasoningEffort = "high"
+)
+
+// ReasoningSummary selects the summary of its reasoning that a reasoning
+// model returns. The raw reasoning itself is never returned.
+type ReasoningSummary string
+
+const (
+	ReasoningSummaryAuto     ReasoningSummary = "auto"
+	ReasoningSummaryConcise  ReasoningSummary = "concise"
+	ReasoningSummaryDetailed ReasoningSummary = "detailed"
+)
+
+// ResponseReasoning configures reasoning models. With a Summary, the
+// reasoning items of the output hold a summary of the reasoning, which is
+// streamed with the ResponseEventReasoningSummaryTextDelta events.
+type ResponseReasoning struct {
+	Effort  ReasoningEffort  `json:"effort,omitempty"`
+	Summary ReasoningSummary `json:"summary,omitempty"`
+}
+
+// ResponseContent is a content part of a message item: input text from the
+// user, or output text or a refusal from the model.
+type ResponseContent struct {
+	Type ResponseContentType `json:"type"`
+	Text string              `json:"text,omitempty"`
+	// Refusal explains why the model refused to answer.
+	Refusal     string `json:"refusal,omitempty"`
+	Annotations []any  `json:"annotations,omitempty"`
+
+	// ImageURL or FileID is set for input images. ImageURL is returned only
+	// with ResponseIncludeInputImageURL.
+	ImageURL string         `json:"image_url,omitempty"`
+	Detail   ImageURLDetail `json:"detail,omitempty"`
+
+	// FileID, or FileData with Filename, is set for input files and images.
+	FileID   string `json:"file_id,omitempty"`
+	FileData string `json:"file_data,omitempty"`
+	Filename string `json:"filename,omitempty"`
+}
+
+// NewResponseInputImageURL returns an input image at url, which may be a data
+// URL.
+func NewResponseInputImageURL(url string, detail ImageURLDetail) ResponseContent {
+	return ResponseContent{Type: ResponseContentTypeInputImage, ImageURL: url, Detail: detail}
+}
+
+// NewResponseInputImageFile returns an input image uploaded as fileID.
+func NewResponseInputImageFile(fileID string, detail ImageURLDetail) ResponseContent {
+	return ResponseContent{Type: ResponseContentTypeInputImage, FileID: fileID, Detail: detail}
+}
+
+// NewResponseInputFile returns an input file uploaded as fileID, e.g. a PDF
+// uploaded with UploadResponseInputFile.
+func NewResponseInputFile(fileID string) ResponseContent {
+	return ResponseContent{Type: ResponseContentTypeInputFile, FileID: fileID}
+}
+
+// NewResponseInputFileData returns an input file sent inline with the request,
+// as a base64 data URL typed from the extension of filename.
+func NewResponseInputFileData(filename string, data []byte) ResponseContent {
+	mediaType := mime.TypeByExtension(filepath.Ext(filename))
+	if mediaType == "" {
+		mediaType = "application/octet-stream"
+	}
+	return ResponseContent{
+		Type:     ResponseContentTypeInputFile,
+		Filename: filename,
+		FileData: fmt.Sprintf("data:%s;base64,%s", mediaType, base64.StdEncoding.EncodeToString(data)),
+	}
+}
+
+// ResponseReasoningSummaryPart is a part of the summary of a reasoning item.
+type ResponseReasoningSummaryPart struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// ResponseFileSearchResult is a chunk of a file retrieved by a file_search
+// call.
+type ResponseFileSearchResult struct {
+	FileID     string         `json:"file_id"`
+	Filename   string         `json:"filename"`
+	Score      float64        `json:"score"`
+	Text       string         `json:"text"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// ResponseItem is an input or output item of a response: a message, the
+// reasoning of the model, a function call or its output. The output items of
+// a response are valid input items, so a conversation is continued without
+// storing it by appending them to the input of the next request.
+type ResponseItem struct {
+	Type   ResponseItemType `json:"type,omitempty"`
+	ID     string           `json:"id,omitempty"`
+	Status string           `json:"status,omitempty"`
+
+	// Role and Content are set for messages.
+	Role    string            `json:"role,omitempty"`
+	Content []ResponseContent `json:"content,omitempty"`
+
+	// CallID identifies a function call, and the output that answers it.
+	CallID    string `json:"call_id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+	Output    string `json:"output,omitempty"`
+
+	// Summary is set for reasoning items, and EncryptedContent when
+	// ResponseIncludeReasoningEncryptedContent is included. A reasoning item
+	// sent back as input with its EncryptedContent restores the reasoning of
+	// a response that was not stored.
+	Summary          []ResponseReasoningSummaryPart `json:"summary,omitempty"`
+	EncryptedContent string                         `json:"encrypted_content,omitempty"`
+
+	// Queries are set for file_search_call items, and Results when
+	// ResponseIncludeFileSearchResults is included.
+	Queries []string                   `json:"queries,omitempty"`
+	Results []ResponseFileSearchResult `json:"results,omitempty"`
+}
+
+// MarshalJSON always sends the summary of reasoning items, which the API
+// requires even when it is empty.
+func (i ResponseItem) MarshalJSON() ([]byte, error) {
+	type Alias ResponseItem
+	item := &struct {
+		Summary *[]ResponseReasoningSummaryPart `json:"summary,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(&i),
+	}
+	if i.Type == ResponseItemTypeReasoning {
+		summary := i.Summary
+		if summary == nil {
+			summary = []ResponseReasoningSummaryPart{}
+		}
+		item.Summary = &summary
+	}
+	return json.Marshal(item)
+}
+
+// NewResponseMessage returns a message item of role with text, e.g. the
+// prompt of the user.
+func NewResponseMessage(role, text string) ResponseItem {
+	contentType := ResponseContentTypeInputText
+	if role == ChatMessageRoleAssistant {
+		contentType = ResponseContentTypeOutputText
+	}
+	return ResponseItem{
+		Type:    ResponseItemTypeMessage,
+		Role:    role,
+		Content: []ResponseContent{{Type: contentType, Text: text}},
+	}
+}
+
+// NewResponseMessageContent returns a message item of role with content, e.g.
+// the text of a question and the file it is about.
+func NewResponseMessageContent(role string, content ...ResponseContent) ResponseItem {
+	return ResponseItem{Type: ResponseItemTypeMessage, Role: role, Content: content}
+}
+
+// NewResponseFunctionCallOutput returns the item that answers the function
+// call callID with output.
+func NewResponseFunctionCallOutput(callID, output string) ResponseItem {
+	return ResponseItem{Type: ResponseItemTypeFunctionCallOutput, CallID: callID, Output: output}
+}
+
+// ResponseTool is a tool the model may call: a function, or a hosted tool
+// such as file_search.
+type ResponseTool struct {
+	Type        ToolType `json:"type"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	// Parameters is the JSON schema of the arguments of a function, e.g. a
+	// jsonschema.Definition.
+	Parameters any   `json:"parameters,omitempty"`
+	Strict     *bool `json:"strict,omitempty"`
+	// VectorStoreIDs are searched by file_search.
+	VectorStoreIDs []string `json:"vector_store_ids,omitempty"`
+	MaxNumResults  int      `json:"max_num_results,omitempty"`
+}
+
+// ResponsePrompt invokes a prompt template managed in the dashboard. Its
+// Variables fill the placeholders of the template.
+type ResponsePrompt struct {
+	ID string `json:"id"`
+	// Version defaults to the current version of the prompt.
+	Version   string                            `json:"version,omitempty"`
+	Variables map[string]ResponsePromptVariable `json:"variables,omitempty"`
+}
+
+// ResponsePromptVariable is the value of a variable of a prompt: a string, or
+// a content part such as an input image or file when Content is set.
+type ResponsePromptVariable struct {
+	Text    string
+	Content *ResponseContent
+}
+
+// NewResponsePromptContent returns a variable holding content, e.g. the input
+// file returned by UploadResponseInputFile.
+func NewResponsePromptContent(content ResponseContent) ResponsePromptVariable {
+	return ResponsePromptVariable{Content: &content}
+}
+
+func (v ResponsePromptVariable) MarshalJSON() ([]byte, error) {
+	if v.Content != nil {
+		return json.Marshal(v.Content)
+	}
+	return json.Marshal(v.Text)
+}
+
+func (v *ResponsePromptVariable) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*v = ResponsePromptVariable{}
+		return json.Unmarshal(data, &v.Text)
+	}
+	*v = ResponsePromptVariable{Content: &ResponseContent{}}
+	return json.Unmarshal(data, v.Content)
+}
+
+// ResponseRequest represents a request to create a model response.
+type ResponseRequest struct {
+	// Model may be left empty when Prompt sets it.
+	Model string `json:"model,omitempty"`
+	// Prompt invokes a prompt template, whose messages precede Input.
+	Prompt *ResponsePrompt `json:"prompt,omitempty"`
+	// Input lists the items the model responds to, e.g. a message built with
+	// NewResponseMessage.
+	Input []ResponseItem `json:"input,omitempty"`
+	// Instructions are inserted as a system message ahead of Input.
+	Instructions string `json:"instructions,omitempty"`
+	// Store set to false keeps the response from being stored, e.g. for zero
+	// data retention. The conversation is then continued by sending back the
+	// output items, with the reasoning items requested with
+	// ResponseIncludeReasoningEncryptedContent so that the model keeps its
+	// reasoning across turns.
+	Store *bool `json:"store,omitempty"`
+	// PreviousResponseID continues the conversation of a stored response.
+	PreviousResponseID string             `json:"previous_response_id,omitempty"`
+	Reasoning          *ResponseReasoning `json:"reasoning,omitempty"`
+	MaxOutputTokens    int                `json:"max_output_tokens,omitempty"`
+	Temperature        *float32           `json:"temperature,omitempty"`
+	TopP               *float32           `json:"top_p,omitempty"`
+	Tools              []ResponseTool     `json:"tools,omitempty"`
+	// Include lists the data to return that is omitted by default.
+	Include           []ResponseInclude `json:"include,omitempty"`
+	ToolChoice        any               `json:"tool_choice,omitempty"`
+	ParallelToolCalls *bool             `json:"parallel_tool_calls,omitempty"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+	ServiceTier       ServiceTier       `json:"service_tier,omitempty"`
+	User              string            `json:"user,omitempty"`
+}
+
+// ModelResponse is a response of a model. Its Output holds the items generated
+// by the model, read with OutputText and ReasoningSummaryText.
+type ModelResponse struct {
+	ID                 string             `json:"id"`
+	Object             string             `json:"object"`
+	CreatedAt          int64              `json:"created_at"`
+	Status             ResponseStatus     `json:"status"`
+	Model              string             `json:"model"`
+	Output             []ResponseItem     `json:"output"`
+	PreviousResponseID *string            `json:"previous_response_id,omitempty"`
+	Reasoning          *ResponseReasoning `json:"reasoning,omitempty"`
+	Metadata           map[string]string  `json:"metadata,omitempty"`
+	Prompt             *ResponsePrompt    `json:"prompt,omitempty"`
+	Usage              *ResponseUsage     `json:"usage,omitempty"`
+	// IncompleteDetails tells why a response is ResponseStatusIncomplete, and
+	// Error why it is ResponseStatusFailed.
+	IncompleteDetails *ResponseIncompleteDetails `json:"incomplete_details,omitempty"`
+	Error             *ResponseError             `json:"error,omitempty"`
+
+	httpHeader
+}
+
+// ResponseUsage is the token usage of a response.
+type ResponseUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+	TotalTokens  int `json:"total_tokens"`
+	// InputTokensDetails counts the cached input tokens, and
+	// OutputTokensDetails the reasoning tokens.
+	InputTokensDetails  *PromptTokensDetails     `json:"input_tokens_details,omitempty"`
+	OutputTokensDetails *CompletionTokensDetails `json:"output_tokens_details,omitempty"`
+}
+
+// ResponseIncompleteReason is why a response is incomplete.
+type ResponseIncompleteReason string
+
+const (
+	ResponseIncompleteMaxOutputTokens ResponseIncompleteReason = "max_output_tokens"
+	ResponseIncompleteContentFilter   ResponseIncompleteReason = "content_filter"
+)
+
+// ResponseIncompleteDetails tells why a response is incomplete.
+type ResponseIncompleteDetails struct {
+	Reason ResponseIncompleteReason `json:"reason"`
+}
+
+// ResponseError is the error that made a response fail.
+type ResponseError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("response error %s: %s", e.Code, e.Message)
+}
+
+// OutputText returns the text of the output messages of the response.
+func (r ModelResponse) OutputText() string {
+	var text strings.Builder
+	for _, item := range r.Output {
+		if item.Type != ResponseItemTypeMessage {
+			continue
+		}
+		for _, content := range item.Content {
+			if content.Type == ResponseContentTypeOutputText {
+				text.WriteString(content.Text)
+			}
+		}
+	}
+	return text.String()
+}
+
+// ReasoningSummaryText returns the summaries of the reasoning items of the
+// response, separated by blank lines. It is empty unless the request asked for
+// a ResponseReasoning summary.
+func (r ModelResponse) ReasoningSummaryText() string {
+	var parts []string
+	for _, item := range r.Output {
+		if item.Type != ResponseItemTypeReasoning {
+			continue
+		}
+		for _, part := range item.Summary {
+			parts = append(parts, part.Text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// ResponseDeleteResponse is the outcome of DeleteResponse.
+type ResponseDeleteResponse struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+
+	httpHeader
+}
+
+// CreateResponse creates a model response. Use CreateResponseStream to
+// receive it as it is generated.
+func (c *Client) CreateResponse(
+	ctx context.Context,
+	request ResponseRequest,
+	opts ...RequestOption,
+) (response ModelResponse, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(responsesSuffix, request.Model), withModel(request.Model),
+		withServiceTier(request.ServiceTier), withBody(request), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// RetrieveResponse retrieves a stored response. Data omitted by default is
+// requested with WithInclude, e.g.
+// WithInclude(string(ResponseIncludeFileSearchResults)).
+func (c *Client) RetrieveResponse(
+	ctx context.Context,
+	responseID string,
+	opts ...RequestOption,
+) (response ModelResponse, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", responsesSuffix, responseID)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// UploadResponseInputFile uploads the local file at path, e.g. a PDF, with
+// PurposeUserData and returns it as an input file to send in a message.
+func (c *Client) UploadResponseInputFile(
+	ctx context.Context,
+	path string,
+	opts ...RequestOption,
+) (content ResponseContent, err error) {
+	file, err := c.CreateFile(ctx, FileRequest{FilePath: path, Purpose: string(PurposeUserData)}, opts...)
+	if err != nil {
+		return
+	}
+	return NewResponseInputFile(file.ID), nil
+}
+
+// DeleteResponse deletes a stored response.
+func (c *Client) DeleteResponse(
+	ctx context.Context,
+	responseID string,
+	opts ...RequestOption,
+) (response ResponseDeleteResponse, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", responsesSuffix, responseID)
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
@@ -0,0 +1,162 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+
+	utils "github.com/sashabaranov/go-openai/internal"
+)
+
+// ResponseEventType is the type of an event of a response stream.
+type ResponseEventType string
+
+const (
+	ResponseEventCreated    ResponseEventType = "response.created"
+	ResponseEventInProgress ResponseEventType = "response.in_progress"
+	ResponseEventCompleted  ResponseEventType = "response.completed"
+	ResponseEventFailed     ResponseEventType = "response.failed"
+	ResponseEventIncomplete ResponseEventType = "response.incomplete"
+
+	ResponseEventOutputItemAdded  ResponseEventType = "response.output_item.added"
+	ResponseEventOutputItemDone   ResponseEventType = "response.output_item.done"
+	ResponseEventContentPartAdded ResponseEventType = "response.content_part.added"
+	ResponseEventContentPartDone  ResponseEventType = "response.content_part.done"
+	ResponseEventOutputTextDelta  ResponseEventType = "response.output_text.delta"
+	ResponseEventOutputTextDone   ResponseEventType = "response.output_text.done"
+	ResponseEventRefusalDelta     ResponseEventType = "response.refusal.delta"
+	ResponseEventRefusalDone      ResponseEventType = "response.refusal.done"
+
+	ResponseEventFunctionCallArgumentsDelta ResponseEventType = "response.function_call_arguments.delta"
+	ResponseEventFunctionCallArgumentsDone  ResponseEventType = "response.function_call_arguments.done"
+
+	ResponseEventReasoningSummaryPartAdded ResponseEventType = "response.reasoning_summary_part.added"
+	ResponseEventReasoningSummaryPartDone  ResponseEventType = "response.reasoning_summary_part.done"
+	ResponseEventReasoningSummaryTextDelta ResponseEventType = "response.reasoning_summary_text.delta"
+	ResponseEventReasoningSummaryTextDone  ResponseEventType = "response.reasoning_summary_text.done"
+
+	ResponseEventError ResponseEventType = "error"
+)
+
+// ResponseStreamEvent is an event of a response stream. Which fields are set
+// depends on Type.
+type ResponseStreamEvent struct {
+	Type           ResponseEventType `json:"type"`
+	SequenceNumber int               `json:"sequence_number"`
+
+	// Response is set by the events of the lifecycle of the response, e.g.
+	// ResponseEventCompleted.
+	Response *ModelResponse `json:"response,omitempty"`
+
+	// OutputIndex and ItemID locate the output item the event is about, and
+	// ContentIndex the content part of a message.
+	OutputIndex  int    `json:"output_index"`
+	ContentIndex int    `json:"content_index"`
+	ItemID       string `json:"item_id,omitempty"`
+	// SummaryIndex locates the part of the summary of a reasoning item.
+	SummaryIndex int `json:"summary_index"`
+
+	// Item is set by the output item events.
+	Item *ResponseItem `json:"item,omitempty"`
+	// Part is set by the content part events.
+	Part *ResponseContent `json:"part,omitempty"`
+	// Delta is the text added by the delta events, including the reasoning
+	// summary ones.
+	Delta string `json:"delta,omitempty"`
+	// Text is the whole text of the text done events.
+	Text      string `json:"text,omitempty"`
+	Refusal   string `json:"refusal,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+
+	// Code, Message and Param describe the error of ResponseEventError.
+	Code    string  `json:"code,omitempty"`
+	Message string  `json:"message,omitempty"`
+	Param   *string `json:"param,omitempty"`
+}
+
+// ResponseStream is a response being generated, received event by event with
+// Recv.
+type ResponseStream struct {
+	scanner     *SSEScanner
+	body        io.ReadCloser
+	unmarshaler utils.Unmarshaler
+	closeOnce   sync.Once
+	closeErr    error
+
+	httpHeader
+}
+
+// Recv returns the next event of the stream, or io.EOF once the stream has
+// ended. An error event of the stream is returned with an *APIError.
+func (s *ResponseStream) Recv() (event ResponseStreamEvent, err error) {
+	for s.scanner.Next() {
+		data := s.scanner.Scan().Data
+		if data == "" {
+			continue
+		}
+		if data == string(doneData) {
+			return ResponseStreamEvent{}, io.EOF
+		}
+		if err = s.unmarshaler.Unmarshal([]byte(data), &event); err != nil {
+			return ResponseStreamEvent{}, err
+		}
+		if event.Type == ResponseEventError {
+			err = &APIError{Code: event.Code, Message: event.Message, Param: event.Param, Type: string(event.Type)}
+		}
+		return event, err
+	}
+	if err = s.scanner.Err(); err != nil {
+		return ResponseStreamEvent{}, err
+	}
+	return ResponseStreamEvent{}, io.EOF
+}
+
+// Close releases the connection of the stream. It is safe to call more than
+// once.
+func (s *ResponseStream) Close() error {
+	s.closeOnce.Do(func() {
+		s.closeErr = s.body.Close()
+	})
+	return s.closeErr
+}
+
+// CreateResponseStream creates a model response and streams its events as it
+// is generated: the text with ResponseEventOutputTextDelta, the reasoning
+// summary with ResponseEventReasoningSummaryTextDelta, and the whole response
+// with ResponseEventCompleted.
+func (c *Client) CreateResponseStream(
+	ctx context.Context,
+	request ResponseRequest,
+	opts ...RequestOption,
+) (stream *ResponseStream, err error) {
+	body := struct {
+		ResponseRequest
+		Stream bool `json:"stream"`
+	}{request, true}
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(responsesSuffix, request.Model), withModel(request.Model),
+		withServiceTier(request.ServiceTier), withBody(body), withStream(), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	resp, err := c.doWithRetry(req) //nolint:bodyclose // body is closed in stream.Close()
+	if err != nil {
+		return
+	}
+	if isFailureStatusCode(resp) {
+		return nil, c.handleErrorResp(resp)
+	}
+	c.withIdleTimeout(req, resp)
+
+	var unmarshaler utils.Unmarshaler = &utils.JSONUnmarshaler{}
+	if c.config.JSONCodec != nil {
+		unmarshaler = c.config.JSONCodec
+	}
+	return &ResponseStream{
+		scanner:     NewSSEScannerSize(resp.Body, false, c.config.StreamMaxLineSize),
+		body:        resp.Body,
+		unmarshaler: unmarshaler,
+		httpHeader:  httpHeader{header: resp.Header},
+	}, nil
+}
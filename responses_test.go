@@ -0,0 +1,351 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+const responseWithReasoning = `{"id":"resp_1","object":"response","status":"completed","model":"o4-mini","output":[` +
+	`{"type":"reasoning","id":"rs_1","summary":[{"type":"summary_text","text":"Compared both."},` +
+	`{"type":"summary_text","text":"Picked the larger."}]},` +
+	`{"type":"message","id":"msg_1","role":"assistant","content":[{"type":"output_text","text":"9.11"}]}]}`
+
+// TestResponses Tests the responses endpoints of the API using the mocked server.
+func TestResponses(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]any
+		err := json.NewDecoder(r.Body).Decode(&request)
+		checks.NoError(t, err, "Decode error")
+		reasoning, _ := request["reasoning"].(map[string]any)
+		if reasoning["effort"] != "high" || reasoning["summary"] != "auto" {
+			t.Errorf("unexpected reasoning: %v", request["reasoning"])
+		}
+		fmt.Fprint(w, responseWithReasoning)
+	})
+	server.RegisterHandler("/v1/responses/resp_1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			fmt.Fprint(w, `{"id":"resp_1","object":"response","deleted":true}`)
+			return
+		}
+		fmt.Fprint(w, responseWithReasoning)
+	})
+
+	ctx := context.Background()
+	response, err := client.CreateResponse(ctx, openai.ResponseRequest{
+		Model: "o4-mini",
+		Input: []openai.ResponseItem{openai.NewResponseMessage(openai.ChatMessageRoleUser, "9.11 or 9.9?")},
+		Reasoning: &openai.ResponseReasoning{
+			Effort:  openai.ReasoningEffortHigh,
+			Summary: openai.ReasoningSummaryAuto,
+		},
+	})
+	checks.NoError(t, err, "CreateResponse error")
+	if text := response.OutputText(); text != "9.11" {
+		t.Errorf("unexpected output text: %q", text)
+	}
+	if summary := response.ReasoningSummaryText(); summary != "Compared both.\n\nPicked the larger." {
+		t.Errorf("unexpected reasoning summary: %q", summary)
+	}
+
+	response, err = client.RetrieveResponse(ctx, "resp_1")
+	checks.NoError(t, err, "RetrieveResponse error")
+	if response.Status != openai.ResponseStatusCompleted {
+		t.Errorf("unexpected status: %s", response.Status)
+	}
+
+	deleted, err := client.DeleteResponse(ctx, "resp_1")
+	checks.NoError(t, err, "DeleteResponse error")
+	if !deleted.Deleted {
+		t.Error("expected the response to be deleted")
+	}
+}
+
+func TestResponseItemReasoningSummary(t *testing.T) {
+	data, err := json.Marshal(openai.ResponseItem{Type: openai.ResponseItemTypeReasoning, ID: "rs_1"})
+	checks.NoError(t, err, "Marshal error")
+	if string(data) != `{"summary":[],"type":"reasoning","id":"rs_1"}` {
+		t.Errorf("unexpected reasoning item: %s", data)
+	}
+}
+
+func TestCreateResponseStream(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]any
+		err := json.NewDecoder(r.Body).Decode(&request)
+		checks.NoError(t, err, "Decode error")
+		if request["stream"] != true {
+			t.Errorf("expected a stream request, got %v", request["stream"])
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, data := range []string{
+			`{"type":"response.reasoning_summary_text.delta","item_id":"rs_1","summary_index":0,"delta":"Compared"}`,
+			`{"type":"response.reasoning_summary_text.delta","item_id":"rs_1","summary_index":0,"delta":" both."}`,
+			`{"type":"response.output_text.delta","item_id":"msg_1","output_index":1,"delta":"9.11"}`,
+			`{"type":"response.completed","response":` + responseWithReasoning + `}`,
+			`{"type":"error","code":"server_error","message":"boom"}`,
+		} {
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+		}
+	})
+
+	stream, err := client.CreateResponseStream(context.Background(), openai.ResponseRequest{
+		Model:     "o4-mini",
+		Input:     []openai.ResponseItem{openai.NewResponseMessage(openai.ChatMessageRoleUser, "9.11 or 9.9?")},
+		Reasoning: &openai.ResponseReasoning{Summary: openai.ReasoningSummaryAuto},
+	})
+	checks.NoError(t, err, "CreateResponseStream error")
+	defer stream.Close()
+
+	var summary, text string
+	var completed *openai.ModelResponse
+	for {
+		event, recvErr := stream.Recv()
+		var apiErr *openai.APIError
+		if errors.As(recvErr, &apiErr) {
+			if apiErr.Message != "boom" {
+				t.Errorf("unexpected error event: %v", apiErr)
+			}
+			continue
+		}
+		if errors.Is(recvErr, io.EOF) {
+			break
+		}
+		checks.NoError(t, recvErr, "Recv error")
+		switch event.Type {
+		case openai.ResponseEventReasoningSummaryTextDelta:
+			summary += event.Delta
+		case openai.ResponseEventOutputTextDelta:
+			text += event.Delta
+		case openai.ResponseEventCompleted:
+			completed = event.Response
+		}
+	}
+	if summary != "Compared both." || text != "9.11" {
+		t.Errorf("unexpected deltas: summary %q, text %q", summary, text)
+	}
+	if completed == nil || completed.OutputText() != "9.11" {
+		t.Errorf("unexpected completed response: %+v", completed)
+	}
+}
+
+func TestResponseInclude(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	const response = `{"id":"resp_1","object":"response","status":"completed","output":[` +
+		`{"type":"file_search_call","id":"fs_1","queries":["refunds"],` +
+		`"results":[{"file_id":"file-1","filename":"policy.md","score":0.9,"text":"30 days"}]},` +
+		`{"type":"reasoning","id":"rs_1","summary":[],"encrypted_content":"gAAAA"}]}`
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.ResponseRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		checks.NoError(t, err, "Decode error")
+		if len(request.Include) != 2 || request.Include[0] != openai.ResponseIncludeFileSearchResults {
+			t.Errorf("unexpected include: %v", request.Include)
+		}
+		fmt.Fprint(w, response)
+	})
+	server.RegisterHandler("/v1/responses/resp_1", func(w http.ResponseWriter, r *http.Request) {
+		if include := r.URL.Query()["include[]"]; len(include) != 1 || include[0] != "file_search_call.results" {
+			t.Errorf("unexpected include query: %v", include)
+		}
+		fmt.Fprint(w, response)
+	})
+
+	ctx := context.Background()
+	created, err := client.CreateResponse(ctx, openai.ResponseRequest{
+		Model: "o4-mini",
+		Input: []openai.ResponseItem{openai.NewResponseMessage(openai.ChatMessageRoleUser, "Refund policy?")},
+		Include: []openai.ResponseInclude{
+			openai.ResponseIncludeFileSearchResults,
+			openai.ResponseIncludeReasoningEncryptedContent,
+		},
+	})
+	checks.NoError(t, err, "CreateResponse error")
+	search, reasoning := created.Output[0], created.Output[1]
+	if len(search.Results) != 1 || search.Results[0].Filename != "policy.md" || search.Queries[0] != "refunds" {
+		t.Errorf("unexpected file search call: %+v", search)
+	}
+	if reasoning.EncryptedContent != "gAAAA" {
+		t.Errorf("unexpected encrypted content: %q", reasoning.EncryptedContent)
+	}
+
+	_, err = client.RetrieveResponse(ctx, "resp_1", openai.WithInclude(string(openai.ResponseIncludeFileSearchResults)))
+	checks.NoError(t, err, "RetrieveResponse error")
+}
+
+func TestResponseStatelessReasoning(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	turns := 0
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]any
+		err := json.NewDecoder(r.Body).Decode(&request)
+		checks.NoError(t, err, "Decode error")
+		if request["store"] != false {
+			t.Errorf("expected store to be false, got %v", request["store"])
+		}
+		turns++
+		if turns == 2 {
+			input, _ := request["input"].([]any)
+			if len(input) != 4 {
+				t.Fatalf("expected 4 input items, got %d", len(input))
+			}
+			reasoning, _ := input[1].(map[string]any)
+			if reasoning["type"] != "reasoning" || reasoning["encrypted_content"] != "gAAAA" {
+				t.Errorf("unexpected reasoning item: %v", reasoning)
+			}
+		}
+		fmt.Fprint(w, `{"id":"resp_1","object":"response","status":"completed","output":[`+
+			`{"type":"reasoning","id":"rs_1","summary":[],"encrypted_content":"gAAAA"},`+
+			`{"type":"message","id":"msg_1","role":"assistant","content":[{"type":"output_text","text":"42"}]}]}`)
+	})
+
+	store := false
+	request := openai.ResponseRequest{
+		Model:   "o4-mini",
+		Input:   []openai.ResponseItem{openai.NewResponseMessage(openai.ChatMessageRoleUser, "6 times 7?")},
+		Store:   &store,
+		Include: []openai.ResponseInclude{openai.ResponseIncludeReasoningEncryptedContent},
+	}
+	response, err := client.CreateResponse(context.Background(), request)
+	checks.NoError(t, err, "CreateResponse error")
+
+	request.Input = append(request.Input, response.Output...)
+	request.Input = append(request.Input, openai.NewResponseMessage(openai.ChatMessageRoleUser, "Plus 1?"))
+	_, err = client.CreateResponse(context.Background(), request)
+	checks.NoError(t, err, "CreateResponse error")
+}
+
+func TestResponseInputFiles(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	checks.NoError(t, os.WriteFile(path, []byte("%PDF-1.7"), 0o600), "WriteFile error")
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, r *http.Request) {
+		if purpose := r.FormValue("purpose"); purpose != string(openai.PurposeUserData) {
+			t.Errorf("unexpected purpose: %q", purpose)
+		}
+		fmt.Fprint(w, `{"id":"file-1","object":"file","filename":"report.pdf","purpose":"user_data"}`)
+	})
+
+	file, err := client.UploadResponseInputFile(context.Background(), path)
+	checks.NoError(t, err, "UploadResponseInputFile error")
+	message := openai.NewResponseMessageContent(openai.ChatMessageRoleUser,
+		openai.ResponseContent{Type: openai.ResponseContentTypeInputText, Text: "Summarize these."},
+		file,
+		openai.NewResponseInputFileData("notes.pdf", []byte("%PDF")),
+		openai.NewResponseInputImageURL("https://example.com/chart.png", openai.ImageURLDetailLow),
+	)
+	data, err := json.Marshal(message.Content[1:])
+	checks.NoError(t, err, "Marshal error")
+	want := `[{"type":"input_file","file_id":"file-1"},` +
+		`{"type":"input_file","file_data":"data:application/pdf;base64,JVBERg==","filename":"notes.pdf"},` +
+		`{"type":"input_image","image_url":"https://example.com/chart.png","detail":"low"}]`
+	if string(data) != want {
+		t.Errorf("unexpected content:\n got %s\nwant %s", data, want)
+	}
+}
+
+func TestResponsePrompt(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]any
+		err := json.NewDecoder(r.Body).Decode(&request)
+		checks.NoError(t, err, "Decode error")
+		if _, ok := request["model"]; ok {
+			t.Errorf("expected no model, got %v", request["model"])
+		}
+		prompt, _ := json.Marshal(request["prompt"])
+		want := `{"id":"pmpt_1","variables":{"city":"Paris",` +
+			`"photo":{"file_id":"file-1","type":"input_image"}},"version":"2"}`
+		if string(prompt) != want {
+			t.Errorf("unexpected prompt:\n got %s\nwant %s", prompt, want)
+		}
+		fmt.Fprintf(w, `{"id":"resp_1","object":"response","status":"completed","prompt":%s}`, prompt)
+	})
+
+	response, err := client.CreateResponse(context.Background(), openai.ResponseRequest{
+		Prompt: &openai.ResponsePrompt{
+			ID:      "pmpt_1",
+			Version: "2",
+			Variables: map[string]openai.ResponsePromptVariable{
+				"city":  {Text: "Paris"},
+				"photo": openai.NewResponsePromptContent(openai.NewResponseInputImageFile("file-1", "")),
+			},
+		},
+	})
+	checks.NoError(t, err, "CreateResponse error")
+	variables := response.Prompt.Variables
+	if variables["city"].Text != "Paris" || variables["photo"].Content.FileID != "file-1" {
+		t.Errorf("unexpected prompt variables: %+v", variables)
+	}
+}
+
+func TestResponseUsageAndIncompleteDetails(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/responses", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"id":"resp_1","object":"response","status":"incomplete","model":"o4-mini",`+
+			`"incomplete_details":{"reason":"max_output_tokens"},"error":null,`+
+			`"usage":{"input_tokens":30,"output_tokens":20,"total_tokens":50,`+
+			`"input_tokens_details":{"cached_tokens":10},"output_tokens_details":{"reasoning_tokens":20}}}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var reported openai.Usage
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.OnUsage = func(_ context.Context, _ string, usage openai.Usage) {
+		reported = usage
+	}
+	client := openai.NewClientWithConfig(config)
+
+	response, err := client.CreateResponse(context.Background(), openai.ResponseRequest{Model: "o4-mini"})
+	checks.NoError(t, err, "CreateResponse error")
+	if response.IncompleteDetails == nil || response.IncompleteDetails.Reason != openai.ResponseIncompleteMaxOutputTokens {
+		t.Errorf("unexpected incomplete details: %+v", response.IncompleteDetails)
+	}
+	if response.Error != nil {
+		t.Errorf("unexpected error: %v", response.Error)
+	}
+	usage := response.Usage
+	if usage == nil || usage.InputTokensDetails.CachedTokens != 10 || usage.OutputTokensDetails.ReasoningTokens != 20 {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+	if reported.TotalTokens != 50 || reported.CompletionTokensDetails.ReasoningTokens != 20 {
+		t.Errorf("unexpected reported usage: %+v", reported)
+	}
+}
+
+func TestResponseError(t *testing.T) {
+	var response openai.ModelResponse
+	err := json.Unmarshal([]byte(`{"id":"resp_1","status":"failed",`+
+		`"error":{"code":"rate_limit_exceeded","message":"Slow down."}}`), &response)
+	checks.NoError(t, err, "Unmarshal error")
+	if response.Error == nil || response.Error.Error() != "response error rate_limit_exceeded: Slow down." {
+		t.Errorf("unexpected error: %v", response.Error)
+	}
+}
@@ -0,0 +1,210 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMinBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff = 8 * time.Second
+)
+
+// RetryMode selects which calls are retried automatically.
+type RetryMode int
+
+const (
+	// RetryIdempotent retries only calls that are safe to send again: GET
+	// calls, such as listing and retrieving objects, and calls with an
+	// idempotency key, see WithIdempotencyKey and
+	// ClientConfig.AutoIdempotencyKeys. Other calls could create duplicates of
+	// vector stores, batches or files when the failed attempt reached the API.
+	RetryIdempotent RetryMode = iota
+	// RetryAll retries every call, including calls that create objects.
+	RetryAll
+)
+
+// RetryPolicy controls how requests that fail with a transient error are
+// retried. The zero value disables retries.
+//
+// A request that is answered with 429 or 5xx, or fails in transport, is sent
+// again when Mode allows it. Streams are retried only while being
+// established: once the first event is received, errors are returned to the
+// caller. Streams are established with POST requests, so with the default
+// RetryIdempotent mode they are retried only when they carry an idempotency
+// key, e.g. with ClientConfig.AutoIdempotencyKeys: run streams create runs,
+// which a retry could duplicate. Set Mode to RetryAll to retry every stream,
+// as earlier versions did.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// Mode selects the calls that are retried. Defaults to RetryIdempotent.
+	Mode RetryMode
+	// MinBackoff is the delay before the first retry. It doubles with every
+	// further retry. Defaults to 500ms.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between retries, including delays requested
+	// with a Retry-After header. Defaults to 8s.
+	MaxBackoff time.Duration
+	// Backoff, when set, decides the delay before each retry instead of
+	// MinBackoff and MaxBackoff, and can stop retrying early, e.g. with an
+	// ExponentialBackoff with jitter or a RateLimitBackoff.
+	Backoff Backoff
+	// Budget caps the wall-clock time of a call until its response arrives,
+	// across all of its attempts, including redirects and the delays between
+	// retries. A retry that would start after the budget is spent is not
+	// attempted, and the last response is returned instead. An attempt still
+	// waiting for its response when the budget is spent is cancelled, and the
+	// call fails with an error matching context.DeadlineExceeded. Reading the
+	// response, e.g. the events of a stream, is not limited by the budget.
+	// Zero means no budget.
+	Budget time.Duration
+}
+
+// retryBudget is the time left for the retries of a single call.
+type retryBudget struct {
+	deadline time.Time
+}
+
+func newRetryBudget(budget time.Duration) retryBudget {
+	if budget <= 0 {
+		return retryBudget{}
+	}
+	return retryBudget{deadline: time.Now().Add(budget)}
+}
+
+// allows reports whether a retry after delay starts within the budget.
+func (b retryBudget) allows(delay time.Duration) bool {
+	return b.deadline.IsZero() || time.Now().Add(delay).Before(b.deadline)
+}
+
+// nextDelay returns the delay before retry number attempt, starting at 0, and
+// whether to retry at all.
+func (p RetryPolicy) nextDelay(attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if p.Backoff != nil {
+		return p.Backoff.NextDelay(attempt, resp, err)
+	}
+
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+	delay, _ := ExponentialBackoff{Min: p.MinBackoff, Max: maxBackoff}.NextDelay(attempt, resp, err)
+	if after, ok := retryAfter(resp); ok {
+		delay = after
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay, true
+}
+
+// retryAfter parses the Retry-After header, in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date), true
+	}
+	return 0, false
+}
+
+func isRetryableStatusCode(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// allowsRetry reports whether the mode of p allows sending req again.
+func (p RetryPolicy) allowsRetry(req *http.Request) bool {
+	if p.Mode == RetryAll {
+		return true
+	}
+	return req.Method == http.MethodGet || req.Method == http.MethodHead ||
+		req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// isTransientFailure reports whether an attempt failed with a retryable status
+// or a transport error other than the cancellation of the call.
+func isTransientFailure(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+	return isRetryableStatusCode(resp.StatusCode)
+}
+
+// doWithRetry sends req and retries it according to the client's RetryPolicy
+// while the response status is transient or the request fails in transport. The response of the last attempt is
+// returned, so callers handle exhausted retries like any other failure.
+func (c *Client) doWithRetry(req *http.Request) (resp *http.Response, err error) {
+	defer func() {
+		resp, err = prepareResponse(req, resp, err)
+	}()
+
+	policy := c.config.RetryPolicy
+	budget := newRetryBudget(policy.Budget)
+	if !budget.deadline.IsZero() {
+		ctx, cancel := context.WithCancel(req.Context())
+		timer := time.AfterFunc(time.Until(budget.deadline), cancel)
+		req = req.WithContext(ctx)
+		defer func() {
+			if !timer.Stop() {
+				// The budget was spent before a response arrived.
+				if resp != nil {
+					resp.Body.Close()
+				}
+				resp, err = nil, fmt.Errorf("retry budget of %s spent: %w", policy.Budget, context.DeadlineExceeded)
+			}
+			if err != nil || resp == nil {
+				cancel()
+				return
+			}
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+		}()
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err = c.config.HTTPClient.Do(req) //nolint:bodyclose // body is closed by the caller or below
+		if attempt >= policy.MaxRetries || !isTransientFailure(req, resp, err) || !policy.allowsRetry(req) {
+			return resp, err
+		}
+		// A body that cannot be rewound cannot be sent again.
+		if req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+
+		delay, retry := policy.nextDelay(attempt, resp, err)
+		if !retry || !budget.allows(delay) {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req.Body = body
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func setupRetryTestClient(
+	t *testing.T,
+	policy openai.RetryPolicy,
+	handler func(http.ResponseWriter, *http.Request),
+) *openai.Client {
+	t.Helper()
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", handler)
+	ts := server.OpenAITestServer()
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.RetryPolicy = policy
+	return openai.NewClientWithConfig(config)
+}
+
+// setupRetryAllTestClient returns a client that retries chat completions,
+// which are not retried by default.
+func setupRetryAllTestClient(
+	t *testing.T,
+	policy openai.RetryPolicy,
+	handler func(http.ResponseWriter, *http.Request),
+) *openai.Client {
+	t.Helper()
+	policy.Mode = openai.RetryAll
+	return setupRetryTestClient(t, policy, handler)
+}
+
+var retryTestRequest = openai.ChatCompletionRequest{
+	Model:    openai.GPT3Dot5Turbo,
+	Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hello"}},
+}
+
+func TestChatCompletionStreamRetriesTransientFailures(t *testing.T) {
+	attempts := 0
+	client := setupRetryAllTestClient(t, openai.RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond},
+		func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			body, _ := io.ReadAll(r.Body)
+			if len(body) == 0 {
+				t.Errorf("attempt %d was sent without a body", attempts)
+			}
+			switch attempts {
+			case 1:
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			case 2:
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				fmt.Fprint(w, `{"error":{"message":"rate limited","type":"requests"}}`)
+				return
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, `data: {"id":"1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{"content":"hi"}}]}`)
+			fmt.Fprint(w, "\n\ndata: [DONE]\n\n")
+		})
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), retryTestRequest)
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	defer stream.Close()
+
+	resp, err := stream.Recv()
+	checks.NoError(t, err, "Recv error")
+	if resp.Choices[0].Delta.Content != "hi" {
+		t.Errorf("unexpected content: %q", resp.Choices[0].Delta.Content)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestChatCompletionStreamRetriesExhausted(t *testing.T) {
+	attempts := 0
+	client := setupRetryAllTestClient(t, openai.RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond},
+		func(w http.ResponseWriter, _ *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"error":{"message":"server error","type":"server_error"}}`)
+		})
+
+	_, err := client.CreateChatCompletionStream(context.Background(), retryTestRequest)
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected a 500 APIError, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestChatCompletionStreamDoesNotRetryClientErrors(t *testing.T) {
+	attempts := 0
+	client := setupRetryAllTestClient(t, openai.RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond},
+		func(w http.ResponseWriter, _ *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"message":"bad request","type":"invalid_request_error"}}`)
+		})
+
+	_, err := client.CreateChatCompletionStream(context.Background(), retryTestRequest)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestChatCompletionStreamRetryZeroPolicy(t *testing.T) {
+	attempts := 0
+	client := setupRetryAllTestClient(t, openai.RetryPolicy{},
+		func(w http.ResponseWriter, _ *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+	_, err := client.CreateChatCompletionStream(context.Background(), retryTestRequest)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestChatCompletionStreamRetryContextCanceled(t *testing.T) {
+	client := setupRetryAllTestClient(t, openai.RetryPolicy{MaxRetries: 3, MinBackoff: time.Hour},
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.CreateChatCompletionStream(ctx, retryTestRequest)
+	checks.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestChatCompletionStreamRetryBudget(t *testing.T) {
+	attempts := 0
+	policy := openai.RetryPolicy{
+		MaxRetries: 10,
+		MinBackoff: 20 * time.Millisecond,
+		MaxBackoff: 20 * time.Millisecond,
+		Budget:     50 * time.Millisecond,
+	}
+	client := setupRetryAllTestClient(t, policy, func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error":{"message":"rate limited","type":"requests"}}`)
+	})
+
+	start := time.Now()
+	_, err := client.CreateChatCompletionStream(context.Background(), retryTestRequest)
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected a 429 APIError, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > policy.Budget {
+		t.Errorf("retries took %s, longer than the %s budget", elapsed, policy.Budget)
+	}
+	if attempts < 2 || attempts > 3 {
+		t.Errorf("expected 2 or 3 attempts within the budget, got %d", attempts)
+	}
+}
+
+func TestRetryIdempotentMode(t *testing.T) {
+	server := test.NewTestServer()
+	var modelAttempts, vectorAttempts int
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		modelAttempts++
+		if modelAttempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	})
+	server.RegisterHandler("/v1/vector_stores", func(w http.ResponseWriter, _ *http.Request) {
+		vectorAttempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"error":{"message":"overloaded","type":"server_error"}}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.RetryPolicy = openai.RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond}
+	client := openai.NewClientWithConfig(config)
+
+	_, err := client.ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+	if modelAttempts != 2 {
+		t.Errorf("expected the GET call to be retried, got %d attempts", modelAttempts)
+	}
+
+	_, err = client.CreateVector(context.Background(), openai.VectorRequest{})
+	checks.HasError(t, err, "CreateVector should fail")
+	if vectorAttempts != 1 {
+		t.Errorf("expected the POST call not to be retried, got %d attempts", vectorAttempts)
+	}
+
+	vectorAttempts = 0
+	_, err = client.CreateVector(context.Background(), openai.VectorRequest{},
+		openai.WithIdempotencyKey(openai.NewIdempotencyKey()))
+	checks.HasError(t, err, "CreateVector should fail")
+	if vectorAttempts != 3 {
+		t.Errorf("expected the POST call with an idempotency key to be retried, got %d attempts", vectorAttempts)
+	}
+}
+
+func TestChatCompletionStreamRetryIdempotentMode(t *testing.T) {
+	attempts := 0
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"error":{"message":"overloaded","type":"server_error"}}`)
+	}
+	policy := openai.RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond}
+
+	client := setupRetryTestClient(t, policy, handler)
+	_, err := client.CreateChatCompletionStream(context.Background(), retryTestRequest)
+	checks.HasError(t, err, "CreateChatCompletionStream should fail")
+	if attempts != 1 {
+		t.Errorf("expected the stream not to be retried by default, got %d attempts", attempts)
+	}
+
+	attempts = 0
+	_, err = client.CreateChatCompletionStream(context.Background(), retryTestRequest,
+		openai.WithIdempotencyKey(openai.NewIdempotencyKey()))
+	checks.HasError(t, err, "CreateChatCompletionStream should fail")
+	if attempts != 3 {
+		t.Errorf("expected the stream with an idempotency key to be retried, got %d attempts", attempts)
+	}
+}
+
+func TestRetryBudgetBoundsSlowAttempts(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	policy := openai.RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond, Budget: 50 * time.Millisecond}
+	client := setupRetryAllTestClient(t, policy, func(_ http.ResponseWriter, r *http.Request) {
+		// Simulate an attempt that hangs.
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	start := time.Now()
+	_, err := client.CreateChatCompletion(context.Background(), retryTestRequest)
+	checks.ErrorIs(t, err, context.DeadlineExceeded, "the slow attempt should be cut at the budget")
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the call took %s, far longer than the %s budget", elapsed, policy.Budget)
+	}
+}
@@ -29,6 +29,7 @@ type Run struct {
 	Usage          Usage              `json:"usage,omitempty"`
 
 	Temperature *float32 `json:"temperature,omitempty"`
+	TopP        *float32 `json:"top_p,omitempty"`
 	// The maximum number of prompt tokens that may be used over the course of the run.
 	// If the run exceeds the number of prompt tokens specified, the run will end with status 'incomplete'.
 	MaxPromptTokens int `json:"max_prompt_tokens,omitempty"`
@@ -37,6 +38,8 @@ type Run struct {
 	MaxCompletionTokens int `json:"max_completion_tokens,omitempty"`
 	// ThreadTruncationStrategy defines the truncation strategy to use for the thread.
 	TruncationStrategy *ThreadTruncationStrategy `json:"truncation_strategy,omitempty"`
+	// ResponseFormat is AssistantResponseFormatAuto or a response format object.
+	ResponseFormat any `json:"response_format,omitempty"`
 
 	httpHeader
 }
@@ -111,7 +114,9 @@ type RunRequest struct {
 
 	// This can be either a string or a ToolChoice object.
 	ToolChoice any `json:"tool_choice,omitempty"`
-	// This can be either a string or a ResponseFormat object.
+	// This can be either AssistantResponseFormatAuto or a
+	// *ChatCompletionResponseFormat, e.g. with a JSON schema, and overrides
+	// the response format of the assistant.
 	ResponseFormat any `json:"response_format,omitempty"`
 }
 
@@ -157,6 +162,10 @@ type RunModifyRequest struct {
 type RunList struct {
 	Runs []Run `json:"data"`
 
+	FirstID string `json:"first_id"`
+	LastID  string `json:"last_id"`
+	HasMore bool   `json:"has_more"`
+
 	httpHeader
 }
 
@@ -221,6 +230,44 @@ type StepDetailsMessageCreation struct {
 	MessageID string `json:"message_id"`
 }
 
+// ToolTypeFileSearch is the type of the file_search tool calls of run steps.
+const ToolTypeFileSearch ToolType = "file_search"
+
+// RunStepIncludeFileSearchContent requests the content of the retrieved chunks
+// in FileSearchResult.Content. Pass it to the run step methods with WithInclude.
+const RunStepIncludeFileSearchContent = "step_details.tool_calls[*].file_search.results[*].content"
+
+// FileSearchToolCall holds what a file_search tool call retrieved.
+type FileSearchToolCall struct {
+	RankingOptions *FileSearchRankingOptions `json:"ranking_options,omitempty"`
+	Results        []FileSearchResult        `json:"results,omitempty"`
+}
+
+// FileSearchResult is a file that a file_search tool call retrieved chunks from.
+type FileSearchResult struct {
+	FileID   string  `json:"file_id"`
+	FileName string  `json:"file_name"`
+	Score    float64 `json:"score"`
+	// Content is only returned when RunStepIncludeFileSearchContent is included.
+	Content []FileSearchResultContent `json:"content,omitempty"`
+}
+
+// FileSearchResultContent is a chunk of text retrieved from a file.
+type FileSearchResultContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// WithInclude adds fields to the include[] query parameter, e.g.
+// RunStepIncludeFileSearchContent, to return data that is omitted by default.
+func WithInclude(fields ...string) RequestOption {
+	return func(args *requestOptions) {
+		for _, field := range fields {
+			WithQueryParam("include[]", field)(args)
+		}
+	}
+}
+
 // RunStepList is a list of steps.
 type RunStepList struct {
 	RunSteps []RunStep `json:"data"`
@@ -239,11 +286,35 @@ type Pagination struct {
 	Before *string
 }
 
+// encode returns the pagination as a URL query string, including the leading "?",
+// or an empty string when no field is set.
+func (p Pagination) encode() string {
+	urlValues := url.Values{}
+	if p.Limit != nil {
+		urlValues.Add("limit", fmt.Sprintf("%d", *p.Limit))
+	}
+	if p.Order != nil {
+		urlValues.Add("order", *p.Order)
+	}
+	if p.After != nil {
+		urlValues.Add("after", *p.After)
+	}
+	if p.Before != nil {
+		urlValues.Add("before", *p.Before)
+	}
+
+	if len(urlValues) == 0 {
+		return ""
+	}
+	return "?" + urlValues.Encode()
+}
+
 // CreateRun creates a new run.
 func (c *Client) CreateRun(
 	ctx context.Context,
 	threadID string,
 	request RunRequest,
+	opts ...RequestOption,
 ) (response Run, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/runs", threadID)
 	req, err := c.newRequest(
@@ -251,7 +322,7 @@ func (c *Client) CreateRun(
 		http.MethodPost,
 		c.fullURL(urlSuffix),
 		withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -265,13 +336,14 @@ func (c *Client) RetrieveRun(
 	ctx context.Context,
 	threadID string,
 	runID string,
+	opts ...RequestOption,
 ) (response Run, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/runs/%s", threadID, runID)
 	req, err := c.newRequest(
 		ctx,
 		http.MethodGet,
 		c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -286,6 +358,7 @@ func (c *Client) ModifyRun(
 	threadID string,
 	runID string,
 	request RunModifyRequest,
+	opts ...RequestOption,
 ) (response Run, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/runs/%s", threadID, runID)
 	req, err := c.newRequest(
@@ -293,7 +366,7 @@ func (c *Client) ModifyRun(
 		http.MethodPost,
 		c.fullURL(urlSuffix),
 		withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -307,6 +380,7 @@ func (c *Client) ListRuns(
 	ctx context.Context,
 	threadID string,
 	pagination Pagination,
+	opts ...RequestOption,
 ) (response RunList, err error) {
 	urlValues := url.Values{}
 	if pagination.Limit != nil {
@@ -332,7 +406,7 @@ func (c *Client) ListRuns(
 		ctx,
 		http.MethodGet,
 		c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -346,14 +420,14 @@ func (c *Client) SubmitToolOutputs(
 	ctx context.Context,
 	threadID string,
 	runID string,
-	request SubmitToolOutputsRequest) (response Run, err error) {
+	request SubmitToolOutputsRequest, opts ...RequestOption) (response Run, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/runs/%s/submit_tool_outputs", threadID, runID)
 	req, err := c.newRequest(
 		ctx,
 		http.MethodPost,
 		c.fullURL(urlSuffix),
 		withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -366,13 +440,13 @@ func (c *Client) SubmitToolOutputs(
 func (c *Client) CancelRun(
 	ctx context.Context,
 	threadID string,
-	runID string) (response Run, err error) {
+	runID string, opts ...RequestOption) (response Run, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/runs/%s/cancel", threadID, runID)
 	req, err := c.newRequest(
 		ctx,
 		http.MethodPost,
 		c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -384,14 +458,14 @@ func (c *Client) CancelRun(
 // CreateThreadAndRun submits tool outputs.
 func (c *Client) CreateThreadAndRun(
 	ctx context.Context,
-	request CreateThreadAndRunRequest) (response Run, err error) {
+	request CreateThreadAndRunRequest, opts ...RequestOption) (response Run, err error) {
 	urlSuffix := "/threads/runs"
 	req, err := c.newRequest(
 		ctx,
 		http.MethodPost,
 		c.fullURL(urlSuffix),
 		withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -412,13 +486,14 @@ func (c *Client) RetrieveRunStep(
 	threadID string,
 	runID string,
 	stepID string,
+	opts ...RequestOption,
 ) (response RunStep, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/runs/%s/steps/%s", threadID, runID, stepID)
 	req, err := c.newRequest(
 		ctx,
 		http.MethodGet,
 		c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -433,6 +508,7 @@ func (c *Client) ListRunSteps(
 	threadID string,
 	runID string,
 	pagination Pagination,
+	opts ...RequestOption,
 ) (response RunStepList, err error) {
 	urlValues := url.Values{}
 	if pagination.Limit != nil {
@@ -458,7 +534,7 @@ func (c *Client) ListRunSteps(
 		ctx,
 		http.MethodGet,
 		c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -516,7 +592,7 @@ type AssistantStream struct {
 
 func (c *Client) CreateThreadAndRunStream(
 	ctx context.Context,
-	request CreateThreadAndRunRequest) (stream *StreamerV2, err error) {
+	request CreateThreadAndRunRequest, opts ...RequestOption) (stream *StreamerV2, err error) {
 	type createThreadAndStreamRequest struct {
 		CreateThreadAndRunRequest
 		Stream bool `json:"stream"`
@@ -533,7 +609,9 @@ func (c *Client) CreateThreadAndRunStream(
 		http.MethodPost,
 		c.fullURL(urlSuffix),
 		withBody(sr),
+		withStream(),
 		withBetaAssistantVersion(c.config.AssistantVersion),
+		withRequestOptions(opts),
 	)
 
 	if err != nil {
@@ -551,7 +629,7 @@ type RunRequestStreaming struct {
 func (c *Client) CreateRunStream(
 	ctx context.Context,
 	threadID string,
-	request RunRequest) (stream *StreamerV2, err error) {
+	request RunRequest, opts ...RequestOption) (stream *StreamerV2, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/runs", threadID)
 
 	r := RunRequestStreaming{
@@ -564,7 +642,9 @@ func (c *Client) CreateRunStream(
 		http.MethodPost,
 		c.fullURL(urlSuffix),
 		withBody(r),
+		withStream(),
 		withBetaAssistantVersion(c.config.AssistantVersion),
+		withRequestOptions(opts),
 	)
 	if err != nil {
 		return
@@ -583,7 +663,7 @@ func (c *Client) SubmitToolOutputsStream(
 	ctx context.Context,
 	threadID string,
 	runID string,
-	request SubmitToolOutputsRequest) (stream *StreamerV2, err error) {
+	request SubmitToolOutputsRequest, opts ...RequestOption) (stream *StreamerV2, err error) {
 	urlSuffix := fmt.Sprintf("/threads/%s/runs/%s/submit_tool_outputs", threadID, runID)
 
 	r := SubmitToolOutputsRequestStreaming{
@@ -596,7 +676,9 @@ func (c *Client) SubmitToolOutputsStream(
 		http.MethodPost,
 		c.fullURL(urlSuffix),
 		withBody(r),
+		withStream(),
 		withBetaAssistantVersion(c.config.AssistantVersion),
+		withRequestOptions(opts),
 	)
 	if err != nil {
 		return
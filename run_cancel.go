@@ -0,0 +1,73 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// DefaultRunPollInterval is the interval at which CancelRunAndWait polls a
+// run when no interval is given.
+const DefaultRunPollInterval = 500 * time.Millisecond
+
+// IsTerminal reports whether a run with status s has finished and will not
+// change anymore, so that a new run can be created on its thread.
+func (s RunStatus) IsTerminal() bool {
+	switch s {
+	case RunStatusCompleted, RunStatusCancelled, RunStatusFailed, RunStatusIncomplete, RunStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+// CancelRunAndWait cancels a run and, since cancelling is asynchronous,
+// retrieves it every pollInterval until it reaches a terminal status, which is
+// usually RunStatusCancelled but can be another one when the run finished
+// first. A pollInterval of zero means DefaultRunPollInterval. Runs that have
+// already finished are returned as they are, and runs already being cancelled,
+// e.g. by an earlier call, are waited for. The wait is bounded by ctx; on
+// errors, the run as last retrieved is returned with the error.
+func (c *Client) CancelRunAndWait(
+	ctx context.Context,
+	threadID, runID string,
+	pollInterval time.Duration,
+	opts ...RequestOption,
+) (Run, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultRunPollInterval
+	}
+
+	run, err := c.CancelRun(ctx, threadID, runID, opts...)
+	if err != nil {
+		// Cancelling a run that is finished, or already being cancelled, is
+		// rejected: return the former and wait for the latter.
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusBadRequest {
+			return run, err
+		}
+		current, retrieveErr := c.RetrieveRun(ctx, threadID, runID, opts...)
+		if retrieveErr != nil || (!current.Status.IsTerminal() && current.Status != RunStatusCancelling) {
+			return run, err
+		}
+		run = current
+	}
+
+	for !run.Status.IsTerminal() {
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return run, ctx.Err()
+		case <-timer.C:
+		}
+
+		current, retrieveErr := c.RetrieveRun(ctx, threadID, runID, opts...)
+		if retrieveErr != nil {
+			return run, retrieveErr
+		}
+		run = current
+	}
+	return run, nil
+}
@@ -0,0 +1,113 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestCancelRunAndWait(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	retrieved := 0
+	server.RegisterHandler("/v1/threads/thread_abc123/runs/run_abc123/cancel",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Fatalf("unexpected method %s", r.Method)
+			}
+			fmt.Fprint(w, `{"id":"run_abc123","status":"cancelling"}`)
+		})
+	server.RegisterHandler("/v1/threads/thread_abc123/runs/run_abc123",
+		func(w http.ResponseWriter, _ *http.Request) {
+			retrieved++
+			status := openai.RunStatusCancelling
+			if retrieved == 3 {
+				status = openai.RunStatusCancelled
+			}
+			fmt.Fprintf(w, `{"id":"run_abc123","status":%q}`, status)
+		})
+
+	run, err := client.CancelRunAndWait(context.Background(), "thread_abc123", "run_abc123", time.Millisecond)
+	checks.NoError(t, err, "CancelRunAndWait error")
+	if run.Status != openai.RunStatusCancelled || retrieved != 3 {
+		t.Errorf("expected the run to be cancelled after 3 polls, got %s after %d", run.Status, retrieved)
+	}
+}
+
+func TestCancelRunAndWaitFinishedRun(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/threads/thread_abc123/runs/run_abc123/cancel",
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"message":"Cannot cancel run with status 'completed'.",`+
+				`"type":"invalid_request_error"}}`)
+		})
+	server.RegisterHandler("/v1/threads/thread_abc123/runs/run_abc123",
+		func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprint(w, `{"id":"run_abc123","status":"completed"}`)
+		})
+
+	run, err := client.CancelRunAndWait(context.Background(), "thread_abc123", "run_abc123", 0)
+	checks.NoError(t, err, "CancelRunAndWait error")
+	if run.Status != openai.RunStatusCompleted {
+		t.Errorf("expected the completed run, got %s", run.Status)
+	}
+}
+
+func TestCancelRunAndWaitAlreadyCancelling(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/threads/thread_abc123/runs/run_abc123/cancel",
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"message":"Cannot cancel run with status 'cancelling'.",`+
+				`"type":"invalid_request_error"}}`)
+		})
+	retrieved := 0
+	server.RegisterHandler("/v1/threads/thread_abc123/runs/run_abc123",
+		func(w http.ResponseWriter, _ *http.Request) {
+			retrieved++
+			status := openai.RunStatusCancelling
+			if retrieved == 2 {
+				status = openai.RunStatusCancelled
+			}
+			fmt.Fprintf(w, `{"id":"run_abc123","status":%q}`, status)
+		})
+
+	run, err := client.CancelRunAndWait(context.Background(), "thread_abc123", "run_abc123", time.Millisecond)
+	checks.NoError(t, err, "CancelRunAndWait error")
+	if run.Status != openai.RunStatusCancelled || retrieved != 2 {
+		t.Errorf("expected the run to be cancelled after 2 retrievals, got %s after %d", run.Status, retrieved)
+	}
+}
+
+func TestCancelRunAndWaitContext(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/threads/thread_abc123/runs/run_abc123/cancel",
+		func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprint(w, `{"id":"run_abc123","status":"cancelling"}`)
+		})
+	server.RegisterHandler("/v1/threads/thread_abc123/runs/run_abc123",
+		func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprint(w, `{"id":"run_abc123","status":"cancelling"}`)
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	run, err := client.CancelRunAndWait(ctx, "thread_abc123", "run_abc123", time.Millisecond)
+	checks.ErrorIs(t, err, context.DeadlineExceeded)
+	if run.Status != openai.RunStatusCancelling {
+		t.Errorf("expected the last retrieved run, got %s", run.Status)
+	}
+}
@@ -235,3 +235,35 @@ func TestRun(t *testing.T) {
 	)
 	checks.NoError(t, err, "ListRunSteps error")
 }
+
+func TestRetrieveRunStepFileSearchResults(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/threads/thread_abc123/runs/run_abc123/steps/step_abc123",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("include[]") != openai.RunStepIncludeFileSearchContent {
+				http.Error(w, "missing include", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"id":"step_abc123","type":"tool_calls","step_details":{"type":"tool_calls",
+				"tool_calls":[{"id":"call_1","type":"file_search","file_search":{
+					"ranking_options":{"ranker":"default_2024_08_21","score_threshold":0.0},
+					"results":[{"file_id":"file-1","file_name":"manual.pdf","score":0.87,
+						"content":[{"type":"text","text":"Press the red button."}]}]}}]}}`)
+		})
+
+	step, err := client.RetrieveRunStep(context.Background(), "thread_abc123", "run_abc123", "step_abc123",
+		openai.WithInclude(openai.RunStepIncludeFileSearchContent))
+	checks.NoError(t, err, "RetrieveRunStep error")
+
+	toolCalls := step.StepDetails.ToolCalls
+	if len(toolCalls) != 1 || toolCalls[0].Type != openai.ToolTypeFileSearch || toolCalls[0].FileSearch == nil {
+		t.Fatalf("expected a file_search tool call, got %+v", toolCalls)
+	}
+	results := toolCalls[0].FileSearch.Results
+	if len(results) != 1 || results[0].FileName != "manual.pdf" || results[0].Score != 0.87 ||
+		len(results[0].Content) != 1 || results[0].Content[0].Text != "Press the red button." {
+		t.Fatalf("unexpected file_search results: %+v", results)
+	}
+}
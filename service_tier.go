@@ -0,0 +1,38 @@
+package openai
+
+import (
+	"errors"
+	"time"
+)
+
+// ServiceTier selects the processing tier of a request.
+type ServiceTier string
+
+const (
+	ServiceTierAuto    ServiceTier = "auto"
+	ServiceTierDefault ServiceTier = "default"
+	// ServiceTierFlex trades latency for lower prices. Requests can take many
+	// minutes and fail with ErrResourceUnavailable when capacity is short.
+	ServiceTierFlex ServiceTier = "flex"
+	// ServiceTierPriority gets faster and more consistent latency at higher
+	// prices. Check the ServiceTier of the response: requests over the
+	// priority capacity are processed with the default tier.
+	ServiceTierPriority ServiceTier = "priority"
+)
+
+// DefaultFlexTimeout is the timeout of flex processing requests when
+// OperationTimeouts.Flex is not set.
+const DefaultFlexTimeout = 15 * time.Minute
+
+const resourceUnavailableCode = "resource_unavailable"
+
+// ErrResourceUnavailable is matched by errors.Is for *APIError responses that
+// report that there is not enough capacity to serve a flex processing request.
+// The error is transient: retry later, or with ServiceTierDefault.
+var ErrResourceUnavailable = errors.New("resource unavailable")
+
+func withServiceTier(tier ServiceTier) RequestOption {
+	return func(args *requestOptions) {
+		args.serviceTier = tier
+	}
+}
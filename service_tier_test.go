@@ -0,0 +1,109 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestFlexServiceTierTimeout(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.ChatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprintf(w, `{"id":"chatcmpl-1","service_tier":%q}`, request.ServiceTier)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.OperationTimeouts = openai.OperationTimeouts{Slow: 20 * time.Millisecond, Flex: 5 * time.Second}
+	client := openai.NewClientWithConfig(config)
+
+	request := openai.ChatCompletionRequest{
+		Model:    openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hi"}},
+	}
+	_, err := client.CreateChatCompletion(context.Background(), request)
+	if !os.IsTimeout(err) {
+		t.Fatalf("expected the default tier request to time out, got %v", err)
+	}
+
+	request.ServiceTier = openai.ServiceTierFlex
+	_, err = client.CreateChatCompletion(context.Background(), request)
+	checks.NoError(t, err, "flex request should use the flex timeout")
+}
+
+func TestResourceUnavailableError(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error":{"message":"Resource Unavailable","type":"invalid_request_error",`+
+			`"code":"resource_unavailable"}}`)
+	})
+
+	_, err := client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+		Model:       openai.GPT4o,
+		Messages:    []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hi"}},
+		ServiceTier: openai.ServiceTierFlex,
+	})
+	checks.ErrorIs(t, err, openai.ErrResourceUnavailable, "expected ErrResourceUnavailable")
+
+	rateLimited := &openai.APIError{HTTPStatusCode: http.StatusTooManyRequests, Code: "rate_limit_exceeded"}
+	checks.ErrorIsNot(t, rateLimited, openai.ErrResourceUnavailable, "rate limits are not resource unavailable")
+	described := &openai.APIError{Code: "server_error", Message: "The resource unavailable message"}
+	checks.ErrorIsNot(t, described, openai.ErrResourceUnavailable, "messages are not matched")
+}
+
+func TestPriorityServiceTier(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.ChatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.ServiceTier != openai.ServiceTierPriority {
+			http.Error(w, "expected the priority tier", http.StatusBadRequest)
+			return
+		}
+		if request.Stream {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: {\"id\":\"chatcmpl-1\",\"service_tier\":\"priority\",\"choices\":[]}\n\ndata: [DONE]\n\n")
+			return
+		}
+		fmt.Fprint(w, `{"id":"chatcmpl-1","service_tier":"default"}`)
+	})
+
+	request := openai.ChatCompletionRequest{
+		Model:       openai.GPT4o,
+		Messages:    []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hi"}},
+		ServiceTier: openai.ServiceTierPriority,
+	}
+	resp, err := client.CreateChatCompletion(context.Background(), request)
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if resp.ServiceTier != openai.ServiceTierDefault {
+		t.Errorf("expected the tier used to be reported, got %q", resp.ServiceTier)
+	}
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), request)
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	defer stream.Close()
+	chunk, err := stream.Recv()
+	checks.NoError(t, err, "Recv error")
+	if chunk.ServiceTier != openai.ServiceTierPriority {
+		t.Errorf("expected the stream to report the priority tier, got %q", chunk.ServiceTier)
+	}
+}
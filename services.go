@@ -0,0 +1,506 @@
+package openai
+
+import (
+	"context"
+	"time"
+)
+
+// The interfaces in this file group the methods of Client by API domain so that
+// code depending on a subset of the API can accept the narrowest interface and
+// be tested with a mock generated by tools such as gomock or moq.
+//
+// API is the union of all of them and is implemented by *Client.
+
+// ChatService is the chat completions API.
+type ChatService interface {
+	CreateChatCompletion(
+		ctx context.Context,
+		request ChatCompletionRequest,
+		opts ...RequestOption,
+	) (ChatCompletionResponse, error)
+	CreateChatCompletionStream(
+		ctx context.Context,
+		request ChatCompletionRequest,
+		opts ...RequestOption,
+	) (*ChatCompletionStream, error)
+	StreamChat(
+		ctx context.Context,
+		request ChatCompletionRequest,
+		handlers ChatStreamHandlers,
+		opts ...RequestOption,
+	) (ChatCompletionResponse, error)
+}
+
+// ResponseService is the responses API.
+type ResponseService interface {
+	CreateResponse(ctx context.Context, request ResponseRequest, opts ...RequestOption) (ModelResponse, error)
+	CreateResponseStream(ctx context.Context, request ResponseRequest, opts ...RequestOption) (*ResponseStream, error)
+	RetrieveResponse(ctx context.Context, responseID string, opts ...RequestOption) (ModelResponse, error)
+	DeleteResponse(ctx context.Context, responseID string, opts ...RequestOption) (ResponseDeleteResponse, error)
+	UploadResponseInputFile(ctx context.Context, path string, opts ...RequestOption) (ResponseContent, error)
+}
+
+// RealtimeService is the realtime API.
+type RealtimeService interface {
+	ConnectRealtime(ctx context.Context, model string, opts ...RequestOption) (*RealtimeConn, error)
+	CreateRealtimeSession(
+		ctx context.Context,
+		request RealtimeSession,
+		opts ...RequestOption,
+	) (RealtimeSessionResponse, error)
+	ConnectRealtimeTranscription(ctx context.Context, opts ...RequestOption) (*RealtimeConn, error)
+	CreateRealtimeTranscriptionSession(
+		ctx context.Context,
+		request RealtimeTranscriptionSession,
+		opts ...RequestOption,
+	) (RealtimeTranscriptionSessionResponse, error)
+}
+
+// CompletionService is the legacy completions and edits API.
+type CompletionService interface {
+	CreateCompletion(ctx context.Context, request CompletionRequest, opts ...RequestOption) (CompletionResponse, error)
+	CreateCompletionStream(
+		ctx context.Context,
+		request CompletionRequest,
+		opts ...RequestOption,
+	) (*CompletionStream, error)
+	Edits(ctx context.Context, request EditsRequest, opts ...RequestOption) (EditsResponse, error)
+}
+
+// EmbeddingService is the embeddings API.
+type EmbeddingService interface {
+	CreateEmbeddings(
+		ctx context.Context,
+		conv EmbeddingRequestConverter,
+		opts ...RequestOption,
+	) (EmbeddingResponse, error)
+}
+
+// ModerationService is the moderations API.
+type ModerationService interface {
+	Moderations(ctx context.Context, request ModerationRequest, opts ...RequestOption) (ModerationResponse, error)
+	ModerateLongText(
+		ctx context.Context,
+		request ModerationRequest,
+		options ModerationSplitOptions,
+		opts ...RequestOption,
+	) (ModerationResponse, error)
+}
+
+// AudioService is the speech, transcription and translation API.
+type AudioService interface {
+	CreateTranscription(ctx context.Context, request AudioRequest, opts ...RequestOption) (AudioResponse, error)
+	CreateTranslation(ctx context.Context, request AudioRequest, opts ...RequestOption) (AudioResponse, error)
+	CreateSpeech(ctx context.Context, request CreateSpeechRequest, opts ...RequestOption) (RawResponse, error)
+}
+
+// ImageService is the image generation API.
+type ImageService interface {
+	CreateImage(ctx context.Context, request ImageRequest, opts ...RequestOption) (ImageResponse, error)
+	CreateEditImage(ctx context.Context, request ImageEditRequest, opts ...RequestOption) (ImageResponse, error)
+	CreateVariImage(ctx context.Context, request ImageVariRequest, opts ...RequestOption) (ImageResponse, error)
+}
+
+// VideoService is the video generation API.
+type VideoService interface {
+	CreateVideo(ctx context.Context, request VideoRequest, opts ...RequestOption) (Video, error)
+	RetrieveVideo(ctx context.Context, videoID string, opts ...RequestOption) (Video, error)
+	DeleteVideo(ctx context.Context, videoID string, opts ...RequestOption) (VideoDeleteResponse, error)
+	ListVideos(ctx context.Context, pagination Pagination, opts ...RequestOption) (VideosList, error)
+	RemixVideo(ctx context.Context, videoID string, request VideoRemixRequest, opts ...RequestOption) (Video, error)
+	GetVideoContent(
+		ctx context.Context,
+		videoID string,
+		variant VideoContentVariant,
+		opts ...RequestOption,
+	) (RawResponse, error)
+}
+
+// FilesService is the files API.
+type FilesService interface {
+	CreateFile(ctx context.Context, request FileRequest, opts ...RequestOption) (File, error)
+	CreateFileBytes(ctx context.Context, request FileBytesRequest, opts ...RequestOption) (File, error)
+	DeleteFile(ctx context.Context, fileID string, opts ...RequestOption) error
+	ListFiles(ctx context.Context, opts ...RequestOption) (FilesList, error)
+	GetFile(ctx context.Context, fileID string, opts ...RequestOption) (File, error)
+	GetFileContent(ctx context.Context, fileID string, opts ...RequestOption) (RawResponse, error)
+}
+
+// BatchService is the batch API.
+type BatchService interface {
+	CreateBatch(ctx context.Context, request BatchRequest, opts ...RequestOption) (Batch, error)
+	RetrieveBatch(ctx context.Context, batchID string, opts ...RequestOption) (Batch, error)
+	CancelBatch(ctx context.Context, batchID string, opts ...RequestOption) (Batch, error)
+	ListBatches(ctx context.Context, pagination Pagination, opts ...RequestOption) (BatchesList, error)
+}
+
+// ModelService is the models and engines API.
+type ModelService interface {
+	ListModels(ctx context.Context, opts ...RequestOption) (ModelsList, error)
+	GetModel(ctx context.Context, modelID string, opts ...RequestOption) (Model, error)
+	ListFineTunedModels(ctx context.Context, opts ...RequestOption) (ModelsList, error)
+	DeleteFineTuneModel(ctx context.Context, modelID string, opts ...RequestOption) (FineTuneModelDeleteResponse, error)
+	DeleteFineTunedModel(ctx context.Context, modelID string, opts ...RequestOption) (FineTuneModelDeleteResponse, error)
+	ListEngines(ctx context.Context, opts ...RequestOption) (EnginesList, error)
+	GetEngine(ctx context.Context, engineID string, opts ...RequestOption) (Engine, error)
+}
+
+// FineTuningService is the fine-tuning jobs API, including the deprecated fine-tunes endpoints.
+type FineTuningService interface {
+	CreateFineTuningJob(ctx context.Context, request FineTuningJobRequest, opts ...RequestOption) (FineTuningJob, error)
+	CancelFineTuningJob(ctx context.Context, fineTuningJobID string, opts ...RequestOption) (FineTuningJob, error)
+	RetrieveFineTuningJob(ctx context.Context, fineTuningJobID string, opts ...RequestOption) (FineTuningJob, error)
+	ListFineTuningJobEvents(
+		ctx context.Context,
+		fineTuningJobID string,
+		setters ...ListFineTuningJobEventsParameter,
+	) (FineTuningJobEventList, error)
+
+	CreateFineTune(ctx context.Context, request FineTuneRequest, opts ...RequestOption) (FineTune, error)
+	CancelFineTune(ctx context.Context, fineTuneID string, opts ...RequestOption) (FineTune, error)
+	ListFineTunes(ctx context.Context, opts ...RequestOption) (FineTuneList, error)
+	GetFineTune(ctx context.Context, fineTuneID string, opts ...RequestOption) (FineTune, error)
+	DeleteFineTune(ctx context.Context, fineTuneID string, opts ...RequestOption) (FineTuneDeleteResponse, error)
+	ListFineTuneEvents(ctx context.Context, fineTuneID string, opts ...RequestOption) (FineTuneEventList, error)
+}
+
+// AssistantService is the assistants API.
+type AssistantService interface {
+	CreateAssistant(ctx context.Context, request AssistantRequest, opts ...RequestOption) (Assistant, error)
+	RetrieveAssistant(ctx context.Context, assistantID string, opts ...RequestOption) (Assistant, error)
+	ModifyAssistant(
+		ctx context.Context,
+		assistantID string,
+		request AssistantRequest,
+		opts ...RequestOption,
+	) (Assistant, error)
+	DeleteAssistant(ctx context.Context, assistantID string, opts ...RequestOption) (AssistantDeleteResponse, error)
+	ListAssistants(
+		ctx context.Context,
+		limit *int,
+		order *string,
+		after *string,
+		before *string,
+		opts ...RequestOption,
+	) (AssistantsList, error)
+	ListAssistantsWithOptions(ctx context.Context, opts ...RequestOption) (AssistantsList, error)
+	CreateAssistantFile(
+		ctx context.Context,
+		assistantID string,
+		request AssistantFileRequest,
+		opts ...RequestOption,
+	) (AssistantFile, error)
+	RetrieveAssistantFile(
+		ctx context.Context,
+		assistantID string,
+		fileID string,
+		opts ...RequestOption,
+	) (AssistantFile, error)
+	DeleteAssistantFile(ctx context.Context, assistantID string, fileID string, opts ...RequestOption) error
+	ListAssistantFiles(
+		ctx context.Context,
+		assistantID string,
+		limit *int,
+		order *string,
+		after *string,
+		before *string,
+		opts ...RequestOption,
+	) (AssistantFilesList, error)
+	ListAssistantFilesWithOptions(
+		ctx context.Context,
+		assistantID string,
+		opts ...RequestOption,
+	) (AssistantFilesList, error)
+}
+
+// ThreadService is the threads and messages API.
+type ThreadService interface {
+	CreateThread(ctx context.Context, request ThreadRequest, opts ...RequestOption) (Thread, error)
+	RetrieveThread(ctx context.Context, threadID string, opts ...RequestOption) (Thread, error)
+	ModifyThread(
+		ctx context.Context,
+		threadID string,
+		request ModifyThreadRequest,
+		opts ...RequestOption,
+	) (Thread, error)
+	DeleteThread(ctx context.Context, threadID string, opts ...RequestOption) (ThreadDeleteResponse, error)
+
+	CreateMessage(ctx context.Context, threadID string, request MessageRequest, opts ...RequestOption) (Message, error)
+	ListMessage(
+		ctx context.Context,
+		threadID string,
+		limit *int,
+		order *string,
+		after *string,
+		before *string,
+		opts ...RequestOption,
+	) (MessagesList, error)
+	ListMessagesWithOptions(ctx context.Context, threadID string, opts ...RequestOption) (MessagesList, error)
+	ListMessagesIterator(ctx context.Context, threadID string, opts ...RequestOption) *Iterator[Message]
+	RetrieveMessage(ctx context.Context, threadID, messageID string, opts ...RequestOption) (Message, error)
+	ModifyMessage(
+		ctx context.Context,
+		threadID, messageID string,
+		metadata map[string]string,
+		opts ...RequestOption,
+	) (Message, error)
+	DeleteMessage(ctx context.Context, threadID, messageID string, opts ...RequestOption) (MessageDeletionStatus, error)
+	RetrieveMessageFile(
+		ctx context.Context,
+		threadID, messageID, fileID string,
+		opts ...RequestOption,
+	) (MessageFile, error)
+	ListMessageFiles(ctx context.Context, threadID, messageID string, opts ...RequestOption) (MessageFilesList, error)
+}
+
+// RunService is the runs and run steps API.
+type RunService interface {
+	CreateRun(ctx context.Context, threadID string, request RunRequest, opts ...RequestOption) (Run, error)
+	RetrieveRun(ctx context.Context, threadID string, runID string, opts ...RequestOption) (Run, error)
+	ModifyRun(
+		ctx context.Context,
+		threadID string,
+		runID string,
+		request RunModifyRequest,
+		opts ...RequestOption,
+	) (Run, error)
+	ListRuns(ctx context.Context, threadID string, pagination Pagination, opts ...RequestOption) (RunList, error)
+	ListRunsIterator(ctx context.Context, threadID string, opts ...RequestOption) *Iterator[Run]
+	SubmitToolOutputs(
+		ctx context.Context,
+		threadID string,
+		runID string,
+		request SubmitToolOutputsRequest,
+		opts ...RequestOption,
+	) (Run, error)
+	CancelRun(ctx context.Context, threadID string, runID string, opts ...RequestOption) (Run, error)
+	CancelRunAndWait(
+		ctx context.Context,
+		threadID, runID string,
+		pollInterval time.Duration,
+		opts ...RequestOption,
+	) (Run, error)
+	CreateThreadAndRun(ctx context.Context, request CreateThreadAndRunRequest, opts ...RequestOption) (Run, error)
+	RetrieveRunStep(
+		ctx context.Context,
+		threadID string,
+		runID string,
+		stepID string,
+		opts ...RequestOption,
+	) (RunStep, error)
+	ListRunSteps(
+		ctx context.Context,
+		threadID string,
+		runID string,
+		pagination Pagination,
+		opts ...RequestOption,
+	) (RunStepList, error)
+	ListRunStepsIterator(ctx context.Context, threadID, runID string, opts ...RequestOption) *Iterator[RunStep]
+
+	CreateRunStream(
+		ctx context.Context,
+		threadID string,
+		request RunRequest,
+		opts ...RequestOption,
+	) (*StreamerV2, error)
+	CreateThreadAndRunStream(
+		ctx context.Context,
+		request CreateThreadAndRunRequest,
+		opts ...RequestOption,
+	) (*StreamerV2, error)
+	SubmitToolOutputsStream(
+		ctx context.Context,
+		threadID string,
+		runID string,
+		request SubmitToolOutputsRequest,
+		opts ...RequestOption,
+	) (*StreamerV2, error)
+}
+
+// VectorStoreService is the vector stores API.
+type VectorStoreService interface {
+	CreateVector(ctx context.Context, request VectorRequest, opts ...RequestOption) (Vector, error)
+	RetrieveVector(ctx context.Context, vectorID string, opts ...RequestOption) (Vector, error)
+	ModifyVector(ctx context.Context, vectorID string, request VectorRequest, opts ...RequestOption) (Vector, error)
+	DeleteVector(ctx context.Context, vectorID string, opts ...RequestOption) (VectorDeleteResponse, error)
+	ListVectors(
+		ctx context.Context,
+		limit *int,
+		order *SortOrder,
+		after *string,
+		before *string,
+		opts ...RequestOption,
+	) (VectorList, error)
+	ListVectorsWithOptions(ctx context.Context, opts ...RequestOption) (VectorList, error)
+	CreateVectorFile(
+		ctx context.Context,
+		vectorID string,
+		request VectorFileRequest,
+		opts ...RequestOption,
+	) (VectorFile, error)
+	RetrieveVectorFile(
+		ctx context.Context,
+		vectorID string,
+		fileID string,
+		opts ...RequestOption,
+	) (AssistantFile, error)
+	DeleteVectorFile(ctx context.Context, vectorID string, fileID string, opts ...RequestOption) error
+	ListVectrFiles(
+		ctx context.Context,
+		vectorID string,
+		limit *int,
+		order *SortOrder,
+		after *string,
+		before *string,
+		opts ...RequestOption,
+	) (VectorFilesList, error)
+	ListVectorFilesWithOptions(ctx context.Context, vectorID string, opts ...RequestOption) (VectorFilesList, error)
+	CreateVectorFileBatch(
+		ctx context.Context,
+		vectorID string,
+		request VectorFileBatchRequest,
+		opts ...RequestOption,
+	) (VectorFileBatch, error)
+	RetrieveVectorFileBatch(ctx context.Context, vectorID, batchID string, opts ...RequestOption) (VectorFileBatch, error)
+	UploadDirectoryToVectorStore(
+		ctx context.Context,
+		vectorID string,
+		dir string,
+		options DirectoryUploadOptions,
+		opts ...RequestOption,
+	) (DirectoryUploadResult, error)
+	PurgeVectorStoreFiles(
+		ctx context.Context,
+		vectorID string,
+		options VectorPurgeOptions,
+		opts ...RequestOption,
+	) ([]VectorFilePurgeResult, error)
+}
+
+// EvalService is the evals API.
+type EvalService interface {
+	CreateEval(ctx context.Context, request EvalRequest, opts ...RequestOption) (Eval, error)
+	RetrieveEval(ctx context.Context, evalID string, opts ...RequestOption) (Eval, error)
+	ModifyEval(ctx context.Context, evalID string, request EvalModifyRequest, opts ...RequestOption) (Eval, error)
+	DeleteEval(ctx context.Context, evalID string, opts ...RequestOption) (EvalDeleteResponse, error)
+	ListEvals(ctx context.Context, pagination Pagination, opts ...RequestOption) (EvalsList, error)
+	CreateEvalRun(ctx context.Context, evalID string, request EvalRunRequest, opts ...RequestOption) (EvalRun, error)
+	RetrieveEvalRun(ctx context.Context, evalID, runID string, opts ...RequestOption) (EvalRun, error)
+	CancelEvalRun(ctx context.Context, evalID, runID string, opts ...RequestOption) (EvalRun, error)
+	DeleteEvalRun(ctx context.Context, evalID, runID string, opts ...RequestOption) (EvalRunDeleteResponse, error)
+	ListEvalRuns(ctx context.Context, evalID string, pagination Pagination, opts ...RequestOption) (EvalRunsList, error)
+	RetrieveEvalRunOutputItem(
+		ctx context.Context,
+		evalID, runID, outputItemID string,
+		opts ...RequestOption,
+	) (EvalOutputItem, error)
+	ListEvalRunOutputItems(
+		ctx context.Context,
+		evalID, runID string,
+		status EvalOutputItemStatus,
+		pagination Pagination,
+		opts ...RequestOption,
+	) (EvalOutputItemsList, error)
+}
+
+// ContainerService is the code interpreter containers API.
+type ContainerService interface {
+	CreateContainer(ctx context.Context, request ContainerRequest, opts ...RequestOption) (Container, error)
+	RetrieveContainer(ctx context.Context, containerID string, opts ...RequestOption) (Container, error)
+	DeleteContainer(ctx context.Context, containerID string, opts ...RequestOption) (ContainerDeleteResponse, error)
+	ListContainers(ctx context.Context, pagination Pagination, opts ...RequestOption) (ContainersList, error)
+	CreateContainerFile(
+		ctx context.Context,
+		containerID string,
+		request ContainerFileRequest,
+		opts ...RequestOption,
+	) (ContainerFile, error)
+	RetrieveContainerFile(
+		ctx context.Context,
+		containerID string,
+		fileID string,
+		opts ...RequestOption,
+	) (ContainerFile, error)
+	DeleteContainerFile(
+		ctx context.Context,
+		containerID string,
+		fileID string,
+		opts ...RequestOption,
+	) (ContainerFileDeleteResponse, error)
+	ListContainerFiles(
+		ctx context.Context,
+		containerID string,
+		pagination Pagination,
+		opts ...RequestOption,
+	) (ContainerFilesList, error)
+	GetContainerFileContent(
+		ctx context.Context,
+		containerID string,
+		fileID string,
+		opts ...RequestOption,
+	) (RawResponse, error)
+}
+
+// AdminService is the organization administration API.
+type AdminService interface {
+	UploadCertificate(ctx context.Context, request CertificateRequest, opts ...RequestOption) (Certificate, error)
+	RetrieveCertificate(
+		ctx context.Context,
+		certificateID string,
+		includeContent bool,
+		opts ...RequestOption,
+	) (Certificate, error)
+	ModifyCertificate(
+		ctx context.Context,
+		certificateID string,
+		request CertificateModifyRequest,
+		opts ...RequestOption,
+	) (Certificate, error)
+	DeleteCertificate(
+		ctx context.Context,
+		certificateID string,
+		opts ...RequestOption,
+	) (CertificateDeleteResponse, error)
+	ListCertificates(ctx context.Context, pagination Pagination, opts ...RequestOption) (CertificatesList, error)
+	ActivateCertificates(
+		ctx context.Context,
+		request CertificateActivationRequest,
+		opts ...RequestOption,
+	) (CertificatesList, error)
+	DeactivateCertificates(
+		ctx context.Context,
+		request CertificateActivationRequest,
+		opts ...RequestOption,
+	) (CertificatesList, error)
+
+	CreateAdminAPIKey(ctx context.Context, request AdminAPIKeyRequest, opts ...RequestOption) (AdminAPIKey, error)
+	RetrieveAdminAPIKey(ctx context.Context, keyID string, opts ...RequestOption) (AdminAPIKey, error)
+	DeleteAdminAPIKey(ctx context.Context, keyID string, opts ...RequestOption) (AdminAPIKeyDeleteResponse, error)
+	ListAdminAPIKeys(ctx context.Context, pagination Pagination, opts ...RequestOption) (AdminAPIKeysList, error)
+}
+
+// API is the complete OpenAI API as implemented by Client.
+type API interface {
+	ChatService
+	ResponseService
+	RealtimeService
+	CompletionService
+	EmbeddingService
+	ModerationService
+	AudioService
+	ImageService
+	VideoService
+	FilesService
+	BatchService
+	ModelService
+	FineTuningService
+	AssistantService
+	ThreadService
+	RunService
+	VectorStoreService
+	EvalService
+	ContainerService
+	AdminService
+}
+
+var _ API = (*Client)(nil)
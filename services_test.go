@@ -0,0 +1,25 @@
+package openai_test
+
+import (
+	"reflect"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// TestAPICoversClient makes sure new Client methods are added to one of the
+// service interfaces so that mocks of API stay complete.
+func TestAPICoversClient(t *testing.T) {
+	api := reflect.TypeOf((*openai.API)(nil)).Elem()
+	client := reflect.TypeOf(&openai.Client{})
+
+	// With derives clients rather than calling the API.
+	notAPI := map[string]bool{"With": true}
+
+	for i := 0; i < client.NumMethod(); i++ {
+		method := client.Method(i)
+		if _, ok := api.MethodByName(method.Name); !ok && !notAPI[method.Name] {
+			t.Errorf("Client.%s is not part of the API interface", method.Name)
+		}
+	}
+}
@@ -18,11 +18,16 @@ type SpeechVoice string
 
 const (
 	VoiceAlloy   SpeechVoice = "alloy"
+	VoiceAsh     SpeechVoice = "ash"
+	VoiceBallad  SpeechVoice = "ballad"
+	VoiceCoral   SpeechVoice = "coral"
 	VoiceEcho    SpeechVoice = "echo"
 	VoiceFable   SpeechVoice = "fable"
 	VoiceOnyx    SpeechVoice = "onyx"
 	VoiceNova    SpeechVoice = "nova"
+	VoiceSage    SpeechVoice = "sage"
 	VoiceShimmer SpeechVoice = "shimmer"
+	VoiceVerse   SpeechVoice = "verse"
 )
 
 type SpeechResponseFormat string
@@ -36,9 +41,30 @@ const (
 	SpeechResponseFormatPcm  SpeechResponseFormat = "pcm"
 )
 
+// SpeechPCMSampleRate is the sample rate, in Hz, of SpeechResponseFormatPcm
+// audio, which is raw 16-bit signed little-endian mono samples.
+const SpeechPCMSampleRate = 24000
+
+// SampleRate returns the sample rate, in Hz, of audio in format f, or 0 when
+// it is read from the audio container.
+func (f SpeechResponseFormat) SampleRate() int {
+	if f == SpeechResponseFormatPcm {
+		return SpeechPCMSampleRate
+	}
+	return 0
+}
+
+// Bounds of CreateSpeechRequest.Speed.
+const (
+	MinSpeechSpeed = 0.25
+	MaxSpeechSpeed = 4.0
+)
+
 var (
-	ErrInvalidSpeechModel = errors.New("invalid speech model")
-	ErrInvalidVoice       = errors.New("invalid voice")
+	ErrInvalidSpeechModel  = errors.New("invalid speech model")
+	ErrInvalidVoice        = errors.New("invalid voice")
+	ErrInvalidSpeechFormat = errors.New("invalid speech response format")
+	ErrInvalidSpeechSpeed  = errors.New("speech speed must be between 0.25 and 4.0")
 )
 
 type CreateSpeechRequest struct {
@@ -63,10 +89,28 @@ func isValidSpeechModel(model SpeechModel) bool {
 }
 
 func isValidVoice(voice SpeechVoice) bool {
-	return contains([]SpeechVoice{VoiceAlloy, VoiceEcho, VoiceFable, VoiceOnyx, VoiceNova, VoiceShimmer}, voice)
+	return contains([]SpeechVoice{
+		VoiceAlloy, VoiceAsh, VoiceBallad, VoiceCoral, VoiceEcho, VoiceFable, VoiceOnyx, VoiceNova, VoiceSage,
+		VoiceShimmer, VoiceVerse,
+	}, voice)
 }
 
-func (c *Client) CreateSpeech(ctx context.Context, request CreateSpeechRequest) (response RawResponse, err error) {
+func isValidSpeechFormat(format SpeechResponseFormat) bool {
+	return format == "" || contains([]SpeechResponseFormat{
+		SpeechResponseFormatMp3, SpeechResponseFormatOpus, SpeechResponseFormatAac, SpeechResponseFormatFlac,
+		SpeechResponseFormatWav, SpeechResponseFormatPcm,
+	}, format)
+}
+
+func isValidSpeechSpeed(speed float64) bool {
+	return speed == 0 || (speed >= MinSpeechSpeed && speed <= MaxSpeechSpeed)
+}
+
+func (c *Client) CreateSpeech(
+	ctx context.Context,
+	request CreateSpeechRequest,
+	opts ...RequestOption,
+) (response RawResponse, err error) {
 	if !isValidSpeechModel(request.Model) {
 		err = ErrInvalidSpeechModel
 		return
@@ -75,9 +119,18 @@ func (c *Client) CreateSpeech(ctx context.Context, request CreateSpeechRequest)
 		err = ErrInvalidVoice
 		return
 	}
+	if !isValidSpeechFormat(request.ResponseFormat) {
+		err = ErrInvalidSpeechFormat
+		return
+	}
+	if !isValidSpeechSpeed(request.Speed) {
+		err = ErrInvalidSpeechSpeed
+		return
+	}
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL("/audio/speech", string(request.Model)),
-		withBody(request),
+		withModel(string(request.Model)), withBody(request),
 		withContentType("application/json"),
+		withRequestOptions(opts),
 	)
 	if err != nil {
 		return
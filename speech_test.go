@@ -92,7 +92,7 @@ func TestSpeechIntegration(t *testing.T) {
 		checks.NoError(t, err, "ReadAll error")
 
 		// save buf to file as mp3
-		err = os.WriteFile("test.mp3", buf, 0644)
+		err = os.WriteFile(filepath.Join(t.TempDir(), "test.mp3"), buf, 0644)
 		checks.NoError(t, err, "Create error")
 	})
 	t.Run("invalid model", func(t *testing.T) {
@@ -112,4 +112,34 @@ func TestSpeechIntegration(t *testing.T) {
 		})
 		checks.ErrorIs(t, err, openai.ErrInvalidVoice, "CreateSpeech error")
 	})
+	t.Run("invalid response format", func(t *testing.T) {
+		_, err := client.CreateSpeech(context.Background(), openai.CreateSpeechRequest{
+			Model:          openai.TTSModel1,
+			Input:          "Hello!",
+			Voice:          openai.VoiceCoral,
+			ResponseFormat: "ogg",
+		})
+		checks.ErrorIs(t, err, openai.ErrInvalidSpeechFormat, "CreateSpeech error")
+	})
+
+	t.Run("invalid speed", func(t *testing.T) {
+		for _, speed := range []float64{0.1, 4.5, -1} {
+			_, err := client.CreateSpeech(context.Background(), openai.CreateSpeechRequest{
+				Model: openai.TTSModel1,
+				Input: "Hello!",
+				Voice: openai.VoiceAlloy,
+				Speed: speed,
+			})
+			checks.ErrorIs(t, err, openai.ErrInvalidSpeechSpeed, "CreateSpeech error")
+		}
+	})
+}
+
+func TestSpeechResponseFormatSampleRate(t *testing.T) {
+	if rate := openai.SpeechResponseFormatPcm.SampleRate(); rate != 24000 {
+		t.Errorf("expected PCM audio at 24kHz, got %d", rate)
+	}
+	if rate := openai.SpeechResponseFormatMp3.SampleRate(); rate != 0 {
+		t.Errorf("expected no sample rate for mp3, got %d", rate)
+	}
 }
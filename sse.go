@@ -2,11 +2,22 @@ package openai
 
 import (
 	"bufio"
+	"errors"
 	"io"
+	"math"
 	"strconv"
 	"strings"
 )
 
+// DefaultSSEMaxLineSize is the longest line an SSEScanner accepts by default.
+// Streamed tool call arguments and base64 audio deltas are sent as a single
+// line and regularly exceed the 64KB limit of bufio.Scanner.
+const DefaultSSEMaxLineSize = 16 << 20
+
+// ErrSSELineTooLong is returned when a line of an event stream exceeds the
+// scanner's maximum line size. See ClientConfig.StreamMaxLineSize.
+var ErrSSELineTooLong = errors.New("sse: line exceeds the maximum line size")
+
 // NewEOLSplitterFunc returns a bufio.SplitFunc tied to a new EOLSplitter instance.
 func NewEOLSplitterFunc() bufio.SplitFunc {
 	splitter := NewEOLSplitter()
@@ -84,7 +95,23 @@ type SSEScanner struct {
 }
 
 func NewSSEScanner(r io.Reader, readComment bool) *SSEScanner {
+	return NewSSEScannerSize(r, readComment, 0)
+}
+
+// NewSSEScannerSize returns an SSEScanner that accepts lines of up to
+// maxLineSize bytes. Zero uses DefaultSSEMaxLineSize and a negative value
+// removes the limit.
+func NewSSEScannerSize(r io.Reader, readComment bool, maxLineSize int) *SSEScanner {
+	switch {
+	case maxLineSize == 0:
+		maxLineSize = DefaultSSEMaxLineSize
+	case maxLineSize < 0:
+		maxLineSize = math.MaxInt
+	}
+
 	scanner := bufio.NewScanner(r)
+	// The buffer starts small and only grows up to maxLineSize when needed.
+	scanner.Buffer(nil, maxLineSize)
 
 	// N.B. The bufio.ScanLines handles `\r?\n``, but not `\r` itself as EOL, as
 	// the SSE spec requires
@@ -145,6 +172,11 @@ func (s *SSEScanner) Next() bool {
 	}
 
 	s.err = s.scanner.Err()
+	if errors.Is(s.err, bufio.ErrTooLong) {
+		// The event is incomplete, so don't return what was read of it.
+		s.err = ErrSSELineTooLong
+		return false
+	}
 
 	if !seenNonEmptyLine {
 		return false
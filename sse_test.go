@@ -0,0 +1,43 @@
+package openai_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+func TestSSEScannerLongLines(t *testing.T) {
+	// Larger than the 64KB default of bufio.Scanner.
+	data := strings.Repeat("a", 200*1024)
+	input := "event: thread.message.delta\ndata: " + data + "\n\nevent: done\ndata: [DONE]\n\n"
+
+	scanner := openai.NewSSEScanner(strings.NewReader(input), false)
+	if !scanner.Next() {
+		t.Fatalf("expected an event, got error %v", scanner.Err())
+	}
+	if got := scanner.Scan().Data; got != data {
+		t.Errorf("expected %d bytes of data, got %d", len(data), len(got))
+	}
+	if !scanner.Next() || scanner.Scan().Event != "done" {
+		t.Errorf("expected the done event after the long line")
+	}
+
+	unlimited := openai.NewSSEScannerSize(strings.NewReader(input), false, -1)
+	if !unlimited.Next() || unlimited.Scan().Data != data {
+		t.Errorf("expected the unlimited scanner to read the long line, got error %v", unlimited.Err())
+	}
+}
+
+func TestStreamerV2MaxLineSize(t *testing.T) {
+	input := "event: thread.message.delta\ndata: " + strings.Repeat("a", 4096) + "\n\n"
+
+	stream := openai.NewStreamerV2Size(strings.NewReader(input), 1024)
+	if stream.Next() {
+		t.Fatal("expected the stream to stop at the long line")
+	}
+	if !errors.Is(stream.Err(), openai.ErrSSELineTooLong) {
+		t.Errorf("expected ErrSSELineTooLong, got %v", stream.Err())
+	}
+}
@@ -0,0 +1,53 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MaxStopSequences is the maximum number of stop sequences of a request.
+const MaxStopSequences = 4
+
+// ErrTooManyStopSequences is returned for requests with more than
+// MaxStopSequences stop sequences.
+var ErrTooManyStopSequences = errors.New("too many stop sequences")
+
+// StopSequences are the sequences where the API stops generating tokens. The
+// API accepts a single string or an array: a single sequence is sent as a
+// string, and both forms are decoded, e.g. from stored requests.
+type StopSequences []string
+
+func (s StopSequences) MarshalJSON() ([]byte, error) {
+	if len(s) == 1 {
+		return json.Marshal(s[0])
+	}
+	return json.Marshal([]string(s))
+}
+
+func (s *StopSequences) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*s = nil
+		return nil
+	}
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = StopSequences{single}
+		return nil
+	}
+	var sequences []string
+	if err := json.Unmarshal(data, &sequences); err != nil {
+		return fmt.Errorf("stop must be a string or an array of strings: %w", err)
+	}
+	*s = sequences
+	return nil
+}
+
+// Validate checks that there are at most MaxStopSequences sequences.
+func (s StopSequences) Validate() error {
+	if len(s) > MaxStopSequences {
+		return fmt.Errorf("%w: %d, the limit is %d", ErrTooManyStopSequences, len(s), MaxStopSequences)
+	}
+	return nil
+}
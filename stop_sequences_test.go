@@ -0,0 +1,69 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestStopSequencesJSON(t *testing.T) {
+	testCases := []struct {
+		stop openai.StopSequences
+		json string
+	}{
+		{openai.StopSequences{"\n"}, `{"model":"gpt-4o","messages":null,"stop":"\n"}`},
+		{openai.StopSequences{"END", "STOP"}, `{"model":"gpt-4o","messages":null,"stop":["END","STOP"]}`},
+		{nil, `{"model":"gpt-4o","messages":null}`},
+	}
+	for _, tc := range testCases {
+		data, err := json.Marshal(openai.ChatCompletionRequest{Model: openai.GPT4o, Stop: tc.stop})
+		checks.NoError(t, err, "Marshal error")
+		if string(data) != tc.json {
+			t.Errorf("expected %s, got %s", tc.json, data)
+		}
+
+		var request openai.ChatCompletionRequest
+		checks.NoError(t, json.Unmarshal(data, &request), "Unmarshal error")
+		if fmt.Sprintf("%q", request.Stop) != fmt.Sprintf("%q", tc.stop) {
+			t.Errorf("expected %q, got %q", tc.stop, request.Stop)
+		}
+	}
+
+	var request openai.CompletionRequest
+	checks.NoError(t, json.Unmarshal([]byte(`{"stop":null}`), &request), "Unmarshal error")
+	if request.Stop != nil {
+		t.Errorf("expected null to decode to nil, got %q", request.Stop)
+	}
+	data, err := json.Marshal(request)
+	checks.NoError(t, err, "Marshal error")
+	if strings.Contains(string(data), `"stop"`) {
+		t.Errorf("expected no stop after a null round trip, got %s", data)
+	}
+
+	err = json.Unmarshal([]byte(`{"stop":42}`), &request)
+	checks.HasError(t, err, "stop must be a string or an array")
+}
+
+func TestStopSequencesLimit(t *testing.T) {
+	client := openai.NewClient("test")
+	stop := openai.StopSequences{"a", "b", "c", "d", "e"}
+
+	_, err := client.CreateChatCompletion(context.Background(), openai.ChatCompletionRequest{
+		Model: openai.GPT4o, Stop: stop,
+	})
+	checks.ErrorIs(t, err, openai.ErrTooManyStopSequences)
+	_, err = client.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model: openai.GPT4o, Stop: stop,
+	})
+	checks.ErrorIs(t, err, openai.ErrTooManyStopSequences)
+	_, err = client.CreateCompletion(context.Background(), openai.CompletionRequest{
+		Model: openai.GPT3Dot5TurboInstruct, Prompt: "hi", Stop: stop,
+	})
+	checks.ErrorIs(t, err, openai.ErrTooManyStopSequences)
+	checks.NoError(t, stop[:openai.MaxStopSequences].Validate(), "Validate error")
+}
@@ -20,6 +20,7 @@ type CompletionStream struct {
 func (c *Client) CreateCompletionStream(
 	ctx context.Context,
 	request CompletionRequest,
+	opts ...RequestOption,
 ) (stream *CompletionStream, err error) {
 	urlSuffix := "/completions"
 	if !checkEndpointSupportsModel(urlSuffix, request.Model) {
@@ -33,7 +34,12 @@ func (c *Client) CreateCompletionStream(
 	}
 
 	request.Stream = true
-	req, err := c.newRequest(ctx, "POST", c.fullURL(urlSuffix, request.Model), withBody(request))
+	if err = validateCompletionRequest(request); err != nil {
+		return
+	}
+
+	req, err := c.newRequest(ctx, "POST", c.fullURL(urlSuffix, request.Model),
+		withModel(request.Model), withBody(request), withStream(), withRequestOptions(opts))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,93 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ErrStreamIdleTimeout is matched by errors.Is for every
+// *StreamIdleTimeoutError.
+var ErrStreamIdleTimeout = errors.New("stream idle timeout: no data received")
+
+// StreamIdleTimeoutError is returned by a stream when no data arrives within
+// its idle timeout, set with ClientConfig.StreamIdleTimeout or
+// WithStreamIdleTimeout.
+type StreamIdleTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *StreamIdleTimeoutError) Error() string {
+	return fmt.Sprintf("%s after %s", ErrStreamIdleTimeout, e.Timeout)
+}
+
+func (e *StreamIdleTimeoutError) Unwrap() error {
+	return ErrStreamIdleTimeout
+}
+
+type streamIdleTimeoutKey struct{}
+
+// WithStreamIdleTimeout sets the idle timeout of a single stream, overriding
+// ClientConfig.StreamIdleTimeout, e.g. to allow a reasoning model more time
+// before its first token. Zero disables the timeout for the stream.
+func WithStreamIdleTimeout(timeout time.Duration) RequestOption {
+	return func(args *requestOptions) {
+		args.streamIdleTimeout = &timeout
+	}
+}
+
+// streamIdleTimeout returns the idle timeout of the stream requested by req.
+func (c *Client) streamIdleTimeout(req *http.Request) time.Duration {
+	if timeout, ok := req.Context().Value(streamIdleTimeoutKey{}).(time.Duration); ok {
+		return timeout
+	}
+	return c.config.StreamIdleTimeout
+}
+
+// withIdleTimeout wraps the body of a stream response with the idle timeout
+// of the stream, if any.
+func (c *Client) withIdleTimeout(req *http.Request, resp *http.Response) {
+	if timeout := c.streamIdleTimeout(req); timeout > 0 {
+		resp.Body = newIdleTimeoutReader(resp.Body, timeout)
+	}
+}
+
+// idleTimeoutReader closes the underlying body when a single Read waits for
+// longer than timeout. This unblocks streams whose connection was silently
+// dropped, e.g. by a load balancer, instead of waiting for the request context.
+type idleTimeoutReader struct {
+	body     io.ReadCloser
+	timeout  time.Duration
+	timedOut bool
+}
+
+func newIdleTimeoutReader(body io.ReadCloser, timeout time.Duration) *idleTimeoutReader {
+	return &idleTimeoutReader{body: body, timeout: timeout}
+}
+
+func (r *idleTimeoutReader) Read(p []byte) (int, error) {
+	if r.timedOut {
+		return 0, &StreamIdleTimeoutError{Timeout: r.timeout}
+	}
+
+	expired := make(chan struct{})
+	timer := time.AfterFunc(r.timeout, func() {
+		defer close(expired)
+		r.body.Close()
+	})
+	n, err := r.body.Read(p)
+	if !timer.Stop() {
+		// The timer fired: wait until the body is closed, so that the error
+		// of the read is the timeout even if it returned before the close.
+		<-expired
+		r.timedOut = true
+		return n, &StreamIdleTimeoutError{Timeout: r.timeout}
+	}
+	return n, err
+}
+
+func (r *idleTimeoutReader) Close() error {
+	return r.body.Close()
+}
@@ -0,0 +1,143 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+const idleTestChunk = `data: {"id":"1","object":"chat.completion.chunk",` +
+	`"choices":[{"index":0,"delta":{"content":"hi"}}]}` + "\n\n"
+
+func TestChatCompletionStreamIdleTimeout(t *testing.T) {
+	server := test.NewTestServer()
+	release := make(chan struct{})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, idleTestChunk)
+		w.(http.Flusher).Flush()
+
+		// Simulate a connection that was silently dropped.
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+	defer close(release)
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.StreamIdleTimeout = 50 * time.Millisecond
+	client := openai.NewClientWithConfig(config)
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT3Dot5Turbo,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hello"}},
+	})
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	defer stream.Close()
+
+	resp, err := stream.Recv()
+	checks.NoError(t, err, "first Recv error")
+	if resp.Choices[0].Delta.Content != "hi" {
+		t.Errorf("unexpected content: %q", resp.Choices[0].Delta.Content)
+	}
+
+	start := time.Now()
+	_, err = stream.Recv()
+	if !errors.Is(err, openai.ErrStreamIdleTimeout) {
+		t.Fatalf("expected ErrStreamIdleTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("idle timeout took too long: %s", elapsed)
+	}
+}
+
+func TestStreamIdleTimeoutNotTriggeredBySlowConsumer(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for i := 0; i < 3; i++ {
+			fmt.Fprint(w, idleTestChunk)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.StreamIdleTimeout = 20 * time.Millisecond
+	client := openai.NewClientWithConfig(config)
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT3Dot5Turbo,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hello"}},
+	})
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	defer stream.Close()
+
+	for {
+		// Time spent between reads must not count as idle time.
+		time.Sleep(40 * time.Millisecond)
+		_, err = stream.Recv()
+		if err != nil {
+			break
+		}
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestWithStreamIdleTimeout(t *testing.T) {
+	server := test.NewTestServer()
+	release := make(chan struct{})
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+	defer close(release)
+
+	// The client has no idle timeout: only the option applies.
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := openai.NewClientWithConfig(config)
+
+	timeout := 50 * time.Millisecond
+	stream, err := client.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model:    openai.GPT3Dot5Turbo,
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hello"}},
+	}, openai.WithStreamIdleTimeout(timeout))
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	defer stream.Close()
+
+	_, err = stream.Recv()
+	checks.ErrorIs(t, err, openai.ErrStreamIdleTimeout, "Recv should time out")
+	var timeoutErr *openai.StreamIdleTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected *StreamIdleTimeoutError, got %T", err)
+	}
+	if timeoutErr.Timeout != timeout {
+		t.Errorf("expected timeout %s, got %s", timeout, timeoutErr.Timeout)
+	}
+}
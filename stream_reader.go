@@ -3,9 +3,12 @@ package openai
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
+	"sync/atomic"
 
 	utils "github.com/sashabaranov/go-openai/internal"
 )
@@ -13,8 +16,17 @@ import (
 var (
 	headerData  = []byte("data: ")
 	errorPrefix = []byte(`data: {"error":`)
+	doneData    = []byte("[DONE]")
 )
 
+// ErrStreamClosed is returned by Recv once the stream is closed, including by
+// a Recv that was waiting for data when Close was called.
+var ErrStreamClosed = errors.New("stream closed")
+
+// maxStreamDrainSize bounds how much of a finished stream Close reads to let
+// the connection be reused.
+const maxStreamDrainSize = 4 << 10
+
 type streamable interface {
 	ChatCompletionStreamResponse | CompletionResponse | AssistantStreamEvent
 }
@@ -22,22 +34,66 @@ type streamable interface {
 type streamReader[T streamable] struct {
 	emptyMessagesLimit uint
 	isFinished         bool
+	// finished, receiving and closed are set with atomic operations, because
+	// Close may be called while Recv is waiting for data.
+	finished  int32
+	receiving int32
+	closed    int32
+	closeOnce sync.Once
+	closeErr  error
+
+	// clock measures Stats, and statsEnded is set once the end of the stream
+	// has been timed.
+	clock      streamClock
+	statsEnded bool
+	// onRecv is called with every message and the stats as of the message,
+	// e.g. to report usage.
+	onRecv func(*T, StreamStats)
 
 	reader         *bufio.Reader
 	response       *http.Response
 	errAccumulator utils.ErrorAccumulator
 	unmarshaler    utils.Unmarshaler
 
+	// line holds the lines longer than the buffer of reader, and decoded the
+	// message being decoded. They are reused across messages to avoid an
+	// allocation per line and per message.
+	line    []byte
+	decoded *T
+
 	httpHeader
 }
 
+// Recv returns the next message of the stream, io.EOF once the stream has
+// ended, or ErrStreamClosed once it is closed. It may be called concurrently
+// with Close but not with itself.
 func (stream *streamReader[T]) Recv() (response T, err error) {
+	atomic.StoreInt32(&stream.receiving, 1)
+	defer atomic.StoreInt32(&stream.receiving, 0)
+	if atomic.LoadInt32(&stream.closed) == 1 {
+		err = ErrStreamClosed
+		return
+	}
 	if stream.isFinished {
 		err = io.EOF
 		return
 	}
 
 	response, err = stream.processLines()
+	if err != nil && !stream.isFinished && atomic.LoadInt32(&stream.closed) == 1 {
+		// The read failed because Close closed the body.
+		err = ErrStreamClosed
+	}
+	switch {
+	case err == nil:
+		stream.clock.received(&response)
+		if stream.onRecv != nil {
+			stream.onRecv(&response, stream.clock.stats)
+		}
+	case !stream.statsEnded:
+		stream.statsEnded = true
+		stream.clock.ended()
+	}
 	return
 }
 
@@ -49,7 +105,7 @@ func (stream *streamReader[T]) processLines() (T, error) {
 	)
 
 	for {
-		rawLine, readErr := stream.reader.ReadBytes('\n')
+		rawLine, readErr := stream.readLine()
 		if readErr != nil || hasErrorPrefix {
 			respErr := stream.unmarshalError()
 			if respErr != nil {
@@ -79,21 +135,44 @@ func (stream *streamReader[T]) processLines() (T, error) {
 		}
 
 		noPrefixLine := bytes.TrimPrefix(noSpaceLine, headerData)
-		if string(noPrefixLine) == "[DONE]" {
+		if bytes.Equal(noPrefixLine, doneData) {
 			stream.isFinished = true
+			atomic.StoreInt32(&stream.finished, 1)
 			return *new(T), io.EOF
 		}
 
-		var response T
-		unmarshalErr := stream.unmarshaler.Unmarshal(noPrefixLine, &response)
+		// The message is returned by value, so the struct it is decoded into
+		// can be reused. What it references belongs to the caller.
+		if stream.decoded == nil {
+			stream.decoded = new(T)
+		} else {
+			*stream.decoded = *new(T)
+		}
+		unmarshalErr := stream.unmarshaler.Unmarshal(noPrefixLine, stream.decoded)
 		if unmarshalErr != nil {
 			return *new(T), unmarshalErr
 		}
 
-		return response, nil
+		return *stream.decoded, nil
 	}
 }
 
+// readLine returns the next line, including its '\n'. The line is only valid
+// until the next call.
+func (stream *streamReader[T]) readLine() ([]byte, error) {
+	line, err := stream.reader.ReadSlice('\n')
+	if !errors.Is(err, bufio.ErrBufferFull) {
+		return line, err
+	}
+
+	stream.line = append(stream.line[:0], line...)
+	for errors.Is(err, bufio.ErrBufferFull) {
+		line, err = stream.reader.ReadSlice('\n')
+		stream.line = append(stream.line, line...)
+	}
+	return stream.line, err
+}
+
 func (stream *streamReader[T]) unmarshalError() (errResp *ErrorResponse) {
 	errBytes := stream.errAccumulator.Bytes()
 	if len(errBytes) == 0 {
@@ -108,6 +187,29 @@ func (stream *streamReader[T]) unmarshalError() (errResp *ErrorResponse) {
 	return
 }
 
+// Stats returns the throughput statistics of the stream. They are final once
+// Recv has returned an error, and must not be read concurrently with Recv.
+func (stream *streamReader[T]) Stats() StreamStats {
+	return stream.clock.stats
+}
+
+// Close releases the connection of the stream. A stream that was read to its
+// end is drained so that the connection returns to the pool, and any other
+// stream is aborted, which closes the connection. Close is safe to call more
+// than once and while Recv is waiting for data, which then returns
+// ErrStreamClosed.
 func (stream *streamReader[T]) Close() error {
-	return stream.response.Body.Close()
+	stream.closeOnce.Do(func() {
+		// Recv checks closed after setting receiving and Close checks
+		// receiving after setting closed, so they never read at once.
+		atomic.StoreInt32(&stream.closed, 1)
+		if stream.response == nil {
+			return
+		}
+		if atomic.LoadInt32(&stream.receiving) == 0 && atomic.LoadInt32(&stream.finished) == 1 {
+			_, _ = io.CopyN(io.Discard, stream.reader, maxStreamDrainSize)
+		}
+		stream.closeErr = stream.response.Body.Close()
+	})
+	return stream.closeErr
 }
@@ -4,6 +4,8 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
+	"io"
+	"strings"
 	"testing"
 
 	utils "github.com/sashabaranov/go-openai/internal"
@@ -63,3 +65,49 @@ func TestStreamReaderReturnsErrTestErrorAccumulatorWriteFailed(t *testing.T) {
 	_, err := stream.Recv()
 	checks.ErrorIs(t, err, test.ErrTestErrorAccumulatorWriteFailed, "Did not return error when write failed", err.Error())
 }
+
+func TestStreamReaderLongLines(t *testing.T) {
+	content := strings.Repeat("x", 10000)
+	body := "data: {\"choices\":[{\"delta\":{\"content\":\"" + content + "\"}}]}\n\n" +
+		"data: {\"choices\":[{\"delta\":{\"content\":\"short\"}}]}\n\ndata: [DONE]\n\n"
+	stream := &streamReader[ChatCompletionStreamResponse]{
+		emptyMessagesLimit: defaultEmptyMessagesLimit,
+		reader:             bufio.NewReaderSize(strings.NewReader(body), 16),
+		errAccumulator:     utils.NewErrorAccumulator(),
+		unmarshaler:        &utils.JSONUnmarshaler{},
+	}
+
+	first, err := stream.Recv()
+	checks.NoError(t, err, "Recv error")
+	second, err := stream.Recv()
+	checks.NoError(t, err, "Recv error")
+	if first.Choices[0].Delta.Content != content || second.Choices[0].Delta.Content != "short" {
+		t.Errorf("unexpected messages %q, %q", first.Choices[0].Delta.Content, second.Choices[0].Delta.Content)
+	}
+	_, err = stream.Recv()
+	checks.ErrorIs(t, err, io.EOF, "expected the end of the stream")
+}
+
+func BenchmarkStreamReaderRecv(b *testing.B) {
+	const chunk = `data: {"id":"chatcmpl-1","object":"chat.completion.chunk","created":1,"model":"gpt-4o",` +
+		`"choices":[{"index":0,"delta":{"content":"Hello"},"finish_reason":null}]}` + "\n\n"
+	body := []byte(strings.Repeat(chunk, 100) + "data: [DONE]\n\n")
+	reader := bytes.NewReader(body)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reader.Reset(body)
+		stream := &streamReader[ChatCompletionStreamResponse]{
+			emptyMessagesLimit: defaultEmptyMessagesLimit,
+			reader:             bufio.NewReader(reader),
+			errAccumulator:     utils.NewErrorAccumulator(),
+			unmarshaler:        &utils.JSONUnmarshaler{},
+		}
+		for {
+			if _, err := stream.Recv(); err != nil {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package openai
+
+import (
+	"context"
+	"time"
+)
+
+// StreamStats are the throughput statistics of a stream.
+type StreamStats struct {
+	// Chunks is the number of messages received.
+	Chunks int
+	// TimeToFirstToken is the time from sending the request to receiving the
+	// first message.
+	TimeToFirstToken time.Duration
+	// Duration is the time from sending the request to the end of the
+	// stream, or to the last message while the stream is being read.
+	Duration time.Duration
+	// CompletionTokens is the number of completion tokens reported by the
+	// usage of the stream, zero when the stream reports no usage.
+	CompletionTokens int
+}
+
+// TokensPerSecond returns the generation speed after the first token. It
+// counts CompletionTokens, or Chunks when the stream reports no usage, since
+// chat streams send about one token per chunk.
+func (s StreamStats) TokensPerSecond() float64 {
+	tokens := s.CompletionTokens
+	if tokens == 0 {
+		tokens = s.Chunks
+	}
+	generation := s.Duration - s.TimeToFirstToken
+	if tokens == 0 || generation <= 0 {
+		return 0
+	}
+	return float64(tokens) / generation.Seconds()
+}
+
+type streamStatsKey struct{}
+
+// StreamStatsFromContext returns the statistics of the stream whose usage is
+// reported to ClientConfig.OnUsage with ctx, as of the usage chunk.
+func StreamStatsFromContext(ctx context.Context) (StreamStats, bool) {
+	stats, ok := ctx.Value(streamStatsKey{}).(StreamStats)
+	return stats, ok
+}
+
+// streamClock measures the statistics of a stream.
+type streamClock struct {
+	started time.Time
+	stats   StreamStats
+}
+
+func (c *streamClock) received(response any) {
+	elapsed := time.Since(c.started)
+	if c.stats.Chunks == 0 {
+		c.stats.TimeToFirstToken = elapsed
+	}
+	c.stats.Chunks++
+	c.stats.Duration = elapsed
+	if reporter, ok := response.(usageReporter); ok {
+		if _, usage := reporter.reportedUsage(); usage != nil && usage.CompletionTokens > 0 {
+			c.stats.CompletionTokens = usage.CompletionTokens
+		}
+	}
+}
+
+func (c *streamClock) ended() {
+	c.stats.Duration = time.Since(c.started)
+}
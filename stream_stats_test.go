@@ -0,0 +1,68 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestStreamStats(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		time.Sleep(20 * time.Millisecond)
+		for i := 0; i < 3; i++ {
+			fmt.Fprint(w, `data: {"choices":[{"index":0,"delta":{"content":"token"}}]}`+"\n\n")
+			w.(http.Flusher).Flush()
+			time.Sleep(10 * time.Millisecond)
+		}
+		fmt.Fprint(w, `data: {"choices":[],"usage":{"prompt_tokens":1,"completion_tokens":3,"total_tokens":4}}`+"\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var hookStats openai.StreamStats
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.OnUsage = func(ctx context.Context, _ string, _ openai.Usage) {
+		hookStats, _ = openai.StreamStatsFromContext(ctx)
+	}
+	client := openai.NewClientWithConfig(config)
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), openai.ChatCompletionRequest{
+		Model:         openai.GPT4o,
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
+	})
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	defer stream.Close()
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("unexpected stream error: %v", err)
+	}
+
+	stats := stream.Stats()
+	if stats.Chunks != 4 || stats.CompletionTokens != 3 {
+		t.Errorf("unexpected counts %+v", stats)
+	}
+	if stats.TimeToFirstToken < 20*time.Millisecond || stats.Duration < stats.TimeToFirstToken+20*time.Millisecond {
+		t.Errorf("unexpected timings %+v", stats)
+	}
+	if stats.TokensPerSecond() <= 0 {
+		t.Errorf("unexpected tokens per second %f", stats.TokensPerSecond())
+	}
+	if hookStats.Chunks != 4 || hookStats.CompletionTokens != 3 {
+		t.Errorf("unexpected stats in the usage hook %+v", hookStats)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
@@ -103,6 +104,48 @@ func TestCreateCompletionStream(t *testing.T) {
 	}
 }
 
+func TestCreateCompletionStreamUsage(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/completions", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.CompletionRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "decode request")
+		if request.StreamOptions == nil || !request.StreamOptions.IncludeUsage {
+			t.Errorf("expected stream_options to be sent, got %+v", request.StreamOptions)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"id":"1","model":"gpt-3.5-turbo-instruct","choices":[{"text":"hi"}],"usage":null}`+"\n\n")
+		fmt.Fprint(w, `data: {"id":"1","model":"gpt-3.5-turbo-instruct","choices":[],`+
+			`"usage":{"prompt_tokens":3,"completion_tokens":1,"total_tokens":4}}`+"\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+
+	request := openai.CompletionRequest{
+		Model:         openai.GPT3Dot5TurboInstruct,
+		Prompt:        "Say hi",
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
+	}
+	stream, err := client.CreateCompletionStream(context.Background(), request)
+	checks.NoError(t, err, "CreateCompletionStream error")
+	defer stream.Close()
+
+	chunk, err := stream.Recv()
+	checks.NoError(t, err, "Recv error")
+	if chunk.Choices[0].Text != "hi" || chunk.Usage != (openai.Usage{}) {
+		t.Errorf("unexpected first chunk %+v", chunk)
+	}
+	chunk, err = stream.Recv()
+	checks.NoError(t, err, "Recv error")
+	if len(chunk.Choices) != 0 || chunk.Usage.TotalTokens != 4 {
+		t.Errorf("expected the usage chunk, got %+v", chunk)
+	}
+	_, err = stream.Recv()
+	checks.ErrorIs(t, err, io.EOF)
+
+	_, err = client.CreateCompletion(context.Background(), request)
+	checks.ErrorIs(t, err, openai.ErrCompletionInvalidParameter, "stream_options without streaming")
+}
+
 func TestCreateCompletionStreamError(t *testing.T) {
 	client, server, teardown := setupOpenAITestServer()
 	defer teardown()
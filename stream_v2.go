@@ -57,6 +57,12 @@ type DeltaImageURL struct {
 }
 
 func NewStreamerV2(r io.Reader) *StreamerV2 {
+	return NewStreamerV2Size(r, 0)
+}
+
+// NewStreamerV2Size returns a StreamerV2 that accepts event lines of up to
+// maxLineSize bytes. See NewSSEScannerSize.
+func NewStreamerV2Size(r io.Reader, maxLineSize int) *StreamerV2 {
 	var rc io.ReadCloser
 
 	if closer, ok := r.(io.ReadCloser); ok {
@@ -66,8 +72,9 @@ func NewStreamerV2(r io.Reader) *StreamerV2 {
 	}
 
 	return &StreamerV2{
-		readCloser: rc,
-		scanner:    NewSSEScanner(r, false),
+		readCloser:  rc,
+		scanner:     NewSSEScannerSize(r, false, maxLineSize),
+		maxLineSize: maxLineSize,
 	}
 }
 
@@ -75,8 +82,9 @@ type StreamerV2 struct {
 	// readCloser is only used for closing the stream
 	readCloser io.ReadCloser
 
-	scanner *SSEScanner
-	next    StreamEvent
+	scanner     *SSEScanner
+	next        StreamEvent
+	maxLineSize int
 
 	// buffer for implementing io.Reader
 	buffer []byte
@@ -95,7 +103,7 @@ func (s *StreamerV2) TeeSSE(w io.Writer) {
 		Closer: s.readCloser,
 	}
 
-	s.scanner = NewSSEScanner(s.readCloser, false)
+	s.scanner = NewSSEScannerSize(s.readCloser, false, s.maxLineSize)
 }
 
 // Close closes the underlying io.ReadCloser.
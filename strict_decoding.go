@@ -0,0 +1,151 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownFieldsError is returned with ClientConfig.StrictDecoding when a
+// response has fields that its type does not model.
+type UnknownFieldsError struct {
+	// Type is the Go type of the response, e.g. "openai.ChatCompletionResponse".
+	Type string
+	// Fields are the paths of the unknown fields, sorted.
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("unknown fields in %s response: %s", e.Type, strings.Join(e.Fields, ", "))
+}
+
+func (c *Client) checkUnknownFields(body []byte, v any) error {
+	t := reflect.TypeOf(v)
+	fields := unknownJSONFields(body, t)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if c.config.OnUnknownFields != nil {
+		c.config.OnUnknownFields(t.String(), fields)
+	}
+	if c.config.StrictDecoding {
+		return &UnknownFieldsError{Type: t.String(), Fields: fields}
+	}
+	return nil
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unknownJSONFields returns the paths of the fields in data that decoding into
+// t ignores. Types with their own UnmarshalJSON are not inspected.
+func unknownJSONFields(data []byte, t reflect.Type) []string {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil
+	}
+
+	found := make(map[string]struct{})
+	collectUnknownFields(value, t, "", found)
+
+	fields := make([]string, 0, len(found))
+	for field := range found {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func collectUnknownFields(value any, t reflect.Type, path string, found map[string]struct{}) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		known := jsonFields(t)
+		for key, fieldValue := range object {
+			fieldPath := joinFieldPath(path, key)
+			fieldType, isKnown := known[strings.ToLower(key)]
+			if !isKnown {
+				found[fieldPath] = struct{}{}
+				continue
+			}
+			collectUnknownFields(fieldValue, fieldType, fieldPath, found)
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]any)
+		if !ok {
+			return
+		}
+		for _, item := range items {
+			collectUnknownFields(item, t.Elem(), path+"[]", found)
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		for key, item := range object {
+			collectUnknownFields(item, t.Elem(), joinFieldPath(path, key), found)
+		}
+	default:
+	}
+}
+
+// jsonFields returns the types of the JSON fields of struct type t, keyed by
+// lower case name since encoding/json matches names case-insensitively.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				// Fields of the outer struct take precedence over promoted ones.
+				for key, fieldType := range jsonFields(embedded) {
+					if _, ok := fields[key]; !ok {
+						fields[key] = fieldType
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+	return fields
+}
+
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,97 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func setupStrictDecodingClient(t *testing.T, body string, configure func(*openai.ClientConfig)) *openai.Client {
+	t.Helper()
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, body)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	configure(&config)
+	return openai.NewClientWithConfig(config)
+}
+
+const driftedChatResponse = `{
+	"id": "chatcmpl-1",
+	"object": "chat.completion",
+	"service_tier_v2": "default",
+	"choices": [
+		{"index": 0, "message": {"role": "assistant", "content": "hi"}, "finish_reason": "stop", "score": 1},
+		{"index": 1, "message": {"role": "assistant", "content": "hey"}, "finish_reason": "stop", "score": 2}
+	],
+	"usage": {"prompt_tokens": 1, "completion_tokens": 1, "total_tokens": 2, "future_tokens": 0}
+}`
+
+var chatRequest = openai.ChatCompletionRequest{
+	Model:    openai.GPT3Dot5Turbo,
+	Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: "hello"}},
+}
+
+func TestStrictDecoding(t *testing.T) {
+	client := setupStrictDecodingClient(t, driftedChatResponse, func(config *openai.ClientConfig) {
+		config.StrictDecoding = true
+	})
+
+	resp, err := client.CreateChatCompletion(context.Background(), chatRequest)
+	var fieldsErr *openai.UnknownFieldsError
+	if !errors.As(err, &fieldsErr) {
+		t.Fatalf("expected UnknownFieldsError, got %v", err)
+	}
+	expected := []string{"choices[].score", "service_tier_v2", "usage.future_tokens"}
+	if !reflect.DeepEqual(fieldsErr.Fields, expected) {
+		t.Errorf("expected fields %v, got %v", expected, fieldsErr.Fields)
+	}
+	if fieldsErr.Type != "openai.ChatCompletionResponse" {
+		t.Errorf("unexpected type %q", fieldsErr.Type)
+	}
+	if resp.ID != "chatcmpl-1" {
+		t.Errorf("expected the response to be decoded, got %+v", resp)
+	}
+}
+
+func TestOnUnknownFields(t *testing.T) {
+	var reported []string
+	client := setupStrictDecodingClient(t, driftedChatResponse, func(config *openai.ClientConfig) {
+		config.OnUnknownFields = func(_ string, fields []string) {
+			reported = append(reported, fields...)
+		}
+	})
+
+	_, err := client.CreateChatCompletion(context.Background(), chatRequest)
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if len(reported) != 3 {
+		t.Errorf("expected 3 unknown fields, got %v", reported)
+	}
+}
+
+func TestStrictDecodingKnownFields(t *testing.T) {
+	client := setupStrictDecodingClient(t, `{
+		"id": "chatcmpl-1",
+		"Object": "chat.completion",
+		"choices": [{"index": 0, "message": {"role": "assistant", "content": "hi"}, "finish_reason": "stop"}],
+		"usage": {"prompt_tokens": 1, "completion_tokens": 1, "total_tokens": 2}
+	}`, func(config *openai.ClientConfig) {
+		config.StrictDecoding = true
+	})
+
+	_, err := client.CreateChatCompletion(context.Background(), chatRequest)
+	checks.NoError(t, err, "CreateChatCompletion error")
+}
@@ -10,21 +10,65 @@ const (
 )
 
 type Thread struct {
-	ID        string         `json:"id"`
-	Object    string         `json:"object"`
-	CreatedAt int64          `json:"created_at"`
-	Metadata  map[string]any `json:"metadata"`
+	ID            string         `json:"id"`
+	Object        string         `json:"object"`
+	CreatedAt     int64          `json:"created_at"`
+	Metadata      map[string]any `json:"metadata"`
+	ToolResources *ToolResources `json:"tool_resources,omitempty"`
 
 	httpHeader
 }
 
 type ThreadRequest struct {
-	Messages []ThreadMessage `json:"messages,omitempty"`
-	Metadata map[string]any  `json:"metadata,omitempty"`
+	Messages      []ThreadMessage `json:"messages,omitempty"`
+	Metadata      map[string]any  `json:"metadata,omitempty"`
+	ToolResources *ToolResources  `json:"tool_resources,omitempty"`
 }
 
+// ModifyThreadRequest updates a thread. Fields left nil are not changed.
 type ModifyThreadRequest struct {
-	Metadata map[string]any `json:"metadata"`
+	// Metadata replaces the metadata of the thread.
+	Metadata map[string]any `json:"metadata,omitempty"`
+	// ToolResources replaces the files available to the tools of the thread.
+	ToolResources *ToolResources `json:"tool_resources,omitempty"`
+	// ClearFields lists JSON field names, e.g. "metadata", that are sent as
+	// null so that ModifyThread clears them.
+	ClearFields []string `json:"-"`
+}
+
+func (r ModifyThreadRequest) MarshalJSON() ([]byte, error) {
+	return marshalWithExtraBody(standardJSON{}, r)
+}
+
+func (r ModifyThreadRequest) splitExtraBody() (any, map[string]any) {
+	type Alias ModifyThreadRequest
+	return Alias(r), withClearedFields(nil, r.ClearFields)
+}
+
+// ToolResources are the files made available to the tools of an assistant or
+// a thread.
+type ToolResources struct {
+	CodeInterpreter *CodeInterpreterToolResources `json:"code_interpreter,omitempty"`
+	FileSearch      *FileSearchToolResources      `json:"file_search,omitempty"`
+}
+
+// CodeInterpreterToolResources are the files the code_interpreter tool can use.
+type CodeInterpreterToolResources struct {
+	FileIDs []string `json:"file_ids"`
+}
+
+// FileSearchToolResources are the vector stores the file_search tool searches.
+type FileSearchToolResources struct {
+	VectorStoreIDs []string `json:"vector_store_ids,omitempty"`
+	// VectorStores creates a vector store from files when a thread or an
+	// assistant is created. It can't be combined with VectorStoreIDs.
+	VectorStores []FileSearchVectorStore `json:"vector_stores,omitempty"`
+}
+
+// FileSearchVectorStore describes a vector store created with its thread or assistant.
+type FileSearchVectorStore struct {
+	FileIDs  []string       `json:"file_ids,omitempty"`
+	Metadata map[string]any `json:"metadata,omitempty"`
 }
 
 type ThreadMessageRole string
@@ -53,9 +97,13 @@ type ThreadDeleteResponse struct {
 }
 
 // CreateThread creates a new thread.
-func (c *Client) CreateThread(ctx context.Context, request ThreadRequest) (response Thread, err error) {
+func (c *Client) CreateThread(
+	ctx context.Context,
+	request ThreadRequest,
+	opts ...RequestOption,
+) (response Thread, err error) {
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(threadsSuffix), withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -65,10 +113,14 @@ func (c *Client) CreateThread(ctx context.Context, request ThreadRequest) (respo
 }
 
 // RetrieveThread retrieves a thread.
-func (c *Client) RetrieveThread(ctx context.Context, threadID string) (response Thread, err error) {
+func (c *Client) RetrieveThread(
+	ctx context.Context,
+	threadID string,
+	opts ...RequestOption,
+) (response Thread, err error) {
 	urlSuffix := threadsSuffix + "/" + threadID
 	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -82,10 +134,11 @@ func (c *Client) ModifyThread(
 	ctx context.Context,
 	threadID string,
 	request ModifyThreadRequest,
+	opts ...RequestOption,
 ) (response Thread, err error) {
 	urlSuffix := threadsSuffix + "/" + threadID
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -98,10 +151,11 @@ func (c *Client) ModifyThread(
 func (c *Client) DeleteThread(
 	ctx context.Context,
 	threadID string,
+	opts ...RequestOption,
 ) (response ThreadDeleteResponse, err error) {
 	urlSuffix := threadsSuffix + "/" + threadID
 	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
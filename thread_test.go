@@ -176,3 +176,49 @@ func TestAzureThread(t *testing.T) {
 	_, err = client.DeleteThread(ctx, threadID)
 	checks.NoError(t, err, "DeleteThread error")
 }
+
+func TestThreadToolResources(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler("/v1/threads", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.ThreadRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil ||
+			request.ToolResources == nil || request.ToolResources.FileSearch == nil {
+			http.Error(w, "missing tool resources", http.StatusBadRequest)
+			return
+		}
+		resBytes, _ := json.Marshal(openai.Thread{
+			ID:            "thread_abc123",
+			ToolResources: request.ToolResources,
+		})
+		fmt.Fprintln(w, string(resBytes))
+	})
+
+	thread, err := client.CreateThread(context.Background(), openai.ThreadRequest{
+		ToolResources: &openai.ToolResources{
+			CodeInterpreter: &openai.CodeInterpreterToolResources{FileIDs: []string{"file-abc"}},
+			FileSearch:      &openai.FileSearchToolResources{VectorStoreIDs: []string{"vs_abc"}},
+		},
+	})
+	checks.NoError(t, err, "CreateThread error")
+	if thread.ToolResources.FileSearch.VectorStoreIDs[0] != "vs_abc" ||
+		thread.ToolResources.CodeInterpreter.FileIDs[0] != "file-abc" {
+		t.Fatalf("unexpected tool resources: %+v", thread.ToolResources)
+	}
+}
+
+func TestModifyThreadRequestJSON(t *testing.T) {
+	data, err := json.Marshal(openai.ModifyThreadRequest{
+		ToolResources: &openai.ToolResources{
+			FileSearch: &openai.FileSearchToolResources{VectorStoreIDs: []string{"vs_abc"}},
+		},
+		ClearFields: []string{"metadata"},
+	})
+	checks.NoError(t, err)
+
+	const expected = `{"metadata":null,"tool_resources":{"file_search":{"vector_store_ids":["vs_abc"]}}}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+}
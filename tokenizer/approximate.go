@@ -0,0 +1,64 @@
+package tokenizer
+
+import (
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+// pretokenize splits text the same way tiktoken's cl100k_base pattern does,
+// before byte pair encoding is applied.
+var pretokenize = regexp.MustCompile(
+	`(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+`,
+)
+
+// approximate estimates token counts from the pre-tokenized pieces of text.
+type approximate struct{}
+
+// Approximate is the Counter used when no exact implementation is registered.
+// Common words count as one token, long words as one token per four letters,
+// and characters outside the Latin script as one token each.
+var Approximate Counter = approximate{}
+
+func (approximate) Count(text string) int {
+	count := 0
+	for _, piece := range pretokenize.FindAllString(text, -1) {
+		count += pieceTokens(piece)
+	}
+	return count
+}
+
+func pieceTokens(piece string) int {
+	letters, other, digits := 0, 0, 0
+	for _, r := range piece {
+		switch {
+		case unicode.IsDigit(r):
+			digits++
+		case r < utf8.RuneSelf && unicode.IsLetter(r):
+			letters++
+		case unicode.IsLetter(r):
+			// Non-Latin scripts are mostly encoded as one or more tokens per character.
+			other++
+		}
+	}
+
+	switch {
+	case other > 0:
+		return other + (letters+3)/4
+	case letters > 6:
+		return (letters + 3) / 4
+	case letters > 0, digits > 0:
+		// Numbers are pre-tokenized in groups of up to three digits, each one token.
+		return 1
+	}
+
+	// Punctuation and symbols merge less readily than letters.
+	runes := utf8.RuneCountInString(piece)
+	if runes > 1 && unicode.IsSpace([]rune(piece)[0]) {
+		runes--
+	}
+	if runes == 0 {
+		return 1
+	}
+	return (runes + 1) / 2
+}
@@ -0,0 +1,177 @@
+// Package tokenizer counts tokens the way OpenAI models do, so requests can be
+// budgeted before they are sent.
+//
+// The package does not embed BPE vocabularies. Counts fall back to Approximate,
+// a pre-tokenizer based estimate that is usually within a few percent of
+// tiktoken for English text. For exact counts, register a tiktoken-compatible
+// implementation for each encoding:
+//
+//	tokenizer.Register(tokenizer.O200kBase, myTiktokenEncoder)
+package tokenizer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/sashabaranov/go-openai/internal/prefix"
+)
+
+// Encoding names used by OpenAI models.
+const (
+	O200kBase  = "o200k_base"
+	Cl100kBase = "cl100k_base"
+	P50kBase   = "p50k_base"
+	R50kBase   = "r50k_base"
+)
+
+var (
+	// ErrUnknownModel is returned when no encoding is known for a model.
+	ErrUnknownModel = errors.New("tokenizer: no encoding known for model")
+	// ErrNoEncoder is returned when token IDs are required but only a counter is
+	// registered for the encoding.
+	ErrNoEncoder = errors.New("tokenizer: no encoder registered for encoding")
+)
+
+// Counter counts the tokens in a piece of text.
+type Counter interface {
+	Count(text string) int
+}
+
+// Encoder is a Counter that can also produce token IDs, such as a tiktoken implementation.
+type Encoder interface {
+	Counter
+	Encode(text string) []int
+}
+
+var (
+	mu       sync.RWMutex
+	counters = map[string]Counter{}
+
+	// modelPrefixes maps model name prefixes to encodings. The longest matching
+	// prefix wins, so "gpt-4o" is not shadowed by "gpt-4".
+	modelPrefixes = prefix.NewTable(map[string]string{
+		"gpt-5":                  O200kBase,
+		"gpt-4.5":                O200kBase,
+		"gpt-4.1":                O200kBase,
+		"gpt-4o":                 O200kBase,
+		"chatgpt-4o":             O200kBase,
+		"o1":                     O200kBase,
+		"o3":                     O200kBase,
+		"o4":                     O200kBase,
+		"gpt-4":                  Cl100kBase,
+		"gpt-3.5-turbo":          Cl100kBase,
+		"gpt-35-turbo":           Cl100kBase,
+		"text-embedding-ada-002": Cl100kBase,
+		"text-embedding-3":       Cl100kBase,
+		"davinci-002":            Cl100kBase,
+		"babbage-002":            Cl100kBase,
+		"text-davinci-003":       P50kBase,
+		"text-davinci-002":       P50kBase,
+		"code-davinci":           P50kBase,
+		"code-cushman":           P50kBase,
+		"text-davinci-001":       R50kBase,
+		"text-curie":             R50kBase,
+		"text-babbage":           R50kBase,
+		"text-ada":               R50kBase,
+		"davinci":                R50kBase,
+		"curie":                  R50kBase,
+		"babbage":                R50kBase,
+		"ada":                    R50kBase,
+	})
+)
+
+// Register makes counter the implementation used for an encoding. Registering
+// an Encoder also enables EncoderForModel for the encoding.
+func Register(encoding string, counter Counter) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	counters[encoding] = counter
+}
+
+// RegisterModel maps a model name prefix, such as a fine-tuned model family or
+// an Azure deployment name, to an encoding.
+func RegisterModel(prefix, encoding string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	modelPrefixes.Set(prefix, encoding)
+}
+
+// Unregister removes the counter registered for an encoding, e.g. to restore
+// Approximate after a test.
+func Unregister(encoding string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	delete(counters, encoding)
+}
+
+// UnregisterModel removes a model name prefix registered with RegisterModel.
+func UnregisterModel(prefix string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	modelPrefixes.Delete(prefix)
+}
+
+// EncodingForModel returns the name of the encoding used by model. Fine-tuned
+// model IDs ("ft:gpt-4o-mini:org::id") resolve to their base model.
+func EncodingForModel(model string) (string, error) {
+	model = strings.TrimPrefix(model, "ft:")
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if encoding, ok := modelPrefixes.Lookup(model); ok {
+		return encoding, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownModel, model)
+}
+
+// ForModel returns the counter registered for the model's encoding, or
+// Approximate when none is registered.
+func ForModel(model string) (Counter, error) {
+	encoding, err := EncodingForModel(model)
+	if err != nil {
+		return nil, err
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if counter, ok := counters[encoding]; ok {
+		return counter, nil
+	}
+	return Approximate, nil
+}
+
+// EncoderForModel returns the Encoder registered for the model's encoding.
+// Unlike ForModel it does not fall back to an approximation, because token IDs
+// cannot be estimated.
+func EncoderForModel(model string) (Encoder, error) {
+	encoding, err := EncodingForModel(model)
+	if err != nil {
+		return nil, err
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	encoder, ok := counters[encoding].(Encoder)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoEncoder, encoding)
+	}
+	return encoder, nil
+}
+
+// Count counts the tokens in text for model.
+func Count(model, text string) (int, error) {
+	counter, err := ForModel(model)
+	if err != nil {
+		return 0, err
+	}
+	return counter.Count(text), nil
+}
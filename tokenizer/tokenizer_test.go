@@ -0,0 +1,116 @@
+package tokenizer_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sashabaranov/go-openai/tokenizer"
+)
+
+type byteEncoder struct{}
+
+func (byteEncoder) Count(text string) int { return len(text) }
+
+func (byteEncoder) Encode(text string) []int {
+	ids := make([]int, len(text))
+	for i := range text {
+		ids[i] = int(text[i])
+	}
+	return ids
+}
+
+func TestEncodingForModel(t *testing.T) {
+	cases := map[string]string{
+		"gpt-4o":                      tokenizer.O200kBase,
+		"gpt-4o-mini-2024-07-18":      tokenizer.O200kBase,
+		"o3-mini":                     tokenizer.O200kBase,
+		"gpt-4":                       tokenizer.Cl100kBase,
+		"gpt-4-turbo":                 tokenizer.Cl100kBase,
+		"gpt-3.5-turbo-0125":          tokenizer.Cl100kBase,
+		"text-embedding-3-small":      tokenizer.Cl100kBase,
+		"text-davinci-003":            tokenizer.P50kBase,
+		"davinci":                     tokenizer.R50kBase,
+		"ft:gpt-4o-mini:acme::abc123": tokenizer.O200kBase,
+	}
+	for model, expected := range cases {
+		encoding, err := tokenizer.EncodingForModel(model)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", model, err)
+			continue
+		}
+		if encoding != expected {
+			t.Errorf("%s: expected %s, got %s", model, expected, encoding)
+		}
+	}
+
+	if _, err := tokenizer.EncodingForModel("llama-3"); !errors.Is(err, tokenizer.ErrUnknownModel) {
+		t.Errorf("expected ErrUnknownModel, got %v", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	tokenizer.RegisterModel("test-bytes-model", "test_bytes")
+	t.Cleanup(func() { tokenizer.UnregisterModel("test-bytes-model") })
+
+	counter, err := tokenizer.ForModel("test-bytes-model")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if counter != tokenizer.Approximate {
+		t.Errorf("expected the approximate counter before registration")
+	}
+	if _, err = tokenizer.EncoderForModel("test-bytes-model"); !errors.Is(err, tokenizer.ErrNoEncoder) {
+		t.Errorf("expected ErrNoEncoder, got %v", err)
+	}
+
+	tokenizer.Register("test_bytes", byteEncoder{})
+	t.Cleanup(func() { tokenizer.Unregister("test_bytes") })
+
+	n, err := tokenizer.Count("test-bytes-model", "hello")
+	if err != nil || n != 5 {
+		t.Errorf("expected 5 tokens, got %d (%v)", n, err)
+	}
+	encoder, err := tokenizer.EncoderForModel("test-bytes-model")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids := encoder.Encode("hi"); len(ids) != 2 || ids[0] != 'h' {
+		t.Errorf("unexpected ids: %v", ids)
+	}
+
+	tokenizer.Unregister("test_bytes")
+	if counter, _ = tokenizer.ForModel("test-bytes-model"); counter != tokenizer.Approximate {
+		t.Errorf("expected the approximate counter after unregistration")
+	}
+	tokenizer.UnregisterModel("test-bytes-model")
+	if _, err = tokenizer.EncodingForModel("test-bytes-model"); !errors.Is(err, tokenizer.ErrUnknownModel) {
+		t.Errorf("expected ErrUnknownModel after unregistration, got %v", err)
+	}
+}
+
+func TestApproximate(t *testing.T) {
+	cases := []struct {
+		text     string
+		expected int
+	}{
+		{"", 0},
+		{"hello", 1},
+		{"hello world", 2},
+		{"Hello, world!", 4},
+		{"12345", 2},
+		{"internationalization", 5},
+	}
+	for _, c := range cases {
+		if got := tokenizer.Approximate.Count(c.text); got != c.expected {
+			t.Errorf("%q: expected %d tokens, got %d", c.text, c.expected, got)
+		}
+	}
+
+	// The estimate should stay in the right ballpark for ordinary prose, which
+	// tiktoken encodes at roughly 0.75 words per token.
+	prose := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 20)
+	if got := tokenizer.Approximate.Count(prose); got < 180 || got > 240 {
+		t.Errorf("unexpected estimate for prose: %d", got)
+	}
+}
@@ -0,0 +1,173 @@
+package openai
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"image"
+	_ "image/gif"  // Reads the size of GIF data URLs.
+	_ "image/jpeg" // Reads the size of JPEG data URLs.
+	_ "image/png"  // Reads the size of PNG data URLs.
+	"math"
+	"strings"
+
+	"github.com/sashabaranov/go-openai/tokenizer"
+)
+
+// Token overheads used by the chat format, as documented in the OpenAI cookbook
+// "How to count tokens with tiktoken".
+const (
+	tokensPerMessage   = 3
+	tokensPerName      = 1
+	tokensReplyPriming = 3
+
+	tokensPerToolsPreamble = 12
+	tokensPerTool          = 7
+
+	// tokensPerImageLow is the flat cost of an image with detail "low". High
+	// detail images also cost tokensPerImageTile per 512px tile of the image
+	// scaled to fit in 2048x2048 and then to a shortest side of 768.
+	tokensPerImageLow  = 85
+	tokensPerImageTile = 170
+	// defaultImageSide is the side of the square image that high detail images
+	// of unknown size are counted as.
+	defaultImageSide = 768
+)
+
+// CountMessagesTokens returns the number of prompt tokens the messages and tool
+// definitions will use with model, including the per-message overhead of the
+// chat format and the tokens that prime the assistant's reply.
+//
+// Counts are exact when a tiktoken-compatible encoder is registered with the
+// tokenizer package and approximate otherwise. Tool definitions are always an
+// estimate, because the API renders them into the prompt in an undocumented format.
+// So are high detail images, unless they are PNG, JPEG or GIF data URLs whose
+// size can be read: the others are counted as a square image, at 765 tokens,
+// while wide or tall images cost up to 1445 tokens.
+func CountMessagesTokens(model string, messages []ChatCompletionMessage, tools []Tool) (int, error) {
+	counter, err := tokenizer.ForModel(model)
+	if err != nil {
+		return 0, err
+	}
+	return countMessagesTokens(counter, model, messages, tools)
+}
+
+func countMessagesTokens(
+	counter tokenizer.Counter,
+	model string,
+	messages []ChatCompletionMessage,
+	tools []Tool,
+) (int, error) {
+	perMessage, perName := tokensPerMessage, tokensPerName
+	if strings.HasPrefix(model, GPT3Dot5Turbo0301) {
+		// every message follows <|start|>{role/name}\n{content}<|end|>\n
+		// and if there's a name, the role is omitted
+		perMessage, perName = 4, -1
+	}
+
+	total := tokensReplyPriming
+	for _, message := range messages {
+		total += perMessage
+		total += counter.Count(message.Role)
+		total += counter.Count(message.Content)
+		for _, part := range message.MultiContent {
+			total += countPartTokens(counter, part)
+		}
+		if message.Name != "" {
+			total += counter.Count(message.Name) + perName
+		}
+		if message.FunctionCall != nil {
+			total += counter.Count(message.FunctionCall.Name) + counter.Count(message.FunctionCall.Arguments)
+		}
+		for _, call := range message.ToolCalls {
+			total += counter.Count(call.Function.Name) + counter.Count(call.Function.Arguments)
+		}
+		total += counter.Count(message.ToolCallID)
+	}
+
+	if len(tools) > 0 {
+		total += tokensPerToolsPreamble
+	}
+	for _, tool := range tools {
+		if tool.Function == nil {
+			continue
+		}
+		n, err := countFunctionTokens(counter, tool.Function)
+		if err != nil {
+			return 0, err
+		}
+		total += tokensPerTool + n
+	}
+	return total, nil
+}
+
+// CountPromptTokens returns the number of prompt tokens the request's messages
+// and tools will use. See CountMessagesTokens.
+func (r ChatCompletionRequest) CountPromptTokens() (int, error) {
+	return CountMessagesTokens(r.Model, r.Messages, r.Tools)
+}
+
+func countPartTokens(counter tokenizer.Counter, part ChatMessagePart) int {
+	switch part.Type {
+	case ChatMessagePartTypeText:
+		return counter.Count(part.Text)
+	case ChatMessagePartTypeImageURL:
+		if part.ImageURL == nil {
+			return imageTokens(defaultImageSide, defaultImageSide)
+		}
+		if part.ImageURL.Detail == ImageURLDetailLow {
+			return tokensPerImageLow
+		}
+		width, height, ok := dataURLImageSize(part.ImageURL.URL)
+		if !ok {
+			width, height = defaultImageSide, defaultImageSide
+		}
+		return imageTokens(width, height)
+	default:
+		return 0
+	}
+}
+
+// imageTokens returns the cost of a high detail image of width by height
+// pixels, as documented in the vision guide.
+func imageTokens(width, height int) int {
+	w, h := float64(width), float64(height)
+	if longest := math.Max(w, h); longest > 2048 {
+		w, h = w*2048/longest, h*2048/longest
+	}
+	if shortest := math.Min(w, h); shortest > 768 {
+		w, h = w*768/shortest, h*768/shortest
+	}
+	tiles := int(math.Ceil(w/512) * math.Ceil(h/512))
+	return tokensPerImageLow + tokensPerImageTile*tiles
+}
+
+// dataURLImageSize returns the size of the image of a base64 data URL, if its
+// format is known.
+func dataURLImageSize(url string) (width, height int, ok bool) {
+	if !strings.HasPrefix(url, "data:") {
+		return 0, 0, false
+	}
+	i := strings.Index(url, ";base64,")
+	if i < 0 {
+		return 0, 0, false
+	}
+	data := base64.NewDecoder(base64.StdEncoding, strings.NewReader(url[i+len(";base64,"):]))
+	config, _, err := image.DecodeConfig(data)
+	if err != nil {
+		return 0, 0, false
+	}
+	return config.Width, config.Height, true
+}
+
+func countFunctionTokens(counter tokenizer.Counter, function *FunctionDefinition) (int, error) {
+	total := counter.Count(function.Name) + counter.Count(function.Description)
+	if function.Parameters == nil {
+		return total, nil
+	}
+
+	parameters, err := json.Marshal(function.Parameters)
+	if err != nil {
+		return 0, err
+	}
+	return total + counter.Count(string(parameters)), nil
+}
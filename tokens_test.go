@@ -0,0 +1,122 @@
+package openai_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+	"github.com/sashabaranov/go-openai/tokenizer"
+)
+
+type wordCounter struct{}
+
+func (wordCounter) Count(text string) int {
+	return len(strings.Fields(text))
+}
+
+// registerWordCounter registers test-words-model, whose tokens are the words
+// of the text, for the duration of the test.
+func registerWordCounter(t *testing.T) {
+	t.Helper()
+	tokenizer.Register("test_words", wordCounter{})
+	tokenizer.RegisterModel("test-words-model", "test_words")
+	t.Cleanup(func() {
+		tokenizer.Unregister("test_words")
+		tokenizer.UnregisterModel("test-words-model")
+	})
+}
+
+func TestCountMessagesTokens(t *testing.T) {
+	registerWordCounter(t)
+	messages := []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleSystem, Content: "You are helpful"},
+		{Role: openai.ChatMessageRoleUser, Content: "hi there", Name: "bob"},
+	}
+
+	// system: 3 overhead + 1 role + 3 content
+	// user:   3 overhead + 1 role + 2 content + 1 name + 1 name overhead
+	// reply:  3
+	n, err := openai.CountMessagesTokens("test-words-model", messages, nil)
+	checks.NoError(t, err, "CountMessagesTokens error")
+	if n != 18 {
+		t.Errorf("expected 18 tokens, got %d", n)
+	}
+
+	tools := []openai.Tool{{
+		Type: openai.ToolTypeFunction,
+		Function: &openai.FunctionDefinition{
+			Name:        "get_weather",
+			Description: "Get weather",
+			Parameters:  json.RawMessage(`{"type":"object"}`),
+		},
+	}}
+
+	// tools: 12 preamble + 7 per tool + 1 name + 2 description + 1 parameters
+	request := openai.ChatCompletionRequest{Model: "test-words-model", Messages: messages, Tools: tools}
+	n, err = request.CountPromptTokens()
+	checks.NoError(t, err, "CountPromptTokens error")
+	if n != 41 {
+		t.Errorf("expected 41 tokens, got %d", n)
+	}
+}
+
+func TestCountMessagesTokensApproximate(t *testing.T) {
+	n, err := openai.CountMessagesTokens(openai.GPT4o, []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleUser, MultiContent: []openai.ChatMessagePart{
+			{Type: openai.ChatMessagePartTypeText, Text: "What is in this image?"},
+			{Type: openai.ChatMessagePartTypeImageURL, ImageURL: &openai.ChatMessageImageURL{
+				URL:    "https://example.com/cat.png",
+				Detail: openai.ImageURLDetailLow,
+			}},
+		}},
+	}, nil)
+	checks.NoError(t, err, "CountMessagesTokens error")
+	if n < 90 || n > 110 {
+		t.Errorf("unexpected token estimate: %d", n)
+	}
+
+	_, err = openai.CountMessagesTokens("unknown-model", nil, nil)
+	checks.ErrorIs(t, err, tokenizer.ErrUnknownModel, "expected ErrUnknownModel")
+}
+
+func pngDataURL(t *testing.T, width, height int) string {
+	t.Helper()
+	var buf bytes.Buffer
+	checks.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))), "png.Encode error")
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestCountMessagesTokensImages(t *testing.T) {
+	registerWordCounter(t)
+
+	// Each message costs 3 overhead + 1 role + 3 reply priming besides its image.
+	const remote = "https://example.com/cat.png"
+	for name, c := range map[string]struct {
+		image    openai.ChatMessageImageURL
+		expected int
+	}{
+		"low detail":    {openai.ChatMessageImageURL{URL: remote, Detail: openai.ImageURLDetailLow}, 85},
+		"unknown size":  {openai.ChatMessageImageURL{URL: remote}, 85 + 4*170},
+		"one tile":      {openai.ChatMessageImageURL{URL: pngDataURL(t, 100, 100), Detail: "high"}, 85 + 170},
+		"unscaled tall": {openai.ChatMessageImageURL{URL: pngDataURL(t, 600, 1200)}, 85 + 6*170},
+		// 3000x1000 fits 2048x683, then is too small to be scaled to 768.
+		"scaled wide": {openai.ChatMessageImageURL{URL: pngDataURL(t, 3000, 1000)}, 85 + 4*2*170},
+	} {
+		imageURL := c.image
+		n, err := openai.CountMessagesTokens("test-words-model", []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleUser, MultiContent: []openai.ChatMessagePart{
+				{Type: openai.ChatMessagePartTypeImageURL, ImageURL: &imageURL},
+			}},
+		}, nil)
+		checks.NoError(t, err, "CountMessagesTokens error")
+		if n != 7+c.expected {
+			t.Errorf("%s: expected %d tokens, got %d", name, 7+c.expected, n)
+		}
+	}
+}
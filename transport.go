@@ -0,0 +1,148 @@
+package openai
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// TransportConfig tunes the connection pool of the HTTP transport used when
+// ClientConfig.HTTPClient has no Transport of its own. Go's default transport
+// keeps only 2 idle connections per host, which throttles concurrent
+// workloads such as batch embedding.
+type TransportConfig struct {
+	// MaxIdleConns limits idle connections across all hosts. Zero means no limit.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost limits idle connections kept per host.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits connections per host, including active ones. Zero means no limit.
+	MaxConnsPerHost int
+	// IdleConnTimeout closes connections idle for longer. Zero means no limit.
+	IdleConnTimeout time.Duration
+	// TLSHandshakeTimeout bounds TLS handshakes. Zero means no timeout.
+	TLSHandshakeTimeout time.Duration
+	// DialTimeout bounds establishing TCP connections.
+	DialTimeout time.Duration
+	// KeepAlive is the interval of TCP keep-alive probes.
+	KeepAlive time.Duration
+	// ForceAttemptHTTP2 enables HTTP/2 over TLS.
+	ForceAttemptHTTP2 bool
+	// Proxy routes requests through a proxy. When nil, the proxy is taken
+	// from the environment.
+	Proxy *ProxyConfig
+	// DialContext replaces the TCP dialer, e.g. with DialUnixSocket or a
+	// dialer that opens a tunnel. DialTimeout and KeepAlive are then unused.
+	DialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+	// TLSClientConfig sets client certificates or custom root CAs, e.g. for mTLS.
+	TLSClientConfig *tls.Config
+}
+
+// DefaultTransportConfig returns the TransportConfig set by DefaultConfig and
+// DefaultAzureConfig, tuned for high-throughput use.
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 100,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+		DialTimeout:         30 * time.Second,
+		KeepAlive:           30 * time.Second,
+		ForceAttemptHTTP2:   true,
+	}
+}
+
+// NewTransport returns an *http.Transport with the settings of t, for use in
+// a custom http.Client.
+func (t TransportConfig) NewTransport() *http.Transport {
+	dialContext := t.DialContext
+	if dialContext == nil {
+		dialer := &net.Dialer{
+			Timeout:   t.DialTimeout,
+			KeepAlive: t.KeepAlive,
+		}
+		dialContext = dialer.DialContext
+	}
+	proxy := http.ProxyFromEnvironment
+	if t.Proxy != nil {
+		proxy = t.Proxy.proxyFunc()
+	}
+	return &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dialContext,
+		TLSClientConfig:       t.TLSClientConfig,
+		MaxIdleConns:          t.MaxIdleConns,
+		MaxIdleConnsPerHost:   t.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       t.MaxConnsPerHost,
+		IdleConnTimeout:       t.IdleConnTimeout,
+		TLSHandshakeTimeout:   t.TLSHandshakeTimeout,
+		ExpectContinueTimeout: time.Second,
+		ForceAttemptHTTP2:     t.ForceAttemptHTTP2,
+	}
+}
+
+// DialUnixSocket returns a TransportConfig.DialContext that connects to the
+// Unix domain socket at path, whatever the address requested. It is used to
+// reach local OpenAI-compatible servers, with a BaseURL such as
+// "http://localhost/v1".
+func DialUnixSocket(path string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	var dialer net.Dialer
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "unix", path)
+	}
+}
+
+// sharedTransports holds the transports built for TransportConfigs without
+// dialer, proxy or TLS settings, keyed by config, so that clients created with
+// the same settings, e.g. one per request with DefaultConfig, share a
+// connection pool instead of each leaking one.
+var sharedTransports sync.Map
+
+// transport returns the transport for t: a shared one when t only holds
+// values, or a new one when it has a dialer, proxy or TLS settings, which
+// cannot be compared.
+func (t TransportConfig) transport() *http.Transport {
+	if t.DialContext != nil || t.Proxy != nil || t.TLSClientConfig != nil {
+		return t.NewTransport()
+	}
+	key := transportKey{
+		maxIdleConns:        t.MaxIdleConns,
+		maxIdleConnsPerHost: t.MaxIdleConnsPerHost,
+		maxConnsPerHost:     t.MaxConnsPerHost,
+		idleConnTimeout:     t.IdleConnTimeout,
+		tlsHandshakeTimeout: t.TLSHandshakeTimeout,
+		dialTimeout:         t.DialTimeout,
+		keepAlive:           t.KeepAlive,
+		forceAttemptHTTP2:   t.ForceAttemptHTTP2,
+	}
+	if transport, ok := sharedTransports.Load(key); ok {
+		return transport.(*http.Transport)
+	}
+	transport, _ := sharedTransports.LoadOrStore(key, t.NewTransport())
+	return transport.(*http.Transport)
+}
+
+// transportKey is the comparable part of a TransportConfig.
+type transportKey struct {
+	maxIdleConns, maxIdleConnsPerHost, maxConnsPerHost           int
+	idleConnTimeout, tlsHandshakeTimeout, dialTimeout, keepAlive time.Duration
+	forceAttemptHTTP2                                            bool
+}
+
+// httpClientWithTransport returns the HTTP client to use for config. A client
+// without a Transport gets the one of config.Transport, on a copy so the
+// caller's client is not modified.
+func httpClientWithTransport(config ClientConfig) *http.Client {
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{}
+	}
+	if config.HTTPClient.Transport != nil || reflect.ValueOf(config.Transport).IsZero() {
+		return config.HTTPClient
+	}
+	client := *config.HTTPClient
+	client.Transport = config.Transport.transport()
+	return &client
+}
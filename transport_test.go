@@ -0,0 +1,97 @@
+package openai //nolint:testpackage // testing private field
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDefaultConfigTransport(t *testing.T) {
+	config := DefaultConfig("token")
+	config.Transport.MaxIdleConnsPerHost = 64
+	config.Transport.IdleConnTimeout = time.Minute
+	userClient := config.HTTPClient
+
+	client := NewClientWithConfig(config)
+	transport, ok := client.config.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected an *http.Transport, got %T", client.config.HTTPClient.Transport)
+	}
+	if transport.MaxIdleConnsPerHost != 64 || transport.IdleConnTimeout != time.Minute {
+		t.Errorf("transport settings not applied: %d %s", transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+	if !transport.ForceAttemptHTTP2 || transport.TLSHandshakeTimeout != 10*time.Second {
+		t.Error("expected default settings to be kept")
+	}
+	if userClient.Transport != nil {
+		t.Error("the configured http.Client must not be modified")
+	}
+}
+
+func TestDefaultClientsShareTransport(t *testing.T) {
+	first := NewClient("token")
+	second := NewClientWithConfig(DefaultConfig("other-token"))
+	if first.config.HTTPClient.Transport == nil ||
+		first.config.HTTPClient.Transport != second.config.HTTPClient.Transport {
+		t.Error("expected clients with the same transport settings to share a transport")
+	}
+
+	config := DefaultConfig("token")
+	config.Transport.MaxIdleConnsPerHost = 8
+	if NewClientWithConfig(config).config.HTTPClient.Transport == first.config.HTTPClient.Transport {
+		t.Error("expected other transport settings to get another transport")
+	}
+}
+
+func TestCustomTransportIsKept(t *testing.T) {
+	custom := &http.Transport{}
+	config := DefaultConfig("token")
+	config.HTTPClient = &http.Client{Transport: custom}
+
+	client := NewClientWithConfig(config)
+	if client.config.HTTPClient.Transport != custom {
+		t.Error("expected the custom transport to be used")
+	}
+
+	config = DefaultConfig("token")
+	config.Transport = TransportConfig{}
+	client = NewClientWithConfig(config)
+	if client.config.HTTPClient.Transport != nil {
+		t.Error("expected the zero TransportConfig to keep http.DefaultTransport")
+	}
+}
+
+func TestDialUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "openai.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("unix sockets are not supported: %v", err)
+	}
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/v1/models" {
+				t.Errorf("unexpected path %q", r.URL.Path)
+			}
+			fmt.Fprintln(w, `{"object":"list","data":[{"id":"local-model"}]}`)
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+	go server.Serve(listener) //nolint:errcheck // returns when the server is closed
+	defer server.Close()
+
+	config := DefaultConfig("token")
+	config.BaseURL = "http://localhost/v1"
+	config.Transport.DialContext = DialUnixSocket(socket)
+
+	models, err := NewClientWithConfig(config).ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels error: %v", err)
+	}
+	if len(models.Models) != 1 || models.Models[0].ID != "local-model" {
+		t.Errorf("unexpected models %+v", models.Models)
+	}
+}
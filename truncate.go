@@ -0,0 +1,227 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai/tokenizer"
+)
+
+var (
+	// ErrTruncationBudget is returned by TruncateMessages when the messages that
+	// cannot be dropped already exceed the budget.
+	ErrTruncationBudget = errors.New("messages cannot be truncated to fit the token budget")
+	// ErrTruncationSummarizeMissing is returned when TruncateSummarize is used without a Summarize hook.
+	ErrTruncationSummarizeMissing = errors.New("TruncateSummarize requires a Summarize hook")
+)
+
+// MessageTruncation selects which messages TruncateMessages drops.
+type MessageTruncation int
+
+const (
+	// TruncateKeepSystem drops the oldest messages but keeps system and developer messages.
+	TruncateKeepSystem MessageTruncation = iota
+	// TruncateDropOldest drops the oldest messages regardless of their role.
+	TruncateDropOldest
+	// TruncateSummarize drops messages like TruncateKeepSystem and replaces them
+	// with the message returned by the Summarize hook.
+	TruncateSummarize
+)
+
+// TruncateOptions configures TruncateMessages.
+type TruncateOptions struct {
+	// Model selects the tokenizer and chat format overhead.
+	Model string
+	// MaxTokens is the prompt budget. Leave room for the completion, e.g. the
+	// context window minus MaxCompletionTokens.
+	MaxTokens int
+	// Tools are counted against the budget but never dropped.
+	Tools    []Tool
+	Strategy MessageTruncation
+	// Summarize is called by TruncateSummarize with the dropped messages, oldest
+	// first, and returns the message that replaces them. It is called once,
+	// unless the summary itself does not fit, in which case another message is
+	// dropped and Summarize is called again with all the dropped messages.
+	Summarize func(dropped []ChatCompletionMessage) (ChatCompletionMessage, error)
+	// SummaryTokens is the room TruncateSummarize reserves for the summary,
+	// including its chat format overhead. Zero defaults to
+	// DefaultSummaryTokens, capped at a quarter of MaxTokens.
+	SummaryTokens int
+}
+
+// DefaultSummaryTokens is the room reserved for the summary by
+// TruncateSummarize when TruncateOptions.SummaryTokens is zero.
+const DefaultSummaryTokens = 256
+
+// summaryReserve returns the room to leave for the summary.
+func (opts TruncateOptions) summaryReserve() int {
+	if opts.SummaryTokens > 0 {
+		return opts.SummaryTokens
+	}
+	if reserve := opts.MaxTokens / 4; reserve < DefaultSummaryTokens {
+		return reserve
+	}
+	return DefaultSummaryTokens
+}
+
+// TruncateResult is the outcome of TruncateMessages.
+type TruncateResult struct {
+	// Messages fit the budget and keep the order of the input.
+	Messages []ChatCompletionMessage
+	// Dropped holds the indexes in the input of the messages that were removed.
+	Dropped []int
+	// Summary is the message inserted by TruncateSummarize, if any.
+	Summary *ChatCompletionMessage
+	// Tokens is the prompt size of Messages.
+	Tokens int
+}
+
+// TruncateMessages trims a conversation to fit a token budget so that long
+// running chats don't overflow the context window. Messages are dropped oldest
+// first and the last message is always kept. Tool results are dropped together
+// with the assistant message that requested them, so the remaining history is
+// always accepted by the API.
+func TruncateMessages(messages []ChatCompletionMessage, opts TruncateOptions) (TruncateResult, error) {
+	if opts.Strategy == TruncateSummarize && opts.Summarize == nil {
+		return TruncateResult{}, ErrTruncationSummarizeMissing
+	}
+
+	counter, err := tokenizer.ForModel(opts.Model)
+	if err != nil {
+		counter = tokenizer.Approximate
+	}
+	count := func(msgs []ChatCompletionMessage) (int, error) {
+		return countMessagesTokens(counter, opts.Model, msgs, opts.Tools)
+	}
+
+	dropped := make([]bool, len(messages))
+	var summary *ChatCompletionMessage
+	summarize := func() error {
+		var removed []ChatCompletionMessage
+		for i, d := range dropped {
+			if d {
+				removed = append(removed, messages[i])
+			}
+		}
+		s, summarizeErr := opts.Summarize(removed)
+		if summarizeErr != nil {
+			return summarizeErr
+		}
+		summary = &s
+		return nil
+	}
+	// Until the summary is written, the messages are dropped until the kept
+	// ones leave room for it, so that it is written only once.
+	pending := func() bool {
+		return opts.Strategy == TruncateSummarize && summary == nil && len(droppedIndexes(dropped)) > 0
+	}
+
+	for {
+		kept := keptMessages(messages, dropped, summary)
+		tokens, countErr := count(kept)
+		if countErr != nil {
+			return TruncateResult{}, countErr
+		}
+		budget := opts.MaxTokens
+		if pending() {
+			budget -= opts.summaryReserve()
+		}
+		if tokens <= budget {
+			if pending() {
+				if err = summarize(); err != nil {
+					return TruncateResult{}, err
+				}
+				continue
+			}
+			return TruncateResult{
+				Messages: kept,
+				Dropped:  droppedIndexes(dropped),
+				Summary:  summary,
+				Tokens:   tokens,
+			}, nil
+		}
+
+		if !dropNext(messages, dropped, opts.Strategy) {
+			if pending() {
+				// The summary may still fit in less than the reserved room.
+				if err = summarize(); err != nil {
+					return TruncateResult{}, err
+				}
+				continue
+			}
+			return TruncateResult{}, fmt.Errorf("%w: %d tokens remain, budget is %d",
+				ErrTruncationBudget, tokens, opts.MaxTokens)
+		}
+
+		if summary != nil {
+			// The summary did not fit: summarize again with one more message.
+			if err = summarize(); err != nil {
+				return TruncateResult{}, err
+			}
+		}
+	}
+}
+
+func isSystemMessage(message ChatCompletionMessage) bool {
+	return message.Role == ChatMessageRoleSystem || message.Role == ChatMessageRoleDeveloper
+}
+
+// dropNext marks the oldest droppable message as dropped, along with the tool
+// results that answer it. It reports false when nothing else can be dropped.
+func dropNext(messages []ChatCompletionMessage, dropped []bool, strategy MessageTruncation) bool {
+	last := len(messages) - 1
+	for i := 0; i < last; i++ {
+		if dropped[i] || (strategy != TruncateDropOldest && isSystemMessage(messages[i])) {
+			continue
+		}
+		end := i
+		if len(messages[i].ToolCalls) > 0 {
+			for end < last && messages[end+1].Role == ChatMessageRoleTool {
+				end++
+			}
+		}
+		if end == last {
+			// The last message answers this tool call and must be kept with it.
+			return false
+		}
+		for j := i; j <= end; j++ {
+			dropped[j] = true
+		}
+		return true
+	}
+	return false
+}
+
+// keptMessages returns the messages not dropped, with summary inserted after
+// the leading system messages.
+func keptMessages(
+	messages []ChatCompletionMessage,
+	dropped []bool,
+	summary *ChatCompletionMessage,
+) []ChatCompletionMessage {
+	kept := make([]ChatCompletionMessage, 0, len(messages)+1)
+	inserted := summary == nil
+	for i, message := range messages {
+		if !inserted && !isSystemMessage(message) {
+			kept = append(kept, *summary)
+			inserted = true
+		}
+		if !dropped[i] {
+			kept = append(kept, message)
+		}
+	}
+	if !inserted {
+		kept = append(kept, *summary)
+	}
+	return kept
+}
+
+func droppedIndexes(dropped []bool) []int {
+	var indexes []int
+	for i, d := range dropped {
+		if d {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
@@ -0,0 +1,170 @@
+package openai_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func truncateConversation() []openai.ChatCompletionMessage {
+	return []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleSystem, Content: "be brief"},
+		{Role: openai.ChatMessageRoleUser, Content: "one two three"},
+		{Role: openai.ChatMessageRoleAssistant, ToolCalls: []openai.ToolCall{
+			{ID: "call_1", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "lookup"}},
+		}},
+		{Role: openai.ChatMessageRoleTool, ToolCallID: "call_1", Content: "result"},
+		{Role: openai.ChatMessageRoleAssistant, Content: "four five"},
+		{Role: openai.ChatMessageRoleUser, Content: "six"},
+	}
+}
+
+func TestTruncateMessagesKeepSystem(t *testing.T) {
+	registerWordCounter(t)
+	// With the word counter every message costs 3 overhead + 1 role + its words,
+	// plus 3 tokens of reply priming for the whole conversation.
+	messages := truncateConversation()
+	result, err := openai.TruncateMessages(messages, openai.TruncateOptions{
+		Model:     "test-words-model",
+		MaxTokens: 20,
+		Strategy:  openai.TruncateKeepSystem,
+	})
+	checks.NoError(t, err, "TruncateMessages error")
+
+	// The assistant tool call and its result are dropped together.
+	if !reflect.DeepEqual(result.Dropped, []int{1, 2, 3}) {
+		t.Errorf("unexpected dropped indexes: %v", result.Dropped)
+	}
+	if len(result.Messages) != 3 || result.Messages[0].Role != openai.ChatMessageRoleSystem {
+		t.Errorf("unexpected messages: %+v", result.Messages)
+	}
+	if result.Tokens > 20 {
+		t.Errorf("result exceeds budget: %d", result.Tokens)
+	}
+}
+
+func TestTruncateMessagesDropOldest(t *testing.T) {
+	registerWordCounter(t)
+	result, err := openai.TruncateMessages(truncateConversation(), openai.TruncateOptions{
+		Model:     "test-words-model",
+		MaxTokens: 10,
+		Strategy:  openai.TruncateDropOldest,
+	})
+	checks.NoError(t, err, "TruncateMessages error")
+	if len(result.Messages) != 1 || result.Messages[0].Content != "six" {
+		t.Errorf("expected only the last message to remain, got %+v", result.Messages)
+	}
+
+	_, err = openai.TruncateMessages(truncateConversation(), openai.TruncateOptions{
+		Model:     "test-words-model",
+		MaxTokens: 5,
+		Strategy:  openai.TruncateDropOldest,
+	})
+	checks.ErrorIs(t, err, openai.ErrTruncationBudget, "expected ErrTruncationBudget")
+}
+
+func TestTruncateMessagesSummarize(t *testing.T) {
+	registerWordCounter(t)
+	var calls int
+	result, err := openai.TruncateMessages(truncateConversation(), openai.TruncateOptions{
+		Model:     "test-words-model",
+		MaxTokens: 30,
+		Strategy:  openai.TruncateSummarize,
+		Summarize: func(dropped []openai.ChatCompletionMessage) (openai.ChatCompletionMessage, error) {
+			calls++
+			return openai.ChatCompletionMessage{
+				Role:    openai.ChatMessageRoleSystem,
+				Content: fmt.Sprintf("summary of %d", len(dropped)),
+			}, nil
+		},
+	})
+	checks.NoError(t, err, "TruncateMessages error")
+	if calls == 0 || result.Summary == nil {
+		t.Fatal("expected the summarize hook to be used")
+	}
+	if result.Messages[1].Content != result.Summary.Content {
+		t.Errorf("expected the summary after the system message, got %+v", result.Messages)
+	}
+	if result.Tokens > 30 {
+		t.Errorf("result exceeds budget: %d", result.Tokens)
+	}
+
+	_, err = openai.TruncateMessages(truncateConversation(), openai.TruncateOptions{
+		Strategy: openai.TruncateSummarize,
+	})
+	checks.ErrorIs(t, err, openai.ErrTruncationSummarizeMissing, "expected ErrTruncationSummarizeMissing")
+}
+
+func TestTruncateMessagesSummarizeOnce(t *testing.T) {
+	registerWordCounter(t)
+	// The system message costs 6 tokens, each user message 8 and the reply
+	// priming 3, so 2 user messages fit in 40 tokens with 10 reserved.
+	messages := []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleSystem, Content: "be brief"}}
+	for i := 0; i < 10; i++ {
+		messages = append(messages, openai.ChatCompletionMessage{
+			Role:    openai.ChatMessageRoleUser,
+			Content: fmt.Sprintf("message %d of ten", i),
+		})
+	}
+
+	summarizer := func(summary string, calls *int, lastDropped *int) func(
+		[]openai.ChatCompletionMessage) (openai.ChatCompletionMessage, error) {
+		return func(dropped []openai.ChatCompletionMessage) (openai.ChatCompletionMessage, error) {
+			*calls++
+			*lastDropped = len(dropped)
+			return openai.ChatCompletionMessage{Role: openai.ChatMessageRoleSystem, Content: summary}, nil
+		}
+	}
+
+	var calls, dropped int
+	result, err := openai.TruncateMessages(messages, openai.TruncateOptions{
+		Model:         "test-words-model",
+		MaxTokens:     40,
+		SummaryTokens: 10,
+		Strategy:      openai.TruncateSummarize,
+		Summarize:     summarizer("summary", &calls, &dropped),
+	})
+	checks.NoError(t, err, "TruncateMessages error")
+	if calls != 1 || dropped != 8 {
+		t.Errorf("expected one summary of 8 messages, got %d calls, last with %d messages", calls, dropped)
+	}
+	if len(result.Messages) != 4 || result.Tokens > 40 {
+		t.Errorf("unexpected result: %d messages, %d tokens", len(result.Messages), result.Tokens)
+	}
+
+	// A summary of 19 tokens overflows the reserved room, so one more message
+	// is dropped and the summary is written again.
+	calls, dropped = 0, 0
+	long := strings.Repeat("word ", 15)
+	result, err = openai.TruncateMessages(messages, openai.TruncateOptions{
+		Model:         "test-words-model",
+		MaxTokens:     40,
+		SummaryTokens: 10,
+		Strategy:      openai.TruncateSummarize,
+		Summarize:     summarizer(long, &calls, &dropped),
+	})
+	checks.NoError(t, err, "TruncateMessages error")
+	if calls != 2 || dropped != 9 {
+		t.Errorf("expected two summaries, the last of 9 messages, got %d calls, last with %d messages", calls, dropped)
+	}
+	if result.Tokens > 40 {
+		t.Errorf("result exceeds budget: %d", result.Tokens)
+	}
+}
+
+func TestTruncateMessagesWithinBudget(t *testing.T) {
+	registerWordCounter(t)
+	messages := truncateConversation()
+	result, err := openai.TruncateMessages(messages, openai.TruncateOptions{
+		Model:     "test-words-model",
+		MaxTokens: 1000,
+	})
+	checks.NoError(t, err, "TruncateMessages error")
+	if len(result.Dropped) != 0 || len(result.Messages) != len(messages) {
+		t.Errorf("expected nothing to be dropped, got %v", result.Dropped)
+	}
+}
@@ -0,0 +1,59 @@
+package openai
+
+import "context"
+
+// usageReporter is implemented by the responses that report token usage.
+type usageReporter interface {
+	// reportedUsage returns the model that served the request and its usage,
+	// or nil when the response has none.
+	reportedUsage() (model string, usage *Usage)
+}
+
+func (r *ChatCompletionResponse) reportedUsage() (string, *Usage) {
+	return r.Model, &r.Usage
+}
+
+func (r *ChatCompletionStreamResponse) reportedUsage() (string, *Usage) {
+	return r.Model, r.Usage
+}
+
+func (r *CompletionResponse) reportedUsage() (string, *Usage) {
+	return r.Model, &r.Usage
+}
+
+func (r *EmbeddingResponse) reportedUsage() (string, *Usage) {
+	return string(r.Model), &r.Usage
+}
+
+func (r *EmbeddingResponseBase64) reportedUsage() (string, *Usage) {
+	return string(r.Model), &r.Usage
+}
+
+func (r *ModelResponse) reportedUsage() (string, *Usage) {
+	if r.Usage == nil {
+		return r.Model, nil
+	}
+	return r.Model, &Usage{
+		PromptTokens:            r.Usage.InputTokens,
+		CompletionTokens:        r.Usage.OutputTokens,
+		TotalTokens:             r.Usage.TotalTokens,
+		PromptTokensDetails:     r.Usage.InputTokensDetails,
+		CompletionTokensDetails: r.Usage.OutputTokensDetails,
+	}
+}
+
+// reportUsage calls ClientConfig.OnUsage with the usage reported by response.
+func (c *Client) reportUsage(ctx context.Context, response any) {
+	if c.config.OnUsage == nil {
+		return
+	}
+	reporter, ok := response.(usageReporter)
+	if !ok {
+		return
+	}
+	model, usage := reporter.reportedUsage()
+	if usage == nil || *usage == (Usage{}) {
+		return
+	}
+	c.config.OnUsage(ctx, model, *usage)
+}
@@ -0,0 +1,73 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+type tenantKey struct{}
+
+func TestOnUsage(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.ChatCompletionRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "decode request")
+		if !request.Stream {
+			fmt.Fprint(w, `{"model":"gpt-4o-2024-08-06","usage":{"prompt_tokens":3,"completion_tokens":4,"total_tokens":7}}`)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"model":"gpt-4o-2024-08-06","choices":[{"index":0,"delta":{"content":"Hi"}}]}`+"\n\n")
+		fmt.Fprint(w, `data: {"model":"gpt-4o-2024-08-06","choices":[],`+
+			`"usage":{"prompt_tokens":5,"completion_tokens":6,"total_tokens":11}}`+"\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	type report struct {
+		tenant string
+		model  string
+		total  int
+	}
+	var reports []report
+	config := openai.DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.OnUsage = func(ctx context.Context, model string, usage openai.Usage) {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		reports = append(reports, report{tenant, model, usage.TotalTokens})
+	}
+	client := openai.NewClientWithConfig(config)
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	_, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{Model: openai.GPT4o})
+	checks.NoError(t, err, "CreateChatCompletion error")
+
+	stream, err := client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
+		Model:         openai.GPT4o,
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
+	})
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	defer stream.Close()
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("unexpected stream error: %v", err)
+	}
+
+	expected := []report{{"acme", "gpt-4o-2024-08-06", 7}, {"acme", "gpt-4o-2024-08-06", 11}}
+	if len(reports) != len(expected) || reports[0] != expected[0] || reports[1] != expected[1] {
+		t.Errorf("reports %+v, want %+v", reports, expected)
+	}
+}
@@ -4,21 +4,31 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
 )
 
 const (
-	vectorSuffix      = "/vector_stores"
-	vectorFilesSuffix = "/files"
+	vectorSuffix            = "/vector_stores"
+	vectorFilesSuffix       = "/files"
+	vectorFileBatchesSuffix = "/file_batches"
+)
+
+// VectorStatus is the status of a vector store.
+type VectorStatus string
+
+const (
+	VectorStatusExpired    VectorStatus = "expired"
+	VectorStatusInProgress VectorStatus = "in_progress"
+	VectorStatusCompleted  VectorStatus = "completed"
 )
 
 type Vector struct {
-	ID         string      `json:"id"`
-	Object     string      `json:"object"`
-	CreatedAt  int64       `json:"created_at"`
-	Name       *string     `json:"name,omitempty"`
-	Bytes      int64       `json:"bytes"`
-	FileCounts *FileCounts `json:"file_counts,omitempty"`
+	ID         string       `json:"id"`
+	Object     string       `json:"object"`
+	CreatedAt  int64        `json:"created_at"`
+	Name       *string      `json:"name,omitempty"`
+	Bytes      int64        `json:"bytes"`
+	Status     VectorStatus `json:"status,omitempty"`
+	FileCounts *FileCounts  `json:"file_counts,omitempty"`
 	httpHeader
 }
 
@@ -33,18 +43,22 @@ type FileCounts struct {
 type VectorRequest struct {
 	Name    *string   `json:"name,omitempty"`
 	FileIDs *[]string `json:"file_ids,omitempty"`
+	// ExtraBody holds fields merged into the request JSON, for parameters this
+	// client does not model yet or vendor extensions.
+	ExtraBody map[string]any `json:"-"`
+	// ClearFields lists JSON field names, e.g. "name", that are sent as null so
+	// that ModifyVector clears them.
+	ClearFields []string `json:"-"`
 }
 
-// MarshalJSON provides a custom marshaller for the assistant request to handle the API use cases
-// If Tools is nil, the field is omitted from the JSON.
-// If Tools is an empty slice, it's included in the JSON as an empty array ([]).
-// If Tools is populated, it's included in the JSON with the elements.
-/*
 func (a VectorRequest) MarshalJSON() ([]byte, error) {
+	return marshalWithExtraBody(standardJSON{}, a)
+}
 
-	return json.Marshal(a)
+func (a VectorRequest) splitExtraBody() (any, map[string]any) {
+	type Alias VectorRequest
+	return Alias(a), withClearedFields(a.ExtraBody, a.ClearFields)
 }
-*/
 
 // AssistantsList is a list of assistants.
 type VectorList struct {
@@ -81,14 +95,38 @@ type VectorFileRequest struct {
 
 type VectorFilesList struct {
 	VectorFiles []VectorFile `json:"data"`
+	FirstID     *string      `json:"first_id"`
+	LastID      *string      `json:"last_id"`
+	HasMore     bool         `json:"has_more"`
+
+	httpHeader
+}
+
+// VectorFileBatch is a batch of files being added to a vector store. Its
+// Status is "in_progress" until every file is processed.
+type VectorFileBatch struct {
+	ID            string     `json:"id"`
+	Object        string     `json:"object"`
+	CreatedAt     int64      `json:"created_at"`
+	VectorStoreID string     `json:"vector_store_id"`
+	Status        string     `json:"status"`
+	FileCounts    FileCounts `json:"file_counts"`
 
 	httpHeader
 }
 
+type VectorFileBatchRequest struct {
+	FileIDs []string `json:"file_ids"`
+}
+
 // CreateVector creates a new vector.
-func (c *Client) CreateVector(ctx context.Context, request VectorRequest) (response Vector, err error) {
+func (c *Client) CreateVector(
+	ctx context.Context,
+	request VectorRequest,
+	opts ...RequestOption,
+) (response Vector, err error) {
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(vectorSuffix), withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -101,10 +139,11 @@ func (c *Client) CreateVector(ctx context.Context, request VectorRequest) (respo
 func (c *Client) RetrieveVector(
 	ctx context.Context,
 	vectorID string,
+	opts ...RequestOption,
 ) (response Vector, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s", vectorSuffix, vectorID)
 	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -118,10 +157,11 @@ func (c *Client) ModifyVector(
 	ctx context.Context,
 	vectorID string,
 	request VectorRequest,
+	opts ...RequestOption,
 ) (response Vector, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s", vectorSuffix, vectorID)
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -134,10 +174,11 @@ func (c *Client) ModifyVector(
 func (c *Client) DeleteVector(
 	ctx context.Context,
 	vectorID string,
+	opts ...RequestOption,
 ) (response VectorDeleteResponse, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s", vectorSuffix, vectorID)
 	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -146,41 +187,63 @@ func (c *Client) DeleteVector(
 	return
 }
 
-// ListVectors Lists the currently available assistants.
+// ListVectors Lists the currently available vector stores.
+//
+// Deprecated: use ListVectorsWithOptions.
 func (c *Client) ListVectors(
 	ctx context.Context,
 	limit *int,
-	order *string,
+	order *SortOrder,
 	after *string,
 	before *string,
+	opts ...RequestOption,
 ) (response VectorList, err error) {
-	urlValues := url.Values{}
-	if limit != nil {
-		urlValues.Add("limit", fmt.Sprintf("%d", *limit))
-	}
 	if order != nil {
-		urlValues.Add("order", *order)
-	}
-	if after != nil {
-		urlValues.Add("after", *after)
-	}
-	if before != nil {
-		urlValues.Add("before", *before)
+		opts = append([]RequestOption{WithOrder(*order)}, opts...)
 	}
+	return c.ListVectorsWithOptions(ctx, paginationOptions(limit, nil, after, before, opts)...)
+}
 
-	encodedValues := ""
-	if len(urlValues) > 0 {
-		encodedValues = "?" + urlValues.Encode()
+// WithVectorStatus makes ListVectorsWithOptions return only the vector stores
+// with status. The API has no such filter, so the stores of each page are
+// filtered after listing: pages may hold fewer stores than the limit, and are
+// followed with HasMore and LastID as usual. Unknown statuses fail the call
+// with ErrInvalidListOption.
+func WithVectorStatus(status VectorStatus) ListOption {
+	switch status {
+	case VectorStatusExpired, VectorStatusInProgress, VectorStatusCompleted:
+		return func(args *requestOptions) {
+			args.vectorStatus = status
+		}
 	}
+	return withOptionError(fmt.Errorf("%w: vector store status %q", ErrInvalidListOption, status))
+}
 
-	urlSuffix := fmt.Sprintf("%s%s", vectorSuffix, encodedValues)
-	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+// ListVectorsWithOptions lists the currently available vector stores. Pages
+// are selected with ListOptions such as WithLimit and WithAfter, and stores
+// are filtered by status with WithVectorStatus.
+func (c *Client) ListVectorsWithOptions(
+	ctx context.Context,
+	opts ...RequestOption,
+) (response VectorList, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(vectorSuffix),
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
 
-	err = c.sendRequest(req, &response)
+	if err = c.sendRequest(req, &response); err != nil {
+		return
+	}
+	if status := newRequestOptions(opts).vectorStatus; status != "" {
+		vectors := response.Vectors[:0]
+		for _, vector := range response.Vectors {
+			if vector.Status == status {
+				vectors = append(vectors, vector)
+			}
+		}
+		response.Vectors = vectors
+	}
 	return
 }
 
@@ -189,11 +252,12 @@ func (c *Client) CreateVectorFile(
 	ctx context.Context,
 	vectorID string,
 	request VectorFileRequest,
+	opts ...RequestOption,
 ) (response VectorFile, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s%s", vectorSuffix, vectorID, vectorFilesSuffix)
 	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix),
 		withBody(request),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -207,10 +271,11 @@ func (c *Client) RetrieveVectorFile(
 	ctx context.Context,
 	vectorId string,
 	fileID string,
+	opts ...RequestOption,
 ) (response AssistantFile, err error) {
 	urlSuffix := fmt.Sprintf("%s/%s%s/%s", vectorSuffix, vectorId, vectorFilesSuffix, fileID)
 	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -224,10 +289,11 @@ func (c *Client) DeleteVectorFile(
 	ctx context.Context,
 	vectorID string,
 	fileID string,
+	opts ...RequestOption,
 ) (err error) {
 	urlSuffix := fmt.Sprintf("%s/%s%s/%s", vectorSuffix, vectorID, vectorFilesSuffix, fileID)
 	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
@@ -236,37 +302,72 @@ func (c *Client) DeleteVectorFile(
 	return
 }
 
-// ListAssistantFiles Lists the currently available files for an assistant.
+// ListVectrFiles Lists the files in a vector store.
+//
+// Deprecated: use ListVectorFilesWithOptions.
 func (c *Client) ListVectrFiles(
 	ctx context.Context,
 	vectorID string,
 	limit *int,
-	order *string,
+	order *SortOrder,
 	after *string,
 	before *string,
+	opts ...RequestOption,
 ) (response VectorFilesList, err error) {
-	urlValues := url.Values{}
-	if limit != nil {
-		urlValues.Add("limit", fmt.Sprintf("%d", *limit))
-	}
 	if order != nil {
-		urlValues.Add("order", *order)
+		opts = append([]RequestOption{WithOrder(*order)}, opts...)
 	}
-	if after != nil {
-		urlValues.Add("after", *after)
-	}
-	if before != nil {
-		urlValues.Add("before", *before)
+	return c.ListVectorFilesWithOptions(ctx, vectorID, paginationOptions(limit, nil, after, before, opts)...)
+}
+
+// ListVectorFilesWithOptions lists the files in a vector store. Pages are
+// selected with ListOptions such as WithLimit and WithAfter.
+func (c *Client) ListVectorFilesWithOptions(
+	ctx context.Context,
+	vectorID string,
+	opts ...RequestOption,
+) (response VectorFilesList, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s%s", vectorSuffix, vectorID, vectorFilesSuffix)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
+	if err != nil {
+		return
 	}
 
-	encodedValues := ""
-	if len(urlValues) > 0 {
-		encodedValues = "?" + urlValues.Encode()
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// CreateVectorFileBatch adds files to a vector store in a single batch.
+func (c *Client) CreateVectorFileBatch(
+	ctx context.Context,
+	vectorID string,
+	request VectorFileBatchRequest,
+	opts ...RequestOption,
+) (response VectorFileBatch, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s%s", vectorSuffix, vectorID, vectorFileBatchesSuffix)
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix),
+		withBody(request),
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
+	if err != nil {
+		return
 	}
 
-	urlSuffix := fmt.Sprintf("%s/%s%s%s", vectorSuffix, vectorID, vectorFilesSuffix, encodedValues)
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// RetrieveVectorFileBatch retrieves a file batch, e.g. to check whether its
+// files are processed.
+func (c *Client) RetrieveVectorFileBatch(
+	ctx context.Context,
+	vectorID string,
+	batchID string,
+	opts ...RequestOption,
+) (response VectorFileBatch, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s%s/%s", vectorSuffix, vectorID, vectorFileBatchesSuffix, batchID)
 	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix),
-		withBetaAssistantVersion(c.config.AssistantVersion))
+		withBetaAssistantVersion(c.config.AssistantVersion), withRequestOptions(opts))
 	if err != nil {
 		return
 	}
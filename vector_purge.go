@@ -0,0 +1,103 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const defaultPurgeConcurrency = 4
+
+// VectorPurgeOptions configures PurgeVectorStoreFiles.
+type VectorPurgeOptions struct {
+	// DeleteFiles also deletes the File objects of the detached files, which
+	// are otherwise kept and still count towards the storage of the
+	// organization.
+	DeleteFiles bool
+	// Concurrency is the maximum number of requests in flight. Defaults to 4.
+	Concurrency int
+}
+
+// VectorFilePurgeResult is the outcome of the purge of one file.
+type VectorFilePurgeResult struct {
+	FileID string
+	// Detached reports whether the file was removed from the vector store.
+	Detached bool
+	// Deleted reports whether the File object was deleted, with DeleteFiles.
+	Deleted bool
+	Err     error
+}
+
+// PurgeVectorStoreFiles detaches all the files of a vector store, and deletes
+// them with options.DeleteFiles, concurrently. The files are listed before any
+// of them is detached, so files added during the purge are kept. A file that
+// fails to be purged does not stop the others: the results report every file
+// and the returned error, set when the store could not be listed or some
+// files were not purged, wraps the first failure.
+func (c *Client) PurgeVectorStoreFiles(
+	ctx context.Context,
+	vectorID string,
+	options VectorPurgeOptions,
+	opts ...RequestOption,
+) ([]VectorFilePurgeResult, error) {
+	if options.Concurrency <= 0 {
+		options.Concurrency = defaultPurgeConcurrency
+	}
+
+	var results []VectorFilePurgeResult
+	files := newIterator(func(after string) (Page[VectorFile], error) {
+		list, err := c.ListVectorFilesWithOptions(ctx, vectorID, pageOptions(opts, after)...)
+		if err != nil {
+			return Page[VectorFile]{}, err
+		}
+		return newPage(list.VectorFiles, list.HasMore, func(file VectorFile) string { return file.ID }), nil
+	})
+	for files.Next() {
+		results = append(results, VectorFilePurgeResult{FileID: files.Current().ID})
+	}
+	if err := files.Err(); err != nil {
+		return results, err
+	}
+
+	var (
+		wg    sync.WaitGroup
+		slots = make(chan struct{}, options.Concurrency)
+	)
+	for i := range results {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(result *VectorFilePurgeResult) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			if result.Err = c.DeleteVectorFile(ctx, vectorID, result.FileID, opts...); result.Err != nil {
+				return
+			}
+			result.Detached = true
+			if options.DeleteFiles {
+				if result.Err = c.DeleteFile(ctx, result.FileID, opts...); result.Err == nil {
+					result.Deleted = true
+				}
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+
+	var (
+		failed   int
+		firstErr error
+	)
+	for _, result := range results {
+		if result.Err != nil {
+			if firstErr == nil {
+				firstErr = result.Err
+			}
+			failed++
+		}
+	}
+	if firstErr != nil {
+		return results, fmt.Errorf("%d of %d files not purged: %w", failed, len(results), firstErr)
+	}
+	return results, nil
+}
@@ -0,0 +1,79 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func TestPurgeVectorStoreFiles(t *testing.T) {
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	var (
+		mu       sync.Mutex
+		detached []string
+		deleted  []string
+	)
+	server.RegisterHandler("/v1/vector_stores/vs_abc123/files", func(w http.ResponseWriter, r *http.Request) {
+		pages := map[string]string{
+			"":       `{"data":[{"id":"file-1"},{"id":"file-2"}],"has_more":true}`,
+			"file-2": `{"data":[{"id":"file-3"},{"id":"file-4"}],"has_more":true}`,
+			"file-4": `{"data":[{"id":"file-5"}],"has_more":false}`,
+		}
+		fmt.Fprint(w, pages[r.URL.Query().Get("after")])
+	})
+	server.RegisterHandler("/v1/vector_stores/vs_abc123/files/*", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+		fileID := path.Base(r.URL.Path)
+		if fileID == "file-3" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"message":"No such file","type":"invalid_request_error"}}`)
+			return
+		}
+		mu.Lock()
+		detached = append(detached, fileID)
+		mu.Unlock()
+		fmt.Fprintf(w, `{"id":%q,"object":"vector_store.file.deleted","deleted":true}`, fileID)
+	})
+	server.RegisterHandler("/v1/files/*", func(w http.ResponseWriter, r *http.Request) {
+		fileID := path.Base(r.URL.Path)
+		mu.Lock()
+		deleted = append(deleted, fileID)
+		mu.Unlock()
+		fmt.Fprintf(w, `{"id":%q,"object":"file","deleted":true}`, fileID)
+	})
+
+	results, err := client.PurgeVectorStoreFiles(context.Background(), "vs_abc123",
+		openai.VectorPurgeOptions{DeleteFiles: true, Concurrency: 2})
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || !strings.HasPrefix(err.Error(), "1 of 5 files not purged") {
+		t.Fatalf("expected file-3 to fail with an APIError, got %v", err)
+	}
+	if len(results) != 5 {
+		t.Fatalf("expected 5 results, got %+v", results)
+	}
+	for _, result := range results {
+		failed := result.FileID == "file-3"
+		if (result.Err != nil) != failed || result.Detached == failed || result.Deleted == failed {
+			t.Errorf("unexpected result %+v", result)
+		}
+	}
+
+	sort.Strings(detached)
+	sort.Strings(deleted)
+	const expected = "[file-1 file-2 file-4 file-5]"
+	if fmt.Sprint(detached) != expected || fmt.Sprint(deleted) != expected {
+		t.Errorf("expected %s to be detached and deleted, got %v and %v", expected, detached, deleted)
+	}
+}
@@ -0,0 +1,26 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestVectorRequestClearFields(t *testing.T) {
+	data, err := json.Marshal(openai.VectorRequest{
+		ClearFields: []string{"name"},
+	})
+	checks.NoError(t, err)
+
+	if string(data) != `{"name":null}` {
+		t.Errorf("expected name to be cleared, got %s", data)
+	}
+
+	data, err = json.Marshal(openai.VectorRequest{Name: openai.String("docs")})
+	checks.NoError(t, err)
+	if string(data) != `{"name":"docs"}` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+}
@@ -0,0 +1,205 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultUploadConcurrency = 4
+	// MaxUploadFileSize is the largest file accepted by the files API.
+	MaxUploadFileSize = 512 << 20
+	// MaxVectorFileBatchSize is the largest number of files in a file batch.
+	MaxVectorFileBatchSize = 500
+)
+
+// ErrUploadFileTooLarge is reported by UploadDirectoryToVectorStore for files
+// larger than DirectoryUploadOptions.MaxFileSize, which are not uploaded.
+var ErrUploadFileTooLarge = errors.New("file is too large to upload")
+
+// DirectoryUploadOptions configures UploadDirectoryToVectorStore.
+type DirectoryUploadOptions struct {
+	// Patterns are filepath.Match patterns, e.g. "docs/*" or "*.md", matched
+	// against the path of the files relative to the directory and against
+	// their name. Files matching none of them are ignored. Defaults to all
+	// files.
+	Patterns []string
+	// Extensions, e.g. ".md", restrict the upload to files with one of these
+	// extensions, ignoring case. Defaults to any extension.
+	Extensions []string
+	// MaxFileSize is the size of the largest file uploaded, in bytes. Defaults
+	// to MaxUploadFileSize.
+	MaxFileSize int64
+	// Concurrency is the maximum number of uploads in flight. Defaults to 4.
+	Concurrency int
+}
+
+// FileUploadResult is the outcome of the upload of one file.
+type FileUploadResult struct {
+	// Path is the path of the file relative to the directory.
+	Path string
+	// FileID is the ID of the uploaded file, empty when Err is set.
+	FileID string
+	Err    error
+}
+
+// DirectoryUploadResult is the outcome of UploadDirectoryToVectorStore.
+type DirectoryUploadResult struct {
+	// Files lists the files selected by the options, in lexical order.
+	Files []FileUploadResult
+	// Batches are the file batches created with the uploaded files, of up to
+	// MaxVectorFileBatchSize files each, in the order of Files. It is empty
+	// when no file was uploaded.
+	Batches []VectorFileBatch
+}
+
+// Failed returns the files that were not uploaded.
+func (r DirectoryUploadResult) Failed() []FileUploadResult {
+	var failed []FileUploadResult
+	for _, file := range r.Files {
+		if file.Err != nil {
+			failed = append(failed, file)
+		}
+	}
+	return failed
+}
+
+// UploadDirectoryToVectorStore uploads the files of dir and its
+// subdirectories selected by options, concurrently, with the assistants
+// purpose, then adds the uploaded files to the vector store in file batches of
+// up to MaxVectorFileBatchSize files. The files that fail to upload, or are
+// too large, are reported in the result and do not fail the call. The returned
+// error is set when dir cannot be walked, a pattern is malformed, or a batch
+// cannot be created, in which case the batches created before it are kept in
+// the result. The batches are processed asynchronously; use
+// RetrieveVectorFileBatch to follow them.
+func (c *Client) UploadDirectoryToVectorStore(
+	ctx context.Context,
+	vectorID string,
+	dir string,
+	options DirectoryUploadOptions,
+	opts ...RequestOption,
+) (result DirectoryUploadResult, err error) {
+	if options.MaxFileSize <= 0 {
+		options.MaxFileSize = MaxUploadFileSize
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = defaultUploadConcurrency
+	}
+	for _, pattern := range options.Patterns {
+		if _, err = filepath.Match(pattern, ""); err != nil {
+			return result, fmt.Errorf("pattern %q: %w", pattern, err)
+		}
+	}
+
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		rel, relErr := filepath.Rel(dir, path)
+		if relErr != nil {
+			return relErr
+		}
+		if !options.selects(rel) {
+			return nil
+		}
+		file := FileUploadResult{Path: rel}
+		info, infoErr := entry.Info()
+		switch {
+		case infoErr != nil:
+			file.Err = infoErr
+		case info.Size() > options.MaxFileSize:
+			file.Err = fmt.Errorf("%w: %d bytes, the limit is %d", ErrUploadFileTooLarge, info.Size(), options.MaxFileSize)
+		}
+		result.Files = append(result.Files, file)
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	var (
+		wg    sync.WaitGroup
+		slots = make(chan struct{}, options.Concurrency)
+	)
+	for i := range result.Files {
+		if result.Files[i].Err != nil {
+			continue
+		}
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(file *FileUploadResult) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			uploaded, uploadErr := c.CreateFile(ctx, FileRequest{
+				FilePath: filepath.Join(dir, file.Path),
+				Purpose:  string(PurposeAssistants),
+			}, opts...)
+			file.FileID, file.Err = uploaded.ID, uploadErr
+		}(&result.Files[i])
+	}
+	wg.Wait()
+
+	var fileIDs []string
+	for _, file := range result.Files {
+		if file.Err == nil {
+			fileIDs = append(fileIDs, file.FileID)
+		}
+	}
+	if len(fileIDs) == 0 {
+		return result, ctx.Err()
+	}
+	for len(fileIDs) > 0 {
+		n := len(fileIDs)
+		if n > MaxVectorFileBatchSize {
+			n = MaxVectorFileBatchSize
+		}
+		batch, batchErr := c.CreateVectorFileBatch(ctx, vectorID, VectorFileBatchRequest{FileIDs: fileIDs[:n]}, opts...)
+		if batchErr != nil {
+			return result, batchErr
+		}
+		result.Batches = append(result.Batches, batch)
+		fileIDs = fileIDs[n:]
+	}
+	return result, nil
+}
+
+// selects reports whether the file at path, relative to the directory, is
+// selected by the patterns and extensions of o.
+func (o DirectoryUploadOptions) selects(path string) bool {
+	if len(o.Extensions) > 0 {
+		ext := filepath.Ext(path)
+		found := false
+		for _, extension := range o.Extensions {
+			if strings.EqualFold(ext, extension) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(o.Patterns) == 0 {
+		return true
+	}
+	for _, pattern := range o.Patterns {
+		if matched, _ := filepath.Match(pattern, path); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(pattern, filepath.Base(path)); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func TestUploadDirectoryToVectorStore(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"guide.md":           "# Guide",
+		"notes.TXT":          "notes",
+		"image.png":          "png",
+		"large.md":           "# A document too large to upload",
+		"broken.md":          "# Rejected",
+		"api/reference.md":   "# Reference",
+		"drafts/wip.md":      "# Draft",
+		"drafts/ignored.png": "png",
+	} {
+		path := filepath.Join(dir, name)
+		checks.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755), "MkdirAll error")
+		checks.NoError(t, os.WriteFile(path, []byte(content), 0o600), "WriteFile error")
+	}
+
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, r *http.Request) {
+		_, header, err := r.FormFile("file")
+		checks.NoError(t, err, "FormFile error")
+		if r.FormValue("purpose") != string(openai.PurposeAssistants) {
+			t.Errorf("unexpected purpose %q", r.FormValue("purpose"))
+		}
+		if header.Filename == "broken.md" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"message":"unsupported file","type":"invalid_request_error"}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"id":"file-%s","filename":%q}`, header.Filename, header.Filename)
+	})
+	var batchFileIDs []string
+	server.RegisterHandler("/v1/vector_stores/vs_abc123/file_batches", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.VectorFileBatchRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+		batchFileIDs = request.FileIDs
+		fmt.Fprintf(w, `{"id":"vsfb_abc123","vector_store_id":"vs_abc123","status":"in_progress",`+
+			`"file_counts":{"in_progress":%d,"total":%d}}`, len(request.FileIDs), len(request.FileIDs))
+	})
+
+	result, err := client.UploadDirectoryToVectorStore(context.Background(), "vs_abc123", dir,
+		openai.DirectoryUploadOptions{
+			Extensions:  []string{".md", ".txt"},
+			Patterns:    []string{"*.md", "notes.*"},
+			MaxFileSize: 20,
+			Concurrency: 2,
+		})
+	checks.NoError(t, err, "UploadDirectoryToVectorStore error")
+
+	uploaded := map[string]string{}
+	for _, file := range result.Files {
+		if file.Err == nil {
+			uploaded[file.Path] = file.FileID
+		}
+	}
+	expected := map[string]string{
+		"guide.md":                           "file-guide.md",
+		"notes.TXT":                          "file-notes.TXT",
+		filepath.Join("api", "reference.md"): "file-reference.md",
+		filepath.Join("drafts", "wip.md"):    "file-wip.md",
+	}
+	if fmt.Sprint(uploaded) != fmt.Sprint(expected) {
+		t.Errorf("expected the uploads %v, got %v", expected, uploaded)
+	}
+
+	failed := result.Failed()
+	if len(failed) != 2 || failed[0].Path != "broken.md" || failed[1].Path != "large.md" {
+		t.Fatalf("expected broken.md and large.md to fail, got %+v", failed)
+	}
+	var apiErr *openai.APIError
+	if !errors.As(failed[0].Err, &apiErr) {
+		t.Errorf("expected an APIError for broken.md, got %v", failed[0].Err)
+	}
+	checks.ErrorIs(t, failed[1].Err, openai.ErrUploadFileTooLarge)
+
+	if len(result.Batches) != 1 || result.Batches[0].ID != "vsfb_abc123" ||
+		result.Batches[0].FileCounts.Total != 4 || len(batchFileIDs) != 4 {
+		t.Errorf("expected a batch of the 4 uploaded files, got %+v with %v", result.Batches, batchFileIDs)
+	}
+}
+
+func TestUploadDirectoryToVectorStoreBatches(t *testing.T) {
+	dir := t.TempDir()
+	const files = openai.MaxVectorFileBatchSize + 1
+	for i := 0; i < files; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("%03d.md", i))
+		checks.NoError(t, os.WriteFile(path, []byte("#"), 0o600), "WriteFile error")
+	}
+
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, r *http.Request) {
+		_, header, err := r.FormFile("file")
+		checks.NoError(t, err, "FormFile error")
+		fmt.Fprintf(w, `{"id":"file-%s"}`, header.Filename)
+	})
+	var batchSizes []int
+	server.RegisterHandler("/v1/vector_stores/vs_abc123/file_batches", func(w http.ResponseWriter, r *http.Request) {
+		var request openai.VectorFileBatchRequest
+		checks.NoError(t, json.NewDecoder(r.Body).Decode(&request), "Decode error")
+		batchSizes = append(batchSizes, len(request.FileIDs))
+		fmt.Fprintf(w, `{"id":"vsfb_%d","file_counts":{"total":%d}}`, len(batchSizes), len(request.FileIDs))
+	})
+
+	result, err := client.UploadDirectoryToVectorStore(context.Background(), "vs_abc123", dir,
+		openai.DirectoryUploadOptions{Concurrency: 16})
+	checks.NoError(t, err, "UploadDirectoryToVectorStore error")
+	if fmt.Sprint(batchSizes) != "[500 1]" || len(result.Batches) != 2 || result.Batches[1].ID != "vsfb_2" {
+		t.Errorf("expected batches of 500 and 1 files, got %v and %+v", batchSizes, result.Batches)
+	}
+}
+
+func TestUploadDirectoryToVectorStoreBadPattern(t *testing.T) {
+	client := openai.NewClient(test.GetTestToken())
+	_, err := client.UploadDirectoryToVectorStore(context.Background(), "vs_abc123", t.TempDir(),
+		openai.DirectoryUploadOptions{Patterns: []string{"["}})
+	checks.ErrorIs(t, err, filepath.ErrBadPattern)
+}
@@ -0,0 +1,252 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const (
+	videosSuffix = "/videos"
+)
+
+// Video generation models.
+const (
+	Sora2    = "sora-2"
+	Sora2Pro = "sora-2-pro"
+)
+
+// Video sizes.
+const (
+	VideoSize720x1280  = "720x1280"
+	VideoSize1280x720  = "1280x720"
+	VideoSize1024x1792 = "1024x1792"
+	VideoSize1792x1024 = "1792x1024"
+)
+
+// VideoStatus is the lifecycle state of a video generation job.
+type VideoStatus string
+
+const (
+	VideoStatusQueued     VideoStatus = "queued"
+	VideoStatusInProgress VideoStatus = "in_progress"
+	VideoStatusCompleted  VideoStatus = "completed"
+	VideoStatusFailed     VideoStatus = "failed"
+)
+
+// VideoContentVariant selects which asset GetVideoContent downloads.
+type VideoContentVariant string
+
+const (
+	VideoContentVariantVideo       VideoContentVariant = "video"
+	VideoContentVariantThumbnail   VideoContentVariant = "thumbnail"
+	VideoContentVariantSpritesheet VideoContentVariant = "spritesheet"
+)
+
+// Video represents a video generation job.
+type Video struct {
+	ID                 string      `json:"id"`
+	Object             string      `json:"object"`
+	Model              string      `json:"model"`
+	Status             VideoStatus `json:"status"`
+	Progress           int         `json:"progress"`
+	Prompt             string      `json:"prompt,omitempty"`
+	Seconds            string      `json:"seconds"`
+	Size               string      `json:"size"`
+	CreatedAt          int64       `json:"created_at"`
+	CompletedAt        *int64      `json:"completed_at,omitempty"`
+	ExpiresAt          *int64      `json:"expires_at,omitempty"`
+	RemixedFromVideoID *string     `json:"remixed_from_video_id,omitempty"`
+	Error              *VideoError `json:"error,omitempty"`
+
+	httpHeader
+}
+
+// VideoError describes why a video generation job failed.
+type VideoError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// IsDone reports whether the job has reached a terminal state.
+func (v Video) IsDone() bool {
+	return v.Status == VideoStatusCompleted || v.Status == VideoStatusFailed
+}
+
+// VideoRequest represents a request to create a video generation job.
+// Seconds is a string as the API only accepts a fixed set of durations ("4", "8", "12").
+// InputReference is an optional image used as the first frame of the video.
+type VideoRequest struct {
+	Prompt  string `json:"prompt"`
+	Model   string `json:"model,omitempty"`
+	Seconds string `json:"seconds,omitempty"`
+	Size    string `json:"size,omitempty"`
+
+	InputReference     io.Reader `json:"-"`
+	InputReferenceName string    `json:"-"`
+}
+
+type VideoRemixRequest struct {
+	Prompt string `json:"prompt"`
+}
+
+// VideosList is a list of video generation jobs.
+type VideosList struct {
+	Object  string  `json:"object"`
+	Videos  []Video `json:"data"`
+	FirstID *string `json:"first_id"`
+	LastID  *string `json:"last_id"`
+	HasMore bool    `json:"has_more"`
+
+	httpHeader
+}
+
+type VideoDeleteResponse struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+
+	httpHeader
+}
+
+// CreateVideo starts a video generation job. Poll RetrieveVideo until the job
+// is done, then download the result with GetVideoContent.
+func (c *Client) CreateVideo(
+	ctx context.Context,
+	request VideoRequest,
+	opts ...RequestOption,
+) (response Video, err error) {
+	if request.InputReference == nil {
+		req, reqErr := c.newRequest(ctx, http.MethodPost, c.fullURL(videosSuffix),
+			withBody(request), withRequestOptions(opts))
+		if reqErr != nil {
+			err = reqErr
+			return
+		}
+
+		err = c.sendRequest(req, &response)
+		return
+	}
+
+	body := &bytes.Buffer{}
+	builder := c.createFormBuilder(body)
+
+	err = builder.CreateFormFileReader("input_reference", request.InputReference, request.InputReferenceName)
+	if err != nil {
+		return
+	}
+
+	fields := []struct{ name, value string }{
+		{"prompt", request.Prompt},
+		{"model", request.Model},
+		{"seconds", request.Seconds},
+		{"size", request.Size},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		err = builder.WriteField(f.name, f.value)
+		if err != nil {
+			return
+		}
+	}
+
+	err = builder.Close()
+	if err != nil {
+		return
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(videosSuffix),
+		withBody(body), withContentType(builder.FormDataContentType()), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// RetrieveVideo retrieves a video generation job, including its progress.
+func (c *Client) RetrieveVideo(ctx context.Context, videoID string, opts ...RequestOption) (response Video, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", videosSuffix, videoID)
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// DeleteVideo deletes a video and its generated assets.
+func (c *Client) DeleteVideo(
+	ctx context.Context,
+	videoID string,
+	opts ...RequestOption,
+) (response VideoDeleteResponse, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s", videosSuffix, videoID)
+	req, err := c.newRequest(ctx, http.MethodDelete, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// ListVideos lists video generation jobs.
+func (c *Client) ListVideos(
+	ctx context.Context,
+	pagination Pagination,
+	opts ...RequestOption,
+) (response VideosList, err error) {
+	urlSuffix := videosSuffix + pagination.encode()
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// RemixVideo creates a new video generation job that edits a completed video.
+func (c *Client) RemixVideo(
+	ctx context.Context,
+	videoID string,
+	request VideoRemixRequest,
+	opts ...RequestOption,
+) (response Video, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/remix", videosSuffix, videoID)
+	req, err := c.newRequest(ctx, http.MethodPost, c.fullURL(urlSuffix), withBody(request), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// GetVideoContent downloads an asset of a completed video. An empty variant
+// downloads the MP4 itself. The caller is responsible for closing the returned response.
+func (c *Client) GetVideoContent(
+	ctx context.Context,
+	videoID string,
+	variant VideoContentVariant,
+	opts ...RequestOption,
+) (content RawResponse, err error) {
+	urlSuffix := fmt.Sprintf("%s/%s/content", videosSuffix, videoID)
+	if variant != "" {
+		urlSuffix += "?variant=" + url.QueryEscape(string(variant))
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, c.fullURL(urlSuffix), withRequestOptions(opts))
+	if err != nil {
+		return
+	}
+
+	return c.sendRequestRaw(req)
+}
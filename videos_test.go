@@ -0,0 +1,166 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+// TestVideos Tests the video generation endpoints of the API using the mocked server.
+func TestVideos(t *testing.T) {
+	videoID := "video_abc123"
+
+	client, server, teardown := setupOpenAITestServer()
+	defer teardown()
+
+	server.RegisterHandler(
+		"/v1/videos/"+videoID+"/content",
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Query().Get("variant") {
+			case "":
+				fmt.Fprint(w, "mp4")
+			case "thumbnail":
+				fmt.Fprint(w, "webp")
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/videos/"+videoID+"/remix",
+		func(w http.ResponseWriter, r *http.Request) {
+			var request openai.VideoRemixRequest
+			err := json.NewDecoder(r.Body).Decode(&request)
+			checks.NoError(t, err, "Decode error")
+
+			remixedFrom := videoID
+			resBytes, _ := json.Marshal(openai.Video{
+				ID:                 "video_def456",
+				Object:             "video",
+				Status:             openai.VideoStatusQueued,
+				Prompt:             request.Prompt,
+				RemixedFromVideoID: &remixedFrom,
+			})
+			fmt.Fprintln(w, string(resBytes))
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/videos/"+videoID,
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				resBytes, _ := json.Marshal(openai.Video{
+					ID:       videoID,
+					Object:   "video",
+					Model:    openai.Sora2,
+					Status:   openai.VideoStatusInProgress,
+					Progress: 42,
+				})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodDelete:
+				fmt.Fprintln(w, `{"id": "video_abc123", "object": "video.deleted", "deleted": true}`)
+			}
+		},
+	)
+
+	server.RegisterHandler(
+		"/v1/videos",
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				var request openai.VideoRequest
+				if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+					_, _, err := r.FormFile("input_reference")
+					checks.NoError(t, err, "FormFile error")
+					request.Prompt = r.FormValue("prompt")
+					request.Size = r.FormValue("size")
+				} else {
+					err := json.NewDecoder(r.Body).Decode(&request)
+					checks.NoError(t, err, "Decode error")
+				}
+
+				resBytes, _ := json.Marshal(openai.Video{
+					ID:      videoID,
+					Object:  "video",
+					Model:   request.Model,
+					Status:  openai.VideoStatusQueued,
+					Prompt:  request.Prompt,
+					Seconds: request.Seconds,
+					Size:    request.Size,
+				})
+				fmt.Fprintln(w, string(resBytes))
+			case http.MethodGet:
+				resBytes, _ := json.Marshal(openai.VideosList{
+					Object: "list",
+					Videos: []openai.Video{{ID: videoID}},
+				})
+				fmt.Fprintln(w, string(resBytes))
+			}
+		},
+	)
+
+	ctx := context.Background()
+
+	video, err := client.CreateVideo(ctx, openai.VideoRequest{
+		Prompt:  "A calico cat playing a piano on stage",
+		Model:   openai.Sora2,
+		Seconds: "8",
+		Size:    openai.VideoSize1280x720,
+	})
+	checks.NoError(t, err, "CreateVideo error")
+	if video.Status != openai.VideoStatusQueued || video.Seconds != "8" || video.IsDone() {
+		t.Errorf("unexpected video: %+v", video)
+	}
+
+	video, err = client.CreateVideo(ctx, openai.VideoRequest{
+		Prompt:             "Animate this",
+		Size:               openai.VideoSize720x1280,
+		InputReference:     strings.NewReader("png"),
+		InputReferenceName: "frame.png",
+	})
+	checks.NoError(t, err, "CreateVideo with input reference error")
+	if video.Prompt != "Animate this" || video.Size != openai.VideoSize720x1280 {
+		t.Errorf("unexpected video: %+v", video)
+	}
+
+	video, err = client.RetrieveVideo(ctx, videoID)
+	checks.NoError(t, err, "RetrieveVideo error")
+	if video.Progress != 42 {
+		t.Errorf("unexpected progress: %d", video.Progress)
+	}
+
+	_, err = client.ListVideos(ctx, openai.Pagination{})
+	checks.NoError(t, err, "ListVideos error")
+
+	remix, err := client.RemixVideo(ctx, videoID, openai.VideoRemixRequest{Prompt: "Make it night"})
+	checks.NoError(t, err, "RemixVideo error")
+	if remix.RemixedFromVideoID == nil || *remix.RemixedFromVideoID != videoID {
+		t.Errorf("unexpected remixed_from_video_id: %v", remix.RemixedFromVideoID)
+	}
+
+	for variant, expected := range map[openai.VideoContentVariant]string{
+		"":                                  "mp4",
+		openai.VideoContentVariantThumbnail: "webp",
+	} {
+		content, contentErr := client.GetVideoContent(ctx, videoID, variant)
+		checks.NoError(t, contentErr, "GetVideoContent error")
+		data, readErr := io.ReadAll(content)
+		content.Close()
+		checks.NoError(t, readErr, "ReadAll error")
+		if string(data) != expected {
+			t.Errorf("unexpected content for variant %q: %q", variant, data)
+		}
+	}
+
+	_, err = client.DeleteVideo(ctx, videoID)
+	checks.NoError(t, err, "DeleteVideo error")
+}
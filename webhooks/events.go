@@ -0,0 +1,93 @@
+package webhooks
+
+import "encoding/json"
+
+// EventType is the type of a webhook event.
+type EventType string
+
+const (
+	EventTypeBatchCompleted EventType = "batch.completed"
+	EventTypeBatchCancelled EventType = "batch.cancelled"
+	EventTypeBatchExpired   EventType = "batch.expired"
+	EventTypeBatchFailed    EventType = "batch.failed"
+
+	EventTypeFineTuningJobSucceeded EventType = "fine_tuning.job.succeeded"
+	EventTypeFineTuningJobFailed    EventType = "fine_tuning.job.failed"
+	EventTypeFineTuningJobCancelled EventType = "fine_tuning.job.cancelled"
+
+	EventTypeResponseCompleted  EventType = "response.completed"
+	EventTypeResponseCancelled  EventType = "response.cancelled"
+	EventTypeResponseFailed     EventType = "response.failed"
+	EventTypeResponseIncomplete EventType = "response.incomplete"
+
+	EventTypeEvalRunSucceeded EventType = "eval.run.succeeded"
+	EventTypeEvalRunFailed    EventType = "eval.run.failed"
+	EventTypeEvalRunCanceled  EventType = "eval.run.canceled"
+)
+
+// Event is implemented by every decoded webhook event.
+type Event interface {
+	EventType() EventType
+}
+
+// BaseEvent holds the fields shared by all webhook events.
+type BaseEvent struct {
+	ID        string    `json:"id"`
+	Object    string    `json:"object"`
+	Type      EventType `json:"type"`
+	CreatedAt int64     `json:"created_at"`
+}
+
+func (e BaseEvent) EventType() EventType {
+	return e.Type
+}
+
+// BatchEvent is sent when a batch reaches a terminal state.
+type BatchEvent struct {
+	BaseEvent
+	Data BatchEventData `json:"data"`
+}
+
+type BatchEventData struct {
+	// ID of the batch.
+	ID string `json:"id"`
+}
+
+// FineTuningJobEvent is sent when a fine-tuning job reaches a terminal state.
+type FineTuningJobEvent struct {
+	BaseEvent
+	Data FineTuningJobEventData `json:"data"`
+}
+
+type FineTuningJobEventData struct {
+	// ID of the fine-tuning job.
+	ID string `json:"id"`
+}
+
+// ResponseEvent is sent when a background response reaches a terminal state.
+type ResponseEvent struct {
+	BaseEvent
+	Data ResponseEventData `json:"data"`
+}
+
+type ResponseEventData struct {
+	// ID of the response.
+	ID string `json:"id"`
+}
+
+// EvalRunEvent is sent when an eval run reaches a terminal state.
+type EvalRunEvent struct {
+	BaseEvent
+	Data EvalRunEventData `json:"data"`
+}
+
+type EvalRunEventData struct {
+	// ID of the eval run.
+	ID string `json:"id"`
+}
+
+// UnknownEvent holds events whose type is not modeled by this package.
+type UnknownEvent struct {
+	BaseEvent
+	Data json.RawMessage `json:"data"`
+}
@@ -0,0 +1,180 @@
+// Package webhooks verifies and decodes webhook deliveries sent by the OpenAI API.
+//
+// Deliveries follow the Standard Webhooks specification
+// (https://www.standardwebhooks.com): every request carries webhook-id,
+// webhook-timestamp and webhook-signature headers, and the signature is an
+// HMAC-SHA256 over "<id>.<timestamp>.<body>" keyed with the endpoint secret.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Header names used by webhook deliveries.
+const (
+	HeaderID        = "webhook-id"
+	HeaderTimestamp = "webhook-timestamp"
+	HeaderSignature = "webhook-signature"
+)
+
+// DefaultTolerance is the maximum allowed difference between the delivery
+// timestamp and the local clock.
+const DefaultTolerance = 5 * time.Minute
+
+const (
+	secretPrefix     = "whsec_"
+	signatureVersion = "v1"
+)
+
+var (
+	ErrInvalidSecret      = errors.New("webhooks: secret is not valid base64")
+	ErrMissingHeaders     = errors.New("webhooks: missing webhook-id, webhook-timestamp or webhook-signature header")
+	ErrInvalidTimestamp   = errors.New("webhooks: invalid webhook-timestamp header")
+	ErrTimestampOutOfSync = errors.New("webhooks: timestamp is outside the allowed tolerance")
+	ErrInvalidSignature   = errors.New("webhooks: no matching signature found")
+)
+
+// Verifier checks webhook signatures for a single endpoint secret.
+type Verifier struct {
+	// Tolerance is the maximum allowed clock skew between the delivery
+	// timestamp and now. Defaults to DefaultTolerance.
+	Tolerance time.Duration
+
+	key []byte
+	now func() time.Time
+}
+
+// NewVerifier creates a Verifier for the given endpoint secret, as shown in the
+// OpenAI dashboard. The "whsec_" prefix is optional.
+func NewVerifier(secret string) (*Verifier, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, secretPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSecret, err)
+	}
+
+	return &Verifier{
+		Tolerance: DefaultTolerance,
+		key:       key,
+		now:       time.Now,
+	}, nil
+}
+
+// Verify checks that payload was signed with the verifier's secret and that
+// the delivery timestamp is within Tolerance of the current time.
+func (v *Verifier) Verify(header http.Header, payload []byte) error {
+	id := header.Get(HeaderID)
+	timestamp := header.Get(HeaderTimestamp)
+	signatures := header.Get(HeaderSignature)
+	if id == "" || timestamp == "" || signatures == "" {
+		return ErrMissingHeaders
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	skew := v.now().Sub(time.Unix(seconds, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > v.Tolerance {
+		return ErrTimestampOutOfSync
+	}
+
+	expected := v.sign(id, timestamp, payload)
+	for _, versioned := range strings.Fields(signatures) {
+		version, signature, found := strings.Cut(versioned, ",")
+		if !found || version != signatureVersion {
+			continue
+		}
+
+		decoded, decodeErr := base64.StdEncoding.DecodeString(signature)
+		if decodeErr != nil {
+			continue
+		}
+		if hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
+
+// Unwrap verifies the delivery and decodes it into a typed event.
+// See Parse for the returned types.
+func (v *Verifier) Unwrap(header http.Header, payload []byte) (Event, error) {
+	if err := v.Verify(header, payload); err != nil {
+		return nil, err
+	}
+
+	return Parse(payload)
+}
+
+// Sign returns the webhook-signature header value for a delivery. It is
+// mostly useful to produce fixtures for tests.
+func (v *Verifier) Sign(id string, timestamp time.Time, payload []byte) string {
+	signature := v.sign(id, strconv.FormatInt(timestamp.Unix(), 10), payload)
+	return signatureVersion + "," + base64.StdEncoding.EncodeToString(signature)
+}
+
+func (v *Verifier) sign(id, timestamp string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, v.key)
+	mac.Write([]byte(id))
+	mac.Write([]byte("."))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// Parse decodes a webhook payload without verifying it. The returned value
+// is one of *BatchEvent, *FineTuningJobEvent, *ResponseEvent, *EvalRunEvent,
+// or *UnknownEvent for event types this package does not model.
+func Parse(payload []byte) (Event, error) {
+	var base struct {
+		BaseEvent
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &base); err != nil {
+		return nil, err
+	}
+
+	var (
+		event Event
+		data  any
+	)
+	switch {
+	case strings.HasPrefix(string(base.Type), "batch."):
+		e := &BatchEvent{BaseEvent: base.BaseEvent}
+		event, data = e, &e.Data
+	case strings.HasPrefix(string(base.Type), "fine_tuning.job."):
+		e := &FineTuningJobEvent{BaseEvent: base.BaseEvent}
+		event, data = e, &e.Data
+	case strings.HasPrefix(string(base.Type), "response."):
+		e := &ResponseEvent{BaseEvent: base.BaseEvent}
+		event, data = e, &e.Data
+	case strings.HasPrefix(string(base.Type), "eval.run."):
+		e := &EvalRunEvent{BaseEvent: base.BaseEvent}
+		event, data = e, &e.Data
+	default:
+		return &UnknownEvent{BaseEvent: base.BaseEvent, Data: base.Data}, nil
+	}
+
+	if len(base.Data) > 0 {
+		if err := json.Unmarshal(base.Data, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return event, nil
+}
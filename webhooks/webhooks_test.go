@@ -0,0 +1,165 @@
+package webhooks_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai/webhooks"
+)
+
+var testSecret = "whsec_" + base64.StdEncoding.EncodeToString([]byte("super-secret-signing-key"))
+
+func newDelivery(t *testing.T, v *webhooks.Verifier, ts time.Time, payload []byte) http.Header {
+	t.Helper()
+	header := http.Header{}
+	header.Set(webhooks.HeaderID, "wh_123")
+	header.Set(webhooks.HeaderTimestamp, strconv.FormatInt(ts.Unix(), 10))
+	header.Set(webhooks.HeaderSignature, v.Sign("wh_123", ts, payload))
+	return header
+}
+
+func TestVerify(t *testing.T) {
+	v, err := webhooks.NewVerifier(testSecret)
+	if err != nil {
+		t.Fatalf("NewVerifier error: %v", err)
+	}
+	payload := []byte(`{"object":"event","id":"evt_1","type":"batch.completed","created_at":1,"data":{"id":"batch_1"}}`)
+
+	t.Run("valid", func(t *testing.T) {
+		header := newDelivery(t, v, time.Now(), payload)
+		if err := v.Verify(header, payload); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("multiple signatures", func(t *testing.T) {
+		header := newDelivery(t, v, time.Now(), payload)
+		header.Set(webhooks.HeaderSignature, "v1,bm90LXRoZS1zaWduYXR1cmU= "+header.Get(webhooks.HeaderSignature))
+		if err := v.Verify(header, payload); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		header := newDelivery(t, v, time.Now(), payload)
+		err := v.Verify(header, []byte(string(payload)+" "))
+		if !errors.Is(err, webhooks.ErrInvalidSignature) {
+			t.Fatalf("expected ErrInvalidSignature, got %v", err)
+		}
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		other, _ := webhooks.NewVerifier(base64.StdEncoding.EncodeToString([]byte("another-key")))
+		header := newDelivery(t, other, time.Now(), payload)
+		if err := v.Verify(header, payload); !errors.Is(err, webhooks.ErrInvalidSignature) {
+			t.Fatalf("expected ErrInvalidSignature, got %v", err)
+		}
+	})
+
+	t.Run("stale timestamp", func(t *testing.T) {
+		header := newDelivery(t, v, time.Now().Add(-time.Hour), payload)
+		if err := v.Verify(header, payload); !errors.Is(err, webhooks.ErrTimestampOutOfSync) {
+			t.Fatalf("expected ErrTimestampOutOfSync, got %v", err)
+		}
+	})
+
+	t.Run("missing headers", func(t *testing.T) {
+		if err := v.Verify(http.Header{}, payload); !errors.Is(err, webhooks.ErrMissingHeaders) {
+			t.Fatalf("expected ErrMissingHeaders, got %v", err)
+		}
+	})
+
+	t.Run("invalid timestamp", func(t *testing.T) {
+		header := newDelivery(t, v, time.Now(), payload)
+		header.Set(webhooks.HeaderTimestamp, "yesterday")
+		if err := v.Verify(header, payload); !errors.Is(err, webhooks.ErrInvalidTimestamp) {
+			t.Fatalf("expected ErrInvalidTimestamp, got %v", err)
+		}
+	})
+}
+
+func TestNewVerifierInvalidSecret(t *testing.T) {
+	_, err := webhooks.NewVerifier("whsec_not base64!")
+	if !errors.Is(err, webhooks.ErrInvalidSecret) {
+		t.Fatalf("expected ErrInvalidSecret, got %v", err)
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	v, err := webhooks.NewVerifier(testSecret)
+	if err != nil {
+		t.Fatalf("NewVerifier error: %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		payload string
+		check   func(t *testing.T, event webhooks.Event)
+	}{
+		{
+			name:    "batch.completed",
+			payload: `{"object":"event","id":"evt_1","type":"batch.completed","created_at":1,"data":{"id":"batch_1"}}`,
+			check: func(t *testing.T, event webhooks.Event) {
+				e, ok := event.(*webhooks.BatchEvent)
+				if !ok || e.Data.ID != "batch_1" || e.Type != webhooks.EventTypeBatchCompleted {
+					t.Fatalf("unexpected event: %#v", event)
+				}
+			},
+		},
+		{
+			name:    "fine_tuning.job.succeeded",
+			payload: `{"object":"event","id":"evt_2","type":"fine_tuning.job.succeeded","created_at":1,"data":{"id":"ftjob_1"}}`,
+			check: func(t *testing.T, event webhooks.Event) {
+				e, ok := event.(*webhooks.FineTuningJobEvent)
+				if !ok || e.Data.ID != "ftjob_1" {
+					t.Fatalf("unexpected event: %#v", event)
+				}
+			},
+		},
+		{
+			name:    "response.completed",
+			payload: `{"object":"event","id":"evt_3","type":"response.completed","created_at":1,"data":{"id":"resp_1"}}`,
+			check: func(t *testing.T, event webhooks.Event) {
+				e, ok := event.(*webhooks.ResponseEvent)
+				if !ok || e.Data.ID != "resp_1" {
+					t.Fatalf("unexpected event: %#v", event)
+				}
+			},
+		},
+		{
+			name:    "eval.run.failed",
+			payload: `{"object":"event","id":"evt_4","type":"eval.run.failed","created_at":1,"data":{"id":"evalrun_1"}}`,
+			check: func(t *testing.T, event webhooks.Event) {
+				e, ok := event.(*webhooks.EvalRunEvent)
+				if !ok || e.Data.ID != "evalrun_1" || e.EventType() != webhooks.EventTypeEvalRunFailed {
+					t.Fatalf("unexpected event: %#v", event)
+				}
+			},
+		},
+		{
+			name:    "unknown",
+			payload: `{"object":"event","id":"evt_5","type":"realtime.call.incoming","created_at":1,"data":{"call_id":"c_1"}}`,
+			check: func(t *testing.T, event webhooks.Event) {
+				e, ok := event.(*webhooks.UnknownEvent)
+				if !ok || string(e.Data) != `{"call_id":"c_1"}` {
+					t.Fatalf("unexpected event: %#v", event)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload := []byte(tc.payload)
+			event, err := v.Unwrap(newDelivery(t, v, time.Now(), payload), payload)
+			if err != nil {
+				t.Fatalf("Unwrap error: %v", err)
+			}
+			tc.check(t, event)
+		})
+	}
+}